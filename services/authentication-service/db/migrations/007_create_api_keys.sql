@@ -0,0 +1,34 @@
+--services/authentication-service/db/migrations/007_create_api_keys.sql
+
+-- Tenant-scoped API keys for merchant backends.
+-- Invariants: Hashed storage (raw key shown once), soft revocation, rotation chain.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,                       -- References tenants.id.
+    created_by UUID NOT NULL,                      -- References users.id (the issuing admin).
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,                          -- Public start of the key, for display.
+    key_hash TEXT NOT NULL,                        -- SHA-256 of the raw key (NEVER raw).
+    scopes TEXT[] NOT NULL,                        -- e.g. {shipments:read,shipments:write}
+    expires_at TIMESTAMPTZ,                        -- NULL: never expires.
+    last_used_at TIMESTAMPTZ,                      -- Updated at most once a minute.
+    revoked_at TIMESTAMPTZ,                        -- NULL if active.
+    replaced_by_key_id UUID,                       -- Links to the new key after rotation.
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE api_keys ADD CONSTRAINT unique_api_key_hash UNIQUE (key_hash);
+
+ALTER TABLE api_keys ADD CONSTRAINT fk_api_key_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE;
+ALTER TABLE api_keys ADD CONSTRAINT fk_api_key_creator FOREIGN KEY (created_by) REFERENCES users(id);
+
+-- Indexes:
+-- 1. Hash lookup: every API request authenticated with a key (the unique constraint covers it).
+-- 2. By tenant: key listings.
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_created ON api_keys (tenant_id, created_at DESC);
+
+CREATE TRIGGER trig_api_keys_updated_at
+BEFORE UPDATE ON api_keys
+FOR EACH ROW EXECUTE PROCEDURE update_updated_at();
@@ -4,6 +4,7 @@ import (
 	stdErrors "errors"
 
 	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -33,8 +34,84 @@ func MapLoginError(err error) error {
 	if stdErrors.Is(err, domainErr.ErrUserDeleted) {
 		return status.Error(codes.PermissionDenied, "account deleted")
 	}
+	// The password was right; only the requested tenant is refused
+	if stdErrors.Is(err, domainErr.ErrNotTenantMember) || stdErrors.Is(err, domainErr.ErrTenantSuspended) {
+		return MapError(err)
+	}
 
 	//  Fallback (never leak internals)
 	return status.Error(codes.Internal, "internal error")
 
 }
+
+// ErrorDomain is the errdetails.ErrorInfo domain every mapped error carries.
+const ErrorDomain = "auth.logisynapse"
+
+// domainErrorMapping pairs each domain error with the gRPC code and the stable
+// machine-readable reason clients branch on (the message is for humans only).
+var domainErrorMapping = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{domainErr.ErrInvalidCredentials, codes.Unauthenticated, "INVALID_CREDENTIALS"},
+	{domainErr.ErrInvalidSession, codes.Unauthenticated, "INVALID_SESSION"},
+	{domainErr.ErrSessionReused, codes.Unauthenticated, "SESSION_REUSED"},
+	{domainErr.ErrInvalidAPIKey, codes.Unauthenticated, "INVALID_API_KEY"},
+
+	{domainErr.ErrUserSuspended, codes.PermissionDenied, "USER_SUSPENDED"},
+	{domainErr.ErrUserDeleted, codes.PermissionDenied, "USER_DELETED"},
+	{domainErr.ErrUserNotActive, codes.PermissionDenied, "USER_NOT_ACTIVE"},
+	{domainErr.ErrNotTenantOwner, codes.PermissionDenied, "NOT_TENANT_OWNER"},
+	{domainErr.ErrNotTenantAdmin, codes.PermissionDenied, "NOT_TENANT_ADMIN"},
+	{domainErr.ErrNotTenantMember, codes.PermissionDenied, "NOT_TENANT_MEMBER"},
+	{domainErr.ErrUnauthorized, codes.PermissionDenied, "FORBIDDEN"},
+	{domainErr.ErrUnauthorizedAction, codes.PermissionDenied, "FORBIDDEN"},
+	{domainErr.ErrInsufficientPrivilege, codes.PermissionDenied, "FORBIDDEN"},
+
+	{domainErr.ErrEmailAlreadyExists, codes.AlreadyExists, "EMAIL_TAKEN"},
+	{domainErr.ErrDuplicateMembership, codes.AlreadyExists, "ALREADY_MEMBER"},
+	{domainErr.ErrRequestAlreadyPending, codes.AlreadyExists, "REQUEST_ALREADY_PENDING"},
+
+	{domainErr.ErrTenantNotFound, codes.NotFound, "TENANT_NOT_FOUND"},
+	{domainErr.ErrUserNotFound, codes.NotFound, "USER_NOT_FOUND"},
+	{domainErr.ErrMembershipNotFound, codes.NotFound, "MEMBERSHIP_NOT_FOUND"},
+	{domainErr.ErrRequestNotFound, codes.NotFound, "REQUEST_NOT_FOUND"},
+	{domainErr.ErrAPIKeyNotFound, codes.NotFound, "API_KEY_NOT_FOUND"},
+
+	{domainErr.ErrInvalidInput, codes.InvalidArgument, "INVALID_INPUT"},
+	{domainErr.ErrInvalidAPIScope, codes.InvalidArgument, "INVALID_SCOPE"},
+
+	{domainErr.ErrTenantSuspended, codes.FailedPrecondition, "TENANT_SUSPENDED"},
+	{domainErr.ErrCannotRevokeOwner, codes.FailedPrecondition, "CANNOT_REVOKE_OWNER"},
+	{domainErr.ErrCannotRevokeSelf, codes.FailedPrecondition, "CANNOT_REVOKE_SELF"},
+	{domainErr.ErrInvalidState, codes.FailedPrecondition, "INVALID_STATE"},
+	{domainErr.ErrAPIKeyRevoked, codes.FailedPrecondition, "API_KEY_REVOKED"},
+	{domainErr.ErrRequestNotPending, codes.FailedPrecondition, "INVALID_STATE"},
+}
+
+// MapError translates any other command/query error into a gRPC status
+// with an errdetails.ErrorInfo attached. Login keeps using MapLoginError.
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		// Already a status (e.g. transport-level validation)
+		return err
+	}
+	for _, m := range domainErrorMapping {
+		if stdErrors.Is(err, m.err) {
+			return withReason(m.code, m.err.Error(), m.reason)
+		}
+	}
+	return withReason(codes.Internal, "internal error", "INTERNAL")
+}
+
+func withReason(code codes.Code, msg, reason string) error {
+	st, detailErr := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain})
+	if detailErr != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	domainError "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/google/uuid"
+)
+
+type fakeKeys struct {
+	byID map[uuid.UUID]*apikey.APIKey
+}
+
+func (f *fakeKeys) CreateAPIKey(_ context.Context, k *apikey.APIKey) error {
+	stored := *k
+	f.byID[k.KeyID] = &stored
+	return nil
+}
+
+func (f *fakeKeys) GetAPIKeyByID(_ context.Context, id uuid.UUID) (*apikey.APIKey, error) {
+	k, ok := f.byID[id]
+	if !ok {
+		return nil, domainError.ErrAPIKeyNotFound
+	}
+	copied := *k
+	return &copied, nil
+}
+
+func (f *fakeKeys) GetAPIKeyByHash(_ context.Context, hash string) (*apikey.APIKey, error) {
+	for _, k := range f.byID {
+		if k.KeyHash == hash {
+			copied := *k
+			return &copied, nil
+		}
+	}
+	return nil, domainError.ErrAPIKeyNotFound
+}
+
+func (f *fakeKeys) ListAPIKeysByTenant(context.Context, uuid.UUID) ([]apikey.APIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeKeys) UpdateAPIKey(_ context.Context, k *apikey.APIKey) error {
+	stored := *k
+	f.byID[k.KeyID] = &stored
+	return nil
+}
+
+func (f *fakeKeys) RotateAPIKey(ctx context.Context, old, next *apikey.APIKey) error {
+	f.UpdateAPIKey(ctx, old)
+	return f.CreateAPIKey(ctx, next)
+}
+
+func (f *fakeKeys) TouchAPIKey(context.Context, uuid.UUID, time.Time) error { return nil }
+
+type fakeTenants struct{ t *tenant.Tenant }
+
+func (f fakeTenants) CreateTenantWithOwnership(context.Context, *tenant.Tenant) error { return nil }
+func (f fakeTenants) GetTenantByID(context.Context, uuid.UUID) (*tenant.Tenant, error) {
+	if f.t == nil {
+		return nil, domainError.ErrTenantNotFound
+	}
+	return f.t, nil
+}
+func (f fakeTenants) UpdateTenantStatus(context.Context, uuid.UUID, tenant.TenantStatus) error {
+	return nil
+}
+func (f fakeTenants) ListTenantsByOwnerID(_ context.Context, owner uuid.UUID) ([]tenant.Tenant, error) {
+	if f.t == nil || f.t.OwnerUserID != owner {
+		return nil, nil
+	}
+	return []tenant.Tenant{*f.t}, nil
+}
+func (f fakeTenants) UpdateTenant(context.Context, *tenant.Tenant) error { return nil }
+
+type fakeMembers struct {
+	byUser map[uuid.UUID]*membership.MemberShip
+}
+
+func (f fakeMembers) CreateMembership(context.Context, *membership.MemberShip) error { return nil }
+func (f fakeMembers) UpdateMembershipStatus(context.Context, *membership.MemberShip) error {
+	return nil
+}
+func (f fakeMembers) GetMembersByTenantID(context.Context, uuid.UUID) ([]membership.MemberShip, error) {
+	return nil, nil
+}
+func (f fakeMembers) ListMembersByUserID(_ context.Context, userID uuid.UUID) ([]*membership.MemberShip, error) {
+	if m, ok := f.byUser[userID]; ok {
+		return []*membership.MemberShip{m}, nil
+	}
+	return nil, nil
+}
+func (f fakeMembers) GetMember(_ context.Context, userID, tenantID uuid.UUID) (*membership.MemberShip, error) {
+	if m, ok := f.byUser[userID]; ok && m.TenantID == tenantID {
+		return m, nil
+	}
+	return nil, domainError.ErrMembershipNotFound
+}
+func (f fakeMembers) UpdateMemberRole(context.Context, uuid.UUID, uuid.UUID, membership.Role) error {
+	return nil
+}
+func (f fakeMembers) UpsertMembership(context.Context, *membership.MemberShip) error { return nil }
+
+type fakeTx struct{}
+
+func (fakeTx) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	owner, member := uuid.New(), uuid.New()
+	tnt := &tenant.Tenant{TenantID: uuid.New(), OwnerUserID: owner, TenantStatus: tenant.TenantStatusActive}
+	members := fakeMembers{byUser: map[uuid.UUID]*membership.MemberShip{
+		member: {UserID: member, TenantID: tnt.TenantID, MemberShipRole: membership.RoleMember, MemberShipStatus: membership.StatusActive},
+	}}
+	keys := &fakeKeys{byID: map[uuid.UUID]*apikey.APIKey{}}
+	auditLog := &fakeAudit{}
+	create := NewCreateAPIKeyCmd(keys, fakeTenants{tnt}, members, auditLog, fakeTx{})
+	rotate := NewRotateAPIKeyCmd(keys, fakeTenants{tnt}, members, auditLog, fakeTx{})
+	revoke := NewRevokeAPIKeyCmd(keys, fakeTenants{tnt}, members, auditLog, fakeTx{})
+	ctx := context.Background()
+
+	params := CreateAPIKeyParams{TenantID: tnt.TenantID, ActorUserID: member, Name: "warehouse sync", Scopes: []apikey.Scope{"shipments:write"}}
+	if _, err := create.Handle(ctx, params); !errors.Is(err, domainError.ErrNotTenantAdmin) {
+		t.Fatalf("member creating a key: err = %v", err)
+	}
+	params.ActorUserID = owner
+	issued, err := create.Handle(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(issued.RawKey, issued.Key.Prefix+"_") || issued.Key.KeyHash != apikey.Hash(issued.RawKey) {
+		t.Fatalf("raw key %q does not match stored key %+v", issued.RawKey, issued.Key)
+	}
+	if !issued.Key.HasScope(apikey.ScopeShipmentsRead) {
+		t.Errorf("write scope should imply read: %v", issued.Key.Scopes)
+	}
+
+	rotated, err := rotate.Handle(ctx, RotateAPIKeyParams{TenantID: tnt.TenantID, KeyID: issued.Key.KeyID, ActorUserID: owner, GracePeriod: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := keys.byID[issued.Key.KeyID]
+	if rotated.Key.Name != "warehouse sync" || old.ReplacedBy == nil || *old.ReplacedBy != rotated.Key.KeyID {
+		t.Fatalf("rotation: old %+v new %+v", old, rotated.Key)
+	}
+	if !old.Active(time.Now()) || old.Active(time.Now().Add(2*time.Hour)) {
+		t.Error("the old key should work for the grace period only")
+	}
+	if _, err := rotate.Handle(ctx, RotateAPIKeyParams{TenantID: tnt.TenantID, KeyID: issued.Key.KeyID, ActorUserID: owner}); !errors.Is(err, domainError.ErrInvalidState) {
+		t.Errorf("second rotation of the same key: err = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := revoke.Handle(ctx, RevokeAPIKeyParams{TenantID: tnt.TenantID, KeyID: rotated.Key.KeyID, ActorUserID: owner}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if keys.byID[rotated.Key.KeyID].RevokedAt == nil {
+		t.Error("key not revoked")
+	}
+	if _, err := revoke.Handle(ctx, RevokeAPIKeyParams{TenantID: uuid.New(), KeyID: rotated.Key.KeyID, ActorUserID: owner}); !errors.Is(err, domainError.ErrAPIKeyNotFound) {
+		t.Errorf("revoking through another tenant: err = %v", err)
+	}
+
+	want := []string{"API_KEY_CREATED", "API_KEY_ROTATED", "API_KEY_REVOKED"}
+	if strings.Join(auditLog.actions, ",") != strings.Join(want, ",") {
+		t.Errorf("audit = %v; want %v", auditLog.actions, want)
+	}
+}
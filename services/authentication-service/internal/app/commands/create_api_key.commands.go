@@ -0,0 +1,201 @@
+// services/authentication-service/internal/app/commands/create_api_key.commands.go
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/audit"
+	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/policy"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+const maxAPIKeyNameLength = 100
+
+// newRawAPIKey returns "lsk_<8 hex>_<43 base64url>" and its display prefix "lsk_<8 hex>".
+func newRawAPIKey() (raw, prefix string, err error) {
+	var id [4]byte
+	var secret [32]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret[:]); err != nil {
+		return "", "", err
+	}
+	prefix = apikey.RawPrefix + hex.EncodeToString(id[:])
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret[:]), prefix, nil
+}
+
+// requireTenantAdmin lets the tenant's owner and admins, and super admins, manage its keys.
+func requireTenantAdmin(
+	ctx context.Context,
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
+	tenantID, actorUserID uuid.UUID,
+	isSuperAdmin bool,
+) (*tenant.Tenant, error) {
+	t, err := tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil || t == nil {
+		return nil, domainErr.ErrTenantNotFound
+	}
+	if isSuperAdmin {
+		return t, nil
+	}
+	actorMem, err := membershipRepo.GetMember(ctx, actorUserID, tenantID)
+	if err != nil && err != domainErr.ErrMembershipNotFound {
+		return nil, err
+	}
+	role := policy.EffectiveRole(t.OwnerUserID, actorUserID, actorMem)
+	if role != membership.RoleOwner && role != membership.RoleAdmin {
+		return nil, domainErr.ErrNotTenantAdmin
+	}
+	return t, nil
+}
+
+/*
+CREATE API KEY
+
+Golden Rules enforced:
+1. Only tenant admins (or super admins) issue keys, and only for an active tenant
+2. The raw key leaves this command once; only its hash is stored
+3. The key and its audit event are written in one transaction
+*/
+type CreateAPIKeyCmd struct {
+	keyRepo        repository.APIKeyStore
+	tenantRepo     repository.TenantStore
+	membershipRepo repository.MemberShipStore
+	auditRepo      repository.AuditStore
+	txManager      repository.TransactionManager
+}
+
+func NewCreateAPIKeyCmd(
+	keyRepo repository.APIKeyStore,
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
+	auditRepo repository.AuditStore,
+	txManager repository.TransactionManager,
+) *CreateAPIKeyCmd {
+	return &CreateAPIKeyCmd{
+		keyRepo:        keyRepo,
+		tenantRepo:     tenantRepo,
+		membershipRepo: membershipRepo,
+		auditRepo:      auditRepo,
+		txManager:      txManager,
+	}
+}
+
+type CreateAPIKeyParams struct {
+	TenantID     uuid.UUID
+	ActorUserID  uuid.UUID
+	IsSuperAdmin bool
+	Name         string
+	Scopes       []apikey.Scope
+	ExpiresAt    *time.Time // nil: never expires
+	IPAddress    string
+}
+
+// IssuedAPIKey is a stored key together with its raw value, which is never available again.
+type IssuedAPIKey struct {
+	Key    apikey.APIKey
+	RawKey string
+}
+
+func (cmd *CreateAPIKeyCmd) Handle(ctx context.Context, params CreateAPIKeyParams) (*IssuedAPIKey, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len(name) > maxAPIKeyNameLength {
+		return nil, domainErr.ErrInvalidInput
+	}
+	scopes, err := normalizeScopes(params.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(now) {
+		return nil, domainErr.ErrInvalidInput
+	}
+
+	t, err := requireTenantAdmin(ctx, cmd.tenantRepo, cmd.membershipRepo, params.TenantID, params.ActorUserID, params.IsSuperAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if t.TenantStatus != tenant.TenantStatusActive {
+		return nil, domainErr.ErrTenantSuspended
+	}
+
+	raw, prefix, err := newRawAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apikey.APIKey{
+		KeyID:     uuid.New(),
+		TenantID:  params.TenantID,
+		CreatedBy: params.ActorUserID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   apikey.Hash(raw),
+		Scopes:    scopes,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = cmd.txManager.RunInTx(ctx, func(txCtx context.Context) error {
+		if err := cmd.keyRepo.CreateAPIKey(txCtx, &key); err != nil {
+			return fmt.Errorf("failed to store api key: %w", err)
+		}
+		return cmd.auditRepo.Append(txCtx, &audit.AuditEvent{
+			ID:          uuid.New(),
+			ActorUserID: &params.ActorUserID,
+			TenantID:    &params.TenantID,
+			Action:      "API_KEY_CREATED",
+			TargetID:    &key.KeyID,
+			IPAddress:   params.IPAddress,
+			Metadata: map[string]any{
+				"name":       key.Name,
+				"prefix":     key.Prefix,
+				"scopes":     key.Scopes,
+				"expires_at": key.ExpiresAt,
+			},
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedAPIKey{Key: key, RawKey: raw}, nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates; a key needs at least one.
+// Write implies read, so a write-only key is stored with both.
+func normalizeScopes(in []apikey.Scope) ([]apikey.Scope, error) {
+	if len(in) == 0 {
+		return nil, domainErr.ErrInvalidAPIScope
+	}
+	seen := map[apikey.Scope]bool{}
+	for _, s := range in {
+		if !apikey.ValidScope(s) {
+			return nil, fmt.Errorf("%w: %q", domainErr.ErrInvalidAPIScope, s)
+		}
+		seen[s] = true
+	}
+	if seen[apikey.ScopeShipmentsWrite] {
+		seen[apikey.ScopeShipmentsRead] = true
+	}
+	var out []apikey.Scope
+	for _, s := range []apikey.Scope{apikey.ScopeShipmentsRead, apikey.ScopeShipmentsWrite} {
+		if seen[s] {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
@@ -3,8 +3,6 @@ package commands
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -20,6 +18,7 @@ type LoginUserHandler struct {
 	tokenRepo    repository.RefreshTokenStore
 	passwordHash crypto.PasswordHasher
 	tokenSigner  crypto.TokenSigner
+	tenants      sessionTenants
 }
 
 func NewLoginUserHandler(
@@ -27,12 +26,15 @@ func NewLoginUserHandler(
 	tokenRepo repository.RefreshTokenStore,
 	passwordHash crypto.PasswordHasher,
 	tokenSigner crypto.TokenSigner,
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
 ) *LoginUserHandler {
 	return &LoginUserHandler{
 		userRepo:     userRepo,
 		tokenRepo:    tokenRepo,
 		passwordHash: passwordHash,
 		tokenSigner:  tokenSigner,
+		tenants:      sessionTenants{tenantRepo: tenantRepo, membershipRepo: membershipRepo},
 	}
 }
 
@@ -41,6 +43,8 @@ type LoginParams struct {
 	Password          string
 	DeviceFingerprint string
 	IPAddress         string
+	// TenantID is the tenant to log into; uuid.Nil lets the login pick (see sessionTenants.resolve)
+	TenantID uuid.UUID
 }
 type LoginResult struct {
 	AccessToken  string
@@ -78,12 +82,19 @@ func (h *LoginUserHandler) Handler(ctx context.Context, params LoginParams) (*Lo
 	if user.Status == "deleted" {
 		return nil, domainError.ErrUserDeleted
 	}
+	// The tenant (and role in it) the session acts in
+	st, err := h.tenants.resolve(ctx, user, params.TenantID)
+	if err != nil {
+		return nil, err
+	}
 	//Generate Tokens
 	// Access Token (JWT - Stateless)
 	accessToken, jwtDuration, err := h.tokenSigner.SignAccessToken(ctx, crypto.AccessClaims{
 		UserID:       user.UserID,
 		UserEmail:    user.UserEmail,
 		IsSuperAdmin: user.IsSuperAdmin, // Critical for Rule 1 enforcement elsewhere
+		TenantID:     st.TenantID,
+		Role:         string(st.Role),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
@@ -97,16 +108,16 @@ func (h *LoginUserHandler) Handler(ctx context.Context, params LoginParams) (*Lo
 	//.........................................................................
 	// FIX 2: Use SHA-256 for Refresh Tokens (Fast & Deterministic)
 	// We do NOT use Argon2 here because we need fast lookups and it's high-entropy random data.
-	rawHash := sha256.Sum256([]byte(refreshTokenStr))
-	refreshTokenHash := hex.EncodeToString(rawHash[:])
+	refreshTokenHash := hashRefreshToken(refreshTokenStr)
 
 	// if err != nil {
 	// 	return nil, fmt.Errorf("failed to hash refresh token: %w", err)
 	// }
-	expiresAt := time.Now().Add(30 * 24 * time.Hour) // 30 Days
+	expiresAt := time.Now().Add(refreshTokenTTL) // 30 Days
 	refreshTokenEntity := &session.RefreshToken{
 		TokenID:        uuid.New(),
 		UserID:         user.UserID,
+		TenantID:       sessionTenantID(st),
 		TokenHash:      refreshTokenHash, // Storing SHA-256 hash
 		FamilyID:       FamilyID,
 		IssuedAt:       time.Now(),
@@ -0,0 +1,48 @@
+// services/authentication-service/internal/app/commands/logout_user.commands.go
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/audit"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// LogoutUserCmd revokes the session (token family) a refresh token belongs to.
+// Access tokens already issued stay valid until they expire; they are short-lived.
+type LogoutUserCmd struct {
+	tokenRepo repository.RefreshTokenStore
+	auditRepo repository.AuditStore
+}
+
+func NewLogoutUserCmd(tokenRepo repository.RefreshTokenStore, auditRepo repository.AuditStore) *LogoutUserCmd {
+	return &LogoutUserCmd{tokenRepo: tokenRepo, auditRepo: auditRepo}
+}
+
+// Handle is idempotent: an unknown or already revoked token is not an error,
+// the caller wanted the session gone and it is.
+func (cmd *LogoutUserCmd) Handle(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	tok, err := cmd.tokenRepo.GetTokenByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil || tok == nil || tok.RevokedAt != nil {
+		return nil
+	}
+	if err := cmd.tokenRepo.RevokeTokenFamily(ctx, tok.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	event := &audit.AuditEvent{
+		ID:          uuid.New(),
+		ActorUserID: &tok.UserID,
+		Action:      "USER_LOGGED_OUT",
+		TargetID:    &tok.FamilyID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	_ = cmd.auditRepo.Append(ctx, event)
+	return nil
+}
@@ -0,0 +1,199 @@
+// services/authentication-service/internal/app/commands/refresh_session.commands.go
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/audit"
+	domainError "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/session"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/crypto"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// refreshTokenTTL is how long a refresh token lives. Every rotation starts a new one,
+// so a session stays alive as long as it is used at least once a month.
+const refreshTokenTTL = 30 * 24 * time.Hour
+
+// hashRefreshToken is how refresh tokens are stored and looked up.
+// SHA-256, not Argon2: the token is high-entropy random data and lookups must be fast.
+func hashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+/*
+REFRESH SESSION — ROTATION CHAIN
+
+Golden Rules enforced:
+1. A refresh token is used exactly once: it is replaced by a new one in the same family
+2. A replaced token that comes back is a stolen copy → revoke the whole family
+3. Revoked, expired and unknown tokens all fail the same way (no oracle)
+*/
+type RefreshSessionCmd struct {
+	userRepo    repository.UserStore
+	tokenRepo   repository.RefreshTokenStore
+	tokenSigner crypto.TokenSigner
+	auditRepo   repository.AuditStore
+	tenants     sessionTenants
+}
+
+func NewRefreshSessionCmd(
+	userRepo repository.UserStore,
+	tokenRepo repository.RefreshTokenStore,
+	tokenSigner crypto.TokenSigner,
+	auditRepo repository.AuditStore,
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
+) *RefreshSessionCmd {
+	return &RefreshSessionCmd{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		tokenSigner: tokenSigner,
+		auditRepo:   auditRepo,
+		tenants:     sessionTenants{tenantRepo: tenantRepo, membershipRepo: membershipRepo},
+	}
+}
+
+type RefreshSessionParams struct {
+	RefreshToken      string
+	DeviceFingerprint string
+	// TenantID switches the session to another tenant; uuid.Nil keeps the current one
+	TenantID uuid.UUID
+}
+
+func (cmd *RefreshSessionCmd) Handle(ctx context.Context, params RefreshSessionParams) (*LoginResult, error) {
+	if params.RefreshToken == "" {
+		return nil, domainError.ErrInvalidSession
+	}
+	old, err := cmd.tokenRepo.GetTokenByHash(ctx, hashRefreshToken(params.RefreshToken))
+	if err != nil || old == nil {
+		return nil, domainError.ErrInvalidSession
+	}
+	if old.RevokedAt != nil {
+		return nil, domainError.ErrInvalidSession
+	}
+	// Replay detection: this token was already swapped for a newer one
+	if old.ReplacedBy != nil {
+		if err := cmd.tokenRepo.RevokeTokenFamily(ctx, old.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke reused token family: %w", err)
+		}
+		event := &audit.AuditEvent{
+			ID:          uuid.New(),
+			ActorUserID: &old.UserID,
+			Action:      "SESSION_REUSE_DETECTED",
+			TargetID:    &old.FamilyID,
+			Metadata: map[string]any{
+				"device": params.DeviceFingerprint,
+			},
+			CreatedAt: time.Now().UTC(),
+		}
+		_ = cmd.auditRepo.Append(ctx, event)
+		return nil, domainError.ErrSessionReused
+	}
+	if time.Now().After(old.ExpiresAt) {
+		return nil, domainError.ErrInvalidSession
+	}
+
+	// The user may have been suspended since they logged in
+	u, err := cmd.userRepo.GetUserByID(ctx, old.UserID)
+	if err != nil || u == nil {
+		return nil, domainError.ErrInvalidSession
+	}
+	switch u.Status {
+	case user.UserStatusSuspended:
+		return nil, domainError.ErrUserSuspended
+	case user.UserStatusDeleted:
+		return nil, domainError.ErrUserDeleted
+	}
+
+	// Tenant switch, or the session's tenant checked again: the role may have
+	// changed, or the membership been revoked, since the last token
+	st, err := cmd.sessionTenant(ctx, u, old, params.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, jwtDuration, err := cmd.tokenSigner.SignAccessToken(ctx, crypto.AccessClaims{
+		UserID:       u.UserID,
+		UserEmail:    u.UserEmail,
+		IsSuperAdmin: u.IsSuperAdmin,
+		TenantID:     st.TenantID,
+		Role:         string(st.Role),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	refreshTokenStr := uuid.New().String()
+	now := time.Now()
+	next := &session.RefreshToken{
+		TokenID:        uuid.New(),
+		UserID:         old.UserID,
+		TenantID:       sessionTenantID(st),
+		TokenHash:      hashRefreshToken(refreshTokenStr),
+		FamilyID:       old.FamilyID, // same login, same family
+		IssuedAt:       now,
+		ExpiresAt:      now.Add(refreshTokenTTL),
+		DeviceMetadata: params.DeviceFingerprint,
+	}
+	// RotateToken is atomic: if another request rotated old first, it fails and this one loses
+	if err := cmd.tokenRepo.RotateToken(ctx, old.TokenID, next); err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if params.TenantID != uuid.Nil && (old.TenantID == nil || *old.TenantID != params.TenantID) {
+		event := &audit.AuditEvent{
+			ID:          uuid.New(),
+			ActorUserID: &u.UserID,
+			TenantID:    &params.TenantID,
+			Action:      "SESSION_TENANT_SWITCHED",
+			TargetID:    &old.FamilyID,
+			Metadata: map[string]any{
+				"role":   string(st.Role),
+				"device": params.DeviceFingerprint,
+			},
+			CreatedAt: now.UTC(),
+		}
+		// The switch already happened; a lost audit row must not undo it
+		_ = cmd.auditRepo.Append(ctx, event)
+	}
+
+	return &LoginResult{
+		AccessToken:  accessToken,
+		RefreshToken: refreshTokenStr,
+		ExpiresIn:    int64(jwtDuration.Seconds()),
+		TokenType:    "Bearer",
+	}, nil
+}
+
+// sessionTenant is the tenant a refreshed token acts in. An explicit switch
+// must be allowed; the session's own tenant is dropped quietly when the user
+// lost access to it, leaving a tenantless token (or their only tenant).
+func (cmd *RefreshSessionCmd) sessionTenant(ctx context.Context, u *user.User, old *session.RefreshToken, switchTo uuid.UUID) (sessionTenant, error) {
+	if switchTo != uuid.Nil {
+		return cmd.tenants.resolve(ctx, u, switchTo)
+	}
+	if old.TenantID == nil {
+		return cmd.tenants.resolve(ctx, u, uuid.Nil)
+	}
+	st, err := cmd.tenants.resolve(ctx, u, *old.TenantID)
+	if errors.Is(err, domainError.ErrNotTenantMember) || errors.Is(err, domainError.ErrTenantSuspended) {
+		return cmd.tenants.resolve(ctx, u, uuid.Nil)
+	}
+	return st, err
+}
+
+// sessionTenantID is the tenant stored on a refresh token, nil for tenantless sessions.
+func sessionTenantID(st sessionTenant) *uuid.UUID {
+	if st.TenantID == uuid.Nil {
+		return nil
+	}
+	id := st.TenantID
+	return &id
+}
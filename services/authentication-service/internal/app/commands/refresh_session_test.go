@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/audit"
+	domainError "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/session"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/crypto"
+	"github.com/google/uuid"
+)
+
+type fakeTokens struct {
+	byHash map[string]*session.RefreshToken
+}
+
+func (f *fakeTokens) CreateRefreshToken(_ context.Context, t *session.RefreshToken) error {
+	f.byHash[t.TokenHash] = t
+	return nil
+}
+
+func (f *fakeTokens) GetTokenByHash(_ context.Context, hash string) (*session.RefreshToken, error) {
+	return f.byHash[hash], nil
+}
+
+func (f *fakeTokens) RevokeTokenFamily(_ context.Context, familyID uuid.UUID) error {
+	now := time.Now()
+	for _, t := range f.byHash {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *fakeTokens) RotateToken(_ context.Context, oldID uuid.UUID, next *session.RefreshToken) error {
+	for _, t := range f.byHash {
+		if t.TokenID == oldID {
+			t.ReplacedBy = &next.TokenID
+		}
+	}
+	f.byHash[next.TokenHash] = next
+	return nil
+}
+
+type fakeUsers struct{ u *user.User }
+
+func (f fakeUsers) CreateUser(context.Context, *user.User) error { return nil }
+func (f fakeUsers) GetUserByEmail(context.Context, string) (*user.User, error) {
+	return f.u, nil
+}
+func (f fakeUsers) GetUserByID(context.Context, uuid.UUID) (*user.User, error) { return f.u, nil }
+func (f fakeUsers) UpdateStatus(context.Context, uuid.UUID, user.UserStatus) error {
+	return nil
+}
+func (f fakeUsers) SetPasswordHash(context.Context, uuid.UUID, string) error { return nil }
+
+type fakeSigner struct{}
+
+func (fakeSigner) SignAccessToken(context.Context, crypto.AccessClaims) (string, time.Duration, error) {
+	return "jwt", 15 * time.Minute, nil
+}
+
+type fakeAudit struct{ actions []string }
+
+func (f *fakeAudit) Append(_ context.Context, e *audit.AuditEvent) error {
+	f.actions = append(f.actions, e.Action)
+	return nil
+}
+
+func TestRefreshRotatesAndDetectsReplay(t *testing.T) {
+	u := &user.User{UserID: uuid.New(), UserEmail: "a@acme.io", Status: user.UserStatusActive}
+	first := &session.RefreshToken{
+		TokenID:   uuid.New(),
+		UserID:    u.UserID,
+		TokenHash: hashRefreshToken("first"),
+		FamilyID:  uuid.New(),
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	tokens := &fakeTokens{byHash: map[string]*session.RefreshToken{first.TokenHash: first}}
+	auditLog := &fakeAudit{}
+	cmd := NewRefreshSessionCmd(fakeUsers{u}, tokens, fakeSigner{}, auditLog, fakeTenants{}, fakeMembers{})
+	ctx := context.Background()
+
+	res, err := cmd.Handle(ctx, RefreshSessionParams{RefreshToken: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RefreshToken == "first" || res.AccessToken != "jwt" {
+		t.Fatalf("refresh result = %+v", res)
+	}
+	second := tokens.byHash[hashRefreshToken(res.RefreshToken)]
+	if second == nil || second.FamilyID != first.FamilyID {
+		t.Fatalf("rotated token = %+v", second)
+	}
+
+	// The old token comes back: the whole family dies, including the new token
+	_, err = cmd.Handle(ctx, RefreshSessionParams{RefreshToken: "first"})
+	if !errors.Is(err, domainError.ErrSessionReused) {
+		t.Fatalf("replay err = %v", err)
+	}
+	if second.RevokedAt == nil {
+		t.Error("replay did not revoke the family")
+	}
+	if _, err := cmd.Handle(ctx, RefreshSessionParams{RefreshToken: res.RefreshToken}); !errors.Is(err, domainError.ErrInvalidSession) {
+		t.Errorf("refresh with revoked token err = %v", err)
+	}
+	if len(auditLog.actions) != 1 || auditLog.actions[0] != "SESSION_REUSE_DETECTED" {
+		t.Errorf("audit = %v", auditLog.actions)
+	}
+}
+
+func TestRefreshRejectsUnknownAndExpired(t *testing.T) {
+	u := &user.User{UserID: uuid.New(), Status: user.UserStatusActive}
+	expired := &session.RefreshToken{
+		TokenID:   uuid.New(),
+		UserID:    u.UserID,
+		TokenHash: hashRefreshToken("old"),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	tokens := &fakeTokens{byHash: map[string]*session.RefreshToken{expired.TokenHash: expired}}
+	cmd := NewRefreshSessionCmd(fakeUsers{u}, tokens, fakeSigner{}, &fakeAudit{}, fakeTenants{}, fakeMembers{})
+
+	for _, raw := range []string{"", "never-issued", "old"} {
+		if _, err := cmd.Handle(context.Background(), RefreshSessionParams{RefreshToken: raw}); !errors.Is(err, domainError.ErrInvalidSession) {
+			t.Errorf("token %q: err = %v", raw, err)
+		}
+	}
+}
@@ -33,16 +33,16 @@ func NewReqTenantCmd(
 	}
 }
 
-type reqTntParams struct {
-	actorUserID    uuid.UUID
+type ReqTntParams struct {
+	ActorUserID    uuid.UUID
 	DesiredTntName string
 }
 
 // Implement the command methods here (e.g., Execute)
 
-func (cmd *ReqTenantCmd) Handle(ctx context.Context, params reqTntParams) (uuid.UUID, error) {
+func (cmd *ReqTenantCmd) Handle(ctx context.Context, params ReqTntParams) (uuid.UUID, error) {
 	// Check if user exists. Validate User Exists & Active
-	user, err := cmd.userRepo.GetUserByID(ctx, params.actorUserID)
+	user, err := cmd.userRepo.GetUserByID(ctx, params.ActorUserID)
 	if err != nil || user == nil {
 		return uuid.Nil, domainErr.ErrUserNotFound
 	}
@@ -51,7 +51,7 @@ func (cmd *ReqTenantCmd) Handle(ctx context.Context, params reqTntParams) (uuid.
 	}
 	// Anti-Spam Check (Optimization: O(1) Lookup)
 	// "One pending request per user"
-	existingReq, _ := cmd.reqTntRepo.GetPendingTntRequestByUser(ctx, params.actorUserID)
+	existingReq, _ := cmd.reqTntRepo.GetPendingTntRequestByUser(ctx, params.ActorUserID)
 	if existingReq != nil {
 		return uuid.Nil, domainErr.ErrRequestAlreadyPending
 	}
@@ -59,7 +59,7 @@ func (cmd *ReqTenantCmd) Handle(ctx context.Context, params reqTntParams) (uuid.
 	// Create Tenant Request
 	newReq := &tenant.TenantCreationRequest{
 		ID:                uuid.New(),
-		RequesterUserID:   params.actorUserID,
+		RequesterUserID:   params.ActorUserID,
 		DesiredTenantName: params.DesiredTntName,
 		TenantStatus:      tenant.RequestStatusPending,
 		CreatedAt:         time.Now().UTC(),
@@ -71,7 +71,7 @@ func (cmd *ReqTenantCmd) Handle(ctx context.Context, params reqTntParams) (uuid.
 	// 🔐 AUDIT EVENT
 	event := &audit.AuditEvent{
 		ID:          uuid.New(),
-		ActorUserID: &params.actorUserID,
+		ActorUserID: &params.ActorUserID,
 		Action:      "TENANT_REQUEST_CREATED",
 		TargetID:    &newReq.ID,
 		Metadata: map[string]any{
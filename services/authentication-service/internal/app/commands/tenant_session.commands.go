@@ -0,0 +1,95 @@
+// services/authentication-service/internal/app/commands/tenant_session.commands.go
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainError "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/policy"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+/*
+SESSION TENANT — which tenant an access token acts in
+
+Golden Rules enforced:
+1. A token names a tenant only if the user may act in it right now (owner or active member)
+2. The role in the token is the EffectiveRole at signing time, never a client claim
+3. Unknown and foreign tenants fail the same way (no oracle)
+*/
+type sessionTenants struct {
+	tenantRepo     repository.TenantStore
+	membershipRepo repository.MemberShipStore
+}
+
+// sessionTenant is the tenant and role an access token is signed with.
+type sessionTenant struct {
+	TenantID uuid.UUID // uuid.Nil: tenantless token
+	Role     membership.Role
+}
+
+// resolve picks the tenant for u. A requested tenant must be one u may act
+// in (super admins may enter any active tenant). Without a request, a user
+// with exactly one tenant gets it; anyone else gets a tenantless token and
+// chooses a tenant with RefreshSession.
+func (s sessionTenants) resolve(ctx context.Context, u *user.User, requested uuid.UUID) (sessionTenant, error) {
+	if requested != uuid.Nil {
+		return s.enter(ctx, u, requested)
+	}
+	owned, err := s.tenantRepo.ListTenantsByOwnerID(ctx, u.UserID)
+	if err != nil {
+		return sessionTenant{}, fmt.Errorf("failed to list owned tenants: %w", err)
+	}
+	members, err := s.membershipRepo.ListMembersByUserID(ctx, u.UserID)
+	if err != nil {
+		return sessionTenant{}, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	candidates := make(map[uuid.UUID]bool)
+	for _, t := range owned {
+		if t.TenantStatus == tenant.TenantStatusActive {
+			candidates[t.TenantID] = true
+		}
+	}
+	for _, m := range members {
+		if m != nil && m.MemberShipStatus == membership.StatusActive {
+			candidates[m.TenantID] = true
+		}
+	}
+	if len(candidates) != 1 {
+		return sessionTenant{}, nil
+	}
+	for id := range candidates {
+		st, err := s.enter(ctx, u, id)
+		if errors.Is(err, domainError.ErrNotTenantMember) || errors.Is(err, domainError.ErrTenantSuspended) {
+			return sessionTenant{}, nil
+		}
+		return st, err
+	}
+	return sessionTenant{}, nil
+}
+
+// enter checks that u may act in tenantID and returns their role there.
+func (s sessionTenants) enter(ctx context.Context, u *user.User, tenantID uuid.UUID) (sessionTenant, error) {
+	t, err := s.tenantRepo.GetTenantByID(ctx, tenantID)
+	if err != nil || t == nil {
+		return sessionTenant{}, domainError.ErrNotTenantMember
+	}
+	member, err := s.membershipRepo.GetMember(ctx, u.UserID, tenantID)
+	if err != nil && !errors.Is(err, domainError.ErrMembershipNotFound) {
+		return sessionTenant{}, fmt.Errorf("failed to get membership: %w", err)
+	}
+	role := policy.EffectiveRole(t.OwnerUserID, u.UserID, member)
+	if role == membership.RoleNone && !u.IsSuperAdmin {
+		return sessionTenant{}, domainError.ErrNotTenantMember
+	}
+	if t.TenantStatus == tenant.TenantStatusSuspended {
+		return sessionTenant{}, domainError.ErrTenantSuspended
+	}
+	return sessionTenant{TenantID: tenantID, Role: role}, nil
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainError "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/session"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/crypto"
+	"github.com/Tanmoy095/LogiSynapse/shared/authtoken"
+	"github.com/google/uuid"
+)
+
+type fakeHasher struct{}
+
+func (fakeHasher) HashPassword(_ context.Context, password string) (string, error) {
+	return "hash:" + password, nil
+}
+func (fakeHasher) VerifyPassword(_ context.Context, password, encoded string) (bool, error) {
+	return encoded == "hash:"+password, nil
+}
+
+// decodeClaims reads the payload of a signed access token the way the gateway does.
+func decodeClaims(t *testing.T, token string) authtoken.Claims {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token %q is not a JWT", token)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c authtoken.Claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newTestSigner(t *testing.T) crypto.TokenSigner {
+	t.Helper()
+	signer, err := crypto.NewHS256Signer([]byte(strings.Repeat("k", 32)), "auth", "gateway", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestLoginSignsTenantAndRole(t *testing.T) {
+	u := &user.User{UserID: uuid.New(), UserEmail: "a@acme.io", PasswordHash: "hash:pw", Status: user.UserStatusActive}
+	tnt := &tenant.Tenant{TenantID: uuid.New(), OwnerUserID: uuid.New(), TenantStatus: tenant.TenantStatusActive}
+	members := fakeMembers{byUser: map[uuid.UUID]*membership.MemberShip{
+		u.UserID: {UserID: u.UserID, TenantID: tnt.TenantID, MemberShipRole: membership.RoleAdmin, MemberShipStatus: membership.StatusActive},
+	}}
+	tokens := &fakeTokens{byHash: map[string]*session.RefreshToken{}}
+	login := NewLoginUserHandler(fakeUsers{u}, tokens, fakeHasher{}, newTestSigner(t), fakeTenants{tnt}, members)
+	ctx := context.Background()
+
+	// The user's only tenant is picked without asking
+	res, err := login.Handler(ctx, LoginParams{Email: u.UserEmail, Password: "pw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := decodeClaims(t, res.AccessToken)
+	if c.Subject != u.UserID.String() || c.TenantID != tnt.TenantID.String() || c.Role != "admin" {
+		t.Fatalf("claims = %+v", c)
+	}
+	if !c.Audience.Contains("gateway") || c.ExpiresAt-c.IssuedAt != int64(crypto.DefaultAccessTokenTTL/time.Second) {
+		t.Errorf("claims = %+v", c)
+	}
+	if id := c.Identity(); id.TenantID != tnt.TenantID.String() || id.Role != "admin" {
+		t.Errorf("identity = %+v", id)
+	}
+	stored := tokens.byHash[hashRefreshToken(res.RefreshToken)]
+	if stored == nil || stored.TenantID == nil || *stored.TenantID != tnt.TenantID {
+		t.Errorf("refresh token tenant = %+v", stored)
+	}
+
+	// A tenant the user is not in fails like an unknown one
+	_, err = login.Handler(ctx, LoginParams{Email: u.UserEmail, Password: "pw", TenantID: uuid.New()})
+	if !errors.Is(err, domainError.ErrNotTenantMember) {
+		t.Errorf("foreign tenant err = %v", err)
+	}
+}
+
+func TestRefreshSwitchesTenant(t *testing.T) {
+	u := &user.User{UserID: uuid.New(), UserEmail: "a@acme.io", Status: user.UserStatusActive}
+	tnt := &tenant.Tenant{TenantID: uuid.New(), OwnerUserID: uuid.New(), TenantStatus: tenant.TenantStatusActive}
+	members := fakeMembers{byUser: map[uuid.UUID]*membership.MemberShip{
+		u.UserID: {UserID: u.UserID, TenantID: tnt.TenantID, MemberShipRole: membership.RoleMember, MemberShipStatus: membership.StatusActive},
+	}}
+	first := &session.RefreshToken{
+		TokenID:   uuid.New(),
+		UserID:    u.UserID,
+		TokenHash: hashRefreshToken("first"),
+		FamilyID:  uuid.New(),
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	tokens := &fakeTokens{byHash: map[string]*session.RefreshToken{first.TokenHash: first}}
+	auditLog := &fakeAudit{}
+	cmd := NewRefreshSessionCmd(fakeUsers{u}, tokens, newTestSigner(t), auditLog, fakeTenants{tnt}, members)
+	ctx := context.Background()
+
+	if _, err := cmd.Handle(ctx, RefreshSessionParams{RefreshToken: "first", TenantID: uuid.New()}); !errors.Is(err, domainError.ErrNotTenantMember) {
+		t.Fatalf("switch to foreign tenant err = %v", err)
+	}
+	res, err := cmd.Handle(ctx, RefreshSessionParams{RefreshToken: "first", TenantID: tnt.TenantID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := decodeClaims(t, res.AccessToken); c.TenantID != tnt.TenantID.String() || c.Role != "member" {
+		t.Fatalf("claims = %+v", c)
+	}
+	next := tokens.byHash[hashRefreshToken(res.RefreshToken)]
+	if next == nil || next.TenantID == nil || *next.TenantID != tnt.TenantID {
+		t.Fatalf("rotated token = %+v", next)
+	}
+	if len(auditLog.actions) != 1 || auditLog.actions[0] != "SESSION_TENANT_SWITCHED" {
+		t.Errorf("audit = %v", auditLog.actions)
+	}
+
+	// Refreshing without a switch keeps the tenant
+	res, err = cmd.Handle(ctx, RefreshSessionParams{RefreshToken: res.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := decodeClaims(t, res.AccessToken); c.TenantID != tnt.TenantID.String() {
+		t.Errorf("tenant lost on refresh: %+v", c)
+	}
+}
@@ -64,7 +64,7 @@ func NewTransTntOwnership(t repository.TenantStore, m repository.MemberShipStore
 }
 
 type TransferOwnershipParams struct {
-	tenantID     uuid.UUID
+	TenantID     uuid.UUID
 	ActorUserID  uuid.UUID
 	IsSuperAdmin bool
 	// We identify the new owner by ID.
@@ -81,7 +81,7 @@ func (cmd *TransTntOwnership) Handle(ctx context.Context, params TransferOwnersh
 	//  Transaction Scope
 	return cmd.txManager.RunInTx(ctx, func(txCtx context.Context) error {
 		//Fetch Tenant (Locking row for update is ideal in SQL implementation)
-		tnt, err := cmd.tenantRepo.GetTenantByID(txCtx, params.tenantID)
+		tnt, err := cmd.tenantRepo.GetTenantByID(txCtx, params.TenantID)
 		if err != nil || tnt == nil {
 			return domainErr.ErrTenantNotFound
 		}
@@ -113,7 +113,7 @@ func (cmd *TransTntOwnership) Handle(ctx context.Context, params TransferOwnersh
 		// We Upsert a membership for the old owner.
 		oldOwnerMembership := &membership.MemberShip{
 			UserID:           oldOwnerID,
-			TenantID:         params.tenantID,
+			TenantID:         params.TenantID,
 			MemberShipRole:   membership.RoleAdmin,    // Degrades to Admin
 			MemberShipStatus: membership.StatusActive, // Ensures they can login
 			CreatedAt:        time.Now().UTC(),
@@ -131,7 +131,7 @@ func (cmd *TransTntOwnership) Handle(ctx context.Context, params TransferOwnersh
 		event := &audit.AuditEvent{
 			ID:          uuid.New(),
 			ActorUserID: &params.ActorUserID,
-			TenantID:    &params.tenantID,
+			TenantID:    &params.TenantID,
 			Action:      "TENANT_OWNERSHIP_TRANSFERRED",
 			TargetID:    &params.NewOwnerUserID,
 			Metadata: map[string]any{
@@ -0,0 +1,58 @@
+// services/authentication-service/internal/app/queries/authenticate_api_key.queries.go
+package queries
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+)
+
+// lastUsedResolution is how stale LastUsedAt may get. Writing it on every
+// request would turn the hottest read path into a write path.
+const lastUsedResolution = time.Minute
+
+// AuthenticateAPIKeyQuery resolves a raw key to the key it belongs to.
+// It is called by the gateway for every request that presents a key.
+type AuthenticateAPIKeyQuery struct {
+	keyRepo    repository.APIKeyStore
+	tenantRepo repository.TenantStore
+	now        func() time.Time
+}
+
+func NewAuthenticateAPIKeyQuery(keyRepo repository.APIKeyStore, tenantRepo repository.TenantStore) *AuthenticateAPIKeyQuery {
+	return &AuthenticateAPIKeyQuery{keyRepo: keyRepo, tenantRepo: tenantRepo, now: time.Now}
+}
+
+// Handle fails with ErrInvalidAPIKey for unknown, revoked and expired keys and
+// for keys of suspended tenants alike (no oracle).
+func (q *AuthenticateAPIKeyQuery) Handle(ctx context.Context, rawKey string) (*apikey.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apikey.RawPrefix) {
+		return nil, domainErr.ErrInvalidAPIKey
+	}
+	key, err := q.keyRepo.GetAPIKeyByHash(ctx, apikey.Hash(rawKey))
+	if err != nil || key == nil {
+		return nil, domainErr.ErrInvalidAPIKey
+	}
+	now := q.now().UTC()
+	if !key.Active(now) {
+		return nil, domainErr.ErrInvalidAPIKey
+	}
+	t, err := q.tenantRepo.GetTenantByID(ctx, key.TenantID)
+	if err != nil || t == nil || t.TenantStatus != tenant.TenantStatusActive {
+		return nil, domainErr.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Best effort: a failed touch must not fail the caller's request
+		if err := q.keyRepo.TouchAPIKey(ctx, key.KeyID, now); err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+	key.KeyHash = ""
+	return key, nil
+}
@@ -0,0 +1,29 @@
+// services/authentication-service/internal/app/queries/get_me.queries.go
+package queries
+
+import (
+	"context"
+
+	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// Queries are the read side: no state changes, no audit events.
+
+type GetMeQuery struct {
+	userRepo repository.UserStore
+}
+
+func NewGetMeQuery(userRepo repository.UserStore) *GetMeQuery {
+	return &GetMeQuery{userRepo: userRepo}
+}
+
+func (q *GetMeQuery) Handle(ctx context.Context, userID uuid.UUID) (*user.User, error) {
+	u, err := q.userRepo.GetUserByID(ctx, userID)
+	if err != nil || u == nil {
+		return nil, domainErr.ErrUserNotFound
+	}
+	return u, nil
+}
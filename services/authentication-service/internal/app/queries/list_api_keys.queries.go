@@ -0,0 +1,62 @@
+// services/authentication-service/internal/app/queries/list_api_keys.queries.go
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/policy"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+type ListAPIKeysQuery struct {
+	keyRepo        repository.APIKeyStore
+	tenantRepo     repository.TenantStore
+	membershipRepo repository.MemberShipStore
+}
+
+func NewListAPIKeysQuery(
+	keyRepo repository.APIKeyStore,
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
+) *ListAPIKeysQuery {
+	return &ListAPIKeysQuery{keyRepo: keyRepo, tenantRepo: tenantRepo, membershipRepo: membershipRepo}
+}
+
+type ListAPIKeysParams struct {
+	TenantID     uuid.UUID
+	ActorUserID  uuid.UUID
+	IsSuperAdmin bool
+}
+
+// Handle lists a tenant's keys, revoked ones included, newest first.
+// Only the owner, admins and super admins may see them; hashes never leave the service.
+func (q *ListAPIKeysQuery) Handle(ctx context.Context, params ListAPIKeysParams) ([]apikey.APIKey, error) {
+	t, err := q.tenantRepo.GetTenantByID(ctx, params.TenantID)
+	if err != nil || t == nil {
+		return nil, domainErr.ErrTenantNotFound
+	}
+	if !params.IsSuperAdmin {
+		actorMem, err := q.membershipRepo.GetMember(ctx, params.ActorUserID, params.TenantID)
+		if err != nil && err != domainErr.ErrMembershipNotFound {
+			return nil, err
+		}
+		role := policy.EffectiveRole(t.OwnerUserID, params.ActorUserID, actorMem)
+		if role != membership.RoleOwner && role != membership.RoleAdmin {
+			return nil, domainErr.ErrNotTenantAdmin
+		}
+	}
+
+	keys, err := q.keyRepo.ListAPIKeysByTenant(ctx, params.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	for i := range keys {
+		keys[i].KeyHash = ""
+	}
+	return keys, nil
+}
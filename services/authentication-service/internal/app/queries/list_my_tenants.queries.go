@@ -0,0 +1,62 @@
+// services/authentication-service/internal/app/queries/list_my_tenants.queries.go
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/tenant"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// TenantMembership is one tenant as seen by one user.
+type TenantMembership struct {
+	Tenant tenant.Tenant
+	Role   membership.Role
+	Status membership.MemberShipStatus
+}
+
+type ListMyTenantsQuery struct {
+	tenantRepo     repository.TenantStore
+	membershipRepo repository.MemberShipStore
+}
+
+func NewListMyTenantsQuery(tenantRepo repository.TenantStore, membershipRepo repository.MemberShipStore) *ListMyTenantsQuery {
+	return &ListMyTenantsQuery{tenantRepo: tenantRepo, membershipRepo: membershipRepo}
+}
+
+// Handle returns owned tenants first, then memberships (active and pending invites).
+// Ownership comes from the tenants table, not memberships (Rule 2), so an owner
+// never shows up twice even if a stale membership row exists.
+func (q *ListMyTenantsQuery) Handle(ctx context.Context, userID uuid.UUID) ([]TenantMembership, error) {
+	owned, err := q.tenantRepo.ListTenantsByOwnerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned tenants: %w", err)
+	}
+	out := make([]TenantMembership, 0, len(owned))
+	seen := make(map[uuid.UUID]bool, len(owned))
+	for _, t := range owned {
+		seen[t.TenantID] = true
+		out = append(out, TenantMembership{Tenant: t, Role: membership.RoleOwner, Status: membership.StatusActive})
+	}
+
+	members, err := q.membershipRepo.ListMembersByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	for _, m := range members {
+		if m == nil || m.MemberShipStatus == membership.StatusRevoked || seen[m.TenantID] {
+			continue
+		}
+		t, err := q.tenantRepo.GetTenantByID(ctx, m.TenantID)
+		if err != nil || t == nil {
+			// Membership pointing at a tenant that is gone: skip, don't fail the whole list
+			continue
+		}
+		seen[m.TenantID] = true
+		out = append(out, TenantMembership{Tenant: *t, Role: m.MemberShipRole, Status: m.MemberShipStatus})
+	}
+	return out, nil
+}
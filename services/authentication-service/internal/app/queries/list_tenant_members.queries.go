@@ -0,0 +1,90 @@
+// services/authentication-service/internal/app/queries/list_tenant_members.queries.go
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	domainErr "github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/errors"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/policy"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/user"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/ports/repository"
+	"github.com/google/uuid"
+)
+
+// Member is a membership joined with the user it belongs to.
+type Member struct {
+	User       user.User
+	Membership membership.MemberShip
+}
+
+type ListTenantMembersQuery struct {
+	tenantRepo     repository.TenantStore
+	membershipRepo repository.MemberShipStore
+	userRepo       repository.UserStore
+}
+
+func NewListTenantMembersQuery(
+	tenantRepo repository.TenantStore,
+	membershipRepo repository.MemberShipStore,
+	userRepo repository.UserStore,
+) *ListTenantMembersQuery {
+	return &ListTenantMembersQuery{tenantRepo: tenantRepo, membershipRepo: membershipRepo, userRepo: userRepo}
+}
+
+type ListTenantMembersParams struct {
+	TenantID     uuid.UUID
+	ActorUserID  uuid.UUID
+	IsSuperAdmin bool
+}
+
+// Handle lists the owner first, then active and pending members.
+// Any active member may see the list; pending invitees may not.
+func (q *ListTenantMembersQuery) Handle(ctx context.Context, params ListTenantMembersParams) ([]Member, error) {
+	t, err := q.tenantRepo.GetTenantByID(ctx, params.TenantID)
+	if err != nil || t == nil {
+		return nil, domainErr.ErrTenantNotFound
+	}
+
+	if !params.IsSuperAdmin {
+		actorMem, err := q.membershipRepo.GetMember(ctx, params.ActorUserID, params.TenantID)
+		if err != nil && err != domainErr.ErrMembershipNotFound {
+			return nil, err
+		}
+		if policy.EffectiveRole(t.OwnerUserID, params.ActorUserID, actorMem) == membership.RoleNone {
+			return nil, domainErr.ErrUnauthorized
+		}
+	}
+
+	owner, err := q.userRepo.GetUserByID(ctx, t.OwnerUserID)
+	if err != nil || owner == nil {
+		return nil, fmt.Errorf("failed to load tenant owner: %w", domainErr.ErrUserNotFound)
+	}
+	out := []Member{{
+		User: *owner,
+		Membership: membership.MemberShip{
+			UserID:           t.OwnerUserID,
+			TenantID:         t.TenantID,
+			MemberShipRole:   membership.RoleOwner,
+			MemberShipStatus: membership.StatusActive,
+			CreatedAt:        t.CreatedAt,
+		},
+	}}
+
+	members, err := q.membershipRepo.GetMembersByTenantID(ctx, params.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	for _, m := range members {
+		if m.MemberShipStatus == membership.StatusRevoked || m.UserID == t.OwnerUserID {
+			continue
+		}
+		u, err := q.userRepo.GetUserByID(ctx, m.UserID)
+		if err != nil || u == nil {
+			continue
+		}
+		out = append(out, Member{User: *u, Membership: m})
+	}
+	return out, nil
+}
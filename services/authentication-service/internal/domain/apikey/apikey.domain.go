@@ -0,0 +1,76 @@
+// services/authentication-service/internal/domain/apikey/apikey.domain.go
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RawPrefix starts every raw key, so leaked keys are easy to grep for.
+const RawPrefix = "lsk_"
+
+// Hash is how keys are stored and looked up.
+// SHA-256, not Argon2: keys are high-entropy random data and every request looks one up.
+func Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Scope is one thing a key may do. Keys never carry admin rights:
+// managing keys, members and the tenant itself still needs a person.
+type Scope string
+
+const (
+	ScopeShipmentsRead  Scope = "shipments:read"
+	ScopeShipmentsWrite Scope = "shipments:write"
+)
+
+// ValidScope reports whether s is a scope keys can be issued with.
+func ValidScope(s Scope) bool {
+	return s == ScopeShipmentsRead || s == ScopeShipmentsWrite
+}
+
+// APIKey lets a merchant backend act for a tenant without a user session.
+type APIKey struct {
+	KeyID     uuid.UUID
+	TenantID  uuid.UUID
+	CreatedBy uuid.UUID // the admin who issued it; calls made with the key act as them
+	Name      string    // what the merchant calls it, e.g. "warehouse sync"
+
+	// Prefix is the public start of the key ("lsk_3f9a1c2e"), shown in listings
+	// so a merchant can tell keys apart without the secret.
+	Prefix string
+	// KeyHash is the SHA-256 hash of the full raw key.
+	// WHY: Like refresh tokens, the raw key is shown once and never stored.
+	KeyHash string
+
+	Scopes []Scope
+
+	ExpiresAt  *time.Time // nil: never expires
+	LastUsedAt *time.Time
+	// RevokedAt is the kill switch; revoked keys stay for the audit trail.
+	RevokedAt *time.Time
+	// ReplacedBy links a rotated key to its successor.
+	ReplacedBy *uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasScope reports whether the key was issued with s.
+func (k *APIKey) HasScope(s Scope) bool {
+	for _, have := range k.Scopes {
+		if have == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the key may be used at now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -15,12 +15,27 @@ var (
 	ErrUserDeleted        = errors.New("user account is deleted")
 	ErrEmailAlreadyExists = errors.New("email already exists")
 
+	// Session Errors
+	ErrInvalidSession = errors.New("refresh token is invalid or expired")
+	// ErrSessionReused means a rotated refresh token came back: someone holds a stolen copy,
+	// so the whole token family has been revoked.
+	ErrSessionReused = errors.New("refresh token was already used; session revoked")
+
+	// API Key Errors
+	// ErrInvalidAPIKey covers unknown, revoked and expired keys alike (no oracle).
+	ErrInvalidAPIKey   = errors.New("api key is invalid, revoked or expired")
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+	ErrAPIKeyRevoked   = errors.New("api key is revoked")
+	ErrInvalidAPIScope = errors.New("unknown api key scope")
+
 	// Authorization/Tenant Errors
 	ErrTenantNotFound      = errors.New("tenant not found")
 	ErrTenantSuspended     = errors.New("tenant is suspended")
 	ErrNotTenantOwner      = errors.New("operation requires tenant ownership")
 	ErrMembershipNotFound  = errors.New("membership not found")
 	ErrDuplicateMembership = errors.New("user is already a member of this tenant")
+	// ErrNotTenantMember covers unknown tenants too, so a session cannot probe tenant IDs.
+	ErrNotTenantMember = errors.New("user is not an active member of this tenant")
 
 	// System/Validation Errors
 	ErrInvalidInput          = errors.New("invalid input arguments")
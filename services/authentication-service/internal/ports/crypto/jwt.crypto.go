@@ -0,0 +1,64 @@
+//services/authentication-service/internal/ports/crypto/jwt.crypto.go
+
+package crypto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/authtoken"
+	"github.com/google/uuid"
+)
+
+// DefaultAccessTokenTTL keeps access tokens short-lived: a revoked membership
+// or a tenant switch is reflected within this long.
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// hs256Signer is the private implementation of the TokenSigner interface.
+// It signs the authtoken format with a key shared with the gateway
+// (AUTH_JWT_SECRET on both sides).
+type hs256Signer struct {
+	key      []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewHS256Signer is the Constructor (Factory). issuer and audience may be
+// empty; a zero ttl means DefaultAccessTokenTTL.
+func NewHS256Signer(key []byte, issuer, audience string, ttl time.Duration) (TokenSigner, error) {
+	if len(key) < 32 {
+		return nil, errors.New("jwt signing key must be at least 32 bytes")
+	}
+	if ttl <= 0 {
+		ttl = DefaultAccessTokenTTL
+	}
+	return &hs256Signer{key: key, issuer: issuer, audience: audience, ttl: ttl, now: time.Now}, nil
+}
+
+// SignAccessToken implements the TokenSigner interface.
+func (s *hs256Signer) SignAccessToken(ctx context.Context, claims AccessClaims) (string, time.Duration, error) {
+	now := s.now()
+	c := authtoken.Claims{
+		Subject:    claims.UserID.String(),
+		Email:      claims.UserEmail,
+		Role:       claims.Role,
+		SuperAdmin: claims.IsSuperAdmin,
+		Issuer:     s.issuer,
+		ExpiresAt:  now.Add(s.ttl).Unix(),
+		IssuedAt:   now.Unix(),
+	}
+	if claims.TenantID != uuid.Nil {
+		c.TenantID = claims.TenantID.String()
+	}
+	if s.audience != "" {
+		c.Audience = authtoken.Audience{s.audience}
+	}
+	token, err := authtoken.SignHS256(c, s.key)
+	if err != nil {
+		return "", 0, err
+	}
+	return token, s.ttl, nil
+}
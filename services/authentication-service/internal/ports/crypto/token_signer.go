@@ -14,8 +14,8 @@ type AccessClaims struct {
 	UserID       uuid.UUID
 	UserEmail    string
 	IsSuperAdmin bool
-	Role         string // "admin" or "member"
-
+	TenantID     uuid.UUID // tenant the token acts in; uuid.Nil for tenantless tokens
+	Role         string    // "owner", "admin" or "member" within TenantID
 }
 
 // TokenSigner defines how we mint tokens.
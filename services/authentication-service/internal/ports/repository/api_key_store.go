@@ -0,0 +1,26 @@
+// services/authentication-service/internal/ports/repository/api_key_store.go
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	"github.com/google/uuid"
+)
+
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, key *apikey.APIKey) error
+	// GetAPIKeyByID returns domain errors.ErrAPIKeyNotFound for unknown IDs.
+	GetAPIKeyByID(ctx context.Context, keyID uuid.UUID) (*apikey.APIKey, error)
+	// GetAPIKeyByHash is the hot path of every key-authenticated request.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*apikey.APIKey, error)
+	// ListAPIKeysByTenant returns revoked keys too, newest first.
+	ListAPIKeysByTenant(ctx context.Context, tenantID uuid.UUID) ([]apikey.APIKey, error)
+	// UpdateAPIKey persists revocation, expiry and ReplacedBy changes.
+	UpdateAPIKey(ctx context.Context, key *apikey.APIKey) error
+	// RotateAPIKey atomically inserts next and stores old's changes (ReplacedBy, expiry).
+	RotateAPIKey(ctx context.Context, old *apikey.APIKey, next *apikey.APIKey) error
+	TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error
+}
@@ -0,0 +1,575 @@
+// services/authentication-service/internal/transport/grpcserver/auth.grpcserver.go
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	authv1 "github.com/Tanmoy095/LogiSynapse/services/authentication-service/api/proto/auth/v1"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/app/auth"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/app/commands"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/app/queries"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/apikey"
+	"github.com/Tanmoy095/LogiSynapse/services/authentication-service/internal/domain/membership"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Transport layer rules:
+// 1. The actor always comes from identity metadata (set by the gateway), never from the request body
+// 2. Only shape validation happens here; business rules live in commands
+// 3. Every error leaves through auth.MapError / auth.MapLoginError
+
+const minPasswordLength = 8
+
+// Handlers is everything the server dispatches to.
+type Handlers struct {
+	Register          *commands.RegisterUserHandler
+	Login             *commands.LoginUserHandler
+	Refresh           *commands.RefreshSessionCmd
+	Logout            *commands.LogoutUserCmd
+	CreateTenant      *commands.CreateTenantCmdByPlatform
+	RequestTenant     *commands.ReqTenantCmd
+	InviteMember      *commands.AddMembershipCmd
+	AcceptInvitation  *commands.AcceptInvitationCmd
+	RevokeMember      *commands.RevokeMembershipCmd
+	TransferOwnership *commands.TransTntOwnership
+	CreateAPIKey      *commands.CreateAPIKeyCmd
+	RevokeAPIKey      *commands.RevokeAPIKeyCmd
+	RotateAPIKey      *commands.RotateAPIKeyCmd
+
+	GetMe             *queries.GetMeQuery
+	ListMyTenants     *queries.ListMyTenantsQuery
+	ListTenantMembers *queries.ListTenantMembersQuery
+	ListAPIKeys       *queries.ListAPIKeysQuery
+	AuthenticateKey   *queries.AuthenticateAPIKeyQuery
+}
+
+type Server struct {
+	authv1.UnimplementedAuthServiceServer
+	h Handlers
+}
+
+func NewServer(h Handlers) *Server {
+	return &Server{h: h}
+}
+
+func (s *Server) RegisterUser(ctx context.Context, req *authv1.RegisterUserRequest) (*authv1.RegisterUserResponse, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	email := strings.TrimSpace(req.GetEmail())
+	if !strings.Contains(email, "@") {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "email", Description: "must be a valid email address"})
+	}
+	if len(req.GetPassword()) < minPasswordLength {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "password", Description: "must be at least 8 characters"})
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations)
+	}
+
+	userID, err := s.h.Register.Handle(ctx, commands.RegisterUserParams{
+		Email:     email,
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Password:  req.GetPassword(),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.RegisterUserResponse{UserId: userID.String()}, nil
+}
+
+func (s *Server) LoginUser(ctx context.Context, req *authv1.LoginUserRequest) (*authv1.LoginUserResponse, error) {
+	tenantID, err := optionalID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.h.Login.Handler(ctx, commands.LoginParams{
+		Email:             strings.TrimSpace(req.GetEmail()),
+		Password:          req.GetPassword(),
+		DeviceFingerprint: req.GetDeviceFingerprint(),
+		IPAddress:         peerAddr(ctx),
+		TenantID:          tenantID,
+	})
+	if err != nil {
+		return nil, auth.MapLoginError(err)
+	}
+	return toLoginResponse(res), nil
+}
+
+func (s *Server) RefreshSession(ctx context.Context, req *authv1.RefreshSessionRequest) (*authv1.LoginUserResponse, error) {
+	tenantID, err := optionalID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.h.Refresh.Handle(ctx, commands.RefreshSessionParams{
+		RefreshToken:      req.GetRefreshToken(),
+		DeviceFingerprint: req.GetDeviceFingerprint(),
+		TenantID:          tenantID,
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return toLoginResponse(res), nil
+}
+
+func (s *Server) LogoutUser(ctx context.Context, req *authv1.LogoutUserRequest) (*authv1.LogoutUserResponse, error) {
+	if err := s.h.Logout.Handle(ctx, req.GetRefreshToken()); err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.LogoutUserResponse{Success: true}, nil
+}
+
+func (s *Server) CreateTenant(ctx context.Context, req *authv1.CreateTenantRequest) (*authv1.CreateTenantResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err := parseID("owner_user_id", req.GetOwnerUserId())
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := s.h.CreateTenant.Handle(ctx, commands.CreateTenantParams{
+		TenantName:        req.GetName(),
+		ActorUserID:       actor,
+		IsActorSuperAdmin: isSuperAdmin(ctx),
+		OwnerUserID:       ownerID,
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.CreateTenantResponse{TenantId: tenantID.String()}, nil
+}
+
+func (s *Server) InviteMember(ctx context.Context, req *authv1.InviteMemberRequest) (*authv1.InviteMemberResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	role := membership.Role(strings.ToLower(req.GetRole()))
+	if role != membership.RoleAdmin && role != membership.RoleMember {
+		return nil, badRequest([]*errdetails.BadRequest_FieldViolation{{Field: "role", Description: "must be admin or member"}})
+	}
+	if err := s.h.InviteMember.Handle(ctx, commands.AddMembershipParams{
+		TenantID:        tenantID,
+		ActorUserID:     actor,
+		TargetUserEmail: strings.TrimSpace(req.GetEmail()),
+		Role:            role,
+	}); err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.InviteMemberResponse{Success: true}, nil
+}
+
+func (s *Server) RequestTenant(ctx context.Context, req *authv1.RequestTenantRequest) (*authv1.RequestTenantResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	requestID, err := s.h.RequestTenant.Handle(ctx, commands.ReqTntParams{
+		ActorUserID:    actor,
+		DesiredTntName: strings.TrimSpace(req.GetName()),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.RequestTenantResponse{RequestId: requestID.String()}, nil
+}
+
+func (s *Server) AcceptInvitation(ctx context.Context, req *authv1.AcceptInvitationRequest) (*authv1.AcceptInvitationResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.h.AcceptInvitation.Handle(ctx, actor, tenantID); err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.AcceptInvitationResponse{Success: true}, nil
+}
+
+func (s *Server) RevokeMember(ctx context.Context, req *authv1.RevokeMemberRequest) (*authv1.RevokeMemberResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	target, err := parseID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.h.RevokeMember.Handle(ctx, commands.RevokeMembershipParams{
+		TenantID:     tenantID,
+		ActorUserID:  actor,
+		TargetUserID: target,
+	}); err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.RevokeMemberResponse{Success: true}, nil
+}
+
+func (s *Server) TransferOwnership(ctx context.Context, req *authv1.TransferOwnershipRequest) (*authv1.TransferOwnershipResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	newOwner, err := parseID("new_owner_user_id", req.GetNewOwnerUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.h.TransferOwnership.Handle(ctx, commands.TransferOwnershipParams{
+		TenantID:       tenantID,
+		ActorUserID:    actor,
+		IsSuperAdmin:   isSuperAdmin(ctx),
+		NewOwnerUserID: newOwner,
+	}); err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.TransferOwnershipResponse{Success: true}, nil
+}
+
+func (s *Server) GetMe(ctx context.Context, _ *authv1.GetMeRequest) (*authv1.GetMeResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.h.GetMe.Handle(ctx, actor)
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.GetMeResponse{User: &authv1.User{
+		UserId:       u.UserID.String(),
+		Email:        u.UserEmail,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Status:       string(u.Status),
+		IsSuperAdmin: u.IsSuperAdmin,
+		CreatedAt:    formatTime(u.CreatedAt),
+	}}, nil
+}
+
+func (s *Server) ListMyTenants(ctx context.Context, _ *authv1.ListMyTenantsRequest) (*authv1.ListMyTenantsResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenants, err := s.h.ListMyTenants.Handle(ctx, actor)
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	resp := &authv1.ListMyTenantsResponse{Tenants: make([]*authv1.TenantMembership, 0, len(tenants))}
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, &authv1.TenantMembership{
+			TenantId:         t.Tenant.TenantID.String(),
+			Name:             t.Tenant.TenantName,
+			TenantStatus:     string(t.Tenant.TenantStatus),
+			Role:             string(t.Role),
+			MembershipStatus: string(t.Status),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) ListTenantMembers(ctx context.Context, req *authv1.ListTenantMembersRequest) (*authv1.ListTenantMembersResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.h.ListTenantMembers.Handle(ctx, queries.ListTenantMembersParams{
+		TenantID:     tenantID,
+		ActorUserID:  actor,
+		IsSuperAdmin: isSuperAdmin(ctx),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	resp := &authv1.ListTenantMembersResponse{Members: make([]*authv1.Member, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &authv1.Member{
+			UserId:    m.User.UserID.String(),
+			Email:     m.User.UserEmail,
+			FirstName: m.User.FirstName,
+			LastName:  m.User.LastName,
+			Role:      string(m.Membership.MemberShipRole),
+			Status:    string(m.Membership.MemberShipStatus),
+			JoinedAt:  formatTime(m.Membership.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) CreateApiKey(ctx context.Context, req *authv1.CreateApiKeyRequest) (*authv1.IssuedApiKeyResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	if strings.TrimSpace(req.GetName()) == "" {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "name", Description: "is required"})
+	}
+	scopes := make([]apikey.Scope, len(req.GetScopes()))
+	for i, raw := range req.GetScopes() {
+		scopes[i] = apikey.Scope(strings.ToLower(strings.TrimSpace(raw)))
+		if !apikey.ValidScope(scopes[i]) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "scopes", Description: "must be shipments:read or shipments:write"})
+		}
+	}
+	if len(scopes) == 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "scopes", Description: "at least one scope is required"})
+	}
+	var expiresAt *time.Time
+	if raw := req.GetExpiresAt(); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "expires_at", Description: "must be an RFC3339 timestamp"})
+		} else if !t.After(time.Now()) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: "expires_at", Description: "must be in the future"})
+		}
+		expiresAt = &t
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations)
+	}
+
+	issued, err := s.h.CreateAPIKey.Handle(ctx, commands.CreateAPIKeyParams{
+		TenantID:     tenantID,
+		ActorUserID:  actor,
+		IsSuperAdmin: isSuperAdmin(ctx),
+		Name:         req.GetName(),
+		Scopes:       scopes,
+		ExpiresAt:    expiresAt,
+		IPAddress:    peerAddr(ctx),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.IssuedApiKeyResponse{ApiKey: toAPIKey(&issued.Key), Key: issued.RawKey}, nil
+}
+
+func (s *Server) ListApiKeys(ctx context.Context, req *authv1.ListApiKeysRequest) (*authv1.ListApiKeysResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.h.ListAPIKeys.Handle(ctx, queries.ListAPIKeysParams{
+		TenantID:     tenantID,
+		ActorUserID:  actor,
+		IsSuperAdmin: isSuperAdmin(ctx),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	resp := &authv1.ListApiKeysResponse{ApiKeys: make([]*authv1.ApiKey, 0, len(keys))}
+	for i := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, toAPIKey(&keys[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) RevokeApiKey(ctx context.Context, req *authv1.RevokeApiKeyRequest) (*authv1.RevokeApiKeyResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := parseID("key_id", req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.h.RevokeAPIKey.Handle(ctx, commands.RevokeAPIKeyParams{
+		TenantID:     tenantID,
+		KeyID:        keyID,
+		ActorUserID:  actor,
+		IsSuperAdmin: isSuperAdmin(ctx),
+		IPAddress:    peerAddr(ctx),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.RevokeApiKeyResponse{ApiKey: toAPIKey(key)}, nil
+}
+
+func (s *Server) RotateApiKey(ctx context.Context, req *authv1.RotateApiKeyRequest) (*authv1.IssuedApiKeyResponse, error) {
+	actor, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := parseID("tenant_id", req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := parseID("key_id", req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	grace := time.Duration(req.GetGracePeriodSeconds()) * time.Second
+	if grace < 0 || grace > commands.MaxAPIKeyRotationGrace {
+		return nil, badRequest([]*errdetails.BadRequest_FieldViolation{{Field: "grace_period_seconds", Description: "must be from 0 to 86400"}})
+	}
+	issued, err := s.h.RotateAPIKey.Handle(ctx, commands.RotateAPIKeyParams{
+		TenantID:     tenantID,
+		KeyID:        keyID,
+		ActorUserID:  actor,
+		IsSuperAdmin: isSuperAdmin(ctx),
+		GracePeriod:  grace,
+		IPAddress:    peerAddr(ctx),
+	})
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.IssuedApiKeyResponse{ApiKey: toAPIKey(&issued.Key), Key: issued.RawKey}, nil
+}
+
+// AuthenticateApiKey has no caller: the key is the credential.
+func (s *Server) AuthenticateApiKey(ctx context.Context, req *authv1.AuthenticateApiKeyRequest) (*authv1.AuthenticateApiKeyResponse, error) {
+	key, err := s.h.AuthenticateKey.Handle(ctx, req.GetKey())
+	if err != nil {
+		return nil, auth.MapError(err)
+	}
+	return &authv1.AuthenticateApiKeyResponse{
+		KeyId:     key.KeyID.String(),
+		TenantId:  key.TenantID.String(),
+		CreatedBy: key.CreatedBy.String(),
+		Scopes:    scopeStrings(key.Scopes),
+	}, nil
+}
+
+// callerID is the authenticated user the gateway forwarded. Callers holding
+// an API key are refused: a key is not a user and has no rights over
+// members, tenants or other keys.
+func callerID(ctx context.Context) (uuid.UUID, error) {
+	id, ok := identity.FromContext(ctx)
+	if ok && id.APIKeyID != "" {
+		return uuid.Nil, status.Error(codes.PermissionDenied, "api keys cannot call the identity service; sign in as a user")
+	}
+	if !ok || id.UserID == "" {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	userID, err := uuid.Parse(id.UserID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "malformed caller identity")
+	}
+	return userID, nil
+}
+
+func isSuperAdmin(ctx context.Context) bool {
+	id, ok := identity.FromContext(ctx)
+	return ok && id.SuperAdmin
+}
+
+func parseID(field, raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, badRequest([]*errdetails.BadRequest_FieldViolation{{Field: field, Description: "must be a UUID"}})
+	}
+	return id, nil
+}
+
+// optionalID is parseID for fields that may be left empty (uuid.Nil).
+func optionalID(field, raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	return parseID(field, raw)
+}
+
+func badRequest(violations []*errdetails.BadRequest_FieldViolation) error {
+	st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(
+		&errdetails.BadRequest{FieldViolations: violations},
+		&errdetails.ErrorInfo{Reason: "INVALID_INPUT", Domain: auth.ErrorDomain},
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid request")
+	}
+	return st.Err()
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func toLoginResponse(res *commands.LoginResult) *authv1.LoginUserResponse {
+	return &authv1.LoginUserResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+		TokenType:    res.TokenType,
+	}
+}
+
+func toAPIKey(k *apikey.APIKey) *authv1.ApiKey {
+	out := &authv1.ApiKey{
+		KeyId:     k.KeyID.String(),
+		TenantId:  k.TenantID.String(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    scopeStrings(k.Scopes),
+		CreatedBy: k.CreatedBy.String(),
+		CreatedAt: formatTime(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		out.ExpiresAt = formatTime(*k.ExpiresAt)
+	}
+	if k.LastUsedAt != nil {
+		out.LastUsedAt = formatTime(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		out.RevokedAt = formatTime(*k.RevokedAt)
+	}
+	if k.ReplacedBy != nil {
+		out.ReplacedByKeyId = k.ReplacedBy.String()
+	}
+	return out
+}
+
+func scopeStrings(scopes []apikey.Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,161 @@
+// services/billing-service/cmd/main.go
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	temporalworkflow "go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
+
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billing"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingapi"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingrun"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/config"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/payment"
+	PostgresStore "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store/postgres"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/usage"
+	billingworker "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/worker"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	pkgkafka "github.com/Tanmoy095/LogiSynapse/shared/kafka"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+func main() {
+	// =========================================================================
+	// 1. LOAD CONFIG
+	// =========================================================================
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load billing config: %v", err)
+	}
+	// BILLING_DRY_RUN=true makes the scheduled run report what it WOULD charge.
+	// Handy for the first month in a new environment.
+	dryRun := os.Getenv("BILLING_DRY_RUN") == "true"
+
+	// =========================================================================
+	// 2. SETUP DEPENDENCIES (DB, STORES, DOMAIN SERVICES)
+	// =========================================================================
+	db, err := sql.Open("postgres", cfg.CommonConfig.GetDBURL())
+	if err != nil {
+		log.Fatalf("failed to open billing DB: %v", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to billing DB: %v", err)
+	}
+
+	usageStore := PostgresStore.NewPostgresUsageStore(db)
+	pricingStore := PostgresStore.NewPostgresPricingStore(db)
+	ledgerStore := PostgresStore.NewPostgresLedgerStore(db)
+	invoiceStore := PostgresStore.NewPostgresInvoiceStore(db)
+	accountStore := PostgresStore.NewAccountStore(db)
+	attemptStore := PostgresStore.NewPaymentAttemptStore(db)
+	gateway := payment.NewStripeGateway(cfg.StripeSecretKey)
+
+	paymentService := payment.NewPaymentService(invoiceStore, invoiceStore, accountStore, gateway, ledgerStore, attemptStore)
+
+	billingHost := &billingrun.BillingActivities{
+		Usage:      usageStore,
+		Calculator: billing.NewBillingCalculator(usageStore, pricingStore, ledgerStore),
+		Generator:  invoice.NewInvoiceGenerator(ledgerStore, invoiceStore),
+		Invoices:   invoiceStore,
+		Finalizer:  invoice.NewInvoiceFinalizer(invoiceStore),
+		Payments:   paymentService,
+	}
+
+	// =========================================================================
+	// 3. SETUP TEMPORAL CLIENT
+	// =========================================================================
+	temporalHost := os.Getenv("TEMPORAL_HOST_PORT")
+	if temporalHost == "" {
+		temporalHost = "temporal:7233" // Default for Docker environment
+	}
+	c, err := client.Dial(client.Options{
+		HostPort: temporalHost,
+	})
+	if err != nil {
+		log.Fatalln("Unable to create Temporal client", err)
+	}
+	defer c.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := billingrun.EnsureMonthlySchedule(ctx, c, dryRun); err != nil {
+		log.Fatalf("failed to register billing schedule: %v", err)
+	}
+
+	// =========================================================================
+	// 4. REGISTER ACTIVITIES & WORKFLOWS
+	// =========================================================================
+	w := worker.New(c, billingrun.TaskQueue, worker.Options{})
+
+	w.RegisterWorkflowWithOptions(billingrun.MonthlyBillingWorkflow, temporalworkflow.RegisterOptions{Name: billingrun.MonthlyBillingWorkflowName})
+	w.RegisterWorkflowWithOptions(billingrun.TenantBillingWorkflow, temporalworkflow.RegisterOptions{Name: billingrun.TenantBillingWorkflowName})
+
+	billingrun.RegisterTenants(w, billingrun.ListBillableTenants, billingHost.ACTIVITY_ListBillableTenants)
+	billingrun.RegisterAction(w, billingrun.CalculateTenantUsage, billingHost.ACTIVITY_CalculateTenantUsage)
+	billingrun.RegisterActivity(w, billingrun.PreviewTenantCharges, billingHost.ACTIVITY_PreviewTenantCharges)
+	billingrun.RegisterActivity(w, billingrun.GenerateTenantInvoice, billingHost.ACTIVITY_GenerateTenantInvoice)
+	billingrun.RegisterAction(w, billingrun.FinalizeTenantInvoice, billingHost.ACTIVITY_FinalizeTenantInvoice)
+	billingrun.RegisterAction(w, billingrun.ChargeTenantInvoice, billingHost.ACTIVITY_ChargeTenantInvoice)
+
+	// =========================================================================
+	// 5. START RECONCILER & WORKER
+	// =========================================================================
+	// The Reconciler settles PENDING payments the billing run refuses to touch.
+	reconciler := billingworker.NewReconciler(paymentService, attemptStore, *gateway)
+	go reconciler.Start(ctx)
+
+	// =========================================================================
+	// 6. START USAGE AGGREGATOR (usage events from the gateway via Kafka)
+	// =========================================================================
+	// The gateway reports API_REQUEST and SHIPMENT_CREATED usage; the aggregator
+	// sums it per tenant and flushes it into usage_aggregates for the billing run.
+	// Not ctx: the final flush in Stop runs after ctx is cancelled.
+	aggregator := usage.NewAggregator(context.Background(), usageStore, 30*time.Second)
+	aggregator.Start(4)
+	defer aggregator.Stop()
+	if cfg.CommonConfig.KAFKA_BROKER != "" {
+		usageConsumer := pkgkafka.NewConsumer([]string{cfg.CommonConfig.KAFKA_BROKER}, cfg.UsageTopic, "billing-service-usage")
+		defer usageConsumer.Close()
+		go usageConsumer.Start(ctx, aggregator.KafkaHandler)
+	} else {
+		log.Println("KAFKA_BROKER not set: no usage will be metered")
+	}
+
+	// =========================================================================
+	// 7. START BILLING API (gRPC, for the gateway)
+	// =========================================================================
+	// The gateway forwards the caller as metadata; every call is scoped to its tenant.
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", cfg.GRPCAddr, err)
+	}
+	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(identity.UnaryServerInterceptor()))
+	proto.RegisterBillingServiceServer(grpcSrv, billingapi.NewServer(usageStore, invoiceStore, ledgerStore, attemptStore, paymentService, accountStore))
+	go func() {
+		log.Printf("billing API listening on %s", cfg.GRPCAddr)
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			log.Printf("billing API stopped: %v", err)
+		}
+	}()
+	defer grpcSrv.GracefulStop()
+
+	log.Printf("billing worker started on %s (dry run: %v)", billingrun.TaskQueue, dryRun)
+	if err := w.Run(worker.InterruptCh()); err != nil {
+		log.Fatalln("Unable to start worker", err)
+	}
+}
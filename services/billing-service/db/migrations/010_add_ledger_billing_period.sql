@@ -0,0 +1,26 @@
+-- services/billing-service/db/migrations/010_add_ledger_billing_period.sql
+-- Ledger entries are written by the monthly billing run AFTER the month has closed
+-- (the June run executes on July 1st), so created_at cannot tell us which invoice
+-- an entry belongs to. We store the billing period explicitly.
+
+ALTER TABLE billing_ledger
+  ADD COLUMN IF NOT EXISTS billing_year INT;
+
+ALTER TABLE billing_ledger
+  ADD COLUMN IF NOT EXISTS billing_month INT;
+
+-- Backfill existing rows from created_at (best effort for historical data)
+UPDATE billing_ledger
+SET billing_year = EXTRACT(YEAR FROM created_at),
+    billing_month = EXTRACT(MONTH FROM created_at)
+WHERE billing_year IS NULL OR billing_month IS NULL;
+
+ALTER TABLE billing_ledger ALTER COLUMN billing_year SET NOT NULL;
+ALTER TABLE billing_ledger ALTER COLUMN billing_month SET NOT NULL;
+
+-- Usage entries use deterministic text keys ("usage_<tenant>_<yyyy>_<mm>_<type>"),
+-- payments use the invoice UUID. TEXT holds both.
+ALTER TABLE billing_ledger ALTER COLUMN reference_id TYPE TEXT USING reference_id::TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_billing_ledger_tenant_period
+ON billing_ledger (tenant_id, billing_year, billing_month);
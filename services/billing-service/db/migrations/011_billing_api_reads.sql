@@ -0,0 +1,9 @@
+--services/billing-service/db/migrations/011_billing_api_reads.sql
+
+-- MarkInvoicePaid sets updated_at, which 005 never created.
+ALTER TABLE invoices
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- The billing API lists a tenant's payment attempts, newest first
+CREATE INDEX IF NOT EXISTS idx_payment_attempts_tenant_created
+ON payment_attempts (tenant_id, created_at DESC);
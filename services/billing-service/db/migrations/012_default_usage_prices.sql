@@ -0,0 +1,11 @@
+--services/billing-service/db/migrations/012_default_usage_prices.sql
+
+-- The gateway now meters API_REQUEST and SHIPMENT_CREATED. A usage type without
+-- an active price rule fails the whole billing run, so both start out free;
+-- set real prices with a newer rule (globally or per tenant).
+INSERT INTO pricing_rules (usage_type, tenant_id, unit_price_cents, currency, effective_from)
+SELECT t.usage_type, NULL, 0, 'USD', '2020-01-01T00:00:00Z'
+FROM (VALUES ('API_REQUEST'), ('SHIPMENT_CREATED')) AS t(usage_type)
+WHERE NOT EXISTS (
+    SELECT 1 FROM pricing_rules p WHERE p.usage_type = t.usage_type AND p.tenant_id IS NULL
+);
@@ -0,0 +1,17 @@
+-- services/billing-service/db/migrations/013_create_processed_usage_events.sql
+
+-- Usage events already counted into usage_aggregates, by event ID.
+-- flush_history makes a retried flush a no-op, but Kafka redelivers events
+-- into later batches; a flush claims each event ID here in the same
+-- transaction and leaves out the ones an earlier flush claimed.
+
+CREATE TABLE IF NOT EXISTS processed_usage_events (
+    event_id TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    usage_type TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Redeliveries come within days; old rows can be pruned by age
+CREATE INDEX IF NOT EXISTS idx_processed_usage_events_processed_at
+ON processed_usage_events (processed_at);
@@ -10,4 +10,6 @@ import (
 // AccountStore handles persistence for tenant billing accounts.
 type AccountStore interface {
 	GetBillingAccountDetails(ctx context.Context, tenantID uuid.UUID) (*Account, error)
+	// GetPlan returns the tenant's plan; tenants without an account are on FREE.
+	GetPlan(ctx context.Context, tenantID uuid.UUID) (PlanType, error)
 }
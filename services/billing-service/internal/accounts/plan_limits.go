@@ -0,0 +1,24 @@
+// services/billing-service/internal/accounts/plan_limits.go
+package accounts
+
+// PlanLimits is what a plan allows a tenant. Zero means unlimited.
+// The gateway enforces them; billing-service only hands them out.
+type PlanLimits struct {
+	RequestsPerMinute int   // API requests through the gateway, per tenant
+	MonthlyShipments  int64 // shipments created per (UTC) billing month
+}
+
+var planLimits = map[PlanType]PlanLimits{
+	FreePlan:       {RequestsPerMinute: 60, MonthlyShipments: 100},
+	ProPlan:        {RequestsPerMinute: 600, MonthlyShipments: 5000},
+	EnterprisePlan: {RequestsPerMinute: 6000}, // shipments are unmetered, priced per contract
+}
+
+// Limits returns the limits of plan. Unknown plans get the FREE limits, so
+// a typo in accounts.current_plan never lifts every limit.
+func Limits(plan PlanType) PlanLimits {
+	if l, ok := planLimits[plan]; ok {
+		return l
+	}
+	return planLimits[FreePlan]
+}
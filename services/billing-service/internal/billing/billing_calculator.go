@@ -9,6 +9,7 @@ import (
 	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
 	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/ledger"
 	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/google/uuid"
 )
 
 // BillingCalculator orchestrates the transformation
@@ -66,6 +67,56 @@ func (bc *billingCalculator) BillPeriod(ctx context.Context, year int, month int
 
 }
 
+// BillTenantPeriod is the single-tenant variant of BillPeriod.
+// The monthly billing workflow runs one of these per tenant so a failing tenant
+// is retried on its own instead of blocking everyone else.
+func (bc *billingCalculator) BillTenantPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) error {
+	billTime := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+	records, err := bc.tenantUsage(ctx, tenantID, year, month)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := bc.ProcessingSingleRecord(ctx, record, billTime, year, month); err != nil {
+			return fmt.Errorf("billing failed for tenant %s usage %s: %w", record.TenantID, record.UsageType, err)
+		}
+	}
+	return nil
+}
+
+// PreviewTenantPeriod prices a tenant's usage WITHOUT writing to the ledger.
+// Used by dry runs to report what a billing run would charge.
+func (bc *billingCalculator) PreviewTenantPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error) {
+	billTime := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+	records, err := bc.tenantUsage(ctx, tenantID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	var entries []ledger.LedgerEntry
+	for _, record := range records {
+		entry, err := bc.buildLedgerEntry(ctx, record, billTime, year, month)
+		if err != nil {
+			return nil, fmt.Errorf("pricing failed for tenant %s usage %s: %w", record.TenantID, record.UsageType, err)
+		}
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+// tenantUsage loads one tenant's usage for the period. A billing run calls it
+// once per tenant, so it must not read the whole period each time.
+func (bc *billingCalculator) tenantUsage(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]store.UsageRecord, error) {
+	records, err := bc.usageStore.GetTenantUsageForPeriod(ctx, tenantID, year, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage for period: %w", err)
+	}
+	return records, nil
+}
+
 // ProcessingSingleRecord processes a single usage record:
 // - Fetches the applicable price rule
 // - Calculates total cost
@@ -78,30 +129,52 @@ func (bc *billingCalculator) ProcessingSingleRecord(
 	year int,
 	month int,
 ) error {
+	ledgerEntry, err := bc.buildLedgerEntry(ctx, record, billTime, year, month)
+	if err != nil {
+		return err
+	}
+	if ledgerEntry == nil {
+		// Optimization: Don't clutter ledger with $0.00 entries (unless required for audit)
+		return nil
+	}
+	// Step 4: Persist to Ledger
+	// The store implementation handles idempotency (ON CONFLICT DO NOTHING
+
+	if err := bc.ledgerStore.CreateLedgerEntry(ctx, *ledgerEntry); err != nil {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+	return nil
+}
 
-	// Implementation for processing a single record goes here
+// buildLedgerEntry prices a usage record. It returns nil when there is nothing to charge.
+func (bc *billingCalculator) buildLedgerEntry(
+	ctx context.Context,
+	record store.UsageRecord,
+	billTime time.Time,
+	year int,
+	month int,
+) (*ledger.LedgerEntry, error) {
 
 	//Fetch the price rule for this usage type and tenant
 	priceRule, err := bc.pricingStore.GetPriceRules(ctx, record.UsageType, record.TenantID, billTime)
 	if err != nil {
 		// If no price exists, we CANNOT bill. This is a critical configuration error.
-		return fmt.Errorf("failed to get price rule: %w", err)
+		return nil, fmt.Errorf("failed to get price rule: %w", err)
 	}
 	//Calculate Cost (Flat pricing for now)
 	// Math: Quantity * UnitPriceCents
 	totalCostCents := record.TotalQuantity * priceRule.UnitPriceCents
 	if totalCostCents == 0 {
-		// Optimization: Don't clutter ledger with $0.00 entries (unless required for audit)
-		return nil
+		return nil, nil
 	}
 
 	//Create Deterministic Ledger Entry ID
 	// Format: "usage_TENANT_YEAR_MONTH_TYP
 	ledgerEntryID := fmt.Sprintf("usage_%s_%04d_%02d_%s", record.TenantID.String(), year, month, record.UsageType)
-	ledgerEntry := ledger.LedgerEntry{
+	return &ledger.LedgerEntry{
 		EntryID:         ledgerEntryID,
 		TenantID:        record.TenantID,
-		TransactionType: billingtypes.TransactionType(record.UsageType), // Customer owes us money..ENFORCED: It's a Debit
+		TransactionType: billingtypes.TransactionTypeDebit, // Customer owes us money..ENFORCED: It's a Debit
 		AmountCents:     totalCostCents,
 		Quantity:        record.TotalQuantity,
 		UnitPriceCents:  priceRule.UnitPriceCents,
@@ -109,13 +182,8 @@ func (bc *billingCalculator) ProcessingSingleRecord(
 		// Helpful description for the invoice UI later
 		Description: fmt.Sprintf("%s Fee: %d units @ %s %d cents/unit",
 			record.UsageType, record.TotalQuantity, priceRule.Currency, priceRule.UnitPriceCents),
-		UsageType: record.UsageType,
-	}
-	// Step 4: Persist to Ledger
-	// The store implementation handles idempotency (ON CONFLICT DO NOTHING
-
-	if err := bc.ledgerStore.CreateLedgerEntry(ctx, ledgerEntry); err != nil {
-		return fmt.Errorf("failed to create ledger entry: %w", err)
-	}
-	return nil
+		UsageType:    record.UsageType,
+		BillingYear:  year,
+		BillingMonth: month,
+	}, nil
 }
@@ -30,6 +30,15 @@ func (m *MockUsageStore) Flush(ctx context.Context, batch store.FlushBatch) erro
 func (m *MockUsageStore) GetUsageForPeriod(ctx context.Context, year, month int) ([]store.UsageRecord, error) {
 	return m.Records, nil
 }
+func (m *MockUsageStore) GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year, month int) ([]store.UsageRecord, error) {
+	var records []store.UsageRecord
+	for _, r := range m.Records {
+		if r.TenantID == tenantID {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
 
 type MockPricingStore struct {
 	Rule pricing.PriceRule
@@ -162,3 +171,59 @@ func TestBillingCalculator_MissingPriceRule(t *testing.T) {
 		t.Error("Should not create ledger entries if pricing fails")
 	}
 }
+
+func TestBillingCalculator_BillTenantPeriodOnlyTouchesTenant(t *testing.T) {
+	// 1. SETUP
+	tenantA, tenantB := uuid.New(), uuid.New()
+	usageRepo := &MockUsageStore{
+		Records: []store.UsageRecord{
+			{TenantID: tenantA, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 10},
+			{TenantID: tenantB, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 3},
+		},
+	}
+	pricingRepo := &MockPricingStore{Rule: pricing.PriceRule{UnitPriceCents: 50, Currency: "USD"}}
+	ledgerRepo := &MockLedgerStore{}
+
+	calc := NewBillingCalculator(usageRepo, pricingRepo, ledgerRepo)
+
+	// 2. EXECUTE
+	if err := calc.BillTenantPeriod(context.Background(), tenantA, 2024, 6); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+
+	// 3. ASSERT
+	if len(ledgerRepo.Entries) != 1 || ledgerRepo.Entries[0].TenantID != tenantA {
+		t.Fatalf("Expected a single entry for tenant A, got %+v", ledgerRepo.Entries)
+	}
+	if ledgerRepo.Entries[0].BillingYear != 2024 || ledgerRepo.Entries[0].BillingMonth != 6 {
+		t.Errorf("Entry should carry the billing period, got %d-%d", ledgerRepo.Entries[0].BillingYear, ledgerRepo.Entries[0].BillingMonth)
+	}
+}
+
+func TestBillingCalculator_PreviewWritesNothing(t *testing.T) {
+	// 1. SETUP
+	tenantID := uuid.New()
+	usageRepo := &MockUsageStore{
+		Records: []store.UsageRecord{
+			{TenantID: tenantID, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 4},
+		},
+	}
+	pricingRepo := &MockPricingStore{Rule: pricing.PriceRule{UnitPriceCents: 25, Currency: "USD"}}
+	ledgerRepo := &MockLedgerStore{}
+
+	calc := NewBillingCalculator(usageRepo, pricingRepo, ledgerRepo)
+
+	// 2. EXECUTE
+	entries, err := calc.PreviewTenantPeriod(context.Background(), tenantID, 2024, 6)
+
+	// 3. ASSERT
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].AmountCents != 100 {
+		t.Fatalf("Expected one 100 cent entry, got %+v", entries)
+	}
+	if len(ledgerRepo.Entries) != 0 {
+		t.Error("Preview must not write to the ledger")
+	}
+}
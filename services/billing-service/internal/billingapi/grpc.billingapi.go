@@ -0,0 +1,372 @@
+// services/billing-service/internal/billingapi/grpc.billingapi.go
+
+// Package billingapi serves proto.BillingService: a tenant's usage, invoices,
+// ledger and payment attempts, plus paying an invoice. The tenant always comes
+// from the caller (shared/identity metadata set by the gateway), never from
+// the request, so one tenant cannot read another's billing data.
+package billingapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/accounts"
+	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/ledger"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/payment"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Focused read interfaces, implemented by the Postgres stores.
+type UsageReader interface {
+	GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]store.UsageRecord, error)
+}
+
+type InvoiceReader interface {
+	ListInvoicesForTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]invoice.Invoice, error)
+	// GetTenantInvoice returns nil, nil when the tenant has no such invoice.
+	GetTenantInvoice(ctx context.Context, tenantID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error)
+}
+
+type LedgerReader interface {
+	GetEntriesForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error)
+}
+
+type AttemptReader interface {
+	ListAttemptsForTenant(ctx context.Context, tenantID uuid.UUID, invoiceID uuid.UUID, limit int) ([]*payment.PaymentAttempt, error)
+}
+
+type PlanReader interface {
+	GetPlan(ctx context.Context, tenantID uuid.UUID) (accounts.PlanType, error)
+}
+
+// InvoicePayer is the PaymentService.
+type InvoicePayer interface {
+	PayInvoice(ctx context.Context, invoiceID uuid.UUID) error
+}
+
+const (
+	defaultInvoiceLimit = 12
+	maxInvoiceLimit     = 100
+	defaultAttemptLimit = 50
+	maxAttemptLimit     = 500
+)
+
+type Server struct {
+	proto.UnimplementedBillingServiceServer
+	usage    UsageReader
+	invoices InvoiceReader
+	ledger   LedgerReader
+	attempts AttemptReader
+	payer    InvoicePayer
+	plans    PlanReader
+	now      func() time.Time // the billing period in progress is taken from this (UTC)
+}
+
+func NewServer(usage UsageReader, invoices InvoiceReader, ledger LedgerReader, attempts AttemptReader, payer InvoicePayer, plans PlanReader) *Server {
+	return &Server{
+		usage:    usage,
+		invoices: invoices,
+		ledger:   ledger,
+		attempts: attempts,
+		payer:    payer,
+		plans:    plans,
+		now:      time.Now,
+	}
+}
+
+// callerTenant is the tenant every call is scoped to. Super admins are not
+// special here: billing data is always read as some tenant.
+func callerTenant(ctx context.Context) (uuid.UUID, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "billing calls need an authenticated caller")
+	}
+	if caller.TenantID == "" {
+		return uuid.Nil, status.Error(codes.PermissionDenied, "billing calls need a tenant")
+	}
+	tenantID, err := uuid.Parse(caller.TenantID)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.PermissionDenied, "unknown tenant")
+	}
+	return tenantID, nil
+}
+
+func (s *Server) GetCurrentUsage(ctx context.Context, _ *proto.GetCurrentUsageRequest) (*proto.UsageSummary, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	records, err := s.usage.GetTenantUsageForPeriod(ctx, tenantID, now.Year(), int(now.Month()))
+	if err != nil {
+		return nil, internalError("current usage", err)
+	}
+	resp := &proto.UsageSummary{Year: int32(now.Year()), Month: int32(now.Month()), Items: make([]*proto.UsageItem, len(records))}
+	for i, r := range records {
+		resp.Items[i] = &proto.UsageItem{UsageType: string(r.UsageType), Quantity: r.TotalQuantity}
+	}
+	return resp, nil
+}
+
+// GetPlan answers the gateway's limit lookups. shipments_used lags the
+// aggregator by up to one flush interval; the gateway counts on top of it.
+func (s *Server) GetPlan(ctx context.Context, _ *proto.GetPlanRequest) (*proto.Plan, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.plans.GetPlan(ctx, tenantID)
+	if err != nil {
+		return nil, internalError("get plan", err)
+	}
+	now := s.now().UTC()
+	records, err := s.usage.GetTenantUsageForPeriod(ctx, tenantID, now.Year(), int(now.Month()))
+	if err != nil {
+		return nil, internalError("get plan", err)
+	}
+	limits := accounts.Limits(plan)
+	resp := &proto.Plan{
+		Plan:              string(plan),
+		RequestsPerMinute: int32(limits.RequestsPerMinute),
+		MonthlyShipments:  limits.MonthlyShipments,
+	}
+	for _, r := range records {
+		if r.UsageType == billingtypes.ShipmentCreated {
+			resp.ShipmentsUsed += r.TotalQuantity
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) ListInvoices(ctx context.Context, req *proto.ListInvoicesRequest) (*proto.ListInvoicesResponse, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.invoices.ListInvoicesForTenant(ctx, tenantID, clampLimit(req.Limit, defaultInvoiceLimit, maxInvoiceLimit))
+	if err != nil {
+		return nil, internalError("list invoices", err)
+	}
+	resp := &proto.ListInvoicesResponse{Invoices: make([]*proto.Invoice, len(list))}
+	for i := range list {
+		resp.Invoices[i] = toProtoInvoice(&list[i])
+	}
+	return resp, nil
+}
+
+func (s *Server) GetInvoice(ctx context.Context, req *proto.GetInvoiceRequest) (*proto.Invoice, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.tenantInvoice(ctx, tenantID, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoInvoice(inv), nil
+}
+
+func (s *Server) ListLedgerEntries(ctx context.Context, req *proto.ListLedgerEntriesRequest) (*proto.ListLedgerEntriesResponse, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	year, month := int(req.Year), int(req.Month)
+	switch {
+	case year == 0 && month == 0:
+		now := s.now().UTC()
+		year, month = now.Year(), int(now.Month())
+	case year < 2000 || month < 1 || month > 12:
+		return nil, status.Error(codes.InvalidArgument, "year and month must both be set, with month between 1 and 12")
+	}
+	entries, err := s.ledger.GetEntriesForPeriod(ctx, tenantID, year, month)
+	if err != nil {
+		return nil, internalError("list ledger entries", err)
+	}
+	resp := &proto.ListLedgerEntriesResponse{Entries: make([]*proto.LedgerEntry, len(entries))}
+	for i, e := range entries {
+		resp.Entries[i] = &proto.LedgerEntry{
+			ReferenceId:     e.EntryID,
+			TransactionType: string(e.TransactionType),
+			UsageType:       string(e.UsageType),
+			Quantity:        e.Quantity,
+			UnitPriceCents:  e.UnitPriceCents,
+			AmountCents:     e.AmountCents,
+			Currency:        e.Currency,
+			Description:     e.Description,
+			Year:            int32(e.BillingYear),
+			Month:           int32(e.BillingMonth),
+			CreatedAt:       formatTime(e.CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) ListPaymentAttempts(ctx context.Context, req *proto.ListPaymentAttemptsRequest) (*proto.ListPaymentAttemptsResponse, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invoiceID := uuid.Nil
+	if req.InvoiceId != "" {
+		if invoiceID, err = uuid.Parse(req.InvoiceId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invoice_id is not a valid ID")
+		}
+	}
+	list, err := s.attempts.ListAttemptsForTenant(ctx, tenantID, invoiceID, clampLimit(req.Limit, defaultAttemptLimit, maxAttemptLimit))
+	if err != nil {
+		return nil, internalError("list payment attempts", err)
+	}
+	resp := &proto.ListPaymentAttemptsResponse{Attempts: make([]*proto.PaymentAttempt, len(list))}
+	for i, a := range list {
+		resp.Attempts[i] = &proto.PaymentAttempt{
+			AttemptId:         a.AttemptID.String(),
+			InvoiceId:         a.InvoiceID.String(),
+			Provider:          a.Provider,
+			ProviderPaymentId: a.ProviderPaymentID,
+			Status:            string(a.Status),
+			AmountCents:       a.AmountCents,
+			Currency:          a.Currency,
+			ErrorCode:         deref(a.ErrorCode),
+			ErrorMessage:      deref(a.ErrorMessage),
+			CreatedAt:         formatTime(a.CreatedAt),
+			UpdatedAt:         formatTime(a.UpdatedAt),
+		}
+	}
+	return resp, nil
+}
+
+// PayInvoice checks the invoice is the caller's and FINALIZED, charges it
+// through the PaymentService and returns it as it is afterwards.
+func (s *Server) PayInvoice(ctx context.Context, req *proto.PayInvoiceRequest) (*proto.Invoice, error) {
+	tenantID, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.tenantInvoice(ctx, tenantID, req.InvoiceId)
+	if err != nil {
+		return nil, err
+	}
+	switch inv.Status {
+	case invoice.InvoicePaid:
+		return toProtoInvoice(inv), nil // nothing to do, same as PaymentService
+	case invoice.InvoiceFinalized:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "invoice is %s; only FINALIZED invoices can be paid", inv.Status)
+	}
+
+	if err := s.payer.PayInvoice(ctx, inv.InvoiceID); err != nil {
+		return nil, paymentError(inv.InvoiceID, err)
+	}
+	paid, err := s.invoices.GetTenantInvoice(ctx, tenantID, inv.InvoiceID)
+	if err != nil || paid == nil {
+		// The charge went through; report the invoice as we last saw it rather than fail the call
+		log.Printf("billingapi: invoice %s paid but reload failed: %v", inv.InvoiceID, err)
+		inv.Status = invoice.InvoicePaid
+		return toProtoInvoice(inv), nil
+	}
+	return toProtoInvoice(paid), nil
+}
+
+func (s *Server) tenantInvoice(ctx context.Context, tenantID uuid.UUID, rawID string) (*invoice.Invoice, error) {
+	invoiceID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invoice_id is not a valid ID")
+	}
+	inv, err := s.invoices.GetTenantInvoice(ctx, tenantID, invoiceID)
+	if err != nil {
+		return nil, internalError("get invoice", err)
+	}
+	if inv == nil {
+		return nil, status.Error(codes.NotFound, invoice.ErrInvoiceNotFound.Error())
+	}
+	return inv, nil
+}
+
+// paymentError maps PaymentService failures to codes the gateway can show:
+// a declined card is the caller's problem to fix, a Stripe outage is worth a retry.
+func paymentError(invoiceID uuid.UUID, err error) error {
+	switch {
+	case errors.Is(err, payment.ErrPaymentInProgress):
+		return status.Error(codes.Aborted, payment.ErrPaymentInProgress.Error())
+	case errors.Is(err, payment.ErrPaymentFailed), errors.Is(err, payment.ErrNoPaymentMethod), errors.Is(err, payment.ErrInvalidAmount):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, payment.ErrProviderDown):
+		return status.Error(codes.Unavailable, payment.ErrProviderDown.Error())
+	default:
+		log.Printf("billingapi: paying invoice %s failed: %v", invoiceID, err)
+		return status.Error(codes.Internal, "payment could not be completed")
+	}
+}
+
+// internalError logs a store failure and hides it from the caller.
+func internalError(what string, err error) error {
+	log.Printf("billingapi: %s failed: %v", what, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func toProtoInvoice(inv *invoice.Invoice) *proto.Invoice {
+	out := &proto.Invoice{
+		InvoiceId:   inv.InvoiceID.String(),
+		Year:        int32(inv.Year),
+		Month:       int32(inv.Month),
+		TotalCents:  inv.TotalCents,
+		Currency:    inv.Currency,
+		Status:      string(inv.Status),
+		Lines:       make([]*proto.InvoiceLine, len(inv.Lines)),
+		CreatedAt:   formatTime(inv.CreatedAt),
+		FinalizedAt: formatOptional(inv.FinalizedAt),
+		PaidAt:      formatOptional(inv.PaidAt),
+	}
+	for i, l := range inv.Lines {
+		out.Lines[i] = &proto.InvoiceLine{
+			Id:             l.ID.String(),
+			UsageType:      string(l.UsageType),
+			Quantity:       l.Quantity,
+			UnitPriceCents: l.UnitPriceCents,
+			LineTotalCents: l.LineTotalCents,
+			Description:    l.Description,
+		}
+	}
+	return out
+}
+
+func clampLimit(n int32, def, max int) int {
+	switch {
+	case n <= 0:
+		return def
+	case int(n) > max:
+		return max
+	}
+	return int(n)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptional(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,264 @@
+package billingapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/accounts"
+	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/ledger"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/payment"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	acme   = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	globex = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+// fakeBilling keeps every tenant's data in memory and scopes reads by tenant
+// the way the Postgres stores do.
+type fakeBilling struct {
+	usage    map[uuid.UUID][]store.UsageRecord
+	invoices map[uuid.UUID]*invoice.Invoice
+	entries  []ledger.LedgerEntry
+	attempts []*payment.PaymentAttempt
+
+	plans map[uuid.UUID]accounts.PlanType
+
+	payErr error
+	paid   []uuid.UUID
+}
+
+func (f *fakeBilling) GetPlan(_ context.Context, tenantID uuid.UUID) (accounts.PlanType, error) {
+	if p, ok := f.plans[tenantID]; ok {
+		return p, nil
+	}
+	return accounts.FreePlan, nil
+}
+
+func (f *fakeBilling) GetTenantUsageForPeriod(_ context.Context, tenantID uuid.UUID, year int, month int) ([]store.UsageRecord, error) {
+	var out []store.UsageRecord
+	for _, r := range f.usage[tenantID] {
+		if r.BillingPeriod.Year == year && r.BillingPeriod.Month == month {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeBilling) ListInvoicesForTenant(_ context.Context, tenantID uuid.UUID, limit int) ([]invoice.Invoice, error) {
+	var out []invoice.Invoice
+	for _, inv := range f.invoices {
+		if inv.TenantID == tenantID && len(out) < limit {
+			out = append(out, *inv)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeBilling) GetTenantInvoice(_ context.Context, tenantID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	inv, ok := f.invoices[invoiceID]
+	if !ok || inv.TenantID != tenantID {
+		return nil, nil
+	}
+	copied := *inv
+	return &copied, nil
+}
+
+func (f *fakeBilling) GetEntriesForPeriod(_ context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error) {
+	var out []ledger.LedgerEntry
+	for _, e := range f.entries {
+		if e.TenantID == tenantID && e.BillingYear == year && e.BillingMonth == month {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeBilling) ListAttemptsForTenant(_ context.Context, tenantID uuid.UUID, invoiceID uuid.UUID, limit int) ([]*payment.PaymentAttempt, error) {
+	var out []*payment.PaymentAttempt
+	for _, a := range f.attempts {
+		if a.TenantID == tenantID && (invoiceID == uuid.Nil || a.InvoiceID == invoiceID) && len(out) < limit {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeBilling) PayInvoice(_ context.Context, invoiceID uuid.UUID) error {
+	if f.payErr != nil {
+		return f.payErr
+	}
+	f.paid = append(f.paid, invoiceID)
+	now := time.Date(2025, 7, 2, 9, 0, 0, 0, time.UTC)
+	f.invoices[invoiceID].Status = invoice.InvoicePaid
+	f.invoices[invoiceID].PaidAt = &now
+	return nil
+}
+
+func newTestServer() (*Server, *fakeBilling) {
+	f := &fakeBilling{
+		usage: map[uuid.UUID][]store.UsageRecord{
+			acme: {
+				{TenantID: acme, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 42, BillingPeriod: store.BillingPeriod{Year: 2025, Month: 7}},
+				{TenantID: acme, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 300, BillingPeriod: store.BillingPeriod{Year: 2025, Month: 6}},
+			},
+			globex: {{TenantID: globex, UsageType: billingtypes.ShipmentCreated, TotalQuantity: 7, BillingPeriod: store.BillingPeriod{Year: 2025, Month: 7}}},
+		},
+		invoices: map[uuid.UUID]*invoice.Invoice{},
+		plans:    map[uuid.UUID]accounts.PlanType{acme: accounts.ProPlan},
+		entries: []ledger.LedgerEntry{
+			{EntryID: "e-1", TenantID: acme, AmountCents: 3000, TransactionType: billingtypes.TransactionTypeDebit, BillingYear: 2025, BillingMonth: 6},
+			{EntryID: "e-2", TenantID: globex, AmountCents: 100, TransactionType: billingtypes.TransactionTypeDebit, BillingYear: 2025, BillingMonth: 6},
+		},
+	}
+	s := NewServer(f, f, f, f, f, f)
+	s.now = func() time.Time { return time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC) }
+	return s, f
+}
+
+func (f *fakeBilling) addInvoice(tenantID uuid.UUID, month int, st invoice.InvoiceStatus) uuid.UUID {
+	id := uuid.New()
+	f.invoices[id] = &invoice.Invoice{
+		InvoiceID: id, TenantID: tenantID, Year: 2025, Month: month, TotalCents: 3000, Currency: "USD", Status: st,
+		Lines: []invoice.InvoiceLine{{ID: uuid.New(), UsageType: billingtypes.ShipmentCreated, Quantity: 300, UnitPriceCents: 10, LineTotalCents: 3000}},
+	}
+	return id
+}
+
+func as(tenantID uuid.UUID) context.Context {
+	return identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenantID.String(), Role: "admin"})
+}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Errorf("err = %v; want %s", err, code)
+	}
+}
+
+func TestCallsNeedATenant(t *testing.T) {
+	s, _ := newTestServer()
+	_, err := s.GetCurrentUsage(context.Background(), &proto.GetCurrentUsageRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	ops := identity.NewContext(context.Background(), identity.Identity{UserID: "ops", SuperAdmin: true})
+	_, err = s.ListInvoices(ops, &proto.ListInvoicesRequest{})
+	wantCode(t, err, codes.PermissionDenied)
+}
+
+func TestCurrentUsage(t *testing.T) {
+	s, _ := newTestServer()
+	resp, err := s.GetCurrentUsage(as(acme), &proto.GetCurrentUsageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Year != 2025 || resp.Month != 7 || len(resp.Items) != 1 || resp.Items[0].Quantity != 42 {
+		t.Errorf("usage = %+v", resp)
+	}
+}
+
+func TestGetPlan(t *testing.T) {
+	s, _ := newTestServer()
+	pro, err := s.GetPlan(as(acme), &proto.GetPlanRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pro.Plan != "PRO" || pro.RequestsPerMinute != 600 || pro.MonthlyShipments != 5000 || pro.ShipmentsUsed != 42 {
+		t.Errorf("acme plan = %+v", pro)
+	}
+	free, err := s.GetPlan(as(globex), &proto.GetPlanRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free.Plan != "FREE" || free.MonthlyShipments != 100 || free.ShipmentsUsed != 7 {
+		t.Errorf("globex plan = %+v", free)
+	}
+}
+
+func TestInvoicesAreTenantScoped(t *testing.T) {
+	s, f := newTestServer()
+	mine := f.addInvoice(acme, 6, invoice.InvoiceFinalized)
+	theirs := f.addInvoice(globex, 6, invoice.InvoiceFinalized)
+
+	list, err := s.ListInvoices(as(acme), &proto.ListInvoicesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Invoices) != 1 || list.Invoices[0].InvoiceId != mine.String() {
+		t.Errorf("invoices = %+v", list.Invoices)
+	}
+
+	inv, err := s.GetInvoice(as(acme), &proto.GetInvoiceRequest{InvoiceId: mine.String()})
+	if err != nil || len(inv.Lines) != 1 || inv.Lines[0].LineTotalCents != 3000 {
+		t.Errorf("invoice = %+v, err %v", inv, err)
+	}
+	_, err = s.GetInvoice(as(acme), &proto.GetInvoiceRequest{InvoiceId: theirs.String()})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.PayInvoice(as(acme), &proto.PayInvoiceRequest{InvoiceId: theirs.String()})
+	wantCode(t, err, codes.NotFound)
+	_, err = s.GetInvoice(as(acme), &proto.GetInvoiceRequest{InvoiceId: "not-a-uuid"})
+	wantCode(t, err, codes.InvalidArgument)
+	if len(f.paid) != 0 {
+		t.Errorf("paid %v", f.paid)
+	}
+}
+
+func TestLedgerEntries(t *testing.T) {
+	s, _ := newTestServer()
+	resp, err := s.ListLedgerEntries(as(acme), &proto.ListLedgerEntriesRequest{Year: 2025, Month: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].ReferenceId != "e-1" {
+		t.Errorf("entries = %+v", resp.Entries)
+	}
+	_, err = s.ListLedgerEntries(as(acme), &proto.ListLedgerEntriesRequest{Month: 6})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestPayInvoice(t *testing.T) {
+	s, f := newTestServer()
+	id := f.addInvoice(acme, 6, invoice.InvoiceFinalized)
+	inv, err := s.PayInvoice(as(acme), &proto.PayInvoiceRequest{InvoiceId: id.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Status != "PAID" || inv.PaidAt != "2025-07-02T09:00:00Z" {
+		t.Errorf("invoice after payment = %+v", inv)
+	}
+
+	// Paying again is a no-op
+	if _, err := s.PayInvoice(as(acme), &proto.PayInvoiceRequest{InvoiceId: id.String()}); err != nil || len(f.paid) != 1 {
+		t.Errorf("second payment: err %v, charges %d", err, len(f.paid))
+	}
+
+	draft := f.addInvoice(acme, 7, invoice.InvoiceDraft)
+	_, err = s.PayInvoice(as(acme), &proto.PayInvoiceRequest{InvoiceId: draft.String()})
+	wantCode(t, err, codes.FailedPrecondition)
+}
+
+func TestPayInvoiceErrors(t *testing.T) {
+	cases := map[error]codes.Code{
+		fmt.Errorf("%w (attempt x)", payment.ErrPaymentInProgress):                                  codes.Aborted,
+		fmt.Errorf("payment failed after retries: %w: card was declined", payment.ErrPaymentFailed): codes.FailedPrecondition,
+		payment.ErrProviderDown:     codes.Unavailable,
+		fmt.Errorf("db is on fire"): codes.Internal,
+	}
+	for payErr, code := range cases {
+		s, f := newTestServer()
+		id := f.addInvoice(acme, 6, invoice.InvoiceFinalized)
+		f.payErr = payErr
+		_, err := s.PayInvoice(as(acme), &proto.PayInvoiceRequest{InvoiceId: id.String()})
+		wantCode(t, err, code)
+	}
+}
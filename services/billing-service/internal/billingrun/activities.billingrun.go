@@ -0,0 +1,152 @@
+// services/billing-service/internal/billingrun/activities.billingrun.go
+
+package billingrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/ledger"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/payment"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+)
+
+// BillingActivities wraps the existing billing building blocks as Temporal activities.
+// Every step is idempotent on its own (deterministic ledger IDs, invoice state machine,
+// pending-attempt guard), which is what makes a crashed run safe to resume.
+type BillingActivities struct {
+	Usage      store.UsageStore
+	Calculator interface {
+		BillTenantPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) error
+		PreviewTenantPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error)
+	}
+	Generator interface {
+		GenerateInvoiceForTenant(ctx context.Context, tenantID uuid.UUID, year int, month int) (*invoice.Invoice, error)
+	}
+	Invoices interface {
+		GetInvoice(ctx context.Context, tenantID uuid.UUID, year int, month int) (*invoice.Invoice, error)
+	}
+	Finalizer interface {
+		FinalizeInvoice(ctx context.Context, invoiceID uuid.UUID) error
+	}
+	Payments interface {
+		PayInvoice(ctx context.Context, invoiceID uuid.UUID) error
+	}
+}
+
+// Activity 1: Which tenants have usage in this period?
+func (a *BillingActivities) ACTIVITY_ListBillableTenants(ctx context.Context, year int, month int) ([]string, error) {
+	records, err := a.Usage.GetUsageForPeriod(ctx, year, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage for %04d-%02d: %w", year, month, err)
+	}
+	seen := make(map[uuid.UUID]bool)
+	var tenants []string
+	for _, r := range records {
+		if !seen[r.TenantID] {
+			seen[r.TenantID] = true
+			tenants = append(tenants, r.TenantID.String())
+		}
+	}
+	// Stable order keeps the workflow history (and the report) readable
+	sort.Strings(tenants)
+	return tenants, nil
+}
+
+// Activity 2: Usage -> Ledger for one tenant. Safe to retry: ledger IDs are deterministic.
+func (a *BillingActivities) ACTIVITY_CalculateTenantUsage(ctx context.Context, in TenantBillingInput) error {
+	tenantID, err := parseTenant(in.TenantID)
+	if err != nil {
+		return err
+	}
+	return a.Calculator.BillTenantPeriod(ctx, tenantID, in.Year, in.Month)
+}
+
+// Activity 2 (dry run): price the usage without touching the ledger.
+func (a *BillingActivities) ACTIVITY_PreviewTenantCharges(ctx context.Context, in TenantBillingInput) (TenantBillingResult, error) {
+	tenantID, err := parseTenant(in.TenantID)
+	if err != nil {
+		return TenantBillingResult{}, err
+	}
+	entries, err := a.Calculator.PreviewTenantPeriod(ctx, tenantID, in.Year, in.Month)
+	if err != nil {
+		return TenantBillingResult{}, err
+	}
+	result := TenantBillingResult{TenantID: in.TenantID, Status: TenantPreviewed}
+	for _, e := range entries {
+		result.AmountCents += e.AmountCents
+		result.Currency = e.Currency
+	}
+	return result, nil
+}
+
+// Activity 3: Ledger -> Draft invoice.
+// If a previous run already finalized the invoice we hand that one back, so a
+// resumed run carries on to the charge step instead of failing.
+func (a *BillingActivities) ACTIVITY_GenerateTenantInvoice(ctx context.Context, in TenantBillingInput) (InvoiceRef, error) {
+	tenantID, err := parseTenant(in.TenantID)
+	if err != nil {
+		return InvoiceRef{}, err
+	}
+	inv, err := a.Generator.GenerateInvoiceForTenant(ctx, tenantID, in.Year, in.Month)
+	if errors.Is(err, invoice.ErrInvoiceAlreadyFinalized) {
+		inv, err = a.Invoices.GetInvoice(ctx, tenantID, in.Year, in.Month)
+	}
+	if errors.Is(err, invoice.ErrCurrencyMismatch) {
+		// Retrying will not fix the ledger, an operator has to
+		return InvoiceRef{}, temporal.NewNonRetryableApplicationError("invoice generation failed", "CurrencyMismatch", err)
+	}
+	if err != nil {
+		return InvoiceRef{}, err
+	}
+	if inv == nil {
+		return InvoiceRef{}, nil // No ledger activity, nothing to bill
+	}
+	return InvoiceRef{
+		InvoiceID:  inv.InvoiceID.String(),
+		Status:     string(inv.Status),
+		TotalCents: inv.TotalCents,
+		Currency:   inv.Currency,
+	}, nil
+}
+
+// Activity 4: DRAFT -> FINALIZED. Already finalized (or paid) counts as done.
+func (a *BillingActivities) ACTIVITY_FinalizeTenantInvoice(ctx context.Context, ref InvoiceRef) error {
+	invoiceID, err := uuid.Parse(ref.InvoiceID)
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError("invalid invoice id", "InvalidInvoice", err)
+	}
+	err = a.Finalizer.FinalizeInvoice(ctx, invoiceID)
+	if errors.Is(err, invoice.ErrInvoiceAlreadyFinalized) || errors.Is(err, invoice.ErrInvoiceNotDraft) {
+		// The charge step re-checks the status, so a VOID invoice still surfaces there
+		return nil
+	}
+	return err
+}
+
+// Activity 5: Charge the card on file. PayInvoice is a no-op for PAID invoices and
+// refuses to open a second attempt while one is PENDING.
+func (a *BillingActivities) ACTIVITY_ChargeTenantInvoice(ctx context.Context, ref InvoiceRef) error {
+	invoiceID, err := uuid.Parse(ref.InvoiceID)
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError("invalid invoice id", "InvalidInvoice", err)
+	}
+	err = a.Payments.PayInvoice(ctx, invoiceID)
+	if errors.Is(err, payment.ErrPaymentInProgress) {
+		return temporal.NewNonRetryableApplicationError("payment already in progress", "PaymentInProgress", err)
+	}
+	return err
+}
+
+func parseTenant(id string) (uuid.UUID, error) {
+	tenantID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, temporal.NewNonRetryableApplicationError("invalid tenant id "+id, "InvalidTenant", err)
+	}
+	return tenantID, nil
+}
@@ -0,0 +1,145 @@
+package billingrun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/ledger"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/payment"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/google/uuid"
+)
+
+// --- FAKES ---
+
+type fakeUsage struct{ records []store.UsageRecord }
+
+func (f *fakeUsage) Flush(ctx context.Context, batch store.FlushBatch) error { return nil }
+func (f *fakeUsage) GetUsageForPeriod(ctx context.Context, year, month int) ([]store.UsageRecord, error) {
+	return f.records, nil
+}
+func (f *fakeUsage) GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year, month int) ([]store.UsageRecord, error) {
+	panic("unimplemented")
+}
+
+type fakeCalculator struct{ preview []ledger.LedgerEntry }
+
+func (f *fakeCalculator) BillTenantPeriod(ctx context.Context, tenantID uuid.UUID, year, month int) error {
+	return nil
+}
+func (f *fakeCalculator) PreviewTenantPeriod(ctx context.Context, tenantID uuid.UUID, year, month int) ([]ledger.LedgerEntry, error) {
+	return f.preview, nil
+}
+
+type fakeGenerator struct {
+	inv *invoice.Invoice
+	err error
+}
+
+func (f *fakeGenerator) GenerateInvoiceForTenant(ctx context.Context, tenantID uuid.UUID, year, month int) (*invoice.Invoice, error) {
+	return f.inv, f.err
+}
+
+type fakeInvoices struct{ inv *invoice.Invoice }
+
+func (f *fakeInvoices) GetInvoice(ctx context.Context, tenantID uuid.UUID, year, month int) (*invoice.Invoice, error) {
+	return f.inv, nil
+}
+
+type fakeFinalizer struct{ err error }
+
+func (f *fakeFinalizer) FinalizeInvoice(ctx context.Context, invoiceID uuid.UUID) error { return f.err }
+
+type fakePayments struct{ err error }
+
+func (f *fakePayments) PayInvoice(ctx context.Context, invoiceID uuid.UUID) error { return f.err }
+
+// --- TESTS ---
+
+func TestListBillableTenants_Dedupes(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	acts := &BillingActivities{Usage: &fakeUsage{records: []store.UsageRecord{
+		{TenantID: a, UsageType: billingtypes.ShipmentCreated},
+		{TenantID: a, UsageType: billingtypes.APIRequest},
+		{TenantID: b, UsageType: billingtypes.ShipmentCreated},
+	}}}
+
+	tenants, err := acts.ACTIVITY_ListBillableTenants(context.Background(), 2024, 6)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tenants) != 2 {
+		t.Fatalf("expected 2 tenants, got %v", tenants)
+	}
+}
+
+func TestGenerateTenantInvoice_ResumesFinalizedInvoice(t *testing.T) {
+	// A crashed run already finalized the invoice: regenerating must hand back the existing one
+	existing := &invoice.Invoice{InvoiceID: uuid.New(), Status: invoice.InvoiceFinalized, TotalCents: 500, Currency: "USD"}
+	acts := &BillingActivities{
+		Generator: &fakeGenerator{err: invoice.ErrInvoiceAlreadyFinalized},
+		Invoices:  &fakeInvoices{inv: existing},
+	}
+
+	ref, err := acts.ACTIVITY_GenerateTenantInvoice(context.Background(), TenantBillingInput{TenantID: uuid.NewString(), Year: 2024, Month: 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref.InvoiceID != existing.InvoiceID.String() || ref.TotalCents != 500 {
+		t.Fatalf("expected existing invoice, got %+v", ref)
+	}
+}
+
+func TestGenerateTenantInvoice_NothingToBill(t *testing.T) {
+	acts := &BillingActivities{Generator: &fakeGenerator{}}
+
+	ref, err := acts.ACTIVITY_GenerateTenantInvoice(context.Background(), TenantBillingInput{TenantID: uuid.NewString(), Year: 2024, Month: 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref.InvoiceID != "" {
+		t.Fatalf("expected empty ref, got %+v", ref)
+	}
+}
+
+func TestFinalizeTenantInvoice_AlreadyFinalizedIsSuccess(t *testing.T) {
+	acts := &BillingActivities{Finalizer: &fakeFinalizer{err: fmt.Errorf("%w: current status is PAID", invoice.ErrInvoiceNotDraft)}}
+
+	if err := acts.ACTIVITY_FinalizeTenantInvoice(context.Background(), InvoiceRef{InvoiceID: uuid.NewString()}); err != nil {
+		t.Fatalf("expected finalize to be idempotent, got %v", err)
+	}
+}
+
+func TestChargeTenantInvoice_PendingAttemptIsNotRetried(t *testing.T) {
+	acts := &BillingActivities{Payments: &fakePayments{err: fmt.Errorf("%w (attempt x)", payment.ErrPaymentInProgress)}}
+
+	err := acts.ACTIVITY_ChargeTenantInvoice(context.Background(), InvoiceRef{InvoiceID: uuid.NewString()})
+	if err == nil {
+		t.Fatal("expected charge to fail while a payment is pending")
+	}
+}
+
+func TestPreviewTenantCharges_SumsEntries(t *testing.T) {
+	acts := &BillingActivities{Calculator: &fakeCalculator{preview: []ledger.LedgerEntry{
+		{AmountCents: 500, Currency: "USD"},
+		{AmountCents: 120, Currency: "USD"},
+	}}}
+	tenantID := uuid.NewString()
+
+	result, err := acts.ACTIVITY_PreviewTenantCharges(context.Background(), TenantBillingInput{TenantID: tenantID, Year: 2024, Month: 6, DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != TenantPreviewed || result.AmountCents != 620 || result.TenantID != tenantID {
+		t.Fatalf("unexpected preview: %+v", result)
+	}
+}
+
+func TestParseTenant_RejectsGarbage(t *testing.T) {
+	if _, err := parseTenant("not-a-uuid"); err == nil {
+		t.Fatal("expected invalid tenant id to fail")
+	}
+}
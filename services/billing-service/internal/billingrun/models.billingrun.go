@@ -0,0 +1,96 @@
+// services/billing-service/internal/billingrun/models.billingrun.go
+
+package billingrun
+
+import "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+
+const (
+	// TaskQueue is polled by the billing-service worker (cmd/main.go).
+	TaskQueue = contracts.BillingTaskQueue
+
+	MonthlyBillingWorkflowName = contracts.MonthlyBillingWorkflowName
+	TenantBillingWorkflowName  = contracts.TenantBillingWorkflowName
+
+	// ScheduleID identifies the Temporal schedule that starts the run every month.
+	ScheduleID = "monthly-billing-run"
+)
+
+// TenantStatus is the final state of one tenant in a billing run.
+type TenantStatus string
+
+const (
+	TenantCharged   TenantStatus = "CHARGED"    // invoice finalized and paid
+	TenantNoCharges TenantStatus = "NO_CHARGES" // nothing to invoice this month
+	TenantPreviewed TenantStatus = "PREVIEWED"  // dry run: priced, nothing written
+	TenantFailed    TenantStatus = "FAILED"
+)
+
+// MonthlyBillingInput is the argument of MonthlyBillingWorkflow.
+// A zero Year/Month means "the month before the run started" which is what the schedule wants.
+type MonthlyBillingInput struct {
+	Year   int
+	Month  int
+	DryRun bool // price usage and report, but write nothing and charge nobody
+}
+
+// TenantBillingInput is the argument of TenantBillingWorkflow and its activities.
+type TenantBillingInput struct {
+	TenantID string
+	Year     int
+	Month    int
+	DryRun   bool
+}
+
+// InvoiceRef is the slice of an invoice the workflow needs to carry between activities.
+// An empty InvoiceID means the tenant had nothing to invoice.
+type InvoiceRef struct {
+	InvoiceID  string
+	Status     string
+	TotalCents int64
+	Currency   string
+}
+
+// TenantBillingResult is returned by TenantBillingWorkflow.
+type TenantBillingResult struct {
+	TenantID    string
+	Status      TenantStatus
+	InvoiceID   string
+	AmountCents int64
+	Currency    string
+	Error       string
+}
+
+// BillingRunSummary is the report returned by MonthlyBillingWorkflow.
+type BillingRunSummary struct {
+	Year             int
+	Month            int
+	DryRun           bool
+	TenantsProcessed int
+	Charged          int
+	NoCharges        int
+	Previewed        int
+	Failed           int
+	// Totals are per currency because tenants can be billed in different currencies
+	TotalCents map[string]int64
+	Tenants    []TenantBillingResult
+}
+
+// add folds one tenant's result into the summary.
+func (s *BillingRunSummary) add(r TenantBillingResult) {
+	s.TenantsProcessed++
+	s.Tenants = append(s.Tenants, r)
+	switch r.Status {
+	case TenantCharged:
+		s.Charged++
+	case TenantNoCharges:
+		s.NoCharges++
+	case TenantPreviewed:
+		s.Previewed++
+	case TenantFailed:
+		s.Failed++
+		return
+	}
+	if r.AmountCents != 0 {
+		s.TotalCents[r.Currency] += r.AmountCents
+	}
+}
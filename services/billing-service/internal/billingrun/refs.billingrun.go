@@ -0,0 +1,78 @@
+// services/billing-service/internal/billingrun/refs.billingrun.go
+
+package billingrun
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Every billing activity, with the task queue it runs on. Like the refs in
+// workflow-orchestrator/internal/registry (which billing-service cannot import),
+// the workflow calls them with Execute and the worker registers them with
+// RegisterActivity/RegisterAction, so the name is written once and a
+// mismatched signature does not compile.
+var (
+	ListBillableTenants   = TenantsRef{Name: "ACTIVITY_ListBillableTenants", TaskQueue: TaskQueue}
+	CalculateTenantUsage  = ActionRef[TenantBillingInput]{Name: "ACTIVITY_CalculateTenantUsage", TaskQueue: TaskQueue}
+	PreviewTenantCharges  = ActivityRef[TenantBillingInput, TenantBillingResult]{Name: "ACTIVITY_PreviewTenantCharges", TaskQueue: TaskQueue}
+	GenerateTenantInvoice = ActivityRef[TenantBillingInput, InvoiceRef]{Name: "ACTIVITY_GenerateTenantInvoice", TaskQueue: TaskQueue}
+	FinalizeTenantInvoice = ActionRef[InvoiceRef]{Name: "ACTIVITY_FinalizeTenantInvoice", TaskQueue: TaskQueue}
+	ChargeTenantInvoice   = ActionRef[InvoiceRef]{Name: "ACTIVITY_ChargeTenantInvoice", TaskQueue: TaskQueue}
+)
+
+// ActivityRef names an activity that takes I and returns (O, error).
+type ActivityRef[I, O any] struct {
+	Name      string
+	TaskQueue string
+}
+
+// Execute schedules the activity on its task queue and waits for the result.
+func (r ActivityRef[I, O]) Execute(ctx workflow.Context, in I) (O, error) {
+	var out O
+	err := workflow.ExecuteActivity(workflow.WithTaskQueue(ctx, r.TaskQueue), r.Name, in).Get(ctx, &out)
+	return out, err
+}
+
+// ActionRef names an activity that takes I and only returns an error.
+type ActionRef[I any] struct {
+	Name      string
+	TaskQueue string
+}
+
+// Execute schedules the activity on its task queue and waits for it to finish.
+func (r ActionRef[I]) Execute(ctx workflow.Context, in I) error {
+	return workflow.ExecuteActivity(workflow.WithTaskQueue(ctx, r.TaskQueue), r.Name, in).Get(ctx, nil)
+}
+
+// TenantsRef names ACTIVITY_ListBillableTenants, which takes the year and
+// month as two arguments; running billing runs still schedule it that way.
+type TenantsRef struct {
+	Name      string
+	TaskQueue string
+}
+
+// Execute schedules the activity on its task queue and waits for the tenant IDs.
+func (r TenantsRef) Execute(ctx workflow.Context, year, month int) ([]string, error) {
+	var tenants []string
+	err := workflow.ExecuteActivity(workflow.WithTaskQueue(ctx, r.TaskQueue), r.Name, year, month).Get(ctx, &tenants)
+	return tenants, err
+}
+
+// RegisterActivity registers fn under the ref's name.
+func RegisterActivity[I, O any](w worker.Registry, ref ActivityRef[I, O], fn func(context.Context, I) (O, error)) {
+	w.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: ref.Name})
+}
+
+// RegisterAction registers fn under the ref's name.
+func RegisterAction[I any](w worker.Registry, ref ActionRef[I], fn func(context.Context, I) error) {
+	w.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: ref.Name})
+}
+
+// RegisterTenants registers fn under the ref's name.
+func RegisterTenants(w worker.Registry, ref TenantsRef, fn func(context.Context, int, int) ([]string, error)) {
+	w.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: ref.Name})
+}
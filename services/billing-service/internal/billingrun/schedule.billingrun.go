@@ -0,0 +1,54 @@
+// services/billing-service/internal/billingrun/schedule.billingrun.go
+
+package billingrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// MonthlyCron fires at 02:00 UTC on the 1st, after the usage aggregator has flushed the closed month.
+const MonthlyCron = "0 2 1 * *"
+
+// EnsureMonthlySchedule registers the Temporal schedule that starts MonthlyBillingWorkflow.
+// Safe to call on every boot: an existing schedule is updated to the current
+// cron and action, so a changed dry-run setting takes effect on the next run.
+func EnsureMonthlySchedule(ctx context.Context, c client.Client, dryRun bool) error {
+	spec := client.ScheduleSpec{
+		CronExpressions: []string{MonthlyCron},
+	}
+	action := &client.ScheduleWorkflowAction{
+		ID:        "monthly-billing", // Temporal appends the scheduled time
+		Workflow:  MonthlyBillingWorkflowName,
+		Args:      []interface{}{MonthlyBillingInput{DryRun: dryRun}},
+		TaskQueue: TaskQueue,
+	}
+	_, err := c.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:     ScheduleID,
+		Spec:   spec,
+		Action: action,
+	})
+	if errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		// Created by an earlier boot, possibly with other settings
+		err = c.ScheduleClient().GetHandle(ctx, ScheduleID).Update(ctx, client.ScheduleUpdateOptions{
+			DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+				schedule := input.Description.Schedule
+				schedule.Spec = &spec
+				schedule.Action = action
+				return &client.ScheduleUpdate{Schedule: &schedule}, nil
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update billing schedule: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create billing schedule: %w", err)
+	}
+	return nil
+}
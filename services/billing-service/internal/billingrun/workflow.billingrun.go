@@ -0,0 +1,170 @@
+// services/billing-service/internal/billingrun/workflow.billingrun.go
+
+package billingrun
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// maxConcurrentTenants caps how many tenant child workflows run at once,
+// so a large run does not hammer Postgres and Stripe at the same time.
+const maxConcurrentTenants = 20
+
+// MonthlyBillingWorkflow bills every tenant with usage in the given month.
+// Each tenant runs in its own child workflow (calculate -> generate -> finalize -> charge),
+// so one broken tenant is reported in the summary instead of failing the whole run.
+func MonthlyBillingWorkflow(ctx workflow.Context, in MonthlyBillingInput) (BillingRunSummary, error) {
+	logger := workflow.GetLogger(ctx)
+
+	if in.Year == 0 || in.Month == 0 {
+		// Started by the schedule on the 1st: bill the month that just closed
+		prev := workflow.Now(ctx).UTC().AddDate(0, -1, 0)
+		in.Year, in.Month = prev.Year(), int(prev.Month())
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second * 45,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    100,
+		},
+	})
+
+	//Step 1: Find the tenants to bill
+	tenants, err := ListBillableTenants.Execute(ctx, in.Year, in.Month)
+	if err != nil {
+		return BillingRunSummary{}, err
+	}
+	logger.Info("billing run started", "year", in.Year, "month", in.Month, "tenants", len(tenants), "dry_run", in.DryRun)
+
+	summary := BillingRunSummary{
+		Year:       in.Year,
+		Month:      in.Month,
+		DryRun:     in.DryRun,
+		TotalCents: make(map[string]int64),
+	}
+
+	//Step 2: Fan out, one child per tenant, in bounded batches
+	for start := 0; start < len(tenants); start += maxConcurrentTenants {
+		end := start + maxConcurrentTenants
+		if end > len(tenants) {
+			end = len(tenants)
+		}
+		batch := tenants[start:end]
+
+		futures := make([]workflow.ChildWorkflowFuture, len(batch))
+		for i, tenantID := range batch {
+			childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				// Deterministic ID: Temporal refuses to run two children for the same
+				// tenant/month at once, which is our first line against double billing.
+				WorkflowID: tenantWorkflowID(tenantID, in.Year, in.Month, in.DryRun),
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:    time.Minute,
+					BackoffCoefficient: 2.0,
+					MaximumInterval:    time.Hour,
+					MaximumAttempts:    3,
+				},
+			})
+			futures[i] = workflow.ExecuteChildWorkflow(childCtx, TenantBillingWorkflowName, TenantBillingInput{
+				TenantID: tenantID,
+				Year:     in.Year,
+				Month:    in.Month,
+				DryRun:   in.DryRun,
+			})
+		}
+
+		//Step 3: Collect results into the report
+		for i, f := range futures {
+			var result TenantBillingResult
+			if err := f.Get(ctx, &result); err != nil {
+				logger.Error("tenant billing failed", "tenant_id", batch[i], "error", err)
+				result = TenantBillingResult{TenantID: batch[i], Status: TenantFailed, Error: err.Error()}
+			}
+			summary.add(result)
+		}
+	}
+
+	logger.Info("billing run finished",
+		"year", in.Year, "month", in.Month,
+		"charged", summary.Charged, "no_charges", summary.NoCharges,
+		"previewed", summary.Previewed, "failed", summary.Failed)
+	return summary, nil
+}
+
+// TenantBillingWorkflow takes one tenant from raw usage to a paid invoice.
+// Completed steps are not re-run when the worker restarts (they are in the history),
+// and each activity is idempotent in case it was mid-flight during the crash.
+func TenantBillingWorkflow(ctx workflow.Context, in TenantBillingInput) (TenantBillingResult, error) {
+	retrypolicy := &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    100,
+	}
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second * 45,
+		RetryPolicy:         retrypolicy,
+	})
+
+	if in.DryRun {
+		return PreviewTenantCharges.Execute(ctx, in)
+	}
+
+	//Step 1: Usage -> Ledger
+	if err := CalculateTenantUsage.Execute(ctx, in); err != nil {
+		return TenantBillingResult{}, err
+	}
+
+	//Step 2: Ledger -> Draft invoice
+	ref, err := GenerateTenantInvoice.Execute(ctx, in)
+	if err != nil {
+		return TenantBillingResult{}, err
+	}
+	if ref.InvoiceID == "" {
+		return TenantBillingResult{TenantID: in.TenantID, Status: TenantNoCharges}, nil
+	}
+
+	//Step 3: Draft -> Finalized
+	if err := FinalizeTenantInvoice.Execute(ctx, ref); err != nil {
+		return TenantBillingResult{}, err
+	}
+
+	//Step 4: Charge. Stripe calls are slow and PaymentService already retries
+	// internally, so we give it more time and fewer attempts.
+	chargeCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 2 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Minute,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    30 * time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+	if err := ChargeTenantInvoice.Execute(chargeCtx, ref); err != nil {
+		return TenantBillingResult{}, err
+	}
+
+	return TenantBillingResult{
+		TenantID:    in.TenantID,
+		Status:      TenantCharged,
+		InvoiceID:   ref.InvoiceID,
+		AmountCents: ref.TotalCents,
+		Currency:    ref.Currency,
+	}, nil
+}
+
+// tenantWorkflowID is stable across retries and restarts of the parent run.
+func tenantWorkflowID(tenantID string, year int, month int, dryRun bool) string {
+	id := fmt.Sprintf("billing-%04d-%02d-%s", year, month, tenantID)
+	if dryRun {
+		// Dry runs must never block (or be mistaken for) the real run
+		id += "-dryrun"
+	}
+	return id
+}
@@ -0,0 +1,122 @@
+package billingrun
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+// fakeBillingActivities stands in for the ledger, invoice and Stripe side.
+type fakeBillingActivities struct {
+	tenants    []string
+	failTenant string // charging this tenant fails permanently
+	calculated int
+	charged    []string
+}
+
+func (f *fakeBillingActivities) register(env *testsuite.TestWorkflowEnvironment) {
+	env.RegisterWorkflowWithOptions(TenantBillingWorkflow, workflow.RegisterOptions{Name: TenantBillingWorkflowName})
+
+	env.RegisterActivityWithOptions(func(year, month int) ([]string, error) {
+		return f.tenants, nil
+	}, activity.RegisterOptions{Name: ListBillableTenants.Name})
+
+	env.RegisterActivityWithOptions(func(in TenantBillingInput) error {
+		f.calculated++
+		return nil
+	}, activity.RegisterOptions{Name: CalculateTenantUsage.Name})
+
+	env.RegisterActivityWithOptions(func(in TenantBillingInput) (TenantBillingResult, error) {
+		return TenantBillingResult{TenantID: in.TenantID, Status: TenantPreviewed, AmountCents: 100, Currency: "USD"}, nil
+	}, activity.RegisterOptions{Name: PreviewTenantCharges.Name})
+
+	env.RegisterActivityWithOptions(func(in TenantBillingInput) (InvoiceRef, error) {
+		return InvoiceRef{InvoiceID: "inv-" + in.TenantID, Status: "DRAFT", TotalCents: 250, Currency: "USD"}, nil
+	}, activity.RegisterOptions{Name: GenerateTenantInvoice.Name})
+
+	env.RegisterActivityWithOptions(func(ref InvoiceRef) error {
+		return nil
+	}, activity.RegisterOptions{Name: FinalizeTenantInvoice.Name})
+
+	env.RegisterActivityWithOptions(func(ref InvoiceRef) error {
+		if ref.InvoiceID == "inv-"+f.failTenant {
+			return temporal.NewNonRetryableApplicationError("card declined", "CardDeclined", errors.New("declined"))
+		}
+		f.charged = append(f.charged, ref.InvoiceID)
+		return nil
+	}, activity.RegisterOptions{Name: ChargeTenantInvoice.Name})
+}
+
+func TestMonthlyBillingWorkflow_ChargesEveryTenant(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	fakes := &fakeBillingActivities{tenants: []string{"t-1", "t-2"}}
+	fakes.register(env)
+
+	env.ExecuteWorkflow(MonthlyBillingWorkflow, MonthlyBillingInput{Year: 2024, Month: 6})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var summary BillingRunSummary
+	require.NoError(t, env.GetWorkflowResult(&summary))
+	require.Equal(t, 2, summary.Charged)
+	require.Equal(t, int64(500), summary.TotalCents["USD"])
+	require.Len(t, fakes.charged, 2)
+}
+
+func TestMonthlyBillingWorkflow_DryRunChargesNobody(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	fakes := &fakeBillingActivities{tenants: []string{"t-1", "t-2"}}
+	fakes.register(env)
+
+	env.ExecuteWorkflow(MonthlyBillingWorkflow, MonthlyBillingInput{Year: 2024, Month: 6, DryRun: true})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var summary BillingRunSummary
+	require.NoError(t, env.GetWorkflowResult(&summary))
+	require.Equal(t, 2, summary.Previewed)
+	require.Equal(t, 0, fakes.calculated)
+	require.Empty(t, fakes.charged)
+}
+
+func TestMonthlyBillingWorkflow_FailedTenantIsReported(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	fakes := &fakeBillingActivities{tenants: []string{"t-1", "t-2"}, failTenant: "t-2"}
+	fakes.register(env)
+
+	env.ExecuteWorkflow(MonthlyBillingWorkflow, MonthlyBillingInput{Year: 2024, Month: 6})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var summary BillingRunSummary
+	require.NoError(t, env.GetWorkflowResult(&summary))
+	require.Equal(t, 1, summary.Charged)
+	require.Equal(t, 1, summary.Failed)
+}
+
+func TestMonthlyBillingWorkflow_DefaultsToPreviousMonth(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.SetStartTime(time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC))
+	fakes := &fakeBillingActivities{}
+	fakes.register(env)
+
+	env.ExecuteWorkflow(MonthlyBillingWorkflow, MonthlyBillingInput{})
+
+	var summary BillingRunSummary
+	require.NoError(t, env.GetWorkflowResult(&summary))
+	require.Equal(t, 2024, summary.Year)
+	require.Equal(t, 12, summary.Month)
+}
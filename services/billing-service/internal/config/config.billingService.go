@@ -12,6 +12,8 @@ type BillingConfig struct {
 	CommonConfig *config.CommonConfig // this helps to access DB and RabbitMQ configs directly
 	//Domain-specific configs can be added here in future if needed
 	StripeSecretKey string // Stripe API secret key
+	GRPCAddr        string // billing API for the gateway (BILLING_GRPC_ADDR, default :50053)
+	UsageTopic      string // usage events on KAFKA_BROKER (USAGE_TOPIC, default usage-events)
 }
 
 // LoadConfig loads the billing service configuration
@@ -25,9 +27,19 @@ func LoadConfig() (*BillingConfig, error) {
 		return nil, fmt.Errorf("STRIPE_SECRET_KEY is required")
 
 	}
+	grpcAddr := os.Getenv("BILLING_GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":50053"
+	}
+	usageTopic := os.Getenv("USAGE_TOPIC")
+	if usageTopic == "" {
+		usageTopic = "usage-events"
+	}
 	return &BillingConfig{
 		CommonConfig:    common,
 		StripeSecretKey: stripeKey,
+		GRPCAddr:        grpcAddr,
+		UsageTopic:      usageTopic,
 	}, nil
 
 }
@@ -28,6 +28,9 @@ type Invoice struct {
 	Status     InvoiceStatus
 	Lines      []InvoiceLine
 	CreatedAt  time.Time
+	// Set by the store when the invoice reaches that status; nil before.
+	FinalizedAt *time.Time
+	PaidAt      *time.Time
 }
 type InvoiceLine struct {
 	ID             uuid.UUID
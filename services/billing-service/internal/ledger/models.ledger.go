@@ -32,4 +32,8 @@ type LedgerEntry struct {
 	UsageType      billingtypes.UsageType
 	Quantity       int64 // e.g., number of shipments
 	UnitPriceCents int64 // price per unit in cents
+	// Billing period the entry belongs to. A June run happens on July 1st,
+	// so CreatedAt cannot be used to decide which invoice an entry lands on.
+	BillingYear  int
+	BillingMonth int
 }
@@ -101,6 +101,15 @@ func (ps *PaymentService) PayInvoiceExecution(ctx context.Context, invoiceID uui
 	if err != nil {
 		return errors.New("failed to fetch billing account details: " + err.Error())
 	}
+	// Double-billing guard: a crash between Stripe and our DB leaves the attempt PENDING.
+	// A new attempt would get a new idempotency key and Stripe would happily charge twice.
+	pending, err := ps.paymentAttemptStore.GetPendingAttemptForInvoice(ctx, inv.InvoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to check pending payment attempts: %w", err)
+	}
+	if pending != nil {
+		return fmt.Errorf("%w (attempt %s)", ErrPaymentInProgress, pending.AttemptID)
+	}
 	// ---  Record Intent (The State Machine) ---
 	attemptID := uuid.New()
 	attempt := &PaymentAttempt{
@@ -16,7 +16,9 @@ var (
 	ErrNoPaymentMethod = errors.New("customerr has no valid payment method on file")
 	ErrAlreadyPaid     = errors.New("invoice is already paid")
 	ErrProviderDown    = errors.New("payment provider is currently unavailable") //e.g stripe API down
-
+	// ErrPaymentInProgress means an earlier attempt is still PENDING. Charging again could double-bill,
+	// so we leave it to the Reconciler to settle the first attempt.
+	ErrPaymentInProgress = errors.New("a payment attempt for this invoice is still pending")
 )
 
 //PaymentRequest Encapsulates all data needed to a payment transaction
@@ -24,4 +24,7 @@ type PaymentAttemptStore interface {
 	// GetAttemptByProviderID is crucial for Webhooks (Lookup by 'pi_...').
 	// It allows us to correlate incoming webhook events with our internal records.it fetches payment attempt using provider payment id
 	GetAttemptByProviderID(ctx context.Context, providerID string) (*PaymentAttempt, error)
+	// GetPendingAttemptForInvoice returns the PENDING attempt for an invoice, or nil if there is none.
+	// PayInvoice uses it to avoid opening a second charge while the first one is still unresolved.
+	GetPendingAttemptForInvoice(ctx context.Context, invoiceID uuid.UUID) (*PaymentAttempt, error)
 }
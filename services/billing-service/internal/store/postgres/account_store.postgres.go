@@ -52,3 +52,17 @@ func (s *AccountStore) GetBillingAccountDetails(ctx context.Context, tenantID uu
 
 	return &acc, nil
 }
+
+// GetPlan reads only current_plan, so it works for accounts that have no
+// payment details yet. Tenants without an account row are on FREE.
+func (s *AccountStore) GetPlan(ctx context.Context, tenantID uuid.UUID) (accounts.PlanType, error) {
+	var plan accounts.PlanType
+	err := s.db.QueryRowContext(ctx, `SELECT current_plan FROM accounts WHERE id = $1`, tenantID).Scan(&plan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return accounts.FreePlan, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("db: plan fetch failed: %w", err)
+	}
+	return plan, nil
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
 	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/invoice"
@@ -120,7 +121,17 @@ func (store *PostgresInvoiceStore) GetInvoice(ctx context.Context, tenantID uuid
 		return nil, fmt.Errorf("failed to fetch invoice header: %w", err)
 	}
 
+	inv.FinalizedAt, inv.PaidAt = nullTimePtr(finalizedAt), nullTimePtr(paidAt)
+
 	// B. Fetch Lines
+	if err := store.loadLines(ctx, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// loadLines fills inv.Lines from invoice_lines.
+func (store *PostgresInvoiceStore) loadLines(ctx context.Context, inv *invoice.Invoice) error {
 	linesQuery := `
 		SELECT id, usage_type, quantity, unit_price_cents, line_total_cents, description
 		FROM invoice_lines
@@ -128,7 +139,7 @@ func (store *PostgresInvoiceStore) GetInvoice(ctx context.Context, tenantID uuid
 	`
 	rows, err := store.db.QueryContext(ctx, linesQuery, inv.InvoiceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch invoice lines: %w", err)
+		return fmt.Errorf("failed to fetch invoice lines: %w", err)
 	}
 	defer rows.Close()
 
@@ -144,13 +155,19 @@ func (store *PostgresInvoiceStore) GetInvoice(ctx context.Context, tenantID uuid
 			&line.LineTotalCents,
 			&line.Description,
 		); err != nil {
-			return nil, err
+			return err
 		}
 		line.UsageType = billingtypes.UsageType(uType)
 		inv.Lines = append(inv.Lines, line)
 	}
+	return rows.Err()
+}
 
-	return &inv, nil
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
 }
 
 // DeleteInvoice allows us to clear a DRAFT so we can regenerate it cleanly
@@ -202,6 +219,7 @@ func (s *PostgresInvoiceStore) GetInvoiceByID(ctx context.Context, invoiceID uui
 		}
 		return nil, fmt.Errorf("failed to fetch invoice by ID: %w", err)
 	}
+	inv.FinalizedAt, inv.PaidAt = nullTimePtr(finalizedAt), nullTimePtr(paidAt)
 	// 2. Fetch Lines (Reusing existing logic logic is fine, or simple query)
 	// For finalization validation, we specifically need to know if lines exist.
 	lineQuery := `SELECT count(*) FROM invoice_lines WHERE invoice_id = $1`
@@ -285,3 +303,84 @@ func (s *PostgresInvoiceStore) MarkInvoicePaid(ctx context.Context, invoiceID uu
 
 	return nil
 }
+
+// -------------------------------!! Billing API (tenant-scoped reads) !!-----------------------------------
+
+// ListInvoicesForTenant returns a tenant's invoice headers, newest billing period first.
+// Lines are left out; GetTenantInvoice loads them for one invoice.
+func (s *PostgresInvoiceStore) ListInvoicesForTenant(ctx context.Context, tenantID uuid.UUID, limit int) ([]invoice.Invoice, error) {
+	query := `
+		SELECT invoice_id, tenant_id, billing_year, billing_month, total_amount_cents, currency, status, created_at, finalized_at, paid_at
+		FROM invoices
+		WHERE tenant_id = $1
+		ORDER BY billing_year DESC, billing_month DESC
+		LIMIT $2
+	`
+	rows, err := s.db.QueryContext(ctx, query, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []invoice.Invoice
+	for rows.Next() {
+		var inv invoice.Invoice
+		var finalizedAt, paidAt sql.NullTime
+		if err := rows.Scan(
+			&inv.InvoiceID,
+			&inv.TenantID,
+			&inv.Year,
+			&inv.Month,
+			&inv.TotalCents,
+			&inv.Currency,
+			&inv.Status,
+			&inv.CreatedAt,
+			&finalizedAt,
+			&paidAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+		inv.FinalizedAt, inv.PaidAt = nullTimePtr(finalizedAt), nullTimePtr(paidAt)
+		invoices = append(invoices, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return invoices, nil
+}
+
+// GetTenantInvoice fetches one invoice with its lines, but only if it belongs to tenantID.
+// Like GetInvoice it returns nil, nil when there is no such invoice, so a caller
+// cannot tell another tenant's invoice from a missing one.
+func (s *PostgresInvoiceStore) GetTenantInvoice(ctx context.Context, tenantID uuid.UUID, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	query := `
+		SELECT invoice_id, tenant_id, billing_year, billing_month, total_amount_cents, currency, status, created_at, finalized_at, paid_at
+		FROM invoices
+		WHERE invoice_id = $1 AND tenant_id = $2
+	`
+	var inv invoice.Invoice
+	var finalizedAt, paidAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, query, invoiceID, tenantID).Scan(
+		&inv.InvoiceID,
+		&inv.TenantID,
+		&inv.Year,
+		&inv.Month,
+		&inv.TotalCents,
+		&inv.Currency,
+		&inv.Status,
+		&inv.CreatedAt,
+		&finalizedAt,
+		&paidAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	inv.FinalizedAt, inv.PaidAt = nullTimePtr(finalizedAt), nullTimePtr(paidAt)
+	if err := s.loadLines(ctx, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
@@ -24,8 +24,8 @@ func NewPostgresLedgerStore(db *sql.DB) *PostgresLedgerStore {
 func (store *PostgresLedgerStore) CreateLedgerEntry(ctx context.Context, entry ledger.LedgerEntry) error {
 	query := `
   INSERT INTO billing_ledger 
-  (tenant_id,  transaction_type,reference_id, amount_cents, usage_type, currency, description,quantity,unit_price_cents, created_at)
-  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+  (tenant_id,  transaction_type,reference_id, amount_cents, usage_type, currency, description,quantity,unit_price_cents, billing_year, billing_month, created_at)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
   ON CONFLICT (tenant_id, reference_id) DO NOTHING;
   
   `
@@ -40,6 +40,8 @@ func (store *PostgresLedgerStore) CreateLedgerEntry(ctx context.Context, entry l
 		entry.Description,
 		entry.Quantity,
 		entry.UnitPriceCents,
+		entry.BillingYear,
+		entry.BillingMonth,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert ledger entry: %w", err)
@@ -59,14 +61,17 @@ func (store *PostgresLedgerStore) CreateLedgerEntry(ctx context.Context, entry l
 
 	return nil
 }
-func (store *PostgresLedgerStore) GetLedgerEntriesForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error) {
-	// Implementation goes here
+
+// GetEntriesForPeriod implements store.LedgerStore.
+// Entries are matched on their billing period, not on created_at (see migration 010).
+func (store *PostgresLedgerStore) GetEntriesForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]ledger.LedgerEntry, error) {
 	query := `
-	SELECT tenant_id, transaction_type, reference_id, amount_cents, usage_type, currency, description,quantity,unit_price_cents, created_at
+	SELECT tenant_id, transaction_type, reference_id, amount_cents, usage_type, currency, description,quantity,unit_price_cents, billing_year, billing_month, created_at
 	FROM billing_ledger
 	WHERE tenant_id = $1
-	AND EXTRACT(YEAR FROM created_at) = $2
-	AND EXTRACT(MONTH FROM created_at) = $3;
+	AND billing_year = $2
+	AND billing_month = $3
+	ORDER BY created_at;
 	`
 	rows, err := store.db.QueryContext(ctx, query, tenantID, year, month)
 	if err != nil {
@@ -88,6 +93,8 @@ func (store *PostgresLedgerStore) GetLedgerEntriesForPeriod(ctx context.Context,
 			&entry.Description,
 			&entry.Quantity,
 			&entry.UnitPriceCents,
+			&entry.BillingYear,
+			&entry.BillingMonth,
 			&createdAt,
 		)
 		if err != nil {
@@ -124,8 +131,8 @@ func (store *PostgresLedgerStore) RecordCreditTransaction(ctx context.Context, t
 	// We use the same table but hardcode the types for a Payment.
 	query := `
 		INSERT INTO billing_ledger 
-		(tenant_id, transaction_type, reference_id, amount_cents, usage_type, currency, description, quantity, unit_price_cents, created_at)
-		VALUES ($1, 'PAYMENT', $2, $3, 'INVOICE_PAYMENT', $4, $5, 1, $3, NOW())
+		(tenant_id, transaction_type, reference_id, amount_cents, usage_type, currency, description, quantity, unit_price_cents, billing_year, billing_month, created_at)
+		VALUES ($1, 'PAYMENT', $2, $3, 'INVOICE_PAYMENT', $4, $5, 1, $3, EXTRACT(YEAR FROM NOW()), EXTRACT(MONTH FROM NOW()), NOW())
 		ON CONFLICT (tenant_id, reference_id) DO NOTHING
 	`
 
@@ -22,3 +22,14 @@ func TestPaymentAttemptMigrationContract(t *testing.T) {
 		t.Fatalf("migration contract mismatch: expected FK to invoices(invoice_id)")
 	}
 }
+
+func TestProcessedUsageEventsMigrationContract(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "db", "migrations", "013_create_processed_usage_events.sql")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read migration file: %v", err)
+	}
+	if !strings.Contains(string(b), "event_id TEXT PRIMARY KEY") {
+		t.Fatalf("migration contract mismatch: Flush claims event IDs with ON CONFLICT (event_id)")
+	}
+}
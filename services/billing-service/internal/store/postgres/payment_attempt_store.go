@@ -128,3 +128,76 @@ func (pa *PaymentAttemptStore) GetAttemptByProviderID(ctx context.Context, provi
 	return &payment, nil
 
 }
+
+// GetPendingAttemptForInvoice returns the most recent PENDING attempt for an invoice, or nil if none exists.
+func (pa *PaymentAttemptStore) GetPendingAttemptForInvoice(ctx context.Context, invoiceID uuid.UUID) (*payment.PaymentAttempt, error) {
+	query := `
+		SELECT attempt_id, invoice_id, tenant_id, status, amount_cents, created_at
+		FROM payment_attempts
+		WHERE invoice_id = $1 AND status = 'PENDING'
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	var attempt payment.PaymentAttempt
+	err := pa.db.QueryRowContext(ctx, query, invoiceID).Scan(&attempt.AttemptID, &attempt.InvoiceID, &attempt.TenantID, &attempt.Status, &attempt.AmountCents, &attempt.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to fetch pending attempt for invoice: %w", err)
+	}
+	return &attempt, nil
+}
+
+// ListAttemptsForTenant returns a tenant's payment attempts, newest first.
+// An invoiceID other than uuid.Nil limits them to that invoice's attempts.
+func (pa *PaymentAttemptStore) ListAttemptsForTenant(ctx context.Context, tenantID uuid.UUID, invoiceID uuid.UUID, limit int) ([]*payment.PaymentAttempt, error) {
+	query := `
+		SELECT attempt_id, invoice_id, tenant_id, provider, provider_payment_id, status, amount_cents, currency,
+		       error_code, error_message, COALESCE(retry_count, 0), created_at, updated_at
+		FROM payment_attempts
+		WHERE tenant_id = $1 AND ($2 = '00000000-0000-0000-0000-000000000000'::uuid OR invoice_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+	rows, err := pa.db.QueryContext(ctx, query, tenantID, invoiceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list payment attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*payment.PaymentAttempt
+	for rows.Next() {
+		attempt := &payment.PaymentAttempt{}
+		var providerPaymentID, errCode, errMsg sql.NullString
+		if err := rows.Scan(
+			&attempt.AttemptID,
+			&attempt.InvoiceID,
+			&attempt.TenantID,
+			&attempt.Provider,
+			&providerPaymentID,
+			&attempt.Status,
+			&attempt.AmountCents,
+			&attempt.Currency,
+			&errCode,
+			&errMsg,
+			&attempt.RetryCount,
+			&attempt.CreatedAt,
+			&attempt.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan payment attempt: %w", err)
+		}
+		attempt.ProviderPaymentID = providerPaymentID.String
+		if errCode.Valid {
+			attempt.ErrorCode = &errCode.String
+		}
+		if errMsg.Valid {
+			attempt.ErrorMessage = &errMsg.String
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: payment attempt iteration error: %w", err)
+	}
+	return attempts, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
 	store "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
+	"github.com/google/uuid"
 )
 
 type PostgresUsageStore struct { // db is the database connection (placeholder, implement as needed)
@@ -64,14 +65,45 @@ func (ps *PostgresUsageStore) Flush(ctx context.Context, batch store.FlushBatch)
 	}
 	defer stmt.Close() //Ensure statement is closed after use
 
+	// A batch ID only covers retries of this batch. An event redelivered by
+	// Kafka lands in a later batch, so each event ID is claimed as well and
+	// events an earlier batch claimed are left out of the totals.
+	claim, err := tx.PrepareContext(ctx, `
+	INSERT INTO processed_usage_events (event_id, tenant_id, usage_type)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (event_id) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare usage event claim: %w", err)
+	}
+	defer claim.Close()
+
 	// Iterate over usage records and execute upsert for each
 	for _, record := range batch.Records {
-		_, err := stmt.ExecContext(ctx,
+		quantity := record.TotalQuantity
+		for _, event := range record.Events {
+			var claimed sql.Result
+			claimed, err = claim.ExecContext(ctx, event.EventID, record.TenantID, record.UsageType)
+			if err != nil {
+				return fmt.Errorf("failed to claim usage event %s: %w", event.EventID, err)
+			}
+			var n int64
+			if n, err = claimed.RowsAffected(); err != nil {
+				return fmt.Errorf("failed to get rows affected for usage event %s: %w", event.EventID, err)
+			}
+			if n == 0 {
+				quantity -= event.Quantity // Counted by an earlier batch
+			}
+		}
+		if quantity <= 0 {
+			continue
+		}
+		_, err = stmt.ExecContext(ctx,
 			record.TenantID,
 			record.UsageType,
 			record.BillingPeriod.Year,
 			record.BillingPeriod.Month,
-			record.TotalQuantity,
+			quantity,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to execute upsert for tenant %s: %w", record.TenantID, err)
@@ -122,3 +154,34 @@ func (ps *PostgresUsageStore) GetUsageForPeriod(ctx context.Context, year int, m
 
 	return usageRecords, nil
 }
+
+// GetTenantUsageForPeriod is GetUsageForPeriod for a single tenant.
+// The billing API uses it to show usage for the month in progress.
+func (ps *PostgresUsageStore) GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]store.UsageRecord, error) {
+	query := `
+	SELECT usage_type, total_quantity
+	FROM usage_aggregates
+	WHERE tenant_id=$1 AND billing_year=$2 AND billing_month=$3
+	ORDER BY usage_type
+	`
+	rows, err := ps.db.QueryContext(ctx, query, tenantID, year, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage aggregates:%w", err)
+	}
+	defer rows.Close()
+
+	var usageRecords []store.UsageRecord
+	for rows.Next() {
+		record := store.UsageRecord{TenantID: tenantID, BillingPeriod: store.BillingPeriod{Year: year, Month: month}}
+		var uType string
+		if err := rows.Scan(&uType, &record.TotalQuantity); err != nil {
+			return nil, fmt.Errorf("failed to scan usage record:%w", err)
+		}
+		record.UsageType = billingtypes.UsageType(uType)
+		usageRecords = append(usageRecords, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return usageRecords, nil
+}
@@ -17,7 +17,17 @@ type UsageRecord struct {
 	UsageType     billingtypes.UsageType
 	TotalQuantity int64
 	BillingPeriod BillingPeriod
+	// Events are the usage events TotalQuantity was summed from, when flushing.
+	// Events without an ID are in TotalQuantity only.
+	Events []UsageEventRef
 }
+
+// UsageEventRef is one usage event's share of a UsageRecord
+type UsageEventRef struct {
+	EventID  string
+	Quantity int64
+}
+
 type BillingPeriod struct {
 	Year  int
 	Month int
@@ -31,10 +41,13 @@ type FlushBatch struct {
 
 type UsageStore interface {
 	// Flush atomically persists a batch.
-	// Must be idempotent by BatchID.
+	// Must be idempotent by BatchID, and must not count an event ID
+	// that an earlier batch already counted.
 	Flush(ctx context.Context, batch FlushBatch) error
 	// GetUsageForPeriod  fetches usage records for a tenant for a specific billing period
 	GetUsageForPeriod(ctx context.Context, year int, month int) ([]UsageRecord, error)
+	// GetTenantUsageForPeriod is GetUsageForPeriod for a single tenant
+	GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]UsageRecord, error)
 }
 
 // --- Pricing Store Interface ---
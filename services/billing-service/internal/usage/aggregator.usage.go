@@ -110,7 +110,10 @@ func (agg *Aggregator) Process(event UsageEvent) {
 	//Now increment the bucket count (outside lock to minimize contention)
 	// Move Increment INSIDE the lock.
 	// This prevents the Flusher from swapping the map while we are updating.
-	bucket.Increment(event.Quantity)
+	// Kafka delivers at least once: a redelivered event is counted only once
+	if !bucket.Add(event.ID, event.Quantity) {
+		fmt.Println("ℹ️ Skipping duplicate usage event:", event.ID)
+	}
 
 }
 
@@ -176,6 +179,8 @@ func (agg *Aggregator) Flush(ctx context.Context) error {
 			TenantID:      bucket.TenantID,   //"CompanyX"
 			UsageType:     bucket.Type,       //"SHIPMENT_CREATED"
 			TotalQuantity: bucket.GetCount(), //e.g., 150
+			// The store leaves out events an earlier flush counted
+			Events: bucket.GetEvents(),
 			BillingPeriod: store.BillingPeriod{
 				Year:  now.Year(),
 				Month: int(now.Month()),
@@ -200,8 +205,8 @@ func (agg *Aggregator) Flush(ctx context.Context) error {
 	for key, bucket := range oldBuckets { //Iterate old buckets
 		existingBucket, exists := agg.buckets[key] //Check if bucket already exists
 		if exists {
-			//Merge counts
-			existingBucket.Increment(bucket.GetCount()) //Add old count to existing bucket
+			//Merge counts and events, so a redelivery meanwhile is not counted twice
+			existingBucket.Merge(bucket)
 		} else {
 			// Re-add the old bucket
 			agg.buckets[key] = bucket //Re-add the old bucket
@@ -17,6 +17,7 @@ import (
 type MockUsageStore struct {
 	mu           sync.Mutex
 	flushedData  map[string]int64 //
+	seenEvents   map[string]bool  // Event IDs already counted, like processed_usage_events
 	shouldFail   bool             // Simulate failure default false .. Because bool zero value is false
 	failureCount int              // Number of times to fail before succeeding
 }
@@ -26,9 +27,15 @@ func (m *MockUsageStore) GetUsageForPeriod(ctx context.Context, year int, month
 	panic("unimplemented")
 }
 
+// GetTenantUsageForPeriod implements [store.UsageStore].
+func (m *MockUsageStore) GetTenantUsageForPeriod(ctx context.Context, tenantID uuid.UUID, year int, month int) ([]store.UsageRecord, error) {
+	panic("unimplemented")
+}
+
 func newMockUsageStore() *MockUsageStore {
 	return &MockUsageStore{
 		flushedData: make(map[string]int64),
+		seenEvents:  make(map[string]bool),
 	}
 }
 func (m *MockUsageStore) Flush(ctx context.Context, batch store.FlushBatch) error {
@@ -42,8 +49,15 @@ func (m *MockUsageStore) Flush(ctx context.Context, batch store.FlushBatch) erro
 	for _, records := range batch.Records {
 		// Create a unique key for each TenantID and UsageType combination
 		key := fmt.Sprintf("%s:%s", records.TenantID, records.UsageType)
+		quantity := records.TotalQuantity
+		for _, event := range records.Events {
+			if m.seenEvents[event.EventID] {
+				quantity -= event.Quantity
+			}
+			m.seenEvents[event.EventID] = true
+		}
 		// Accumulate the TotalQuantity for this key
-		m.flushedData[key] = m.flushedData[key] + records.TotalQuantity //the map should look like map["tenantID-usageType"] = totalQuantity
+		m.flushedData[key] = m.flushedData[key] + quantity //the map should look like map["tenantID-usageType"] = totalQuantity
 	}
 
 	return nil
@@ -170,3 +184,79 @@ func TestAggregator_IgnoreInvalidQuantity(t *testing.T) {
 		t.Errorf("❌ Validation Failed! Expected 1, got %d (Did it count negatives?)", actual)
 	}
 }
+
+// TestAggregator_RedeliveredEventCountedOnce tests that an event redelivered
+// before or after a flush is billed once
+func TestAggregator_RedeliveredEventCountedOnce(t *testing.T) {
+	mockStore := newMockUsageStore()
+	agg := NewAggregator(context.Background(), mockStore, 1*time.Hour)
+	tenantID := uuid.New()
+	event := UsageEvent{ID: "e-1", TenantID: tenantID, Type: billingtypes.APIRequest, Quantity: 7}
+
+	agg.Process(event)
+	agg.Process(event) // Redelivered before the flush
+	agg.Process(UsageEvent{TenantID: tenantID, Type: billingtypes.APIRequest, Quantity: 1})
+	if err := agg.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	agg.Process(event) // Redelivered after it
+	agg.Process(UsageEvent{ID: "e-2", TenantID: tenantID, Type: billingtypes.APIRequest, Quantity: 2})
+	if err := agg.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	key := fmt.Sprintf("%s:%s", tenantID, billingtypes.APIRequest)
+	if got := mockStore.flushedData[key]; got != 10 {
+		t.Errorf("total = %d; want 10 (7 + 1 + 2)", got)
+	}
+}
+
+// TestAggregator_FailedFlushKeepsEventIDs tests that events merged back after a
+// failed flush are still recognised when redelivered
+func TestAggregator_FailedFlushKeepsEventIDs(t *testing.T) {
+	mockStore := newMockUsageStore()
+	agg := NewAggregator(context.Background(), mockStore, 1*time.Hour)
+	tenantID := uuid.New()
+	event := UsageEvent{ID: "e-1", TenantID: tenantID, Type: billingtypes.ShipmentCreated, Quantity: 3}
+
+	agg.Process(event)
+	mockStore.shouldFail = true
+	flushed := make(chan error)
+	go func() { flushed <- agg.Flush(context.Background()) }()
+	agg.Process(event) // Redelivered while the flush is retrying
+	if err := <-flushed; err == nil {
+		t.Fatal("expected flush to fail")
+	}
+	mockStore.shouldFail = false
+	if err := agg.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	key := fmt.Sprintf("%s:%s", tenantID, billingtypes.ShipmentCreated)
+	if got := mockStore.flushedData[key]; got != 3 {
+		t.Errorf("total = %d; want 3", got)
+	}
+}
+
+// TestDecodeUsageEvent tests that only well-formed events of metered types reach the aggregator
+func TestDecodeUsageEvent(t *testing.T) {
+	tenantID := uuid.New()
+	good := fmt.Sprintf(`{"eventId":"e-1","tenantId":"%s","usageType":"API_REQUEST","quantity":12,"occurredAt":"2025-07-01T10:00:00Z"}`, tenantID)
+	event, err := decodeUsageEvent([]byte(good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.TenantID != tenantID || event.Type != billingtypes.APIRequest || event.Quantity != 12 {
+		t.Errorf("event = %+v", event)
+	}
+
+	for _, bad := range []string{
+		`not json`,
+		`{"eventId":"e-2","tenantId":"acme","usageType":"API_REQUEST","quantity":1}`,
+		fmt.Sprintf(`{"eventId":"e-3","tenantId":"%s","usageType":"SMS_SENT","quantity":1}`, tenantID),
+	} {
+		if _, err := decodeUsageEvent([]byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
+	"github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/store"
 	"github.com/google/uuid"
 )
 
@@ -12,6 +13,9 @@ type Bucket struct {
 	TenantID uuid.UUID
 	Type     billingtypes.UsageType
 	Count    int64
+	// Events holds the quantity of each event counted since the last flush,
+	// by event ID, so a redelivered event is not counted twice.
+	Events map[string]int64
 }
 
 // Increment safely adds to the count
@@ -21,9 +25,51 @@ func (b *Bucket) Increment(amount int64) {
 	b.Count += amount
 }
 
+// Add counts an event unless one with the same ID is already in the bucket.
+// Events without an ID are always counted.
+func (b *Bucket) Add(eventID string, quantity int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if eventID != "" {
+		if _, seen := b.Events[eventID]; seen {
+			return false
+		}
+		if b.Events == nil {
+			b.Events = make(map[string]int64)
+		}
+		b.Events[eventID] = quantity
+	}
+	b.Count += quantity
+	return true
+}
+
+// Merge adds another bucket's events into this one, skipping those already in it.
+func (b *Bucket) Merge(other *Bucket) {
+	other.mu.Lock()
+	count, events := other.Count, other.Events
+	other.mu.Unlock()
+	for id, quantity := range events {
+		b.Add(id, quantity)
+		count -= quantity
+	}
+	// What is left was counted from events without an ID
+	b.Increment(count)
+}
+
 // GetCount safely reads the count
 func (b *Bucket) GetCount() int64 {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	return b.Count
 }
+
+// GetEvents safely copies the counted events
+func (b *Bucket) GetEvents() []store.UsageEventRef {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	events := make([]store.UsageEventRef, 0, len(b.Events))
+	for id, quantity := range b.Events {
+		events = append(events, store.UsageEventRef{EventID: id, Quantity: quantity})
+	}
+	return events
+}
@@ -0,0 +1,54 @@
+// services/billing-service/internal/usage/consumer.usage.go
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	billingtypes "github.com/Tanmoy095/LogiSynapse/services/billing-service/internal/billingTypes"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/google/uuid"
+)
+
+// meteredTypes are the usage types producers may report. Anything else would
+// end up in usage_aggregates without a price and fail the billing run.
+var meteredTypes = map[billingtypes.UsageType]bool{
+	billingtypes.ShipmentCreated: true,
+	billingtypes.APIRequest:      true,
+}
+
+// KafkaHandler feeds contracts.UsageEvent messages from the usage topic into
+// the aggregator. Its signature is shared/kafka's Handler.
+// Malformed events are logged and skipped: redelivering them would not fix them.
+func (agg *Aggregator) KafkaHandler(_ context.Context, _ []byte, value []byte) error {
+	event, err := decodeUsageEvent(value)
+	if err != nil {
+		fmt.Println("⚠️ Skipping usage event:", err)
+		return nil
+	}
+	agg.Ingest(event)
+	return nil
+}
+
+func decodeUsageEvent(value []byte) (UsageEvent, error) {
+	var msg contracts.UsageEvent
+	if err := json.Unmarshal(value, &msg); err != nil {
+		return UsageEvent{}, fmt.Errorf("malformed usage event: %w", err)
+	}
+	tenantID, err := uuid.Parse(msg.TenantID)
+	if err != nil {
+		return UsageEvent{}, fmt.Errorf("usage event %s: invalid tenant %q", msg.EventID, msg.TenantID)
+	}
+	usageType := billingtypes.UsageType(msg.UsageType)
+	if !meteredTypes[usageType] {
+		return UsageEvent{}, fmt.Errorf("usage event %s: unknown usage type %q", msg.EventID, msg.UsageType)
+	}
+	return UsageEvent{
+		ID:        msg.EventID,
+		TenantID:  tenantID,
+		Type:      usageType,
+		Quantity:  msg.Quantity,
+		Timestamp: msg.OccurredAt.Unix(),
+	}, nil
+}
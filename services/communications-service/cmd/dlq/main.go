@@ -0,0 +1,105 @@
+//services/communications-service/cmd/dlq/main.go
+
+// Command dlq inspects and repairs the communications-service dead-letter
+// queues. It talks to RabbitMQ directly using the same RABBITMQ_* settings as
+// the service.
+//
+//	dlq list   -queue sms_jobs_dlq [-event shipment.created] [-tenant t1] [-limit 50]
+//	dlq replay -queue sms_jobs_dlq -key k1,k2
+//	dlq replay -queue sms_jobs_dlq -all [-event ...] [-tenant ...]
+//	dlq purge  -queue sms_jobs_dlq [-yes]
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/dlq"
+	"github.com/Tanmoy095/LogiSynapse/shared/config"
+	pkgrabbit "github.com/Tanmoy095/LogiSynapse/shared/rabbitmq"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	queue := fs.String("queue", "", "dead-letter queue (email_jobs_dlq or sms_jobs_dlq)")
+	event := fs.String("event", "", "only messages for this event type")
+	tenant := fs.String("tenant", "", "only messages for this tenant")
+	keys := fs.String("key", "", "comma-separated idempotency keys")
+	limit := fs.Int("limit", 100, "list: maximum messages to print (0 = all)")
+	all := fs.Bool("all", false, "replay: every message matching the filters")
+	yes := fs.Bool("yes", false, "purge: skip the confirmation prompt")
+	fs.Parse(os.Args[2:])
+
+	if *queue == "" {
+		fmt.Fprintln(os.Stderr, "-queue is required")
+		os.Exit(2)
+	}
+	filter := dlq.Filter{Event: *event, TenantID: *tenant}
+	if *keys != "" {
+		filter.Keys = strings.Split(*keys, ",")
+	}
+
+	client, err := pkgrabbit.NewClient(config.LoadCommonConfig().GetRabbitMQURL())
+	if err != nil {
+		fatalf("failed to connect to RabbitMQ: %v", err)
+	}
+	defer client.Close()
+	admin := dlq.NewAdmin(dlq.NewRabbitBroker(client))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	switch cmd {
+	case "list":
+		entries, scanned, err := admin.List(ctx, *queue, filter, *limit)
+		if err != nil {
+			fatalf("list: %v", err)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			enc.Encode(e)
+		}
+		fmt.Fprintf(os.Stderr, "%d shown, %d scanned in %s\n", len(entries), scanned, *queue)
+	case "replay":
+		n, err := admin.Replay(ctx, *queue, filter, *all)
+		if err != nil {
+			fatalf("replay: %v", err)
+		}
+		fmt.Printf("replayed %d message(s) from %s\n", n, *queue)
+	case "purge":
+		confirm := *queue
+		if !*yes {
+			fmt.Printf("This deletes every message in %s. Type the queue name to confirm: ", *queue)
+			line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			confirm = strings.TrimSpace(line)
+		}
+		n, err := admin.Purge(*queue, confirm)
+		if err != nil {
+			fatalf("purge: %v", err)
+		}
+		fmt.Printf("purged %d message(s) from %s\n", n, *queue)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dlq list|replay|purge -queue <dlq> [flags]")
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -4,34 +4,127 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
-	"github.com/Tanmoy095/LogiSynapse/shared/config"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+	commsconfig "github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/config"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/delivery"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/dlq"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/idempotency"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/jobs"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/notifications"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/ratelimit"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/recipients"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/retry"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/router"
+	PostgresStore "github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/store/postgres"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/webhooks"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
 	pkgkafka "github.com/Tanmoy095/LogiSynapse/shared/kafka"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
 	pkgrabbit "github.com/Tanmoy095/LogiSynapse/shared/rabbitmq"
+	_ "github.com/lib/pq"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/grpc"
 )
 
 const (
-	EmailQueue        = "email_Jobs"
-	SMSQueue          = "sms_jobs"
-	EmailDLQ          = "email_jobs_dlq"
-	SMSDLQ            = "sms_jobs_dlq"
-	maxDeliveryReties = 3
+	EmailQueue = jobs.EmailQueue
+	SMSQueue   = jobs.SMSQueue
+	EmailDLQ   = jobs.EmailDLQ
+	SMSDLQ     = jobs.SMSDLQ
 )
 
 func main() {
 
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	// Load common configuration
-	cfg := config.LoadCommonConfig()
+	// Load configuration
+	commsCfg, err := commsconfig.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	cfg := commsCfg.CommonConfig
+
+	// Templates: platform defaults are embedded in the binary, tenant overrides
+	// live in Postgres when a database is configured (in memory otherwise).
+	var overrides templates.OverrideStore
+	var contacts recipients.ContactStore
+	var prefs recipients.PreferenceStore
+	var webhookEndpoints webhooks.EndpointStore
+	var webhookDeliveries webhooks.DeliveryStore
+	var sentStore idempotency.Store
+	var history notifications.Store
+	var limitRules ratelimit.RuleStore
+	var limitBuckets ratelimit.Backend
+	var db *sql.DB
+	if cfg.DB_HOST != "" {
+		db, err = sql.Open("postgres", cfg.GetDBURL())
+		if err != nil {
+			log.Fatalf("Failed to open database: %v", err)
+		}
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		overrides = PostgresStore.NewTemplateStore(db)
+		recipientStore := PostgresStore.NewRecipientStore(db)
+		contacts, prefs = recipientStore, recipientStore
+		webhookStore := PostgresStore.NewWebhookStore(db)
+		webhookEndpoints, webhookDeliveries = webhookStore, webhookStore
+		sentStore = PostgresStore.NewIdempotencyStore(db)
+		history = PostgresStore.NewNotificationStore(db)
+		rateStore := PostgresStore.NewRateLimitStore(db)
+		limitRules, limitBuckets = rateStore, rateStore
+	} else {
+		log.Println("DB_HOST not set: templates, contacts, preferences, webhooks, sent-job keys, notification history and rate limits are kept in memory")
+		overrides = templates.NewMemoryStore()
+		recipientStore := recipients.NewMemoryStore()
+		contacts, prefs = recipientStore, recipientStore
+		webhookStore := webhooks.NewMemoryStore()
+		webhookEndpoints, webhookDeliveries = webhookStore, webhookStore
+		sentStore = idempotency.NewMemoryStore()
+		history = notifications.NewMemoryStore()
+		rateStore := ratelimit.NewMemoryStore()
+		limitRules, limitBuckets = rateStore, rateStore
+	}
+	renderer := templates.NewRenderer(templates.NewDefaultFileStore(), overrides, commsCfg.DefaultLocale)
+
+	resolver := recipients.NewResolver(contacts, prefs)
+	unsub := recipients.NewUnsubscriber(commsCfg.UnsubscribeSecret, commsCfg.PublicURL)
+
+	// Merchant webhooks have their own client: no redirects, so a registered
+	// URL cannot bounce deliveries somewhere else.
+	webhookClient := &http.Client{
+		Timeout:       15 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	dispatcher := webhooks.NewDispatcher(webhookEndpoints, webhookDeliveries, webhookClient, webhooks.DefaultOptions())
+
+	emailSender, smsSender := newSenders(commsCfg)
+	sentGuard := idempotency.NewGuard(sentStore, commsCfg.IdempotencyTTL)
+	tracker := notifications.NewTracker(history)
+	process := skipAlreadySent(sentGuard, newJobProcessor(renderer, resolver, unsub, tracker, emailSender, smsSender))
+
+	// Send rate limits: COMMS_RATE_LIMITS plus whatever the admin API changed
+	limiter := ratelimit.NewLimiter(limitRules, limitBuckets, commsCfg.RateLimits)
+	if err := limiter.Reload(context.Background()); err != nil {
+		log.Printf("Starting with COMMS_RATE_LIMITS only: %v", err)
+	}
+	throttle := newThrottle(limiter, commsCfg.EmailProvider, commsCfg.SMSProvider)
 
 	//connect to RabbitMQ
 	log.Printf("Connecting to RabbitMQ at: %s", cfg.RABBITMQ_HOST)
@@ -57,17 +150,42 @@ func main() {
 	if err := rabbitClient.CreateQueue(SMSDLQ); err != nil {
 		log.Fatalf("Failed to create SMS DLQ: %v", err)
 	}
+	// Delay tiers: a failed job waits in e.g. "sms_jobs.retry.1m0s" until its
+	// TTL expires, then RabbitMQ dead-letters it back onto "sms_jobs".
+	for _, queue := range []string{EmailQueue, SMSQueue} {
+		for _, delay := range retry.Tiers {
+			err := rabbitClient.CreateQueue(retry.DelayQueueName(queue, delay),
+				pkgrabbit.WithMessageTTL(delay),
+				pkgrabbit.WithDeadLetter("", queue))
+			if err != nil {
+				log.Fatalf("Failed to create delay queue for %s (%s): %v", queue, delay, err)
+			}
+		}
+	}
+
+	// Routing rules: which events become notifications, on which channels
+	routes, err := loadRoutes(commsCfg.RoutesFile)
+	if err != nil {
+		log.Fatalf("Failed to load routing rules: %v", err)
+	}
+	eventRouter := router.New(routes)
 
 	//Connect to Kafka (The News Ticker)
-	// We tune our radio to the "shipment.created" channel.
-	var kafkaConsumer *pkgkafka.Consumer
+	// We tune our radio to every topic the routes file lists (KAFKA_TOPIC otherwise).
+	var kafkaConsumers []*pkgkafka.Consumer
 
-	if cfg.KAFKA_BROKER != "" && cfg.KAFKA_TOPIC != "" {
-		log.Printf("Connecting to Kafka at: %s, Topic: %s", cfg.KAFKA_BROKER, cfg.KAFKA_TOPIC)
-		kafkaConsumer = pkgkafka.NewConsumer(
-			[]string{cfg.KAFKA_BROKER},
-			cfg.KAFKA_TOPIC,
-			"communications-group")
+	topics := routes.Topics
+	if len(topics) == 0 && cfg.KAFKA_TOPIC != "" {
+		topics = strings.Split(cfg.KAFKA_TOPIC, ",")
+	}
+	if cfg.KAFKA_BROKER != "" {
+		for _, topic := range topics {
+			log.Printf("Connecting to Kafka at: %s, Topic: %s", cfg.KAFKA_BROKER, topic)
+			kafkaConsumers = append(kafkaConsumers, pkgkafka.NewConsumer(
+				[]string{cfg.KAFKA_BROKER},
+				strings.TrimSpace(topic),
+				"communications-group"))
+		}
 	}
 
 	//ctx is a signal to tell workers to stop
@@ -79,15 +197,36 @@ func main() {
 	wg.Add(1)
 	// He runs into the (Goroutine), connects to the 'EmailQueue',
 	// and stands there WAITING. He is idle right now because the queue is empty.
-	go startEmailWorker(ctx, rabbitClient, &wg)
+	go startEmailWorker(ctx, rabbitClient, process, throttle, commsCfg.RetryPolicies, tracker, &wg)
 
 	//start sms worker
 	wg.Add(1)
-	go startSmsWorker(ctx, rabbitClient, &wg)
+	go startSmsWorker(ctx, rabbitClient, process, throttle, commsCfg.RetryPolicies, tracker, &wg)
+
+	// Webhook dispatcher: sends due deliveries and retries failed ones
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		dispatcher.Run(ctx)
+	}()
+
+	// Forget sent-job keys once they are past the TTL
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sentGuard.Run(ctx, time.Hour)
+	}()
+
+	// Pick up rate limits changed on other replicas
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		limiter.Run(ctx, 30*time.Second)
+	}()
 
 	// --- WORKER 3: The Bridge Dispatcher (The Translator) ---
 
-	if kafkaConsumer != nil {
+	if len(kafkaConsumers) > 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -111,57 +250,98 @@ func main() {
 					log.Println("Bridge Dispatcher:Event type missing or invalid")
 					return nil
 				}
-				// LOGIC: "Is this a shipment?" -> YES.
-				if eventType == "shipment.created" {
-					log.Printf("🌉 Bridge: Shipment Event Detected! Creating Email Job...")
-					//TransLate the Shipment Event into an Email Job
-					// We take the "Fact" (Shipment) and turn it into a "Task" (Email Job).
-					emailJob := map[string]interface{}{
-						"type":    "welcome_email",
-						"payload": event["payload"],
-					}
-					jsonData, err := json.Marshal(emailJob)
+				payload, _ := json.Marshal(event["payload"])
+				tenantID, _ := event["tenant_id"].(string)
+				// Identifies this event for webhook receivers and, per
+				// channel and recipient, as the notification idempotency key.
+				eventID := eventKey(key, eventType, event)
+				locale, _ := event["locale"].(string)
+
+				// Merchant webhooks get every tenant event they subscribed to,
+				// not only the ones we have email/SMS templates for.
+				if tenantID != "" {
+					queued, err := dispatcher.Enqueue(ctx, webhooks.Event{ID: eventID, Type: eventType, TenantID: tenantID, Data: payload})
 					if err != nil {
-						log.Printf("Bridge Dispatcher:Failed to marshal email job:%v", err)
+						log.Printf("Bridge Dispatcher:Failed to queue webhooks for %s:%v", eventType, err)
 						return err
 					}
-					// 6. HANDOFF:
-					// We walk over to the RabbitMQ Kitchen and drop this ticket in the 'EmailQueue'.
-					// NOTE: The 'Email Chef' (from Act 2) is watching this queue.
-					// He will see this ticket instantly!
-					idempotencyKey := string(key)
-					if idempotencyKey == "" {
-						idempotencyKey = fmt.Sprintf("shipment-%v", event["payload"])
+					if queued > 0 {
+						log.Printf("🌉 Bridge: %s -> %d webhook delivery(ies)", eventType, queued)
 					}
-					wrappedEmail, err := wrapJobWithMeta(jsonData, idempotencyKey, 0)
-					if err != nil {
-						return err
+				}
+
+				// LOGIC: "Do we tell anyone about this?" The routing rules pick
+				// the channels, template and priority, or drop the event.
+				route, ok := eventRouter.Route(eventType, payload)
+				if !ok {
+					return nil // not something we email or text about
+				}
+				// LOGIC: "Who needs to hear about this, and how?"
+				// The resolver turns one event into one delivery per person and
+				// channel, minus anyone who opted out or is in quiet hours.
+				deliveries, err := resolver.Resolve(ctx, recipients.Event{
+					Type:     eventType,
+					TenantID: tenantID,
+					Locale:   locale,
+					Payload:  payload,
+					Channels: route.Channels,
+					Urgent:   route.Priority == router.PriorityHigh,
+				})
+				if err != nil {
+					log.Printf("Bridge Dispatcher:Failed to resolve recipients for %s:%v", eventType, err)
+					return err
+				}
+				if len(deliveries) == 0 {
+					log.Printf("🌉 Bridge: %s has nobody to notify", eventType)
+					return nil
+				}
+
+				for _, d := range deliveries {
+					job := jobs.Notification{
+						Type:     "notification",
+						Event:    eventType,
+						Channel:  d.Channel,
+						Template: route.Template,
+						Priority: string(route.Priority),
+						TenantID: tenantID,
+						Locale:   d.Recipient.Locale,
+						To:       d.Recipient.Address(d.Channel),
+						Role:     d.Recipient.Role,
+						Payload:  payload,
 					}
-					if err := rabbitClient.Publish(ctx, EmailQueue, wrappedEmail); err != nil {
-						log.Printf("Bridge Dispatcher:Failed to publish email job:%v", err)
+					jobBody, err := json.Marshal(job)
+					if err != nil {
 						return err
 					}
-					log.Println("Bridge Dispatcher:Email Job Published to RabbitMQ")
-					// --- JOB B: SMS Alert (This was missing!) ---
-					smsJob := map[string]interface{}{
-						"type":    "sms_alert",
-						"payload": event["payload"],
+					// HANDOFF: drop the ticket in the queue the channel's worker watches.
+					queue := EmailQueue
+					if d.Channel == templates.ChannelSMS {
+						queue = SMSQueue
 					}
-					smsBody, _ := json.Marshal(smsJob)
-
-					// Publish to the SMS Queue
-					wrappedSMS, err := wrapJobWithMeta(smsBody, idempotencyKey, 0)
+					jobKey := eventID + "|" + string(d.Channel) + "|" + job.To
+					wrapped, err := jobs.Wrap(jobBody, jobKey, 0)
 					if err != nil {
 						return err
 					}
-					if err := rabbitClient.Publish(ctx, SMSQueue, wrappedSMS); err != nil {
-						// Senior Tip: If Email succeeded but SMS failed, do we fail everything?
-						// Ideally, yes, so Kafka retries. But we might send duplicate emails.
-						// For now, return error to be safe.
+					// Recorded before the publish so the worker always finds the
+					// record it updates.
+					tracker.Queued(ctx, notifications.Notification{
+						JobID:       jobKey,
+						TenantID:    tenantID,
+						ShipmentIDs: shipmentIDsFromPayload(eventType, payload),
+						Event:       eventType,
+						Template:    route.Template,
+						Channel:     d.Channel,
+						Recipient:   job.To,
+					})
+					if err := rabbitClient.Publish(ctx, queue, wrapped); err != nil {
+						// If one publish fails we return the error so Kafka redelivers the
+						// event; jobs already sent are skipped by their idempotency key.
+						log.Printf("Bridge Dispatcher:Failed to publish %s job:%v", d.Channel, err)
 						return err
 					}
-					log.Println("   -> Sent to SMS Queue")
 				}
+				log.Printf("🌉 Bridge: %s -> %d %s priority notification job(s)", eventType, len(deliveries), route.Priority)
 				return nil
 			}
 
@@ -169,11 +349,89 @@ func main() {
 			// This function connects to the internet and waits.
 			// When a message comes, it grabs the data and calls 'bridgeHandler' (above) with it.
 			log.Println("🎧 Bridge Listener Started")
-			kafkaConsumer.Start(ctx, bridgeHandler)
+			var listeners sync.WaitGroup
+			for _, consumer := range kafkaConsumers {
+				listeners.Add(1)
+				go func(c *pkgkafka.Consumer) {
+					defer listeners.Done()
+					c.Start(ctx, bridgeHandler)
+				}(consumer)
+			}
+			listeners.Wait()
 
 		}()
 	}
 
+	// Public HTTP API: the unsubscribe page and provider callbacks
+	mux := http.NewServeMux()
+	recipientsAPI := recipients.NewHandler(contacts, prefs, unsub)
+	recipientsAPI.RegisterPublic(mux)
+	// Tenant APIs, for the gateway only: each request carries the service
+	// token and the caller, whose tenant is the only one it may act for
+	internalMux := http.NewServeMux()
+	templates.NewHandler(renderer, overrides).Register(internalMux)
+	recipientsAPI.Register(internalMux)
+	webhooks.NewHandler(webhookEndpoints, webhookDeliveries, dispatcher, commsCfg.WebhookAllowHTTP).Register(internalMux)
+	callbacks := notifications.Callbacks{PublicURL: commsCfg.PublicURL}
+	if commsCfg.SMSProvider == "twilio" {
+		callbacks.TwilioAuthToken = commsCfg.TwilioAuthToken
+	}
+	if commsCfg.SendGridWebhookKey != "" {
+		callbacks.SendGrid, err = delivery.NewSendGridVerifier(commsCfg.SendGridWebhookKey)
+		if err != nil {
+			log.Fatalf("Invalid SENDGRID_WEBHOOK_PUBLIC_KEY: %v", err)
+		}
+	}
+	notificationsAPI := notifications.NewHandler(history, tracker, callbacks)
+	notificationsAPI.RegisterCallbacks(mux)
+	notificationsAPI.Register(internalMux)
+	// DLQ admin gets its own connection: messages it is inspecting stay unacked
+	// on that channel and must not interfere with the workers.
+	var adminClient *pkgrabbit.RabbitmqClient
+	if commsCfg.AdminToken != "" {
+		adminClient, err = pkgrabbit.NewClient(amqpURL)
+		if err != nil {
+			log.Fatalf("Failed to connect DLQ admin to RabbitMQ: %v", err)
+		}
+		dlq.NewHandler(dlq.NewAdmin(dlq.NewRabbitBroker(adminClient)), commsCfg.AdminToken).Register(mux)
+		ratelimit.NewHandler(limiter, limitRules, commsCfg.AdminToken).Register(mux)
+	} else {
+		log.Println("COMMS_ADMIN_TOKEN not set: DLQ and rate limit admin endpoints disabled")
+	}
+	httpServer := &http.Server{Addr: commsCfg.HTTPAddr, Handler: mux}
+	go func() {
+		log.Printf("HTTP API listening on %s", commsCfg.HTTPAddr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("HTTP API stopped: %v", err)
+		}
+	}()
+	var internalServer *http.Server
+	if commsCfg.InternalToken != "" {
+		internalServer = &http.Server{Addr: commsCfg.InternalHTTPAddr, Handler: caller.Authenticate(commsCfg.InternalToken, internalMux)}
+		go func() {
+			log.Printf("Tenant API listening on %s", commsCfg.InternalHTTPAddr)
+			if err := internalServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Tenant API stopped: %v", err)
+			}
+		}()
+	} else {
+		log.Println("COMMS_INTERNAL_TOKEN not set: tenant APIs disabled")
+	}
+
+	// gRPC API: notification history for the gateway, for the caller it forwards
+	grpcListener, err := net.Listen("tcp", commsCfg.GRPCAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", commsCfg.GRPCAddr, err)
+	}
+	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(identity.UnaryServerInterceptor()))
+	proto.RegisterNotificationServiceServer(grpcSrv, notifications.NewGRPCServer(history))
+	go func() {
+		log.Printf("gRPC API listening on %s", commsCfg.GRPCAddr)
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			log.Printf("gRPC API stopped: %v", err)
+		}
+	}()
+
 	log.Println("Service running. Press Ctrl + c to stop")
 	//waiting for stop signal
 	stopSignal := make(chan os.Signal, 1)
@@ -187,14 +445,31 @@ func main() {
 	log.Println("🛑 Closing time...")
 	//cancel stop door this tells workers stop accepting new messege
 	cancel() // Tell everyone to stop accepting new work
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP API shutdown: %v", err)
+	}
+	if internalServer != nil {
+		if err := internalServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Tenant API shutdown: %v", err)
+		}
+	}
+	shutdownCancel()
+	grpcSrv.GracefulStop()
 	//wait for workers to finish processing current messege
 	wg.Wait()
 	//now all workers quit .we can close rabbitmq connection
 	if err := rabbitClient.Close(); err != nil {
 		log.Fatalf("Failed to clsoe RabbitMQ connection: %v", err)
 	}
-	if kafkaConsumer != nil {
-		kafkaConsumer.Close()
+	if adminClient != nil {
+		adminClient.Close()
+	}
+	for _, consumer := range kafkaConsumers {
+		consumer.Close()
+	}
+	if db != nil {
+		db.Close()
 	}
 	log.Println("Service shutdown complete. Safe to exit")
 
@@ -202,7 +477,7 @@ func main() {
 
 //worker Logic
 
-func startEmailWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, wg *sync.WaitGroup) {
+func startEmailWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, process jobProcessor, throttle throttle, policies retry.Policies, tracker *notifications.Tracker, wg *sync.WaitGroup) {
 
 	//signOut when the function finiosh
 
@@ -227,14 +502,14 @@ func startEmailWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, wg
 
 			log.Printf("📧 Email Chef: I got a job! Payload: %s", string(d.Body))
 
-			if err := processWithRetryOrDLQ(ctx, client, d, EmailQueue, EmailDLQ); err != nil {
+			if err := processWithRetryOrDLQ(ctx, client, process, throttle, policies, tracker, d, EmailQueue, EmailDLQ); err != nil {
 				log.Printf("Email Worker failed: %v", err)
 			}
 		}
 	}
 }
 
-func startSmsWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, wg *sync.WaitGroup) {
+func startSmsWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, process jobProcessor, throttle throttle, policies retry.Policies, tracker *notifications.Tracker, wg *sync.WaitGroup) {
 
 	defer wg.Done()
 	msg, err := client.Consume(SMSQueue)
@@ -253,7 +528,7 @@ func startSmsWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, wg *s
 				return
 			}
 			log.Printf("📱 Processing SMS: %s", string(d.Body))
-			if err := processWithRetryOrDLQ(ctx, client, d, SMSQueue, SMSDLQ); err != nil {
+			if err := processWithRetryOrDLQ(ctx, client, process, throttle, policies, tracker, d, SMSQueue, SMSDLQ); err != nil {
 				log.Printf("SMS Worker failed: %v", err)
 			}
 		}
@@ -262,68 +537,336 @@ func startSmsWorker(ctx context.Context, client *pkgrabbit.RabbitmqClient, wg *s
 
 }
 
-func processWithRetryOrDLQ(ctx context.Context, client *pkgrabbit.RabbitmqClient, d amqp.Delivery, queueName, dlqName string) error {
-	idempotencyKey, retryCount, payload, err := unwrapJobWithMeta(d.Body)
+func processWithRetryOrDLQ(ctx context.Context, client *pkgrabbit.RabbitmqClient, process jobProcessor, throttle throttle, policies retry.Policies, tracker *notifications.Tracker, d amqp.Delivery, queueName, dlqName string) error {
+	job, err := jobs.Unwrap(d.Body)
 	if err != nil {
 		return err
 	}
-	processErr := processJob(payload)
+	// The job's event and channel select its retry policy and rate limits
+	described := jobs.Describe(job)
+
+	// Over the tenant's or provider's send rate: a short wait is slept off
+	// here; a longer one parks the job in a delay tier so the rest of the
+	// queue keeps moving. Neither counts as a retry.
+	for {
+		wait := throttle(ctx, described)
+		if wait == 0 {
+			break
+		}
+		if wait > maxInlineThrottle {
+			tier := retry.TierFor(wait)
+			next := time.Now().UTC().Add(tier)
+			job.NextAttemptAt = &next
+			wrapped, err := json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			if err := client.Publish(ctx, retry.DelayQueueName(queueName, tier), wrapped); err != nil {
+				return err
+			}
+			log.Printf("Job %s over its send rate, delayed %s", job.IdempotencyKey, tier)
+			return d.Ack(false)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return d.Nack(false, true)
+		}
+	}
+
+	processErr := process(ctx, job.IdempotencyKey, job.Payload)
 	if processErr == nil {
 		if err := d.Ack(false); err != nil {
 			return err
 		}
 		return nil
 	}
-
-	if retryCount >= maxDeliveryReties {
-		wrapped, wrapErr := wrapJobWithMeta(payload, idempotencyKey, retryCount)
-		if wrapErr != nil {
-			return wrapErr
+	job.LastError = processErr.Error()
+
+	// Permanent failures (invalid phone number, broken template) go straight to
+	// the DLQ: retrying would only burn provider quota and delay the inevitable.
+	delay, retryable := policies.For(described.Event, string(described.Channel)).Next(job.RetryCount)
+	if !retryable || delivery.IsPermanent(processErr) {
+		log.Printf("Job %s moved to %s after %d retries: %v", job.IdempotencyKey, dlqName, job.RetryCount, processErr)
+		job.NextAttemptAt = nil
+		wrapped, err := json.Marshal(job)
+		if err != nil {
+			return err
 		}
 		if err := client.Publish(ctx, dlqName, wrapped); err != nil {
 			return err
 		}
+		tracker.Failed(ctx, job.IdempotencyKey, processErr, true)
 		return d.Ack(false)
 	}
 
-	wrapped, wrapErr := wrapJobWithMeta(payload, idempotencyKey, retryCount+1)
-	if wrapErr != nil {
-		return wrapErr
+	// Park the job in the delay tier; RabbitMQ moves it back to queueName when
+	// the tier's TTL expires.
+	job.RetryCount++
+	next := time.Now().UTC().Add(delay)
+	job.NextAttemptAt = &next
+	wrapped, err := json.Marshal(job)
+	if err != nil {
+		return err
 	}
-	if err := client.Publish(ctx, queueName, wrapped); err != nil {
+	if err := client.Publish(ctx, retry.DelayQueueName(queueName, delay), wrapped); err != nil {
 		return err
 	}
+	log.Printf("Job %s retry %d in %s: %v", job.IdempotencyKey, job.RetryCount, delay, processErr)
+	tracker.Failed(ctx, job.IdempotencyKey, processErr, false)
 	return d.Ack(false)
 }
 
-func processJob(body []byte) error {
-	if len(body) == 0 {
-		return fmt.Errorf("empty job payload")
+// maxInlineThrottle is the longest a worker sleeps for a rate limit token
+// before parking the job instead: longer would hold up other tenants' jobs.
+const maxInlineThrottle = 200 * time.Millisecond
+
+// throttle returns how long a job has to wait for its tenant's and provider's
+// send budget; zero means send now.
+type throttle func(ctx context.Context, job jobs.Notification) time.Duration
+
+// newThrottle reserves from the limiter for the provider the job's channel
+// sends through.
+func newThrottle(limiter *ratelimit.Limiter, emailProvider, smsProvider string) throttle {
+	return func(ctx context.Context, job jobs.Notification) time.Duration {
+		provider := emailProvider
+		if job.Channel == templates.ChannelSMS {
+			provider = smsProvider
+		}
+		wait, err := limiter.Reserve(ctx, job.TenantID, job.Channel, provider)
+		if err != nil {
+			// A limiter outage should not stop notifications going out
+			log.Printf("Rate limit check failed, sending anyway: %v", err)
+			return 0
+		}
+		return wait
 	}
-	time.Sleep(500 * time.Millisecond)
-	return nil
 }
 
-type queuedJob struct {
-	IdempotencyKey string          `json:"idempotency_key"`
-	RetryCount     int32           `json:"retry_count"`
-	Payload        json.RawMessage `json:"payload"`
+// jobProcessor handles one unwrapped job. A non-nil error sends the job round
+// the retry loop (and eventually to the DLQ); a delivery.PermanentError skips
+// the retries.
+type jobProcessor func(ctx context.Context, idempotencyKey string, body []byte) error
+
+// newJobProcessor renders the job's template, hands the result to the sender
+// for its channel and records the outcome in the notification history.
+func newJobProcessor(renderer *templates.Renderer, resolver *recipients.Resolver, unsub *recipients.Unsubscriber, tracker *notifications.Tracker, emailSender delivery.EmailSender, smsSender delivery.SMSSender) jobProcessor {
+	return func(ctx context.Context, idempotencyKey string, body []byte) error {
+		if len(body) == 0 {
+			return delivery.Permanent(fmt.Errorf("empty job payload"))
+		}
+		var job jobs.Notification
+		if err := json.Unmarshal(body, &job); err != nil {
+			return delivery.Permanent(fmt.Errorf("invalid job: %w", err))
+		}
+		if !job.Normalize() {
+			return delivery.Permanent(fmt.Errorf("unknown job type %q", job.Type))
+		}
+
+		templateName := job.Template
+		if templateName == "" {
+			templateName = job.Event
+		}
+		msg, err := renderer.Render(ctx, templates.Key{
+			TenantID:  job.TenantID,
+			EventType: templateName,
+			Channel:   job.Channel,
+			Locale:    job.Locale,
+		}, job.Payload)
+		if err != nil {
+			err = fmt.Errorf("render %s/%s: %w", templateName, job.Channel, err)
+			if isPermanentRenderError(err) {
+				return delivery.Permanent(err)
+			}
+			return err // e.g. template database unavailable
+		}
+
+		to := job.To
+		if to == "" {
+			email, phone := contactFromPayload(job.Event, job.Payload)
+			to = email
+			if job.Channel == templates.ChannelSMS {
+				to = phone
+			}
+		}
+		if to == "" {
+			log.Printf("No %s address for %s job %s, skipping", job.Channel, job.Event, idempotencyKey)
+			tracker.Skipped(ctx, idempotencyKey, "no "+string(job.Channel)+" address")
+			return nil
+		}
+		// The recipient may have unsubscribed while this job sat in the queue.
+		optedOut, err := resolver.OptedOut(ctx, job.TenantID, to, job.Channel)
+		if err != nil {
+			return err
+		}
+		if optedOut {
+			log.Printf("%s opted out of %s, dropping job %s", to, job.Channel, idempotencyKey)
+			tracker.Skipped(ctx, idempotencyKey, "recipient opted out")
+			return nil
+		}
+
+		var providerMessageID string
+		switch job.Channel {
+		case templates.ChannelEmail:
+			unsubscribeURL := unsub.URL(job.TenantID, to, job.Channel)
+			body := msg.Body
+			if msg.HTML {
+				body += fmt.Sprintf("\n<p style=\"font-size:12px;color:#888\"><a href=\"%s\">Unsubscribe</a> from these emails.</p>", html.EscapeString(unsubscribeURL))
+			} else {
+				body += "\n\nUnsubscribe: " + unsubscribeURL
+			}
+			providerMessageID, err = emailSender.SendEmail(ctx, delivery.EmailMessage{
+				To:             to,
+				Subject:        msg.Subject,
+				Body:           body,
+				HTML:           msg.HTML,
+				IdempotencyKey: idempotencyKey,
+				UnsubscribeURL: unsubscribeURL,
+			})
+		case templates.ChannelSMS:
+			// SMS opt-out is handled by the carrier/provider (reply STOP)
+			providerMessageID, err = smsSender.SendSMS(ctx, delivery.SMSMessage{
+				To:             to,
+				Body:           msg.Body,
+				IdempotencyKey: idempotencyKey,
+			})
+		default:
+			return delivery.Permanent(fmt.Errorf("unknown channel %q", job.Channel))
+		}
+		if err != nil {
+			return err
+		}
+		tracker.Sent(ctx, idempotencyKey, providerMessageID)
+		return nil
+	}
 }
 
-func wrapJobWithMeta(body []byte, idempotencyKey string, retryCount int32) ([]byte, error) {
-	return json.Marshal(queuedJob{
-		IdempotencyKey: idempotencyKey,
-		RetryCount:     retryCount,
-		Payload:        json.RawMessage(body),
-	})
+// eventKey identifies a Kafka event so that a redelivery maps to the same
+// jobs: the envelope's event_id when the producer set one, otherwise the Kafka
+// key and event type (one shipment's events share its key), otherwise the
+// payload.
+func eventKey(key []byte, eventType string, event map[string]interface{}) string {
+	if id, _ := event["event_id"].(string); id != "" {
+		return id
+	}
+	if len(key) > 0 {
+		return string(key) + "|" + eventType
+	}
+	return fmt.Sprintf("%s-%v", eventType, event["payload"])
+}
+
+// skipAlreadySent drops jobs whose idempotency key was already sent on their
+// channel. The key is claimed before the send, so two workers handed the same
+// job (Kafka redelivery, a replayed DLQ message) cannot both send it; a failed
+// send releases the claim for the retry. Jobs send at most once per TTL.
+func skipAlreadySent(guard *idempotency.Guard, process jobProcessor) jobProcessor {
+	return func(ctx context.Context, idempotencyKey string, body []byte) error {
+		var job jobs.Notification
+		if json.Unmarshal(body, &job) != nil || !job.Normalize() {
+			return process(ctx, idempotencyKey, body) // let the processor reject it
+		}
+		channel := string(job.Channel)
+		claimed, err := guard.Claim(ctx, idempotencyKey, channel)
+		if err != nil {
+			// Retry rather than risk a duplicate
+			return fmt.Errorf("idempotency claim: %w", err)
+		}
+		if !claimed {
+			log.Printf("Job %s already sent (or being sent) on %s, skipping", idempotencyKey, channel)
+			return nil
+		}
+		if err := process(ctx, idempotencyKey, body); err != nil {
+			if relErr := guard.Release(ctx, idempotencyKey, channel); relErr != nil {
+				// The claim lapses after idempotency.ClaimTimeout instead
+				log.Printf("Job %s claim not released: %v", idempotencyKey, relErr)
+			}
+			return err
+		}
+		// The message is out; failing the job now would only send it again.
+		if err := guard.Complete(ctx, idempotencyKey, channel); err != nil {
+			log.Printf("Job %s sent but not recorded: %v", idempotencyKey, err)
+		}
+		return nil
+	}
+}
+
+func isPermanentRenderError(err error) bool {
+	return errors.Is(err, templates.ErrTemplateNotFound) ||
+		errors.Is(err, templates.ErrUnknownEvent) ||
+		errors.Is(err, templates.ErrUnknownChannel) ||
+		errors.Is(err, templates.ErrInvalidTemplate) ||
+		errors.Is(err, templates.ErrInvalidPayload)
+}
+
+// contactFromPayload reads the contact details carried on the event itself:
+// the consignee on a shipment, the warehouse contact on a pickup. Only used for
+// jobs queued before the bridge resolved recipients.
+func contactFromPayload(eventType string, payload []byte) (email, phone string) {
+	switch eventType {
+	case "shipment.created":
+		var s contracts.Shipment
+		if json.Unmarshal(payload, &s) == nil {
+			return s.ToAddress.Email, s.ToAddress.Phone
+		}
+	case "pickup.reminder":
+		var p contracts.Pickup
+		if json.Unmarshal(payload, &p) == nil {
+			return p.Address.Email, p.Address.Phone
+		}
+	}
+	return "", ""
+}
+
+// loadRoutes reads the routing rules from path, or the built-in rules when
+// path is empty.
+func loadRoutes(path string) (*router.Config, error) {
+	if path == "" {
+		return router.Default()
+	}
+	log.Printf("Loading routing rules from %s", path)
+	return router.LoadFile(path)
+}
+
+// shipmentIDsFromPayload returns the shipments an event is about, so the
+// notification history can be searched by shipment.
+func shipmentIDsFromPayload(eventType string, payload []byte) []string {
+	if eventType == "pickup.reminder" {
+		var p contracts.Pickup
+		if json.Unmarshal(payload, &p) == nil {
+			return p.ShipmentIDs
+		}
+		return nil
+	}
+	if strings.HasPrefix(eventType, "shipment.") {
+		var s contracts.Shipment
+		if json.Unmarshal(payload, &s) == nil && s.ID != "" {
+			return []string{s.ID}
+		}
+	}
+	return nil
 }
 
-func unwrapJobWithMeta(body []byte) (string, int32, []byte, error) {
-	var job queuedJob
-	if err := json.Unmarshal(body, &job); err != nil {
-		return "", 0, nil, err
+// newSenders picks the email and SMS providers from config. The file sink is
+// the default so a local stack never sends real messages by accident.
+func newSenders(cfg *commsconfig.CommsConfig) (delivery.EmailSender, delivery.SMSSender) {
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	sink := delivery.NewFileSink(cfg.OutboxDir)
+
+	var emailSender delivery.EmailSender = sink
+	switch cfg.EmailProvider {
+	case "smtp":
+		emailSender = delivery.NewSMTPSender(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom)
+	case "sendgrid":
+		emailSender = delivery.NewSendGridSender(cfg.SendGridAPIKey, cfg.EmailFrom, httpClient)
+	}
+	var smsSender delivery.SMSSender = sink
+	if cfg.SMSProvider == "twilio" {
+		twilio := delivery.NewTwilioSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.SMSFrom, httpClient)
+		twilio.StatusCallback = strings.TrimSuffix(cfg.PublicURL, "/") + "/v1/callbacks/twilio"
+		smsSender = twilio
 	}
-	return job.IdempotencyKey, job.RetryCount, []byte(job.Payload), nil
+	log.Printf("Email provider: %s, SMS provider: %s", cfg.EmailProvider, cfg.SMSProvider)
+	return emailSender, smsSender
 }
 
 /*
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/idempotency"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/jobs"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+func TestEventsSharingAKafkaKeyAreAllDelivered(t *testing.T) {
+	ctx := context.Background()
+	var sent []string
+	process := skipAlreadySent(idempotency.NewGuard(idempotency.NewMemoryStore(), 24*time.Hour),
+		func(_ context.Context, key string, _ []byte) error {
+			sent = append(sent, key)
+			return nil
+		})
+	// What the bridge queues for one event and recipient
+	deliver := func(kafkaKey string, envelope string) {
+		t.Helper()
+		var event map[string]interface{}
+		if err := json.Unmarshal([]byte(envelope), &event); err != nil {
+			t.Fatal(err)
+		}
+		eventType := event["event"].(string)
+		body, _ := json.Marshal(jobs.Notification{Type: "notification", Event: eventType, Channel: templates.ChannelEmail, To: "jana@example.com"})
+		if err := process(ctx, eventKey([]byte(kafkaKey), eventType, event)+"|email|jana@example.com", body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The orchestrator keys every event of a shipment by its ID
+	deliver("s-1", `{"event":"shipment.created","payload":{"ID":"s-1"}}`)
+	deliver("s-1", `{"event":"shipment.delivered","payload":{"ID":"s-1"}}`)
+	deliver("s-1", `{"event":"shipment.created","payload":{"ID":"s-1"}}`) // redelivered
+	if len(sent) != 2 {
+		t.Fatalf("sent %v, want shipment.created and shipment.delivered once each", sent)
+	}
+
+	// With an event_id, two events of the same type are told apart too
+	deliver("s-2", `{"event_id":"evt-1","event":"shipment.updated","payload":{"ID":"s-2"}}`)
+	deliver("s-2", `{"event_id":"evt-2","event":"shipment.updated","payload":{"ID":"s-2"}}`)
+	deliver("s-2", `{"event_id":"evt-1","event":"shipment.updated","payload":{"ID":"s-2"}}`) // redelivered
+	if len(sent) != 4 {
+		t.Fatalf("sent %v, want each event_id once", sent)
+	}
+}
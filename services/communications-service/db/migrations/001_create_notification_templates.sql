@@ -0,0 +1,20 @@
+-- services/communications-service/db/migrations/001_create_notification_templates.sql
+
+-- Tenant overrides for notification templates.
+-- Platform defaults ship inside the binary (internal/templates/defaults); a row here
+-- replaces the default for one tenant, event, channel and locale.
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id          BIGSERIAL PRIMARY KEY,
+    tenant_id   TEXT NOT NULL,
+    event_type  TEXT NOT NULL,        -- e.g. 'shipment.created'
+    channel     TEXT NOT NULL CHECK (channel IN ('email', 'sms')),
+    locale      TEXT NOT NULL,        -- BCP 47, e.g. 'en', 'pt-BR'
+    subject     TEXT NOT NULL DEFAULT '',
+    body        TEXT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_notification_template UNIQUE (tenant_id, event_type, channel, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_templates_tenant ON notification_templates (tenant_id);
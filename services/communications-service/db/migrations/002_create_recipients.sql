@@ -0,0 +1,33 @@
+-- services/communications-service/db/migrations/002_create_recipients.sql
+
+-- Merchant contacts: people at a tenant who want copies of notifications.
+-- End customers are not stored here; their contact details travel on the shipment.
+
+CREATE TABLE IF NOT EXISTS merchant_contacts (
+    tenant_id   TEXT NOT NULL,
+    id          TEXT NOT NULL,            -- chosen by the caller (PUT is idempotent)
+    name        TEXT NOT NULL DEFAULT '',
+    email       TEXT NOT NULL DEFAULT '',
+    phone       TEXT NOT NULL DEFAULT '', -- E.164
+    locale      TEXT NOT NULL DEFAULT '',
+    events      TEXT[] NOT NULL DEFAULT '{}', -- subscribed event types; empty = all
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, id),
+    CHECK (email <> '' OR phone <> '')
+);
+
+-- Per-address channel preferences. Keyed by address, not by person, because
+-- an unsubscribe link only knows the address it was sent to.
+CREATE TABLE IF NOT EXISTS contact_preferences (
+    tenant_id    TEXT NOT NULL,           -- '' for events without a tenant
+    address      TEXT NOT NULL,           -- lower-cased email or E.164 phone
+    channel      TEXT NOT NULL CHECK (channel IN ('email', 'sms')),
+    opted_out    BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_start  INTERVAL,                -- NULL = no quiet hours
+    quiet_end    INTERVAL,
+    time_zone    TEXT NOT NULL DEFAULT '',
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, address, channel)
+);
@@ -0,0 +1,55 @@
+-- services/communications-service/db/migrations/003_create_webhooks.sql
+
+-- Merchant webhook endpoints and the delivery log.
+-- webhook_deliveries doubles as the retry queue: the dispatcher claims PENDING
+-- rows whose next_attempt_at has passed.
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id                    UUID PRIMARY KEY,
+    tenant_id             TEXT NOT NULL,
+    url                   TEXT NOT NULL,
+    secret                TEXT NOT NULL,
+    events                TEXT[] NOT NULL DEFAULT '{}', -- empty = all events
+    enabled               BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures  INTEGER NOT NULL DEFAULT 0,
+    disabled_reason       TEXT NOT NULL DEFAULT '',
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_tenant ON webhook_endpoints (tenant_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               UUID PRIMARY KEY,
+    endpoint_id      UUID NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    tenant_id        TEXT NOT NULL,
+    event_id         TEXT NOT NULL,
+    event_type       TEXT NOT NULL,
+    payload          JSONB NOT NULL,
+    status           TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL,
+    last_error       TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A redelivered Kafka event must not notify the same endpoint twice
+CREATE UNIQUE INDEX IF NOT EXISTS uq_webhook_delivery_event ON webhook_deliveries (endpoint_id, event_type, event_id);
+
+-- The dispatcher's poll query
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries (endpoint_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    id             BIGSERIAL PRIMARY KEY,
+    delivery_id    UUID NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    attempt        INTEGER NOT NULL,
+    status_code    INTEGER NOT NULL DEFAULT 0,   -- 0 = no response
+    error          TEXT NOT NULL DEFAULT '',
+    response_body  TEXT NOT NULL DEFAULT '',
+    duration_ms    INTEGER NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_attempts_delivery ON webhook_attempts (delivery_id, attempt);
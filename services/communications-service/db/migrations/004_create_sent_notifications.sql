@@ -0,0 +1,14 @@
+-- services/communications-service/db/migrations/004_create_sent_notifications.sql
+
+-- Idempotency keys of notification jobs that were sent, per channel.
+-- Workers skip a job whose key is already here; rows older than
+-- COMMS_IDEMPOTENCY_TTL are deleted by the service.
+
+CREATE TABLE IF NOT EXISTS sent_notifications (
+    idempotency_key  TEXT NOT NULL,
+    channel          TEXT NOT NULL,
+    sent_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (idempotency_key, channel)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sent_notifications_sent_at ON sent_notifications (sent_at);
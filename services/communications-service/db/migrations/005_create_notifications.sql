@@ -0,0 +1,30 @@
+-- services/communications-service/db/migrations/005_create_notifications.sql
+
+-- Delivery history: one row per notification job (job_id = idempotency key).
+-- The bridge inserts it as queued, workers move it to sent / failed /
+-- dead_lettered / skipped, provider callbacks to delivered / bounced.
+
+CREATE TABLE IF NOT EXISTS notifications (
+    job_id               TEXT PRIMARY KEY,
+    tenant_id            TEXT NOT NULL DEFAULT '',
+    shipment_ids         TEXT[] NOT NULL DEFAULT '{}',
+    event                TEXT NOT NULL,
+    template             TEXT NOT NULL,
+    channel              TEXT NOT NULL CHECK (channel IN ('email', 'sms')),
+    recipient            TEXT NOT NULL,
+    status               TEXT NOT NULL CHECK (status IN ('queued', 'sent', 'delivered', 'failed', 'dead_lettered', 'bounced', 'skipped')),
+    provider_message_id  TEXT NOT NULL DEFAULT '',
+    last_error           TEXT NOT NULL DEFAULT '',
+    attempts             INTEGER NOT NULL DEFAULT 0,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at              TIMESTAMPTZ,
+    delivered_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_shipments ON notifications USING GIN (shipment_ids);
+CREATE INDEX IF NOT EXISTS idx_notifications_tenant ON notifications (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications (recipient, created_at DESC);
+-- Provider callbacks look jobs up by the provider's message ID
+CREATE INDEX IF NOT EXISTS idx_notifications_provider_message
+    ON notifications (channel, provider_message_id) WHERE provider_message_id <> '';
@@ -0,0 +1,19 @@
+-- services/communications-service/db/migrations/006_create_rate_limits.sql
+
+-- Send rate limits. rate_limit_rules holds the rules changed through the admin
+-- API (they override COMMS_RATE_LIMITS); rate_limit_buckets holds the token
+-- buckets every worker replica takes from, so they share one budget.
+
+CREATE TABLE IF NOT EXISTS rate_limit_rules (
+    rule_id     TEXT PRIMARY KEY, -- tenant:<tenant>:<channel> or provider:<name>
+    max_count   INTEGER NOT NULL CHECK (max_count > 0),
+    period_ms   BIGINT NOT NULL CHECK (period_ms > 0),
+    burst       INTEGER NOT NULL CHECK (burst > 0),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket      TEXT PRIMARY KEY,
+    tokens      DOUBLE PRECISION NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,10 @@
+-- services/communications-service/db/migrations/007_add_sent_notifications_status.sql
+
+-- A worker claims a job's key (PENDING) before it sends, so two workers given
+-- the same job cannot both send it; the claim becomes SENT once the provider
+-- accepted the message, or is deleted if the send failed. sent_at is the time
+-- of the last change: the claim, then the send.
+
+ALTER TABLE sent_notifications
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'SENT'
+    CHECK (status IN ('PENDING', 'SENT'));
@@ -0,0 +1,56 @@
+package caller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+func TestAuthenticateAndTenant(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/tenants/{tenant}/things", func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := PathTenant(w, r)
+		if ok {
+			w.Write([]byte(tenantID))
+		}
+	})
+	h := Authenticate("s3cret", mux)
+	call := func(path, token string, id *identity.Identity) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if id != nil {
+			identity.SetHeader(req.Header, *id)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	member := &identity.Identity{UserID: "u-1", TenantID: "acme", Role: "member"}
+
+	if rec := call("/v1/tenants/acme/things", "", member); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no service token: %d", rec.Code)
+	}
+	if rec := call("/v1/tenants/acme/things", "wrong", member); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong service token: %d", rec.Code)
+	}
+	if rec := call("/v1/tenants/acme/things", "s3cret", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no caller: %d", rec.Code)
+	}
+	if rec := call("/v1/tenants/acme/things", "s3cret", member); rec.Code != http.StatusOK || rec.Body.String() != "acme" {
+		t.Errorf("own tenant: %d %s", rec.Code, rec.Body)
+	}
+	if rec := call("/v1/tenants/globex/things", "s3cret", member); rec.Code != http.StatusForbidden {
+		t.Errorf("other tenant: %d", rec.Code)
+	}
+	if rec := call("/v1/tenants/acme/things", "s3cret", &identity.Identity{UserID: "u-2"}); rec.Code != http.StatusForbidden {
+		t.Errorf("tenantless caller: %d", rec.Code)
+	}
+	operator := &identity.Identity{UserID: "op", SuperAdmin: true}
+	if rec := call("/v1/tenants/globex/things", "s3cret", operator); rec.Code != http.StatusOK || rec.Body.String() != "globex" {
+		t.Errorf("operator: %d %s", rec.Code, rec.Body)
+	}
+}
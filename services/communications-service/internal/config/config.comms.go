@@ -0,0 +1,153 @@
+// services/communications-service/internal/config/config.comms.go
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/ratelimit"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/retry"
+	"github.com/Tanmoy095/LogiSynapse/shared/config"
+)
+
+type CommsConfig struct {
+	CommonConfig *config.CommonConfig // DB, Kafka and RabbitMQ settings
+	// HTTPAddr serves the public routes: the unsubscribe page, provider
+	// callbacks and the admin endpoints
+	HTTPAddr string
+	// InternalHTTPAddr serves the tenant APIs (templates, contacts, webhooks,
+	// notification history) to the gateway. Keep it off the public network.
+	InternalHTTPAddr string
+	// InternalToken is the service token the gateway sends to InternalHTTPAddr
+	// along with the caller. Unset disables the tenant APIs.
+	InternalToken string
+	// GRPCAddr serves the notification history to the gateway
+	GRPCAddr string
+	// DefaultLocale is the last locale tried when a template is missing for the recipient's locale
+	DefaultLocale string
+	// PublicURL is where recipients reach this service (unsubscribe links)
+	PublicURL string
+	// UnsubscribeSecret signs unsubscribe tokens. Rotating it breaks links in old emails.
+	UnsubscribeSecret string
+
+	// Delivery providers. "file" writes messages to OutboxDir instead of sending them.
+	EmailProvider string // smtp | sendgrid | file
+	SMSProvider   string // twilio | file
+	OutboxDir     string
+	EmailFrom     string
+	SMSFrom       string // E.164 number or Twilio messaging service SID (MG...)
+
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+
+	SendGridAPIKey string
+	// SendGridWebhookKey verifies signed event webhooks (bounces, deliveries). Empty disables the callback.
+	SendGridWebhookKey string
+
+	TwilioAccountSID string
+	TwilioAuthToken  string
+
+	// WebhookAllowHTTP lets merchants register plain http:// webhook URLs (local development only)
+	WebhookAllowHTTP bool
+
+	// RetryPolicies picks the delays between retries for each job type (COMMS_RETRY_POLICIES)
+	RetryPolicies retry.Policies
+
+	// RateLimits are the starting send limits per tenant and provider
+	// (COMMS_RATE_LIMITS); the admin API overrides them at runtime.
+	RateLimits []ratelimit.Rule
+
+	// RoutesFile holds the bridge's routing rules (COMMS_ROUTES_FILE). Empty uses the built-in routes.
+	RoutesFile string
+
+	// IdempotencyTTL is how long a sent job's key is remembered (COMMS_IDEMPOTENCY_TTL)
+	IdempotencyTTL time.Duration
+
+	// AdminToken guards the /v1/admin endpoints (DLQ tooling, rate limits). Unset disables them.
+	AdminToken string
+}
+
+// LoadConfig loads the communications service configuration.
+// Without DB_HOST tenant overrides are kept in memory; without EMAIL_PROVIDER /
+// SMS_PROVIDER messages go to the file sink.
+func LoadConfig() (*CommsConfig, error) {
+	cfg := &CommsConfig{
+		CommonConfig:       config.LoadCommonConfig(),
+		HTTPAddr:           getEnv("COMMS_HTTP_ADDR", ":8090"),
+		InternalHTTPAddr:   getEnv("COMMS_INTERNAL_HTTP_ADDR", ":8091"),
+		InternalToken:      os.Getenv("COMMS_INTERNAL_TOKEN"),
+		GRPCAddr:           getEnv("COMMS_GRPC_ADDR", ":50055"),
+		DefaultLocale:      getEnv("COMMS_DEFAULT_LOCALE", "en"),
+		PublicURL:          getEnv("COMMS_PUBLIC_URL", "http://localhost:8090"),
+		UnsubscribeSecret:  os.Getenv("COMMS_UNSUBSCRIBE_SECRET"),
+		EmailProvider:      getEnv("EMAIL_PROVIDER", "file"),
+		SMSProvider:        getEnv("SMS_PROVIDER", "file"),
+		OutboxDir:          getEnv("COMMS_OUTBOX_DIR", "./outbox"),
+		EmailFrom:          getEnv("EMAIL_FROM", "LogiSynapse <no-reply@logisynapse.local>"),
+		SMSFrom:            os.Getenv("SMS_FROM"),
+		SMTPAddr:           getEnv("SMTP_ADDR", "localhost:1025"),
+		SMTPUsername:       os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:       os.Getenv("SMTP_PASSWORD"),
+		SendGridAPIKey:     os.Getenv("SENDGRID_API_KEY"),
+		SendGridWebhookKey: os.Getenv("SENDGRID_WEBHOOK_PUBLIC_KEY"),
+		TwilioAccountSID:   os.Getenv("TWILIO_ACCOUNT_SID"),
+		TwilioAuthToken:    os.Getenv("TWILIO_AUTH_TOKEN"),
+		WebhookAllowHTTP:   os.Getenv("COMMS_WEBHOOK_ALLOW_HTTP") == "true",
+		AdminToken:         os.Getenv("COMMS_ADMIN_TOKEN"),
+		RoutesFile:         os.Getenv("COMMS_ROUTES_FILE"),
+	}
+
+	policies, err := retry.ParsePolicies(os.Getenv("COMMS_RETRY_POLICIES"))
+	if err != nil {
+		return nil, fmt.Errorf("COMMS_RETRY_POLICIES: %w", err)
+	}
+	cfg.RetryPolicies = policies
+
+	rateLimits, err := ratelimit.ParseRules(os.Getenv("COMMS_RATE_LIMITS"))
+	if err != nil {
+		return nil, fmt.Errorf("COMMS_RATE_LIMITS: %w", err)
+	}
+	cfg.RateLimits = rateLimits
+
+	ttl, err := time.ParseDuration(getEnv("COMMS_IDEMPOTENCY_TTL", "72h"))
+	if err != nil || ttl <= 0 {
+		return nil, fmt.Errorf("COMMS_IDEMPOTENCY_TTL must be a positive duration such as 72h")
+	}
+	cfg.IdempotencyTTL = ttl
+
+	if cfg.UnsubscribeSecret == "" {
+		if cfg.EmailProvider != "file" {
+			return nil, fmt.Errorf("COMMS_UNSUBSCRIBE_SECRET is required when sending real email")
+		}
+		cfg.UnsubscribeSecret = "local-dev-unsubscribe-secret"
+	}
+
+	switch cfg.EmailProvider {
+	case "file", "smtp":
+	case "sendgrid":
+		if cfg.SendGridAPIKey == "" {
+			return nil, fmt.Errorf("SENDGRID_API_KEY is required when EMAIL_PROVIDER=sendgrid")
+		}
+	default:
+		return nil, fmt.Errorf("unknown EMAIL_PROVIDER %q (want smtp, sendgrid or file)", cfg.EmailProvider)
+	}
+	switch cfg.SMSProvider {
+	case "file":
+	case "twilio":
+		if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" || cfg.SMSFrom == "" {
+			return nil, fmt.Errorf("TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and SMS_FROM are required when SMS_PROVIDER=twilio")
+		}
+	default:
+		return nil, fmt.Errorf("unknown SMS_PROVIDER %q (want twilio or file)", cfg.SMSProvider)
+	}
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
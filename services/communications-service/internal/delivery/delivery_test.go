@@ -0,0 +1,195 @@
+package delivery
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTwilioClassifiesErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		body      string
+		permanent bool
+		kind      error
+	}{
+		{"sent", http.StatusCreated, `{"sid":"SM1"}`, false, nil},
+		{"invalid number", http.StatusBadRequest, `{"code":21211,"message":"The 'To' number is not valid"}`, true, ErrInvalidRecipient},
+		{"unsubscribed", http.StatusBadRequest, `{"code":21610,"message":"Attempt to send to unsubscribed recipient"}`, true, ErrRejected},
+		{"rate limited", http.StatusTooManyRequests, `{"code":20429,"message":"Too Many Requests"}`, false, nil},
+		{"outage", http.StatusServiceUnavailable, `upstream unavailable`, false, nil},
+		{"bad credentials", http.StatusUnauthorized, `{"code":20003,"message":"Authenticate"}`, true, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Accounts/AC1/Messages.json") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if r.FormValue("To") != "+4915112345678" || r.FormValue("From") != "+15005550006" {
+					t.Errorf("unexpected form %v", r.Form)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			s := NewTwilioSender("AC1", "token", "+15005550006", srv.Client())
+			s.BaseURL = srv.URL
+			sid, err := s.SendSMS(context.Background(), SMSMessage{To: "+4915112345678", Body: "hi"})
+			if tc.status == http.StatusCreated {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if sid != "SM1" {
+					t.Errorf("message ID = %q, want SM1", sid)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if IsPermanent(err) != tc.permanent {
+				t.Errorf("IsPermanent = %v, want %v (%v)", IsPermanent(err), tc.permanent, err)
+			}
+			if tc.kind != nil && !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}
+
+func TestTwilioRejectsMalformedNumberWithoutCalling(t *testing.T) {
+	s := NewTwilioSender("AC1", "token", "+15005550006", &http.Client{Transport: failTransport{t}})
+	_, err := s.SendSMS(context.Background(), SMSMessage{To: "0151 123", Body: "hi"})
+	if !IsPermanent(err) || !errors.Is(err, ErrInvalidRecipient) {
+		t.Errorf("expected permanent ErrInvalidRecipient, got %v", err)
+	}
+}
+
+func TestSendGridClassifiesErrors(t *testing.T) {
+	status := http.StatusAccepted
+	body := ""
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("missing API key")
+		}
+		w.Header().Set("X-Message-Id", "sg-1")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	s := NewSendGridSender("key", "noreply@logisynapse.test", srv.Client())
+	s.URL = srv.URL
+	msg := EmailMessage{To: "jana@example.com", Subject: "s", Body: "<p>b</p>", HTML: true}
+
+	if id, err := s.SendEmail(context.Background(), msg); err != nil || id != "sg-1" {
+		t.Fatalf("SendEmail = %q, %v", id, err)
+	}
+
+	status, body = http.StatusBadRequest, `{"errors":[{"message":"Does not contain a valid address.","field":"personalizations.0.to.0.email"}]}`
+	if _, err := s.SendEmail(context.Background(), msg); !IsPermanent(err) {
+		t.Errorf("400 should be permanent, got %v", err)
+	}
+	status, body = http.StatusInternalServerError, ""
+	if _, err := s.SendEmail(context.Background(), msg); err == nil || IsPermanent(err) {
+		t.Errorf("500 should be retryable, got %v", err)
+	}
+}
+
+func TestClassifySMTP(t *testing.T) {
+	if err := classifySMTP(&textproto.Error{Code: 550, Msg: "no such user"}); !errors.Is(err, ErrInvalidRecipient) || !IsPermanent(err) {
+		t.Errorf("550: got %v", err)
+	}
+	if err := classifySMTP(&textproto.Error{Code: 451, Msg: "greylisted"}); err == nil || IsPermanent(err) {
+		t.Errorf("451 should be retryable, got %v", err)
+	}
+}
+
+func TestFileSinkWritesMessages(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewFileSink(dir)
+	if _, err := sink.SendEmail(context.Background(), EmailMessage{To: "jana@example.com", Subject: "Booked", Body: "<p>hi</p>", HTML: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sink.SendSMS(context.Background(), SMSMessage{To: "+4915112345678", Body: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	emails, _ := filepath.Glob(filepath.Join(dir, "email", "*.eml"))
+	sms, _ := filepath.Glob(filepath.Join(dir, "sms", "*.txt"))
+	if len(emails) != 1 || len(sms) != 1 {
+		t.Fatalf("got %d emails, %d sms", len(emails), len(sms))
+	}
+	content, _ := os.ReadFile(emails[0])
+	if !strings.Contains(string(content), "Subject: Booked") {
+		t.Errorf("email file = %q", content)
+	}
+	if _, err := sink.SendSMS(context.Background(), SMSMessage{Body: "hi"}); !IsPermanent(err) {
+		t.Errorf("missing recipient should be permanent, got %v", err)
+	}
+}
+
+type failTransport struct{ t *testing.T }
+
+func (f failTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.t.Error("provider should not have been called")
+	return nil, errors.New("unexpected call")
+}
+
+func TestSendGridVerifier(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	v, err := NewSendGridVerifier(base64.StdEncoding.EncodeToString(der))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`[{"event":"delivered","sg_message_id":"sg-1.filter"}]`)
+	digest := sha256.Sum256(append([]byte("1736143200"), body...))
+	sig, _ := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+
+	if err := v.Verify(base64.StdEncoding.EncodeToString(sig), "1736143200", body); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+	if err := v.Verify(base64.StdEncoding.EncodeToString(sig), "1736143201", body); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("changed timestamp accepted: %v", err)
+	}
+}
+
+func TestVerifyTwilio(t *testing.T) {
+	// Example from Twilio's webhook security docs
+	form := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+	u := "https://mycompany.com/myapp.php?foo=1&bar=2"
+	if got := TwilioSignature("12345", u, form); got != "0/KCTR6DLpKmkAf8muzZqo1nDgQ=" {
+		t.Errorf("signature = %s", got)
+	}
+	if err := VerifyTwilio("12345", u, form, "0/KCTR6DLpKmkAf8muzZqo1nDgQ="); err != nil {
+		t.Error(err)
+	}
+	form.Set("Digits", "9999")
+	if err := VerifyTwilio("12345", u, form, "0/KCTR6DLpKmkAf8muzZqo1nDgQ="); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("tampered form accepted: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// services/communications-service/internal/delivery/file.delivery.go
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileSink "delivers" messages by writing them to a directory, one file per
+// message. Used in local development and tests so nothing real is sent.
+//
+//	<dir>/email/20250106T060000.000000000-1-jana@example.com.eml
+//	<dir>/sms/20250106T060000.000000000-2-+4915112345678.txt
+type FileSink struct {
+	Dir string
+	seq atomic.Int64
+}
+
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{Dir: dir}
+}
+
+func (f *FileSink) SendEmail(ctx context.Context, msg EmailMessage) (string, error) {
+	if msg.To == "" {
+		return "", Permanent(fmt.Errorf("%w: empty email address", ErrInvalidRecipient))
+	}
+	content := fmt.Sprintf("From: %s\nTo: %s\nSubject: %s\nX-Idempotency-Key: %s\nList-Unsubscribe: <%s>\nContent-Type: %s\n\n%s\n",
+		msg.From, msg.To, msg.Subject, msg.IdempotencyKey, msg.UnsubscribeURL, contentType(msg.HTML), msg.Body)
+	return f.write("email", msg.To, ".eml", content)
+}
+
+func (f *FileSink) SendSMS(ctx context.Context, msg SMSMessage) (string, error) {
+	if msg.To == "" {
+		return "", Permanent(fmt.Errorf("%w: empty phone number", ErrInvalidRecipient))
+	}
+	content := fmt.Sprintf("From: %s\nTo: %s\nX-Idempotency-Key: %s\n\n%s\n", msg.From, msg.To, msg.IdempotencyKey, msg.Body)
+	return f.write("sms", msg.To, ".txt", content)
+}
+
+// write stores one message and returns its file name as the message ID.
+func (f *FileSink) write(kind, to, ext, content string) (string, error) {
+	dir := filepath.Join(f.Dir, kind)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("file sink: %w", err)
+	}
+	name := fmt.Sprintf("%s-%d-%s%s", time.Now().UTC().Format("20060102T150405.000000000"), f.seq.Add(1), sanitize(to), ext)
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("file sink: %w", err)
+	}
+	return name, nil
+}
+
+func contentType(html bool) string {
+	if html {
+		return "text/html"
+	}
+	return "text/plain"
+}
+
+// sanitize keeps recipient names usable as file names.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == 0 {
+			return '_'
+		}
+		return r
+	}, s)
+}
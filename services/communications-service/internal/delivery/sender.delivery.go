@@ -0,0 +1,83 @@
+// services/communications-service/internal/delivery/sender.delivery.go
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// EmailMessage is a rendered email ready to hand to a provider.
+type EmailMessage struct {
+	To      string
+	From    string // empty uses the sender's configured default
+	Subject string
+	Body    string
+	HTML    bool
+	// IdempotencyKey is forwarded to providers that support it, so a retried
+	// job does not produce a second email.
+	IdempotencyKey string
+	// UnsubscribeURL is sent as List-Unsubscribe so mail clients can offer one-click unsubscribe.
+	UnsubscribeURL string
+}
+
+// SMSMessage is a rendered text message. To is E.164 (+4915112345678).
+type SMSMessage struct {
+	To             string
+	From           string
+	Body           string
+	IdempotencyKey string
+}
+
+// EmailSender delivers email and returns the provider's message ID, which
+// delivery callbacks (bounces, delivered) refer to. Implementations return a
+// PermanentError for failures that will not go away on retry (bad address,
+// rejected content).
+type EmailSender interface {
+	SendEmail(ctx context.Context, msg EmailMessage) (string, error)
+}
+
+// SMSSender delivers text messages. Same contract as EmailSender.
+type SMSSender interface {
+	SendSMS(ctx context.Context, msg SMSMessage) (string, error)
+}
+
+var (
+	ErrInvalidRecipient = errors.New("invalid recipient")
+	ErrRejected         = errors.New("message rejected by provider")
+)
+
+// PermanentError marks a delivery failure that retrying cannot fix.
+// Everything else is treated as transient (timeouts, 5xx, rate limits).
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return "permanent: " + e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so IsPermanent reports true. Nil stays nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err (or anything it wraps) is a PermanentError.
+func IsPermanent(err error) bool {
+	var p *PermanentError
+	return errors.As(err, &p)
+}
+
+// httpStatusError classifies a provider HTTP response. 408 and 429 are retried
+// like 5xx; any other 4xx means the request itself is wrong and is permanent.
+func httpStatusError(provider string, status int, detail string) error {
+	err := fmt.Errorf("%s: status %d: %s", provider, status, detail)
+	if status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
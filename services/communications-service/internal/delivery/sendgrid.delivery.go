@@ -0,0 +1,94 @@
+// services/communications-service/internal/delivery/sendgrid.delivery.go
+
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+)
+
+const sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridSender sends email through SendGrid's v3 HTTP API.
+type SendGridSender struct {
+	APIKey string
+	From   string
+	Client *http.Client
+	URL    string // overridable for tests
+}
+
+func NewSendGridSender(apiKey, from string, client *http.Client) *SendGridSender {
+	return &SendGridSender{APIKey: apiKey, From: from, Client: client, URL: sendGridURL}
+}
+
+func (s *SendGridSender) SendEmail(ctx context.Context, msg EmailMessage) (string, error) {
+	if _, err := mail.ParseAddress(msg.To); err != nil {
+		return "", Permanent(fmt.Errorf("%w: %q: %v", ErrInvalidRecipient, msg.To, err))
+	}
+	from := msg.From
+	if from == "" {
+		from = s.From
+	}
+	contentType := "text/plain"
+	if msg.HTML {
+		contentType = "text/html"
+	}
+	payload := map[string]interface{}{
+		"personalizations": []map[string]interface{}{{"to": []map[string]string{{"email": msg.To}}}},
+		"from":             map[string]string{"email": from},
+		"subject":          msg.Subject,
+		"content":          []map[string]string{{"type": contentType, "value": msg.Body}},
+	}
+	if msg.IdempotencyKey != "" {
+		// Echoed back in event webhooks so callbacks can be matched to the job
+		payload["custom_args"] = map[string]string{"idempotency_key": msg.IdempotencyKey}
+	}
+	if msg.UnsubscribeURL != "" {
+		payload["headers"] = map[string]string{
+			"List-Unsubscribe":      "<" + msg.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", Permanent(fmt.Errorf("sendgrid: failed to marshal request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", Permanent(fmt.Errorf("sendgrid: failed to create request: %w", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sendgrid: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK {
+		return resp.Header.Get("X-Message-Id"), nil
+	}
+
+	// {"errors":[{"message":"...","field":"personalizations.0.to"}]}
+	var apiErr struct {
+		Errors []struct {
+			Message string `json:"message"`
+			Field   string `json:"field"`
+		} `json:"errors"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	detail := string(raw)
+	if json.Unmarshal(raw, &apiErr) == nil && len(apiErr.Errors) > 0 {
+		detail = apiErr.Errors[0].Message
+		if apiErr.Errors[0].Field != "" {
+			detail = apiErr.Errors[0].Field + ": " + detail
+		}
+	}
+	return "", httpStatusError("sendgrid", resp.StatusCode, detail)
+}
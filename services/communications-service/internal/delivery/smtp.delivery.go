@@ -0,0 +1,124 @@
+// services/communications-service/internal/delivery/smtp.delivery.go
+
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTPSender sends email through an SMTP relay (Postfix, SES SMTP, Mailpit in dev).
+type SMTPSender struct {
+	Addr     string // host:port
+	Username string // empty disables AUTH (local relays)
+	Password string
+	From     string
+}
+
+func NewSMTPSender(addr, username, password, from string) *SMTPSender {
+	return &SMTPSender{Addr: addr, Username: username, Password: password, From: from}
+}
+
+func (s *SMTPSender) SendEmail(ctx context.Context, msg EmailMessage) (string, error) {
+	from := msg.From
+	if from == "" {
+		from = s.From
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return "", Permanent(fmt.Errorf("%w: %q: %v", ErrInvalidRecipient, msg.To, err))
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", Permanent(fmt.Errorf("smtp: invalid from address %q: %v", from, err))
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, _ := net.SplitHostPort(s.Addr)
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	// SMTP relays do not hand back an ID, so we set the Message-ID ourselves;
+	// bounces quote it.
+	messageID := newMessageID(sender.Address)
+
+	// net/smtp has no context support; run it in the background so a cancelled
+	// job does not hang the worker on a stuck relay.
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.Addr, auth, sender.Address, []string{to.Address}, buildMIME(sender, to, messageID, msg))
+	}()
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case err := <-done:
+		if err := classifySMTP(err); err != nil {
+			return "", err
+		}
+		return messageID, nil
+	}
+}
+
+// newMessageID returns "<random@sender-domain>".
+func newMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
+
+func buildMIME(from, to *mail.Address, messageID string, msg EmailMessage) []byte {
+	contentType := "text/plain; charset=UTF-8"
+	if msg.HTML {
+		contentType = "text/html; charset=UTF-8"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: %s\r\n", messageID)
+	if msg.IdempotencyKey != "" {
+		fmt.Fprintf(&buf, "X-Idempotency-Key: %s\r\n", msg.IdempotencyKey)
+	}
+	if msg.UnsubscribeURL != "" {
+		fmt.Fprintf(&buf, "List-Unsubscribe: <%s>\r\n", msg.UnsubscribeURL)
+		buf.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n", contentType)
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	return buf.Bytes()
+}
+
+// classifySMTP maps SMTP reply codes: 5xx is a permanent rejection (unknown
+// mailbox, policy), 4xx is "try again later". Connection errors are transient.
+func classifySMTP(err error) error {
+	if err == nil {
+		return nil
+	}
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && tpErr.Code >= 500 {
+		if tpErr.Code == 550 || tpErr.Code == 553 {
+			return Permanent(fmt.Errorf("%w: smtp %d %s", ErrInvalidRecipient, tpErr.Code, tpErr.Msg))
+		}
+		return Permanent(fmt.Errorf("%w: smtp %d %s", ErrRejected, tpErr.Code, tpErr.Msg))
+	}
+	return fmt.Errorf("smtp: %w", err)
+}
@@ -0,0 +1,103 @@
+// services/communications-service/internal/delivery/twilio.delivery.go
+
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const twilioBaseURL = "https://api.twilio.com"
+
+// Twilio error codes that will never succeed on retry.
+// https://www.twilio.com/docs/api/errors
+var twilioPermanentCodes = map[int]error{
+	21211: ErrInvalidRecipient, // invalid 'To' phone number
+	21214: ErrInvalidRecipient, // 'To' number cannot be reached
+	21408: ErrRejected,         // permission to send to this region not enabled
+	21610: ErrRejected,         // recipient replied STOP
+	21614: ErrInvalidRecipient, // 'To' is not a mobile number
+}
+
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// TwilioSender sends SMS through Twilio's Messages API.
+type TwilioSender struct {
+	AccountSID string
+	AuthToken  string
+	From       string // sending number or messaging service SID
+	Client     *http.Client
+	BaseURL    string // overridable for tests
+	// StatusCallback is where Twilio reports delivered/undelivered. Empty disables callbacks.
+	StatusCallback string
+}
+
+func NewTwilioSender(accountSID, authToken, from string, client *http.Client) *TwilioSender {
+	return &TwilioSender{AccountSID: accountSID, AuthToken: authToken, From: from, Client: client, BaseURL: twilioBaseURL}
+}
+
+func (s *TwilioSender) SendSMS(ctx context.Context, msg SMSMessage) (string, error) {
+	// Reject obviously bad numbers locally instead of paying for the API call
+	if !e164.MatchString(msg.To) {
+		return "", Permanent(fmt.Errorf("%w: %q is not an E.164 phone number", ErrInvalidRecipient, msg.To))
+	}
+	from := msg.From
+	if from == "" {
+		from = s.From
+	}
+	form := url.Values{}
+	form.Set("To", msg.To)
+	form.Set("Body", msg.Body)
+	if strings.HasPrefix(from, "MG") {
+		form.Set("MessagingServiceSid", from)
+	} else {
+		form.Set("From", from)
+	}
+	if s.StatusCallback != "" {
+		form.Set("StatusCallback", s.StatusCallback)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.BaseURL, url.PathEscape(s.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", Permanent(fmt.Errorf("twilio: failed to create request: %w", err))
+	}
+	req.SetBasicAuth(s.AccountSID, s.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if msg.IdempotencyKey != "" {
+		req.Header.Set("I-Twilio-Idempotency-Token", msg.IdempotencyKey)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("twilio: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK {
+		var created struct {
+			SID string `json:"sid"`
+		}
+		json.Unmarshal(raw, &created)
+		return created.SID, nil
+	}
+
+	// {"code": 21211, "message": "The 'To' number 123 is not a valid phone number.", "status": 400}
+	var apiErr struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &apiErr) == nil && apiErr.Code != 0 {
+		if kind, ok := twilioPermanentCodes[apiErr.Code]; ok {
+			return "", Permanent(fmt.Errorf("%w: twilio %d: %s", kind, apiErr.Code, apiErr.Message))
+		}
+		return "", httpStatusError("twilio", resp.StatusCode, fmt.Sprintf("%d %s", apiErr.Code, apiErr.Message))
+	}
+	return "", httpStatusError("twilio", resp.StatusCode, string(raw))
+}
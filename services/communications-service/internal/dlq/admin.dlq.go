@@ -0,0 +1,229 @@
+// services/communications-service/internal/dlq/admin.dlq.go
+
+// Package dlq inspects, replays and purges the email/SMS dead-letter queues.
+// Used by the admin HTTP endpoints and by cmd/dlq.
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/jobs"
+)
+
+var (
+	ErrUnknownQueue       = errors.New("unknown dead-letter queue")
+	ErrNothingSelected    = errors.New("select messages by key, or pass all")
+	ErrConfirmationNeeded = errors.New("purge must be confirmed with the queue name")
+)
+
+// maxScan bounds how many messages one call holds unacked.
+const maxScan = 10000
+
+// Message is one message pulled from a queue and not yet settled.
+type Message struct {
+	Body    []byte
+	ack     func() error
+	requeue func() error
+}
+
+// NewMessage wraps a broker message. ack removes it from the queue for good,
+// requeue puts it back where it was.
+func NewMessage(body []byte, ack, requeue func() error) *Message {
+	return &Message{Body: body, ack: ack, requeue: requeue}
+}
+
+// Broker is the little slice of RabbitMQ the admin needs.
+type Broker interface {
+	Depth(queue string) (int, error)
+	// Get returns ok=false when the queue is empty.
+	Get(queue string) (*Message, bool, error)
+	Publish(ctx context.Context, queue string, body []byte) error
+	Purge(queue string) (int, error)
+}
+
+// Entry is a DLQ message with its envelope unwrapped.
+type Entry struct {
+	IdempotencyKey string     `json:"idempotency_key"`
+	RetryCount     int32      `json:"retry_count"`
+	LastError      string     `json:"last_error,omitempty"`
+	Event          string     `json:"event,omitempty"`
+	Channel        string     `json:"channel,omitempty"`
+	TenantID       string     `json:"tenant_id,omitempty"`
+	To             string     `json:"to,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	// Raw is set when the message is not a job envelope at all.
+	Raw string `json:"raw,omitempty"`
+}
+
+// Filter narrows List and Replay. Empty fields match everything.
+type Filter struct {
+	Event    string
+	TenantID string
+	Keys     []string // idempotency keys
+}
+
+func (f Filter) matches(e Entry) bool {
+	if f.Event != "" && e.Event != f.Event {
+		return false
+	}
+	if f.TenantID != "" && e.TenantID != f.TenantID {
+		return false
+	}
+	if len(f.Keys) > 0 {
+		for _, k := range f.Keys {
+			if k == e.IdempotencyKey {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type Admin struct {
+	broker Broker
+	// mu serialises scans: messages held by one scan are invisible to another.
+	mu sync.Mutex
+}
+
+func NewAdmin(broker Broker) *Admin {
+	return &Admin{broker: broker}
+}
+
+// List returns up to limit matching entries (0 = no limit) and the number of
+// messages scanned. Every message is put back.
+func (a *Admin) List(ctx context.Context, dlq string, f Filter, limit int) ([]Entry, int, error) {
+	var out []Entry
+	scanned, err := a.scan(dlq, func(m *Message, e Entry) (bool, error) {
+		if f.matches(e) && (limit <= 0 || len(out) < limit) {
+			out = append(out, e)
+		}
+		return false, nil
+	})
+	return out, scanned, err
+}
+
+// Replay moves matching messages back onto the work queue with their retry
+// count reset. Without keys it needs all=true, so a typo in a filter cannot
+// silently replay the whole queue.
+func (a *Admin) Replay(ctx context.Context, dlq string, f Filter, all bool) (int, error) {
+	workQueue, ok := jobs.WorkQueueFor[dlq]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownQueue, dlq)
+	}
+	if len(f.Keys) == 0 && !all {
+		return 0, ErrNothingSelected
+	}
+	replayed := 0
+	_, err := a.scan(dlq, func(m *Message, e Entry) (bool, error) {
+		if e.Raw != "" || !f.matches(e) {
+			return false, nil
+		}
+		q, err := jobs.Unwrap(m.Body)
+		if err != nil {
+			return false, nil
+		}
+		q.RetryCount = 0
+		q.LastError = ""
+		q.NextAttemptAt = nil
+		body, err := json.Marshal(q)
+		if err != nil {
+			return false, err
+		}
+		if err := a.broker.Publish(ctx, workQueue, body); err != nil {
+			return false, fmt.Errorf("failed to republish %s: %w", e.IdempotencyKey, err)
+		}
+		replayed++
+		return true, nil
+	})
+	return replayed, err
+}
+
+// Purge empties a DLQ. confirm must be the queue name.
+func (a *Admin) Purge(dlq, confirm string) (int, error) {
+	if _, ok := jobs.WorkQueueFor[dlq]; !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownQueue, dlq)
+	}
+	if confirm != dlq {
+		return 0, ErrConfirmationNeeded
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.broker.Purge(dlq)
+}
+
+// scan pulls every message currently in dlq and calls fn on it. When fn
+// returns true the message is acked (consumed); all others are requeued once
+// the scan finishes, whether it succeeded or not.
+func (a *Admin) scan(dlq string, fn func(*Message, Entry) (bool, error)) (int, error) {
+	if _, ok := jobs.WorkQueueFor[dlq]; !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownQueue, dlq)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	depth, err := a.broker.Depth(dlq)
+	if err != nil {
+		return 0, err
+	}
+	if depth > maxScan {
+		depth = maxScan
+	}
+
+	var held []*Message
+	defer func() {
+		// Newest first, so a broker that requeues at the head keeps the order.
+		for i := len(held) - 1; i >= 0; i-- {
+			held[i].requeue()
+		}
+	}()
+
+	scanned := 0
+	// Requeued messages are not redelivered while we hold them, so reading
+	// depth messages visits each one exactly once.
+	for scanned < depth {
+		m, ok, err := a.broker.Get(dlq)
+		if err != nil {
+			return scanned, err
+		}
+		if !ok {
+			break
+		}
+		scanned++
+		take, err := fn(m, entryFor(m.Body))
+		if err != nil {
+			held = append(held, m)
+			return scanned, err
+		}
+		if take {
+			if err := m.ack(); err != nil {
+				return scanned, err
+			}
+			continue
+		}
+		held = append(held, m)
+	}
+	return scanned, nil
+}
+
+func entryFor(body []byte) Entry {
+	q, err := jobs.Unwrap(body)
+	if err != nil || q.Payload == nil {
+		return Entry{Raw: string(body)}
+	}
+	n := jobs.Describe(q)
+	return Entry{
+		IdempotencyKey: q.IdempotencyKey,
+		RetryCount:     q.RetryCount,
+		LastError:      q.LastError,
+		Event:          n.Event,
+		Channel:        string(n.Channel),
+		TenantID:       n.TenantID,
+		To:             n.To,
+		NextAttemptAt:  q.NextAttemptAt,
+	}
+}
@@ -0,0 +1,143 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/jobs"
+)
+
+// fakeBroker mimics RabbitMQ basic.get: a message that is handed out is
+// invisible until it is acked (gone) or requeued (back at the front).
+type fakeBroker struct {
+	queues    map[string][][]byte
+	published map[string][][]byte
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{queues: map[string][][]byte{}, published: map[string][][]byte{}}
+}
+
+func (b *fakeBroker) Depth(queue string) (int, error) { return len(b.queues[queue]), nil }
+
+func (b *fakeBroker) Get(queue string) (*Message, bool, error) {
+	q := b.queues[queue]
+	if len(q) == 0 {
+		return nil, false, nil
+	}
+	body := q[0]
+	b.queues[queue] = q[1:]
+	return NewMessage(body,
+		func() error { return nil },
+		func() error { b.queues[queue] = append([][]byte{body}, b.queues[queue]...); return nil },
+	), true, nil
+}
+
+func (b *fakeBroker) Publish(_ context.Context, queue string, body []byte) error {
+	b.published[queue] = append(b.published[queue], body)
+	return nil
+}
+
+func (b *fakeBroker) Purge(queue string) (int, error) {
+	n := len(b.queues[queue])
+	delete(b.queues, queue)
+	return n, nil
+}
+
+func (b *fakeBroker) deadLetter(t *testing.T, key, event, tenant string) {
+	t.Helper()
+	body, _ := json.Marshal(jobs.Notification{Event: event, Channel: "sms", TenantID: tenant, To: "+4915112345678"})
+	wrapped, err := jobs.Wrap(body, key, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, _ := jobs.Unwrap(wrapped)
+	q.LastError = "provider unavailable"
+	env, _ := json.Marshal(q)
+	b.queues[jobs.SMSDLQ] = append(b.queues[jobs.SMSDLQ], env)
+}
+
+func TestListFiltersAndLeavesQueueIntact(t *testing.T) {
+	b := newFakeBroker()
+	b.deadLetter(t, "k1", "shipment.created", "t1")
+	b.deadLetter(t, "k2", "pickup.reminder", "t1")
+	b.deadLetter(t, "k3", "shipment.created", "t2")
+	b.queues[jobs.SMSDLQ] = append(b.queues[jobs.SMSDLQ], []byte("not json"))
+	admin := NewAdmin(b)
+
+	entries, scanned, err := admin.List(context.Background(), jobs.SMSDLQ, Filter{Event: "shipment.created"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scanned != 4 || len(entries) != 2 {
+		t.Fatalf("scanned %d, got %d entries; want 4, 2", scanned, len(entries))
+	}
+	e := entries[0]
+	if e.IdempotencyKey != "k1" || e.RetryCount != 3 || e.LastError != "provider unavailable" || e.TenantID != "t1" {
+		t.Errorf("entry = %+v", e)
+	}
+
+	entries, _, _ = admin.List(context.Background(), jobs.SMSDLQ, Filter{}, 0)
+	if len(entries) != 4 || entries[3].Raw != "not json" {
+		t.Errorf("unfiltered list = %+v", entries)
+	}
+	if got := len(b.queues[jobs.SMSDLQ]); got != 4 {
+		t.Errorf("queue depth after list = %d, want 4", got)
+	}
+}
+
+func TestReplayResetsRetryCountAndRemovesOnlySelected(t *testing.T) {
+	b := newFakeBroker()
+	b.deadLetter(t, "k1", "shipment.created", "t1")
+	b.deadLetter(t, "k2", "shipment.created", "t2")
+	admin := NewAdmin(b)
+
+	n, err := admin.Replay(context.Background(), jobs.SMSDLQ, Filter{Keys: []string{"k2"}}, false)
+	if err != nil || n != 1 {
+		t.Fatalf("Replay = %d, %v", n, err)
+	}
+	out := b.published[jobs.SMSQueue]
+	if len(out) != 1 {
+		t.Fatalf("published %d to the work queue", len(out))
+	}
+	q, _ := jobs.Unwrap(out[0])
+	if q.IdempotencyKey != "k2" || q.RetryCount != 0 || q.LastError != "" || q.NextAttemptAt != nil {
+		t.Errorf("replayed envelope = %+v", q)
+	}
+	if left := b.queues[jobs.SMSDLQ]; len(left) != 1 || entryFor(left[0]).IdempotencyKey != "k1" {
+		t.Errorf("DLQ after replay = %d messages", len(left))
+	}
+}
+
+func TestReplayNeedsSelection(t *testing.T) {
+	b := newFakeBroker()
+	b.deadLetter(t, "k1", "shipment.created", "t1")
+	admin := NewAdmin(b)
+
+	if _, err := admin.Replay(context.Background(), jobs.SMSDLQ, Filter{TenantID: "t1"}, false); !errors.Is(err, ErrNothingSelected) {
+		t.Fatalf("err = %v, want ErrNothingSelected", err)
+	}
+	n, err := admin.Replay(context.Background(), jobs.SMSDLQ, Filter{TenantID: "t1"}, true)
+	if err != nil || n != 1 {
+		t.Fatalf("Replay all = %d, %v", n, err)
+	}
+}
+
+func TestPurgeRequiresConfirmation(t *testing.T) {
+	b := newFakeBroker()
+	b.deadLetter(t, "k1", "shipment.created", "t1")
+	admin := NewAdmin(b)
+
+	if _, err := admin.Purge(jobs.SMSDLQ, "yes"); !errors.Is(err, ErrConfirmationNeeded) {
+		t.Fatalf("err = %v, want ErrConfirmationNeeded", err)
+	}
+	if _, err := admin.Purge(jobs.SMSQueue, jobs.SMSQueue); !errors.Is(err, ErrUnknownQueue) {
+		t.Fatalf("purging a work queue: err = %v", err)
+	}
+	n, err := admin.Purge(jobs.SMSDLQ, jobs.SMSDLQ)
+	if err != nil || n != 1 {
+		t.Fatalf("Purge = %d, %v", n, err)
+	}
+}
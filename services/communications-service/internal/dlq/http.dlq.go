@@ -0,0 +1,135 @@
+// services/communications-service/internal/dlq/http.dlq.go
+
+package dlq
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler exposes the DLQ admin over HTTP. Every route needs
+// "Authorization: Bearer <admin token>".
+//
+//	GET    /v1/admin/dlq/{queue}?event=&tenant=&key=&limit=   list messages
+//	POST   /v1/admin/dlq/{queue}/replay                       {"keys": [...], "event": "", "tenant_id": "", "all": false}
+//	DELETE /v1/admin/dlq/{queue}?confirm={queue}              purge
+type Handler struct {
+	admin *Admin
+	token string
+}
+
+func NewHandler(admin *Admin, token string) *Handler {
+	return &Handler{admin: admin, token: token}
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/admin/dlq/{queue}", h.authorized(h.list))
+	mux.HandleFunc("POST /v1/admin/dlq/{queue}/replay", h.authorized(h.replay))
+	mux.HandleFunc("DELETE /v1/admin/dlq/{queue}", h.authorized(h.purge))
+}
+
+func (h *Handler) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if h.token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(h.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("admin token required"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+type listResponse struct {
+	Queue    string  `json:"queue"`
+	Scanned  int     `json:"scanned"`
+	Messages []Entry `json:"messages"`
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 100
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a non-negative number"))
+			return
+		}
+		limit = n
+	}
+	f := Filter{Event: q.Get("event"), TenantID: q.Get("tenant")}
+	if keys := q.Get("key"); keys != "" {
+		f.Keys = strings.Split(keys, ",")
+	}
+	queue := r.PathValue("queue")
+	entries, scanned, err := h.admin.List(r.Context(), queue, f, limit)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []Entry{}
+	}
+	writeJSON(w, http.StatusOK, listResponse{Queue: queue, Scanned: scanned, Messages: entries})
+}
+
+type replayRequest struct {
+	Keys     []string `json:"keys"`
+	Event    string   `json:"event"`
+	TenantID string   `json:"tenant_id"`
+	All      bool     `json:"all"`
+}
+
+func (h *Handler) replay(w http.ResponseWriter, r *http.Request) {
+	var req replayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	n, err := h.admin.Replay(r.Context(), r.PathValue("queue"), Filter{Event: req.Event, TenantID: req.TenantID, Keys: req.Keys}, req.All)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	log.Printf("DLQ admin: replayed %d message(s) from %s", n, r.PathValue("queue"))
+	writeJSON(w, http.StatusOK, map[string]int{"replayed": n})
+}
+
+func (h *Handler) purge(w http.ResponseWriter, r *http.Request) {
+	queue := r.PathValue("queue")
+	n, err := h.admin.Purge(queue, r.URL.Query().Get("confirm"))
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	log.Printf("DLQ admin: purged %d message(s) from %s", n, queue)
+	writeJSON(w, http.StatusOK, map[string]int{"purged": n})
+}
+
+func writeAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrUnknownQueue):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, ErrNothingSelected), errors.Is(err, ErrConfirmationNeeded):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		log.Printf("DLQ admin: %v", err)
+		writeError(w, http.StatusBadGateway, errors.New("broker error"))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("DLQ admin: failed to write response: %v", err)
+	}
+}
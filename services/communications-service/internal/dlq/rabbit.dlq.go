@@ -0,0 +1,42 @@
+// services/communications-service/internal/dlq/rabbit.dlq.go
+
+package dlq
+
+import (
+	"context"
+
+	pkgrabbit "github.com/Tanmoy095/LogiSynapse/shared/rabbitmq"
+)
+
+// RabbitBroker adapts the shared RabbitMQ client to Broker. Give it its own
+// client (connection): messages it holds unacked belong to its channel.
+type RabbitBroker struct {
+	client *pkgrabbit.RabbitmqClient
+}
+
+func NewRabbitBroker(client *pkgrabbit.RabbitmqClient) *RabbitBroker {
+	return &RabbitBroker{client: client}
+}
+
+func (b *RabbitBroker) Depth(queue string) (int, error) {
+	return b.client.QueueDepth(queue)
+}
+
+func (b *RabbitBroker) Get(queue string) (*Message, bool, error) {
+	d, ok, err := b.client.Get(queue)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return NewMessage(d.Body,
+		func() error { return d.Ack(false) },
+		func() error { return d.Nack(false, true) },
+	), true, nil
+}
+
+func (b *RabbitBroker) Publish(ctx context.Context, queue string, body []byte) error {
+	return b.client.Publish(ctx, queue, body)
+}
+
+func (b *RabbitBroker) Purge(queue string) (int, error) {
+	return b.client.Purge(queue)
+}
@@ -0,0 +1,95 @@
+// services/communications-service/internal/idempotency/guard.idempotency.go
+
+// Package idempotency remembers which notification jobs were already sent, so
+// a job that reaches a worker twice (Kafka redelivery, a replayed DLQ message)
+// goes out once.
+package idempotency
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Store records sends per (idempotency key, channel). A key is first claimed,
+// then completed once sent or released if the send failed.
+type Store interface {
+	// Claim atomically records key for channel as pending at claimedAt and
+	// reports whether it did. It does when no record exists, when the key was
+	// sent before sentBefore (past the TTL), or when an earlier claim made
+	// before pendingBefore was never completed (its worker died mid-send).
+	Claim(ctx context.Context, key, channel string, claimedAt, sentBefore, pendingBefore time.Time) (bool, error)
+	// Complete marks a claimed key as sent at sentAt.
+	Complete(ctx context.Context, key, channel string, sentAt time.Time) error
+	// Release deletes a pending claim, so the job can be sent again.
+	Release(ctx context.Context, key, channel string) error
+	// DeleteBefore removes records older than before and returns how many.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ClaimTimeout is how long a claim blocks other workers without being
+// completed. It is well above a send's timeout; past it the claiming worker is
+// taken to be gone and the job may be sent again.
+const ClaimTimeout = 5 * time.Minute
+
+// Guard applies a TTL on top of a Store: a key is remembered for ttl after
+// its send, then forgotten.
+type Guard struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func NewGuard(store Store, ttl time.Duration) *Guard {
+	return &Guard{store: store, ttl: ttl, now: time.Now}
+}
+
+// Claim reserves key on channel for the caller's send. False means the key
+// was sent within the TTL or another worker is sending it right now.
+// Jobs without a key are always claimed.
+func (g *Guard) Claim(ctx context.Context, key, channel string) (bool, error) {
+	if key == "" {
+		return true, nil
+	}
+	now := g.now().UTC()
+	return g.store.Claim(ctx, key, channel, now, now.Add(-g.ttl), now.Add(-ClaimTimeout))
+}
+
+// Complete marks a claimed key as sent on channel.
+func (g *Guard) Complete(ctx context.Context, key, channel string) error {
+	if key == "" {
+		return nil
+	}
+	return g.store.Complete(ctx, key, channel, g.now().UTC())
+}
+
+// Release gives up a claim after a failed send.
+func (g *Guard) Release(ctx context.Context, key, channel string) error {
+	if key == "" {
+		return nil
+	}
+	return g.store.Release(ctx, key, channel)
+}
+
+// Expire deletes records past the TTL.
+func (g *Guard) Expire(ctx context.Context) (int64, error) {
+	return g.store.DeleteBefore(ctx, g.now().UTC().Add(-g.ttl))
+}
+
+// Run expires old records every interval until ctx is done.
+func (g *Guard) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := g.Expire(ctx); err != nil {
+			log.Printf("Idempotency: failed to expire keys: %v", err)
+		} else if n > 0 {
+			log.Printf("Idempotency: expired %d key(s)", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
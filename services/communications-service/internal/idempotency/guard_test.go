@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestGuard(ttl time.Duration) (*Guard, *time.Time) {
+	g := NewGuard(NewMemoryStore(), ttl)
+	clock := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return clock }
+	return g, &clock
+}
+
+func TestGuardRemembersPerChannelUntilTTL(t *testing.T) {
+	ctx := context.Background()
+	g, clock := newTestGuard(time.Hour)
+	key := "shp-1|email|a@example.com"
+
+	if ok, err := g.Claim(ctx, key, "email"); err != nil || !ok {
+		t.Fatalf("first claim = %v, %v", ok, err)
+	}
+	if err := g.Complete(ctx, key, "email"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := g.Claim(ctx, key, "email"); ok {
+		t.Error("sent key claimed again")
+	}
+	if ok, _ := g.Claim(ctx, key, "sms"); !ok {
+		t.Error("key blocked on a channel it was never sent on")
+	}
+
+	*clock = clock.Add(61 * time.Minute)
+	if ok, _ := g.Claim(ctx, key, "email"); !ok {
+		t.Error("key still blocked after the TTL")
+	}
+	n, err := g.Expire(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Expire = %d, %v; want the sms claim", n, err)
+	}
+}
+
+func TestGuardClaimIsExclusiveUntilReleased(t *testing.T) {
+	ctx := context.Background()
+	g, clock := newTestGuard(24 * time.Hour)
+	key := "pickup-reminder-p-1|sms|+15550100"
+
+	// Two workers handed the same job: exactly one may send
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claims := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := g.Claim(ctx, key, "sms"); ok {
+				mu.Lock()
+				claims++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if claims != 1 {
+		t.Fatalf("%d workers claimed the key; want 1", claims)
+	}
+
+	// A failed send gives the key back for the retry
+	if err := g.Release(ctx, key, "sms"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := g.Claim(ctx, key, "sms"); !ok {
+		t.Fatal("released key not claimable")
+	}
+
+	// A claim whose worker died lapses after ClaimTimeout
+	*clock = clock.Add(ClaimTimeout - time.Second)
+	if ok, _ := g.Claim(ctx, key, "sms"); ok {
+		t.Error("claim taken over while its worker may still be sending")
+	}
+	*clock = clock.Add(2 * time.Second)
+	if ok, _ := g.Claim(ctx, key, "sms"); !ok {
+		t.Error("abandoned claim never lapses")
+	}
+
+	// Release never drops a completed send
+	if err := g.Complete(ctx, key, "sms"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Release(ctx, key, "sms"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := g.Claim(ctx, key, "sms"); ok {
+		t.Error("release forgot a sent key")
+	}
+}
+
+func TestGuardIgnoresEmptyKeys(t *testing.T) {
+	ctx := context.Background()
+	g := NewGuard(NewMemoryStore(), time.Hour)
+	for i := 0; i < 2; i++ {
+		if ok, err := g.Claim(ctx, "", "email"); err != nil || !ok {
+			t.Fatalf("claim %d of an empty key = %v, %v; jobs without a key always send", i, ok, err)
+		}
+		if err := g.Complete(ctx, "", "email"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// services/communications-service/internal/idempotency/memory.idempotency.go
+
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements Store in process, for local runs without a database
+// and for tests. It only dedupes within one process.
+type MemoryStore struct {
+	mu   sync.Mutex
+	sent map[memoryKey]memoryRecord
+}
+
+type memoryKey struct{ key, channel string }
+
+type memoryRecord struct {
+	at      time.Time // claimed, then sent
+	pending bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sent: make(map[memoryKey]memoryRecord)}
+}
+
+func (s *MemoryStore) Claim(ctx context.Context, key, channel string, claimedAt, sentBefore, pendingBefore time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := memoryKey{key, channel}
+	if r, ok := s.sent[k]; ok {
+		if r.pending && !r.at.Before(pendingBefore) || !r.pending && !r.at.Before(sentBefore) {
+			return false, nil
+		}
+	}
+	s.sent[k] = memoryRecord{at: claimedAt, pending: true}
+	return true, nil
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, key, channel string, sentAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[memoryKey{key, channel}] = memoryRecord{at: sentAt}
+	return nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key, channel string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := memoryKey{key, channel}
+	if r, ok := s.sent[k]; ok && r.pending {
+		delete(s.sent, k)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for k, r := range s.sent {
+		if r.at.Before(before) {
+			delete(s.sent, k)
+			n++
+		}
+	}
+	return n, nil
+}
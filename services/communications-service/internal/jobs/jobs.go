@@ -0,0 +1,110 @@
+// services/communications-service/internal/jobs/jobs.go
+
+// Package jobs defines what travels through the email/SMS queues. It is shared
+// by the service (bridge and workers) and the DLQ admin tooling.
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/recipients"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+const (
+	EmailQueue = "email_Jobs"
+	SMSQueue   = "sms_jobs"
+	EmailDLQ   = "email_jobs_dlq"
+	SMSDLQ     = "sms_jobs_dlq"
+)
+
+// WorkQueueFor maps a DLQ to the queue its jobs are replayed onto.
+var WorkQueueFor = map[string]string{
+	EmailDLQ: EmailQueue,
+	SMSDLQ:   SMSQueue,
+}
+
+// Queued is the envelope around every job on a queue.
+type Queued struct {
+	IdempotencyKey string          `json:"idempotency_key"`
+	RetryCount     int32           `json:"retry_count"`
+	Payload        json.RawMessage `json:"payload"`
+	// Set when a job is parked in a delay queue: when it should be back on the
+	// work queue, and why it failed last time.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// Notification is what the bridge puts on the email/SMS queues.
+// Type is the legacy job name; jobs queued before templates existed carry only
+// Type and Payload, so the worker maps Type back to Event/Channel.
+type Notification struct {
+	Type    string            `json:"type"`
+	Event   string            `json:"event,omitempty"`
+	Channel templates.Channel `json:"channel,omitempty"`
+	// Template is the template rendered for Event; empty means Event itself.
+	Template string `json:"template,omitempty"`
+	Priority string `json:"priority,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+	// To is the resolved address for Channel. Older jobs have none and fall
+	// back to the contact details on the payload.
+	To      string          `json:"to,omitempty"`
+	Role    recipients.Role `json:"role,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// legacyTypes maps pre-template job types to the template they render.
+var legacyTypes = map[string]struct {
+	event   string
+	channel templates.Channel
+}{
+	"welcome_email":         {"shipment.created", templates.ChannelEmail},
+	"sms_alert":             {"shipment.created", templates.ChannelSMS},
+	"pickup_reminder_email": {"pickup.reminder", templates.ChannelEmail},
+	"pickup_reminder_sms":   {"pickup.reminder", templates.ChannelSMS},
+}
+
+// Normalize fills Event and Channel on legacy jobs. It reports false for an
+// unknown legacy type.
+func (n *Notification) Normalize() bool {
+	if n.Event != "" {
+		return true
+	}
+	legacy, ok := legacyTypes[n.Type]
+	if !ok {
+		return false
+	}
+	n.Event, n.Channel = legacy.event, legacy.channel
+	return true
+}
+
+// Wrap puts a job body in a fresh envelope.
+func Wrap(body []byte, idempotencyKey string, retryCount int32) ([]byte, error) {
+	return json.Marshal(Queued{
+		IdempotencyKey: idempotencyKey,
+		RetryCount:     retryCount,
+		Payload:        json.RawMessage(body),
+	})
+}
+
+// Unwrap reads an envelope.
+func Unwrap(body []byte) (Queued, error) {
+	var job Queued
+	if err := json.Unmarshal(body, &job); err != nil {
+		return Queued{}, err
+	}
+	return job, nil
+}
+
+// Describe decodes the notification inside an envelope. Undecodable payloads
+// return a zero Notification rather than an error: DLQ tooling still has to
+// show (and be able to purge) them.
+func Describe(q Queued) Notification {
+	var n Notification
+	if json.Unmarshal(q.Payload, &n) == nil {
+		n.Normalize()
+	}
+	return n
+}
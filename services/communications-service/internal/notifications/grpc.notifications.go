@@ -0,0 +1,99 @@
+// services/communications-service/internal/notifications/grpc.notifications.go
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServer implements proto.NotificationServiceServer over the same store
+// as the HTTP API. The gateway uses it for the notifications GraphQL field;
+// like the HTTP API it only shows the tenant of the caller the gateway
+// forwards (identity.UnaryServerInterceptor).
+type GRPCServer struct {
+	proto.UnimplementedNotificationServiceServer
+	store Store
+}
+
+func NewGRPCServer(store Store) *GRPCServer {
+	return &GRPCServer{store: store}
+}
+
+func (s *GRPCServer) ListNotifications(ctx context.Context, req *proto.ListNotificationsRequest) (*proto.ListNotificationsResponse, error) {
+	tenantID, err := caller.Tenant(ctx, req.TenantId)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	list, err := s.store.List(ctx, Filter{
+		TenantID:   tenantID,
+		ShipmentID: req.ShipmentId,
+		Recipient:  req.Recipient,
+		Limit:      int(req.Limit),
+	})
+	if errors.Is(err, ErrFilterMissing) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("notifications: list failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	resp := &proto.ListNotificationsResponse{Notifications: make([]*proto.Notification, len(list))}
+	for i, n := range list {
+		resp.Notifications[i] = toProto(n)
+	}
+	return resp, nil
+}
+
+func (s *GRPCServer) GetNotification(ctx context.Context, req *proto.GetNotificationRequest) (*proto.Notification, error) {
+	tenantID, err := caller.Tenant(ctx, "")
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	n, err := s.store.Get(ctx, req.JobId)
+	if err == nil && n.TenantID != tenantID {
+		err = ErrNotFound
+	}
+	if errors.Is(err, ErrNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Printf("notifications: get failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return toProto(*n), nil
+}
+
+func toProto(n Notification) *proto.Notification {
+	return &proto.Notification{
+		JobId:             n.JobID,
+		TenantId:          n.TenantID,
+		ShipmentIds:       n.ShipmentIDs,
+		Event:             n.Event,
+		Template:          n.Template,
+		Channel:           string(n.Channel),
+		Recipient:         n.Recipient,
+		Status:            string(n.Status),
+		ProviderMessageId: n.ProviderMessageID,
+		LastError:         n.LastError,
+		Attempts:          int32(n.Attempts),
+		CreatedAt:         n.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         n.UpdatedAt.Format(time.RFC3339),
+		SentAt:            formatOptional(n.SentAt),
+		DeliveredAt:       formatOptional(n.DeliveredAt),
+	}
+}
+
+func formatOptional(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,218 @@
+// services/communications-service/internal/notifications/http.notifications.go
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/delivery"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Handler serves the delivery history and the provider callbacks. The
+// history (Register) goes behind caller.Authenticate and only shows the
+// caller's tenant; the callbacks (RegisterCallbacks) are public and verify
+// the provider's signature.
+//
+//	GET  /v1/notifications?tenant_id=&shipment_id=&recipient=&limit=
+//	GET  /v1/notifications/{job}
+//	POST /v1/callbacks/sendgrid   SendGrid event webhook (delivered, bounce, dropped)
+//	POST /v1/callbacks/twilio     Twilio status callback (delivered, undelivered, failed)
+type Handler struct {
+	store     Store
+	tracker   *Tracker
+	callbacks Callbacks
+}
+
+// Callbacks configures provider callback verification. A provider without
+// credentials gets no callback route.
+type Callbacks struct {
+	SendGrid        *delivery.SendGridVerifier
+	TwilioAuthToken string
+	// PublicURL is the base URL providers call; Twilio signs the full URL.
+	PublicURL string
+}
+
+func NewHandler(store Store, tracker *Tracker, callbacks Callbacks) *Handler {
+	return &Handler{store: store, tracker: tracker, callbacks: callbacks}
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/notifications", h.list)
+	mux.HandleFunc("GET /v1/notifications/{job}", h.get)
+}
+
+func (h *Handler) RegisterCallbacks(mux *http.ServeMux) {
+	if h.callbacks.SendGrid != nil {
+		mux.HandleFunc("POST /v1/callbacks/sendgrid", h.sendGridCallback)
+	}
+	if h.callbacks.TwilioAuthToken != "" {
+		mux.HandleFunc("POST /v1/callbacks/twilio", h.twilioCallback)
+	}
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	tenantID, err := caller.Tenant(r.Context(), q.Get("tenant_id"))
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	f := Filter{TenantID: tenantID, ShipmentID: q.Get("shipment_id"), Recipient: q.Get("recipient")}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a number"))
+			return
+		}
+		f.Limit = n
+	}
+	list, err := h.store.List(r.Context(), f)
+	if errors.Is(err, ErrFilterMissing) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if list == nil {
+		list = []Notification{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"notifications": list})
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := caller.Tenant(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	n, err := h.store.Get(r.Context(), r.PathValue("job"))
+	if err == nil && n.TenantID != tenantID {
+		err = ErrNotFound // no hint that another tenant's job exists
+	}
+	if errors.Is(err, ErrNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+// sendGridEvent is one entry of SendGrid's event webhook batch. Custom args
+// sent with the message (idempotency_key) come back as top-level fields.
+type sendGridEvent struct {
+	Event          string `json:"event"`
+	MessageID      string `json:"sg_message_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Reason         string `json:"reason"`
+}
+
+var sendGridStatuses = map[string]Status{
+	"delivered": StatusDelivered,
+	"bounce":    StatusBounced,
+	"dropped":   StatusBounced,
+}
+
+func (h *Handler) sendGridCallback(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.callbacks.SendGrid.Verify(r.Header.Get(delivery.SendGridSignatureHeader), r.Header.Get(delivery.SendGridTimestampHeader), body); err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+	var events []sendGridEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	for _, ev := range events {
+		status, ok := sendGridStatuses[ev.Event]
+		if !ok {
+			continue // processed, deferred, open, click...
+		}
+		// sg_message_id is the X-Message-Id we stored plus a ".filter..." suffix
+		messageID, _, _ := strings.Cut(ev.MessageID, ".")
+		err := h.tracker.ProviderUpdate(r.Context(), ev.IdempotencyKey, string(templates.ChannelEmail), messageID, status, ev.Reason)
+		if err != nil && !h.unknownJob(err, "sendgrid", messageID) {
+			writeError(w, http.StatusInternalServerError, err) // SendGrid retries the batch
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) twilioCallback(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	callbackURL := strings.TrimSuffix(h.callbacks.PublicURL, "/") + r.URL.RequestURI()
+	if err := delivery.VerifyTwilio(h.callbacks.TwilioAuthToken, callbackURL, r.PostForm, r.Header.Get(delivery.TwilioSignatureHeader)); err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+	var status Status
+	reason := ""
+	switch r.PostForm.Get("MessageStatus") {
+	case "delivered":
+		status = StatusDelivered
+	case "undelivered", "failed":
+		status = StatusBounced
+		reason = "twilio: " + r.PostForm.Get("MessageStatus")
+		if code := r.PostForm.Get("ErrorCode"); code != "" {
+			reason += " (error " + code + ")"
+		}
+	default:
+		w.WriteHeader(http.StatusNoContent) // queued, sending, sent
+		return
+	}
+	sid := r.PostForm.Get("MessageSid")
+	err := h.tracker.ProviderUpdate(r.Context(), "", string(templates.ChannelSMS), sid, status, reason)
+	if err != nil && !h.unknownJob(err, "twilio", sid) {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// unknownJob reports (and logs) callbacks for messages we have no record of,
+// e.g. sent before tracking existed. They are acknowledged, not retried.
+func (h *Handler) unknownJob(err error, provider, messageID string) bool {
+	if !errors.Is(err, ErrNotFound) {
+		return false
+	}
+	log.Printf("Notifications: %s callback for unknown message %s", provider, messageID)
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if status >= 500 {
+		log.Printf("notifications: request failed: %v", err)
+		writeJSON(w, status, map[string]string{"error": "internal error"})
+		return
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("notifications: failed to write response: %v", err)
+	}
+}
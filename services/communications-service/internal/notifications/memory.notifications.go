@@ -0,0 +1,104 @@
+// services/communications-service/internal/notifications/memory.notifications.go
+
+package notifications
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore implements Store in process, for local runs without a database
+// and for tests.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Notification
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]*Notification)}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, n Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[n.JobID]; !ok {
+		s.jobs[n.JobID] = &n
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, jobID string) (*Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.jobs[jobID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *n
+	return &cp, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, f Filter) ([]Notification, error) {
+	f, err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Notification
+	for _, n := range s.jobs {
+		if f.TenantID != "" && n.TenantID != f.TenantID {
+			continue
+		}
+		if f.Recipient != "" && n.Recipient != f.Recipient {
+			continue
+		}
+		if f.ShipmentID != "" && !contains(n.ShipmentIDs, f.ShipmentID) {
+			continue
+		}
+		out = append(out, *n)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].JobID < out[j].JobID
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, jobID string, u Update) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.jobs[jobID]
+	if !ok {
+		return ErrNotFound
+	}
+	n.Apply(u)
+	return nil
+}
+
+func (s *MemoryStore) UpdateByProviderID(ctx context.Context, channel, providerMessageID string, u Update) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.jobs {
+		if string(n.Channel) == channel && n.ProviderMessageID == providerMessageID {
+			n.Apply(u)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
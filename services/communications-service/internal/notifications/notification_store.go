@@ -0,0 +1,20 @@
+// services/communications-service/internal/notifications/notification_store.go
+
+package notifications
+
+import "context"
+
+// Store holds the delivery history.
+type Store interface {
+	// Create records a queued job. An existing record (Kafka redelivered the
+	// event) is left alone.
+	Create(ctx context.Context, n Notification) error
+	Get(ctx context.Context, jobID string) (*Notification, error)
+	// List returns the newest records first.
+	List(ctx context.Context, f Filter) ([]Notification, error)
+	// Update applies u to the job; ErrNotFound if there is no such job.
+	Update(ctx context.Context, jobID string, u Update) error
+	// UpdateByProviderID applies u to the job whose provider message ID is
+	// providerMessageID on channel.
+	UpdateByProviderID(ctx context.Context, channel, providerMessageID string, u Update) error
+}
@@ -0,0 +1,125 @@
+// services/communications-service/internal/notifications/notifications.domain.go
+
+// Package notifications is the delivery history: one record per notification
+// job, updated by the bridge, the workers and provider callbacks. It answers
+// "did the customer get the delay email?".
+package notifications
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+var (
+	ErrNotFound      = errors.New("notification not found")
+	ErrFilterMissing = errors.New("filter by tenant_id, shipment_id or recipient")
+)
+
+type Status string
+
+const (
+	StatusQueued Status = "queued"
+	// StatusFailed: the last attempt failed and the job is waiting for a retry.
+	StatusFailed       Status = "failed"
+	StatusDeadLettered Status = "dead_lettered"
+	// StatusSkipped: nothing was sent (recipient opted out, no address).
+	StatusSkipped Status = "skipped"
+	StatusSent    Status = "sent"
+	// Reported by the provider after the send.
+	StatusDelivered Status = "delivered"
+	StatusBounced   Status = "bounced"
+)
+
+// FromProvider reports whether the status comes from a provider callback.
+// Those are the final word: a late worker update never overwrites them.
+func (s Status) FromProvider() bool {
+	return s == StatusDelivered || s == StatusBounced
+}
+
+// Notification is one job's history. JobID is the job's idempotency key.
+type Notification struct {
+	JobID       string            `json:"job_id"`
+	TenantID    string            `json:"tenant_id,omitempty"`
+	ShipmentIDs []string          `json:"shipment_ids"`
+	Event       string            `json:"event"`
+	Template    string            `json:"template"`
+	Channel     templates.Channel `json:"channel"`
+	Recipient   string            `json:"recipient"`
+	Status      Status            `json:"status"`
+	// ProviderMessageID is the provider's ID for the sent message (SendGrid
+	// X-Message-Id, Twilio SID, our SMTP Message-ID).
+	ProviderMessageID string     `json:"provider_message_id,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	Attempts          int        `json:"attempts"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	SentAt            *time.Time `json:"sent_at,omitempty"`
+	DeliveredAt       *time.Time `json:"delivered_at,omitempty"`
+}
+
+// Update is a status change.
+type Update struct {
+	Status            Status
+	ProviderMessageID string // kept when empty
+	Error             string
+	// Attempt counts this update as a send attempt.
+	Attempt bool
+	At      time.Time
+}
+
+// Apply folds u into n. It reports false when u must be ignored because a
+// provider already reported the final outcome.
+func (n *Notification) Apply(u Update) bool {
+	if n.Status.FromProvider() && !u.Status.FromProvider() {
+		return false
+	}
+	n.Status = u.Status
+	n.UpdatedAt = u.At
+	if u.ProviderMessageID != "" {
+		n.ProviderMessageID = u.ProviderMessageID
+	}
+	if u.Error != "" || u.Status == StatusSent || u.Status == StatusDelivered {
+		n.LastError = u.Error
+	}
+	if u.Attempt {
+		n.Attempts++
+	}
+	switch u.Status {
+	case StatusSent:
+		at := u.At
+		n.SentAt = &at
+	case StatusDelivered:
+		at := u.At
+		n.DeliveredAt = &at
+	}
+	return true
+}
+
+// Filter selects history. At least one of TenantID, ShipmentID or Recipient is required.
+type Filter struct {
+	TenantID   string
+	ShipmentID string
+	Recipient  string
+	Limit      int
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// Normalize checks the filter and applies the default and maximum limit.
+func (f Filter) Normalize() (Filter, error) {
+	if f.TenantID == "" && f.ShipmentID == "" && f.Recipient == "" {
+		return f, ErrFilterMissing
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit > maxLimit {
+		f.Limit = maxLimit
+	}
+	return f, nil
+}
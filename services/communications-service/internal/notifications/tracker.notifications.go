@@ -0,0 +1,70 @@
+// services/communications-service/internal/notifications/tracker.notifications.go
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// Tracker records job progress. Tracking is best effort: a failed write is
+// logged and never fails the send it describes.
+type Tracker struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewTracker(store Store) *Tracker {
+	return &Tracker{store: store, now: time.Now}
+}
+
+// Queued records a job the bridge just published.
+func (t *Tracker) Queued(ctx context.Context, n Notification) {
+	now := t.now().UTC()
+	n.Status = StatusQueued
+	n.CreatedAt, n.UpdatedAt = now, now
+	if n.ShipmentIDs == nil {
+		n.ShipmentIDs = []string{}
+	}
+	if err := t.store.Create(ctx, n); err != nil {
+		log.Printf("Notifications: failed to record job %s: %v", n.JobID, err)
+	}
+}
+
+func (t *Tracker) Sent(ctx context.Context, jobID, providerMessageID string) {
+	t.update(ctx, jobID, Update{Status: StatusSent, ProviderMessageID: providerMessageID, Attempt: true})
+}
+
+// Failed records a failed attempt; dead marks the job as given up on.
+func (t *Tracker) Failed(ctx context.Context, jobID string, err error, dead bool) {
+	status := StatusFailed
+	if dead {
+		status = StatusDeadLettered
+	}
+	t.update(ctx, jobID, Update{Status: status, Error: err.Error(), Attempt: true})
+}
+
+func (t *Tracker) Skipped(ctx context.Context, jobID, reason string) {
+	t.update(ctx, jobID, Update{Status: StatusSkipped, Error: reason})
+}
+
+func (t *Tracker) update(ctx context.Context, jobID string, u Update) {
+	u.At = t.now().UTC()
+	err := t.store.Update(ctx, jobID, u)
+	// Jobs queued before tracking existed have no record
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		log.Printf("Notifications: failed to update job %s to %s: %v", jobID, u.Status, err)
+	}
+}
+
+// ProviderUpdate applies a provider callback. Unlike worker updates the error
+// is returned so the callback handler can ask the provider to retry.
+func (t *Tracker) ProviderUpdate(ctx context.Context, jobID, channel, providerMessageID string, status Status, reason string) error {
+	u := Update{Status: status, Error: reason, At: t.now().UTC()}
+	if jobID != "" {
+		return t.store.Update(ctx, jobID, u)
+	}
+	return t.store.UpdateByProviderID(ctx, channel, providerMessageID, u)
+}
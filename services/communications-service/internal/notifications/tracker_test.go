@@ -0,0 +1,196 @@
+package notifications
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/delivery"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestTracker() (*MemoryStore, *Tracker) {
+	store := NewMemoryStore()
+	tr := NewTracker(store)
+	clock := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	tr.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+	return store, tr
+}
+
+func queue(tr *Tracker, jobID string, channel templates.Channel, shipments ...string) {
+	tr.Queued(context.Background(), Notification{JobID: jobID, TenantID: "t1", ShipmentIDs: shipments,
+		Event: "shipment.created", Template: "shipment.created", Channel: channel, Recipient: "jana@example.com"})
+}
+
+func TestTrackerLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store, tr := newTestTracker()
+	queue(tr, "job-1", templates.ChannelEmail, "shp-1")
+	queue(tr, "job-1", templates.ChannelEmail, "shp-1") // Kafka redelivery: no-op
+
+	tr.Failed(ctx, "job-1", errors.New("sendgrid: status 503"), false)
+	tr.Sent(ctx, "job-1", "sg-1")
+	n, _ := store.Get(ctx, "job-1")
+	if n.Status != StatusSent || n.Attempts != 2 || n.LastError != "" || n.SentAt == nil || n.ProviderMessageID != "sg-1" {
+		t.Fatalf("after send: %+v", n)
+	}
+
+	if err := tr.ProviderUpdate(ctx, "", "email", "sg-1", StatusBounced, "mailbox full"); err != nil {
+		t.Fatal(err)
+	}
+	// A late worker update must not hide the bounce
+	tr.Sent(ctx, "job-1", "")
+	n, _ = store.Get(ctx, "job-1")
+	if n.Status != StatusBounced || n.LastError != "mailbox full" {
+		t.Errorf("after bounce: %+v", n)
+	}
+
+	if err := tr.ProviderUpdate(ctx, "", "sms", "sg-1", StatusDelivered, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("provider ID matched across channels: %v", err)
+	}
+	// Jobs queued before tracking existed are ignored quietly
+	tr.Sent(ctx, "unknown-job", "x")
+}
+
+func TestListByShipment(t *testing.T) {
+	store, tr := newTestTracker()
+	queue(tr, "job-1", templates.ChannelEmail, "shp-1")
+	queue(tr, "job-2", templates.ChannelSMS, "shp-1", "shp-2")
+	queue(tr, "job-3", templates.ChannelSMS, "shp-3")
+
+	got, err := store.List(context.Background(), Filter{ShipmentID: "shp-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].JobID != "job-2" || got[1].JobID != "job-1" {
+		t.Errorf("want job-2, job-1 (newest first), got %+v", got)
+	}
+	if _, err := store.List(context.Background(), Filter{}); !errors.Is(err, ErrFilterMissing) {
+		t.Errorf("unfiltered list: %v", err)
+	}
+}
+
+func TestTwilioCallback(t *testing.T) {
+	ctx := context.Background()
+	store, tr := newTestTracker()
+	queue(tr, "job-1", templates.ChannelSMS, "shp-1")
+	tr.Sent(ctx, "job-1", "SM1")
+
+	mux := http.NewServeMux()
+	NewHandler(store, tr, Callbacks{TwilioAuthToken: "token", PublicURL: "https://comms.test"}).RegisterCallbacks(mux)
+
+	post := func(form url.Values, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/callbacks/twilio", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(delivery.TwilioSignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	form := url.Values{"MessageSid": {"SM1"}, "MessageStatus": {"undelivered"}, "ErrorCode": {"30003"}}
+	if code := post(form, "forged"); code != http.StatusUnauthorized {
+		t.Fatalf("forged signature: status %d", code)
+	}
+	if code := post(form, delivery.TwilioSignature("token", "https://comms.test/v1/callbacks/twilio", form)); code != http.StatusNoContent {
+		t.Fatalf("status %d", code)
+	}
+	n, _ := store.Get(ctx, "job-1")
+	if n.Status != StatusBounced || !strings.Contains(n.LastError, "30003") {
+		t.Errorf("after callback: %+v", n)
+	}
+}
+
+func TestSendGridCallback(t *testing.T) {
+	ctx := context.Background()
+	store, tr := newTestTracker()
+	queue(tr, "job-1", templates.ChannelEmail, "shp-1")
+	tr.Sent(ctx, "job-1", "sg-1")
+
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	verifier, err := delivery.NewSendGridVerifier(base64.StdEncoding.EncodeToString(der))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	NewHandler(store, tr, Callbacks{SendGrid: verifier}).RegisterCallbacks(mux)
+
+	body := `[{"event":"processed","sg_message_id":"sg-1.filter01"},
+		{"event":"delivered","sg_message_id":"sg-1.filter01"},
+		{"event":"bounce","sg_message_id":"sg-9.filter01","reason":"unknown message"}]`
+	digest := sha256.Sum256([]byte("1736143200" + body))
+	sig, _ := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	req := httptest.NewRequest(http.MethodPost, "/v1/callbacks/sendgrid", strings.NewReader(body))
+	req.Header.Set(delivery.SendGridSignatureHeader, base64.StdEncoding.EncodeToString(sig))
+	req.Header.Set(delivery.SendGridTimestampHeader, "1736143200")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	n, _ := store.Get(ctx, "job-1")
+	if n.Status != StatusDelivered || n.DeliveredAt == nil {
+		t.Errorf("after callback: %+v", n)
+	}
+}
+
+func TestHistoryIsScopedToCaller(t *testing.T) {
+	store, tr := newTestTracker()
+	queue(tr, "job-1", templates.ChannelEmail, "shp-1") // tenant t1
+	as := func(tenantID string) context.Context {
+		return identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenantID, Role: "member"})
+	}
+
+	mux := http.NewServeMux()
+	NewHandler(store, tr, Callbacks{}).Register(mux)
+	get := func(ctx context.Context, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+		return rec
+	}
+	if rec := get(as("t1"), "/v1/notifications?shipment_id=shp-1"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "job-1") {
+		t.Errorf("own history: %d %s", rec.Code, rec.Body)
+	}
+	if rec := get(as("t2"), "/v1/notifications?shipment_id=shp-1"); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "job-1") {
+		t.Errorf("t2 sees t1's history: %d %s", rec.Code, rec.Body)
+	}
+	if rec := get(as("t2"), "/v1/notifications?tenant_id=t1"); rec.Code != http.StatusForbidden {
+		t.Errorf("t2 naming t1: %d", rec.Code)
+	}
+	if rec := get(as("t2"), "/v1/notifications/job-1"); rec.Code != http.StatusNotFound {
+		t.Errorf("t2 reading t1's job: %d", rec.Code)
+	}
+	if rec := get(context.Background(), "/v1/notifications/job-1"); rec.Code != http.StatusForbidden {
+		t.Errorf("no caller: %d", rec.Code)
+	}
+
+	srv := NewGRPCServer(store)
+	resp, err := srv.ListNotifications(as("t2"), &proto.ListNotificationsRequest{ShipmentId: "shp-1"})
+	if err != nil || len(resp.Notifications) != 0 {
+		t.Errorf("gRPC t2 list: %v %v", resp, err)
+	}
+	if _, err := srv.ListNotifications(as("t2"), &proto.ListNotificationsRequest{TenantId: "t1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("gRPC t2 naming t1: %v", err)
+	}
+	if _, err := srv.GetNotification(as("t2"), &proto.GetNotificationRequest{JobId: "job-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("gRPC t2 get: %v", err)
+	}
+	if n, err := srv.GetNotification(as("t1"), &proto.GetNotificationRequest{JobId: "job-1"}); err != nil || n.JobId != "job-1" {
+		t.Errorf("gRPC t1 get: %v %v", n, err)
+	}
+}
@@ -0,0 +1,153 @@
+// services/communications-service/internal/ratelimit/http.ratelimit.go
+
+package ratelimit
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Handler changes rate limits at runtime. Every route needs
+// "Authorization: Bearer <admin token>". Rule IDs are as in COMMS_RATE_LIMITS.
+//
+//	GET    /v1/admin/rate-limits          rules in force
+//	PUT    /v1/admin/rate-limits/{rule}   {"limit": 30, "per": "1m", "burst": 60}
+//	DELETE /v1/admin/rate-limits/{rule}   back to the COMMS_RATE_LIMITS rule, or no limit
+//
+// Changes apply on this replica at once and on the others at their next reload.
+type Handler struct {
+	limiter *Limiter
+	store   RuleStore
+	token   string
+}
+
+func NewHandler(limiter *Limiter, store RuleStore, token string) *Handler {
+	return &Handler{limiter: limiter, store: store, token: token}
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/admin/rate-limits", h.authorized(h.list))
+	mux.HandleFunc("PUT /v1/admin/rate-limits/{rule}", h.authorized(h.save))
+	mux.HandleFunc("DELETE /v1/admin/rate-limits/{rule}", h.authorized(h.delete))
+}
+
+func (h *Handler) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if h.token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(h.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("admin token required"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+type ruleResponse struct {
+	ID      string            `json:"id"`
+	Scope   Scope             `json:"scope"`
+	Key     string            `json:"key"`
+	Channel templates.Channel `json:"channel,omitempty"`
+	Limit   int               `json:"limit"`
+	Per     string            `json:"per"`
+	Burst   int               `json:"burst"`
+}
+
+func toResponse(r Rule) ruleResponse {
+	return ruleResponse{ID: r.ID(), Scope: r.Scope, Key: r.Key, Channel: r.Channel, Limit: r.Count, Per: r.Per.String(), Burst: r.Burst}
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	rules := h.limiter.Rules()
+	resp := make([]ruleResponse, len(rules))
+	for i, rule := range rules {
+		resp[i] = toResponse(rule)
+	}
+	writeJSON(w, http.StatusOK, map[string][]ruleResponse{"rules": resp})
+}
+
+type saveRequest struct {
+	Limit int    `json:"limit"`
+	Per   string `json:"per"`
+	Burst int    `json:"burst"` // defaults to limit
+}
+
+func (h *Handler) save(w http.ResponseWriter, r *http.Request) {
+	rule, err := ParseRuleID(r.PathValue("rule"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var req saveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	per, err := time.ParseDuration(req.Per)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("per must be a duration such as 1s or 1m"))
+		return
+	}
+	rule.Limit = Limit{Count: req.Limit, Per: per, Burst: req.Burst}
+	if rule.Burst == 0 {
+		rule.Burst = rule.Count
+	}
+	if err := rule.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.store.SaveRule(r.Context(), rule); err != nil {
+		log.Printf("Rate limits: save %s: %v", rule.ID(), err)
+		writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+		return
+	}
+	h.reload(r)
+	log.Printf("Rate limits: %s set to %s", rule.ID(), rule.Limit)
+	writeJSON(w, http.StatusOK, toResponse(rule))
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	rule, err := ParseRuleID(r.PathValue("rule"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.store.DeleteRule(r.Context(), rule.ID()); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		log.Printf("Rate limits: delete %s: %v", rule.ID(), err)
+		writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+		return
+	}
+	h.reload(r)
+	log.Printf("Rate limits: %s override removed", rule.ID())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reload applies a change here at once. If it fails the change is stored and
+// the periodic reload picks it up.
+func (h *Handler) reload(r *http.Request) {
+	if err := h.limiter.Reload(r.Context()); err != nil {
+		log.Printf("Rate limits: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Rate limits: failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// services/communications-service/internal/ratelimit/limiter.ratelimit.go
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Bucket is a token bucket's state. A bucket nobody has used yet is full.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Take refills the bucket for the time since its last update and takes one
+// token. When there is no whole token it takes nothing and returns how long
+// until there will be one.
+func (b Bucket) Take(l Limit, now time.Time) (Bucket, time.Duration) {
+	if now.Before(b.Updated) {
+		now = b.Updated // another replica's clock, or a bucket updated mid-call
+	}
+	tokens := b.Tokens + now.Sub(b.Updated).Seconds()*l.Rate()
+	if burst := float64(l.Burst); tokens > burst {
+		tokens = burst
+	}
+	if tokens >= 1 {
+		return Bucket{Tokens: tokens - 1, Updated: now}, 0
+	}
+	wait := time.Duration((1 - tokens) / l.Rate() * float64(time.Second))
+	return Bucket{Tokens: tokens, Updated: now}, wait
+}
+
+// Backend holds the buckets. Replicas that share a Backend share budgets.
+type Backend interface {
+	// Take runs Bucket.Take atomically on the named bucket.
+	Take(ctx context.Context, bucket string, l Limit) (time.Duration, error)
+}
+
+// Limiter picks the rules for a send and takes from their buckets.
+// The rules are COMMS_RATE_LIMITS overlaid with the stored ones; Reload (and
+// Run) pick up changes other replicas made through the admin API.
+type Limiter struct {
+	store    RuleStore
+	backend  Backend
+	defaults []Rule
+
+	mu     sync.RWMutex
+	active map[string]Rule // by Rule.ID()
+}
+
+func NewLimiter(store RuleStore, backend Backend, defaults []Rule) *Limiter {
+	l := &Limiter{store: store, backend: backend, defaults: defaults}
+	l.active = l.merge(nil)
+	return l
+}
+
+func (l *Limiter) merge(stored []Rule) map[string]Rule {
+	active := make(map[string]Rule, len(l.defaults)+len(stored))
+	for _, r := range l.defaults {
+		active[r.ID()] = r
+	}
+	for _, r := range stored {
+		active[r.ID()] = r
+	}
+	return active
+}
+
+// Reload re-reads the stored rules. On error the current rules stay.
+func (l *Limiter) Reload(ctx context.Context) error {
+	stored, err := l.store.ListRules(ctx)
+	if err != nil {
+		return fmt.Errorf("ratelimit: load rules: %w", err)
+	}
+	active := l.merge(stored)
+	l.mu.Lock()
+	l.active = active
+	l.mu.Unlock()
+	return nil
+}
+
+// Run reloads the rules every interval until ctx is done.
+func (l *Limiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Reload(ctx); err != nil {
+				log.Printf("Rate limits: %v", err)
+			}
+		}
+	}
+}
+
+// Rules returns the rules in force, sorted by ID.
+func (l *Limiter) Rules() []Rule {
+	l.mu.RLock()
+	rules := make([]Rule, 0, len(l.active))
+	for _, r := range l.active {
+		rules = append(rules, r)
+	}
+	l.mu.RUnlock()
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
+
+// Reserve takes a token for one send by tenantID on channel through provider.
+// A zero wait means send now; otherwise nothing was reserved on the bucket
+// that is out and the caller should try again after wait. The provider's
+// bucket is checked first, so a busy provider costs tenants nothing; a tenant
+// over its own limit may still have used a provider token, which only makes
+// the provider limit slightly conservative.
+func (l *Limiter) Reserve(ctx context.Context, tenantID string, channel templates.Channel, provider string) (time.Duration, error) {
+	l.mu.RLock()
+	providerRule, hasProvider := l.active[Rule{Scope: ScopeProvider, Key: provider}.ID()]
+	tenantRule, hasTenant := l.active[Rule{Scope: ScopeTenant, Key: tenantID, Channel: channel}.ID()]
+	if !hasTenant {
+		tenantRule, hasTenant = l.active[Rule{Scope: ScopeTenant, Key: AnyTenant, Channel: channel}.ID()]
+	}
+	l.mu.RUnlock()
+
+	if hasProvider {
+		wait, err := l.backend.Take(ctx, providerRule.ID(), providerRule.Limit)
+		if err != nil || wait > 0 {
+			return wait, err
+		}
+	}
+	if hasTenant {
+		// The default rule gives every tenant its own bucket, not one to share.
+		bucket := Rule{Scope: ScopeTenant, Key: tenantID, Channel: channel}.ID()
+		return l.backend.Take(ctx, bucket, tenantRule.Limit)
+	}
+	return 0, nil
+}
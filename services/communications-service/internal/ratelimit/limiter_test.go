@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+func TestBucketTake(t *testing.T) {
+	l := Limit{Count: 2, Per: time.Second, Burst: 2}
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	b := Bucket{Tokens: 2, Updated: start}
+
+	var wait time.Duration
+	for i := 0; i < 2; i++ {
+		if b, wait = b.Take(l, start); wait != 0 {
+			t.Fatalf("take %d from a full bucket waited %s", i, wait)
+		}
+	}
+	if b, wait = b.Take(l, start); wait != 500*time.Millisecond {
+		t.Fatalf("empty bucket wait = %s; want 500ms", wait)
+	}
+	if _, wait = b.Take(l, start.Add(500*time.Millisecond)); wait != 0 {
+		t.Fatalf("bucket should have refilled one token, waited %s", wait)
+	}
+	// A long idle period refills to the burst, not beyond
+	b, _ = b.Take(l, start.Add(time.Hour))
+	if b.Tokens != 1 {
+		t.Errorf("tokens after idle take = %v; want burst-1 = 1", b.Tokens)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("tenant:*:sms=30/1m,60; tenant:acme:corp:sms=300/1m; provider:twilio=10/1s,20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules", len(rules))
+	}
+	if r := rules[0]; r.ID() != "tenant:*:sms" || r.Count != 30 || r.Per != time.Minute || r.Burst != 60 {
+		t.Errorf("rule 0 = %+v", r)
+	}
+	if r := rules[1]; r.Key != "acme:corp" || r.Channel != templates.ChannelSMS || r.Burst != 300 {
+		t.Errorf("rule 1 = %+v (burst should default to the count)", r)
+	}
+	if r := rules[2]; r.Scope != ScopeProvider || r.Key != "twilio" || r.Burst != 20 {
+		t.Errorf("rule 2 = %+v", r)
+	}
+
+	for _, bad := range []string{
+		"tenant:*:fax=1/1s",
+		"provider:pigeon=1/1s",
+		"tenant:*:sms=0/1s",
+		"tenant:*:sms=5",
+		"shipment:*:sms=5/1s",
+	} {
+		if _, err := ParseRules(bad); err == nil {
+			t.Errorf("%q should be rejected", bad)
+		}
+	}
+}
+
+func TestLimiterReserve(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	defaults, err := ParseRules("tenant:*:sms=1/1s,2; provider:twilio=3/1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter := NewLimiter(store, store, defaults)
+
+	// Each tenant gets its own bucket from the default rule
+	for _, tenant := range []string{"acme", "acme", "globex"} {
+		if wait, _ := limiter.Reserve(ctx, tenant, templates.ChannelSMS, "twilio"); wait != 0 {
+			t.Fatalf("%s should be within its burst, waited %s", tenant, wait)
+		}
+	}
+	if wait, _ := limiter.Reserve(ctx, "acme", templates.ChannelSMS, "twilio"); wait == 0 {
+		t.Error("acme is over its tenant limit and should wait")
+	}
+	// Twilio's 3 tokens are gone too, so even a fresh tenant waits
+	if wait, _ := limiter.Reserve(ctx, "initech", templates.ChannelSMS, "twilio"); wait == 0 {
+		t.Error("twilio is over its limit and should make every tenant wait")
+	}
+	// Email has no rules
+	if wait, _ := limiter.Reserve(ctx, "acme", templates.ChannelEmail, "sendgrid"); wait != 0 {
+		t.Errorf("email is unlimited, waited %s", wait)
+	}
+
+	// A stored rule overrides the default once reloaded, and deleting it restores the default
+	now = now.Add(time.Minute)
+	if err := store.SaveRule(ctx, Rule{Scope: ScopeProvider, Key: "twilio", Limit: Limit{Count: 1, Per: time.Hour, Burst: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	limiter.Reserve(ctx, "globex", templates.ChannelSMS, "twilio")
+	if wait, _ := limiter.Reserve(ctx, "initech", templates.ChannelSMS, "twilio"); wait < time.Minute {
+		t.Errorf("wait under the 1/h override = %s", wait)
+	}
+	if err := store.DeleteRule(ctx, "provider:twilio"); err != nil {
+		t.Fatal(err)
+	}
+	limiter.Reload(ctx)
+	for _, r := range limiter.Rules() {
+		if r.ID() == "provider:twilio" && r.Count != 3 {
+			t.Errorf("twilio rule after delete = %s; want the default", r.Limit)
+		}
+	}
+}
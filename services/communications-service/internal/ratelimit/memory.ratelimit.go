@@ -0,0 +1,62 @@
+// services/communications-service/internal/ratelimit/memory.ratelimit.go
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements RuleStore and Backend in process, for local runs
+// without a database and for tests. Budgets are per replica.
+type MemoryStore struct {
+	mu      sync.Mutex
+	rules   map[string]Rule
+	buckets map[string]Bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rules: make(map[string]Rule), buckets: make(map[string]Bucket), now: time.Now}
+}
+
+func (s *MemoryStore) ListRules(ctx context.Context) ([]Rule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules := make([]Rule, 0, len(s.rules))
+	for _, r := range s.rules {
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func (s *MemoryStore) SaveRule(ctx context.Context, r Rule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules[r.ID()] = r
+	return nil
+}
+
+func (s *MemoryStore) DeleteRule(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.rules[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.rules, id)
+	return nil
+}
+
+func (s *MemoryStore) Take(ctx context.Context, bucket string, l Limit) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	b, ok := s.buckets[bucket]
+	if !ok {
+		b = Bucket{Tokens: float64(l.Burst), Updated: now}
+	}
+	b, wait := b.Take(l, now)
+	s.buckets[bucket] = b
+	return wait, nil
+}
@@ -0,0 +1,196 @@
+// services/communications-service/internal/ratelimit/rules.ratelimit.go
+
+// Package ratelimit paces notification sends with token buckets, per tenant
+// and per provider, so a bulk import cannot fire thousands of SMS at Twilio in
+// a few seconds. Over-limit jobs are delayed by the workers, never dropped.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+var (
+	ErrInvalidRule = errors.New("invalid rate limit rule")
+	ErrNotFound    = errors.New("rate limit rule not found")
+)
+
+// Scope is what a rule's bucket is shared by.
+type Scope string
+
+const (
+	ScopeTenant   Scope = "tenant"   // one bucket per tenant and channel
+	ScopeProvider Scope = "provider" // one bucket per delivery provider, across tenants
+)
+
+// AnyTenant is the tenant key of the default rule for tenants without their own.
+const AnyTenant = "*"
+
+// Providers are the delivery providers a provider rule can name.
+var Providers = map[string]templates.Channel{
+	"smtp":     templates.ChannelEmail,
+	"sendgrid": templates.ChannelEmail,
+	"twilio":   templates.ChannelSMS,
+	"file":     "", // the local sink, for trying limits out without sending anything
+}
+
+// Limit lets Count sends through every Per, with bursts of up to Burst.
+type Limit struct {
+	Count int
+	Per   time.Duration
+	Burst int
+}
+
+// Rate is the refill rate in tokens per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Count) / l.Per.Seconds()
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s,%d", l.Count, l.Per, l.Burst)
+}
+
+func (l Limit) validate() error {
+	if l.Count <= 0 || l.Per <= 0 {
+		return fmt.Errorf("%w: limit and period must be positive", ErrInvalidRule)
+	}
+	if l.Burst <= 0 {
+		return fmt.Errorf("%w: burst must be positive", ErrInvalidRule)
+	}
+	return nil
+}
+
+// Rule applies a Limit to a tenant (or every tenant, Key "*") on one channel,
+// or to a provider.
+type Rule struct {
+	Scope   Scope
+	Key     string            // tenant ID, "*" or provider name
+	Channel templates.Channel // tenant rules only
+	Limit
+}
+
+// ID names the rule: "tenant:<tenant>:<channel>" or "provider:<name>".
+// It is also the name used in COMMS_RATE_LIMITS and the admin API.
+func (r Rule) ID() string {
+	if r.Scope == ScopeProvider {
+		return string(ScopeProvider) + ":" + r.Key
+	}
+	return string(ScopeTenant) + ":" + r.Key + ":" + string(r.Channel)
+}
+
+// Validate checks the rule's name and limit.
+func (r Rule) Validate() error {
+	switch r.Scope {
+	case ScopeTenant:
+		if r.Key == "" {
+			return fmt.Errorf("%w: tenant rule needs a tenant ID or %q", ErrInvalidRule, AnyTenant)
+		}
+		if !r.Channel.IsValid() {
+			return fmt.Errorf("%w: tenant rule needs channel email or sms", ErrInvalidRule)
+		}
+	case ScopeProvider:
+		if _, ok := Providers[r.Key]; !ok {
+			return fmt.Errorf("%w: unknown provider %q", ErrInvalidRule, r.Key)
+		}
+		if r.Channel != "" {
+			return fmt.Errorf("%w: provider rules have no channel", ErrInvalidRule)
+		}
+	default:
+		return fmt.Errorf("%w: scope must be tenant or provider", ErrInvalidRule)
+	}
+	return r.Limit.validate()
+}
+
+// ParseRuleID is the inverse of Rule.ID; the returned rule has no Limit.
+func ParseRuleID(id string) (Rule, error) {
+	scope, rest, _ := strings.Cut(id, ":")
+	var r Rule
+	switch Scope(scope) {
+	case ScopeProvider:
+		r = Rule{Scope: ScopeProvider, Key: rest}
+	case ScopeTenant:
+		// Tenant IDs may contain ':'; the channel never does.
+		i := strings.LastIndex(rest, ":")
+		if i < 0 {
+			return Rule{}, fmt.Errorf("%w: %q: want tenant:<tenant>:<channel>", ErrInvalidRule, id)
+		}
+		r = Rule{Scope: ScopeTenant, Key: rest[:i], Channel: templates.Channel(rest[i+1:])}
+	default:
+		return Rule{}, fmt.Errorf("%w: %q: want tenant:<tenant>:<channel> or provider:<name>", ErrInvalidRule, id)
+	}
+	if r.Key == "" {
+		return Rule{}, fmt.Errorf("%w: %q has no tenant or provider", ErrInvalidRule, id)
+	}
+	return r, nil
+}
+
+// ParseRules reads COMMS_RATE_LIMITS:
+//
+//	"tenant:*:sms=30/1m,60; tenant:acme:sms=300/1m; provider:twilio=10/1s,20"
+//
+// Each entry is <rule id>=<count>/<period>[,<burst>]; burst defaults to count.
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q: want <rule>=<count>/<period>[,<burst>]", ErrInvalidRule, entry)
+		}
+		r, err := ParseRuleID(strings.TrimSpace(id))
+		if err != nil {
+			return nil, err
+		}
+		if r.Limit, err = ParseLimit(spec); err != nil {
+			return nil, fmt.Errorf("%q: %w", entry, err)
+		}
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("%q: %w", entry, err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// ParseLimit reads "<count>/<period>[,<burst>]", e.g. "30/1m,60".
+func ParseLimit(s string) (Limit, error) {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(s), ",")
+	count, per, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%w: %q: want <count>/<period>[,<burst>]", ErrInvalidRule, s)
+	}
+	var l Limit
+	var err error
+	if l.Count, err = strconv.Atoi(strings.TrimSpace(count)); err != nil {
+		return Limit{}, fmt.Errorf("%w: count: %v", ErrInvalidRule, err)
+	}
+	if l.Per, err = time.ParseDuration(strings.TrimSpace(per)); err != nil {
+		return Limit{}, fmt.Errorf("%w: period: %v", ErrInvalidRule, err)
+	}
+	l.Burst = l.Count
+	if hasBurst {
+		if l.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil {
+			return Limit{}, fmt.Errorf("%w: burst: %v", ErrInvalidRule, err)
+		}
+	}
+	return l, l.validate()
+}
+
+// RuleStore keeps the rules changed at runtime through the admin API. They
+// override the COMMS_RATE_LIMITS rule with the same ID.
+type RuleStore interface {
+	ListRules(ctx context.Context) ([]Rule, error)
+	// SaveRule creates or replaces the rule with r.ID().
+	SaveRule(ctx context.Context, r Rule) error
+	// DeleteRule removes a stored rule; ErrNotFound when there is none.
+	DeleteRule(ctx context.Context, id string) error
+}
@@ -0,0 +1,262 @@
+// services/communications-service/internal/recipients/http.recipients.go
+
+package recipients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Handler exposes contacts, preferences and the public unsubscribe page. The
+// tenant routes (Register) go behind caller.Authenticate and {tenant} must be
+// the caller's tenant; only the unsubscribe routes (RegisterPublic), which the
+// signed token authorizes, are public.
+//
+//	GET    /v1/tenants/{tenant}/contacts           list merchant contacts
+//	PUT    /v1/tenants/{tenant}/contacts/{id}      create/replace a contact
+//	DELETE /v1/tenants/{tenant}/contacts/{id}
+//	PUT    /v1/tenants/{tenant}/preferences        set opt-out / quiet hours for an address
+//	GET    /v1/unsubscribe?token=...               confirmation page
+//	POST   /v1/unsubscribe?token=...               unsubscribe (also RFC 8058 one-click)
+type Handler struct {
+	contacts ContactStore
+	prefs    PreferenceStore
+	unsub    *Unsubscriber
+}
+
+func NewHandler(contacts ContactStore, prefs PreferenceStore, unsub *Unsubscriber) *Handler {
+	return &Handler{contacts: contacts, prefs: prefs, unsub: unsub}
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/tenants/{tenant}/contacts", h.listContacts)
+	mux.HandleFunc("PUT /v1/tenants/{tenant}/contacts/{id}", h.putContact)
+	mux.HandleFunc("DELETE /v1/tenants/{tenant}/contacts/{id}", h.deleteContact)
+	mux.HandleFunc("PUT /v1/tenants/{tenant}/preferences", h.putPreference)
+}
+
+func (h *Handler) RegisterPublic(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/unsubscribe", h.unsubscribePage)
+	mux.HandleFunc("POST /v1/unsubscribe", h.unsubscribe)
+}
+
+type contactJSON struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Email  string   `json:"email,omitempty"`
+	Phone  string   `json:"phone,omitempty"`
+	Locale string   `json:"locale,omitempty"`
+	Events []string `json:"events,omitempty"` // empty subscribes to every event
+}
+
+type preferenceJSON struct {
+	Address  string            `json:"address"`
+	Channel  templates.Channel `json:"channel"`
+	OptedOut bool              `json:"opted_out"`
+	// Quiet hours as "HH:MM" in TimeZone; both empty clears them.
+	QuietStart string `json:"quiet_start,omitempty"`
+	QuietEnd   string `json:"quiet_end,omitempty"`
+	TimeZone   string `json:"time_zone,omitempty"`
+}
+
+func (h *Handler) listContacts(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.contacts.ListContacts(r.Context(), tenantID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]contactJSON, 0, len(list))
+	for _, c := range list {
+		out = append(out, contactJSON{ID: c.ID, Name: c.Name, Email: c.Email, Phone: c.Phone, Locale: c.Locale, Events: c.Events})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) putContact(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	var req contactJSON
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Email == "" && req.Phone == "" {
+		writeError(w, http.StatusBadRequest, errors.New("a contact needs an email or a phone number"))
+		return
+	}
+	c := Contact{
+		ID:       r.PathValue("id"),
+		TenantID: tenantID,
+		Name:     req.Name,
+		Email:    req.Email,
+		Phone:    req.Phone,
+		Locale:   req.Locale,
+		Events:   req.Events,
+	}
+	if err := h.contacts.SaveContact(r.Context(), c); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	req.ID = c.ID
+	writeJSON(w, http.StatusOK, req)
+}
+
+func (h *Handler) deleteContact(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	err := h.contacts.DeleteContact(r.Context(), tenantID, r.PathValue("id"))
+	if errors.Is(err, ErrContactNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) putPreference(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	var req preferenceJSON
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Address == "" || !req.Channel.IsValid() {
+		writeError(w, http.StatusBadRequest, errors.New("address and a valid channel are required"))
+		return
+	}
+	p := Preference{TenantID: tenantID, Address: req.Address, Channel: req.Channel, OptedOut: req.OptedOut}
+	if req.QuietStart != "" || req.QuietEnd != "" {
+		quiet, err := parseQuietHours(req.QuietStart, req.QuietEnd, req.TimeZone)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		p.Quiet = quiet
+	}
+	if err := h.prefs.SavePreference(r.Context(), p); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, req)
+}
+
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!doctype html>
+<html><body style="font-family: sans-serif">
+{{if .Done}}<p>{{.Address}} will no longer receive {{.Channel}} notifications.</p>
+{{else}}<p>Stop sending {{.Channel}} notifications to {{.Address}}?</p>
+<form method="post"><input type="hidden" name="token" value="{{.Token}}"><button type="submit">Unsubscribe</button></form>
+{{end}}</body></html>`))
+
+// unsubscribePage only shows a confirmation form. Mail scanners follow GET
+// links, so a GET must never unsubscribe anyone by itself.
+func (h *Handler) unsubscribePage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	_, address, channel, err := h.unsub.Verify(token)
+	if err != nil {
+		http.Error(w, "This unsubscribe link is not valid.", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	unsubscribePage.Execute(w, map[string]interface{}{"Address": address, "Channel": channel, "Token": token})
+}
+
+// unsubscribe handles both the confirmation form and one-click
+// List-Unsubscribe-Post requests from mail clients (token in the query).
+func (h *Handler) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token = r.FormValue("token")
+	}
+	tenantID, address, channel, err := h.unsub.Verify(token)
+	if err != nil {
+		http.Error(w, "This unsubscribe link is not valid.", http.StatusBadRequest)
+		return
+	}
+	if err := optOut(r.Context(), h.prefs, tenantID, address, channel); err != nil {
+		log.Printf("unsubscribe: %v", err)
+		http.Error(w, "Something went wrong, please try again.", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Unsubscribed %s from %s notifications (tenant %q)", address, channel, tenantID)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	unsubscribePage.Execute(w, map[string]interface{}{"Done": true, "Address": address, "Channel": channel})
+}
+
+// optOut keeps any quiet hours the address already set.
+func optOut(ctx context.Context, prefs PreferenceStore, tenantID, address string, channel templates.Channel) error {
+	p, err := prefs.GetPreference(ctx, tenantID, address, channel)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		p = &Preference{TenantID: tenantID, Address: address, Channel: channel}
+	}
+	p.OptedOut = true
+	return prefs.SavePreference(ctx, *p)
+}
+
+func parseQuietHours(start, end, tz string) (*QuietHours, error) {
+	s, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, errors.New("unknown time zone " + tz)
+		}
+	}
+	return &QuietHours{Start: s, End: e, TimeZone: tz}, nil
+}
+
+// parseClock turns "22:30" into 22h30m.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, errors.New("quiet hours must be HH:MM, got " + s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if status >= 500 {
+		log.Printf("recipients: request failed: %v", err)
+		writeJSON(w, status, map[string]string{"error": "internal error"})
+		return
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("recipients: failed to write response: %v", err)
+	}
+}
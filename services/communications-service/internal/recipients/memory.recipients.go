@@ -0,0 +1,82 @@
+// services/communications-service/internal/recipients/memory.recipients.go
+
+package recipients
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// MemoryStore implements ContactStore and PreferenceStore in process, for
+// local runs without a database and for tests.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	contacts map[string]map[string]Contact // tenant -> id -> contact
+	prefs    map[string]Preference         // prefKey -> preference
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{contacts: make(map[string]map[string]Contact), prefs: make(map[string]Preference)}
+}
+
+func (s *MemoryStore) ListContacts(ctx context.Context, tenantID string) ([]Contact, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Contact
+	for _, c := range s.contacts[tenantID] {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *MemoryStore) SaveContact(ctx context.Context, c Contact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.contacts[c.TenantID] == nil {
+		s.contacts[c.TenantID] = make(map[string]Contact)
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now().UTC()
+	}
+	s.contacts[c.TenantID][c.ID] = c
+	return nil
+}
+
+func (s *MemoryStore) DeleteContact(ctx context.Context, tenantID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.contacts[tenantID][id]; !ok {
+		return ErrContactNotFound
+	}
+	delete(s.contacts[tenantID], id)
+	return nil
+}
+
+func (s *MemoryStore) GetPreference(ctx context.Context, tenantID, address string, channel templates.Channel) (*Preference, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.prefs[prefKey(tenantID, address, channel)]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (s *MemoryStore) SavePreference(ctx context.Context, p Preference) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.UpdatedAt = time.Now().UTC()
+	s.prefs[prefKey(p.TenantID, p.Address, p.Channel)] = p
+	return nil
+}
+
+// Addresses are case-insensitive for email; phone numbers have no case.
+func prefKey(tenantID, address string, channel templates.Channel) string {
+	return tenantID + "|" + string(channel) + "|" + strings.ToLower(address)
+}
@@ -0,0 +1,23 @@
+// services/communications-service/internal/recipients/recipient_store.go
+
+package recipients
+
+import (
+	"context"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// ContactStore holds the merchant contacts registered for each tenant.
+type ContactStore interface {
+	ListContacts(ctx context.Context, tenantID string) ([]Contact, error)
+	SaveContact(ctx context.Context, c Contact) error
+	DeleteContact(ctx context.Context, tenantID, id string) error
+}
+
+// PreferenceStore holds opt-outs and quiet hours.
+// GetPreference returns (nil, nil) when the address never set a preference.
+type PreferenceStore interface {
+	GetPreference(ctx context.Context, tenantID, address string, channel templates.Channel) (*Preference, error)
+	SavePreference(ctx context.Context, p Preference) error
+}
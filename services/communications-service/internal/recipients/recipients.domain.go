@@ -0,0 +1,109 @@
+// services/communications-service/internal/recipients/recipients.domain.go
+
+package recipients
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Role says why someone receives a notification.
+type Role string
+
+const (
+	RoleCustomer  Role = "customer"  // the end customer on the shipment (consignee)
+	RoleMerchant  Role = "merchant"  // a contact the merchant registered for their account
+	RoleWarehouse Role = "warehouse" // the on-site contact for a carrier pickup
+)
+
+var ErrContactNotFound = errors.New("contact not found")
+
+// Recipient is one person a notification is addressed to.
+type Recipient struct {
+	Role   Role
+	Name   string
+	Email  string
+	Phone  string // E.164
+	Locale string // empty uses the event or default locale
+}
+
+// Address returns the channel-specific address, or "" when the recipient
+// cannot be reached on that channel.
+func (r Recipient) Address(channel templates.Channel) string {
+	switch channel {
+	case templates.ChannelEmail:
+		return r.Email
+	case templates.ChannelSMS:
+		return r.Phone
+	}
+	return ""
+}
+
+// Contact is a merchant-side person who wants notifications for a tenant.
+// Events is the list of event types they subscribed to; empty means all.
+type Contact struct {
+	ID        string
+	TenantID  string
+	Name      string
+	Email     string
+	Phone     string
+	Locale    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+// Wants reports whether the contact subscribed to eventType.
+func (c Contact) Wants(eventType string) bool {
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Preference is what one address chose for one channel within a tenant.
+// Preferences are keyed by address rather than by person because customers
+// are not registered anywhere; an email address is all we know about them.
+type Preference struct {
+	TenantID string
+	Address  string // email address or E.164 phone number
+	Channel  templates.Channel
+	OptedOut bool
+	Quiet    *QuietHours // nil means no quiet hours
+	// UpdatedAt is set by the store.
+	UpdatedAt time.Time
+}
+
+// QuietHours is a daily window, in the recipient's time zone, during which
+// intrusive channels (SMS) are not used. Start > End wraps midnight (22:00-07:00).
+type QuietHours struct {
+	Start    time.Duration // offset from local midnight
+	End      time.Duration
+	TimeZone string // IANA name; empty is UTC
+}
+
+// Contains reports whether t falls inside the quiet window.
+func (q QuietHours) Contains(t time.Time) bool {
+	loc := time.UTC
+	if q.TimeZone != "" {
+		if l, err := time.LoadLocation(q.TimeZone); err == nil {
+			loc = l
+		}
+	}
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	offset := local.Sub(midnight)
+	if q.Start == q.End {
+		return false
+	}
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
@@ -0,0 +1,164 @@
+// services/communications-service/internal/recipients/resolver.recipients.go
+
+package recipients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// Event is the part of a Kafka event the resolver needs.
+type Event struct {
+	Type     string
+	TenantID string // empty for events that are not tenant-scoped yet
+	Locale   string
+	Payload  []byte
+	// Channels, when set, replaces the event's default channels (the bridge's
+	// routing rules decide them).
+	Channels []templates.Channel
+	// Urgent messages are sent during quiet hours.
+	Urgent bool
+}
+
+// Delivery is one message to send: who, and on which channel.
+type Delivery struct {
+	Recipient Recipient
+	Channel   templates.Channel
+}
+
+// eventRule describes who hears about an event.
+type eventRule struct {
+	// Channels the event is sent on, in order.
+	Channels []templates.Channel
+	// fromPayload returns the recipients carried on the event itself.
+	fromPayload func(payload []byte) ([]Recipient, error)
+	// merchants: also notify the tenant's merchant contacts who subscribed to the event.
+	merchants bool
+}
+
+var rules = map[string]eventRule{
+	// The consignee hears that their parcel is on its way; merchant contacts get a copy.
+	"shipment.created": {
+		Channels:  []templates.Channel{templates.ChannelEmail, templates.ChannelSMS},
+		merchants: true,
+		fromPayload: func(payload []byte) ([]Recipient, error) {
+			var s contracts.Shipment
+			if err := json.Unmarshal(payload, &s); err != nil {
+				return nil, err
+			}
+			a := s.ToAddress
+			return []Recipient{{Role: RoleCustomer, Name: a.Name, Email: a.Email, Phone: a.Phone}}, nil
+		},
+	},
+	// Reminders go to the people at the dock, not to the whole merchant team.
+	"pickup.reminder": {
+		Channels: []templates.Channel{templates.ChannelEmail, templates.ChannelSMS},
+		fromPayload: func(payload []byte) ([]Recipient, error) {
+			var p contracts.Pickup
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, err
+			}
+			a := p.Address
+			return []Recipient{{Role: RoleWarehouse, Name: a.Name, Email: a.Email, Phone: a.Phone}}, nil
+		},
+	},
+}
+
+// Resolver decides who receives which message for an event, after applying
+// opt-outs and quiet hours.
+type Resolver struct {
+	contacts ContactStore
+	prefs    PreferenceStore
+	now      func() time.Time
+}
+
+func NewResolver(contacts ContactStore, prefs PreferenceStore) *Resolver {
+	return &Resolver{contacts: contacts, prefs: prefs, now: time.Now}
+}
+
+// Resolve returns the deliveries for ev. Unknown event types resolve to nothing.
+// The same address never receives the same event twice on one channel, even
+// if it is both the consignee and a merchant contact.
+func (r *Resolver) Resolve(ctx context.Context, ev Event) ([]Delivery, error) {
+	rule, ok := rules[ev.Type]
+	if !ok {
+		return nil, nil
+	}
+
+	var people []Recipient
+	if rule.fromPayload != nil {
+		fromPayload, err := rule.fromPayload(ev.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", templates.ErrInvalidPayload, ev.Type, err)
+		}
+		people = append(people, fromPayload...)
+	}
+	if rule.merchants && ev.TenantID != "" {
+		contacts, err := r.contacts.ListContacts(ctx, ev.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load merchant contacts: %w", err)
+		}
+		for _, c := range contacts {
+			if c.Wants(ev.Type) {
+				people = append(people, Recipient{Role: RoleMerchant, Name: c.Name, Email: c.Email, Phone: c.Phone, Locale: c.Locale})
+			}
+		}
+	}
+
+	channels := rule.Channels
+	if ev.Channels != nil {
+		channels = ev.Channels
+	}
+
+	now := r.now()
+	seen := make(map[string]bool)
+	var out []Delivery
+	for _, person := range people {
+		if person.Locale == "" {
+			person.Locale = ev.Locale
+		}
+		for _, channel := range channels {
+			address := person.Address(channel)
+			if address == "" {
+				continue
+			}
+			dedupe := string(channel) + "|" + strings.ToLower(address)
+			if seen[dedupe] {
+				continue
+			}
+			seen[dedupe] = true
+
+			pref, err := r.prefs.GetPreference(ctx, ev.TenantID, address, channel)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load contact preference: %w", err)
+			}
+			if pref != nil && pref.OptedOut {
+				continue
+			}
+			// Quiet hours only hold back SMS; an email at 3am does not wake anyone up.
+			if channel == templates.ChannelSMS && !ev.Urgent && pref != nil && pref.Quiet != nil && pref.Quiet.Contains(now) {
+				log.Printf("Resolver: %s %s is in quiet hours, skipping SMS", person.Role, address)
+				continue
+			}
+			out = append(out, Delivery{Recipient: person, Channel: channel})
+		}
+	}
+	return out, nil
+}
+
+// OptedOut is the send-time check: a recipient may unsubscribe while their
+// message is still queued or being retried.
+func (r *Resolver) OptedOut(ctx context.Context, tenantID, address string, channel templates.Channel) (bool, error) {
+	pref, err := r.prefs.GetPreference(ctx, tenantID, address, channel)
+	if err != nil {
+		return false, err
+	}
+	return pref != nil && pref.OptedOut, nil
+}
@@ -0,0 +1,191 @@
+package recipients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+func shipmentEvent(t *testing.T, tenantID string, to contracts.Address) Event {
+	t.Helper()
+	payload, err := json.Marshal(contracts.Shipment{Destination: "Berlin", ToAddress: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Event{Type: "shipment.created", TenantID: tenantID, Payload: payload}
+}
+
+func TestResolveCustomerAndMerchantContacts(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	store.SaveContact(ctx, Contact{ID: "ops", TenantID: "tenant-1", Email: "ops@merchant.test", Locale: "de"})
+	store.SaveContact(ctx, Contact{ID: "pickups-only", TenantID: "tenant-1", Email: "dock@merchant.test", Events: []string{"pickup.reminder"}})
+	// Same address as the consignee: must not get the email twice
+	store.SaveContact(ctx, Contact{ID: "dup", TenantID: "tenant-1", Email: "JANA@example.com"})
+
+	r := NewResolver(store, store)
+	got, err := r.Resolve(ctx, shipmentEvent(t, "tenant-1", contracts.Address{Name: "Jana", Email: "jana@example.com", Phone: "+4915112345678"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"email jana@example.com":  true,
+		"sms +4915112345678":      true,
+		"email ops@merchant.test": true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d deliveries, want %d: %+v", len(got), len(want), got)
+	}
+	for _, d := range got {
+		k := string(d.Channel) + " " + d.Recipient.Address(d.Channel)
+		if !want[k] {
+			t.Errorf("unexpected delivery %s", k)
+		}
+		if d.Recipient.Email == "ops@merchant.test" && (d.Recipient.Role != RoleMerchant || d.Recipient.Locale != "de") {
+			t.Errorf("merchant contact resolved as %+v", d.Recipient)
+		}
+	}
+}
+
+func TestResolveHonoursOptOutAndQuietHours(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	store.SavePreference(ctx, Preference{Address: "Jana@Example.com", Channel: templates.ChannelEmail, OptedOut: true})
+	store.SavePreference(ctx, Preference{Address: "+4915112345678", Channel: templates.ChannelSMS,
+		Quiet: &QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, TimeZone: "Europe/Berlin"}})
+
+	r := NewResolver(store, store)
+	ev := shipmentEvent(t, "", contracts.Address{Email: "jana@example.com", Phone: "+4915112345678"})
+
+	// 23:30 in Berlin: SMS held back, email opted out -> nothing
+	r.now = func() time.Time { return time.Date(2025, 1, 6, 22, 30, 0, 0, time.UTC) }
+	got, err := r.Resolve(ctx, ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no deliveries at night, got %+v", got)
+	}
+
+	// 09:00 in Berlin: SMS goes out
+	r.now = func() time.Time { return time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC) }
+	got, _ = r.Resolve(ctx, ev)
+	if len(got) != 1 || got[0].Channel != templates.ChannelSMS {
+		t.Errorf("expected only the SMS in the morning, got %+v", got)
+	}
+}
+
+func TestResolveRoutedChannelsAndUrgency(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	store.SavePreference(ctx, Preference{Address: "+4915112345678", Channel: templates.ChannelSMS,
+		Quiet: &QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour, TimeZone: "Europe/Berlin"}})
+
+	r := NewResolver(store, store)
+	r.now = func() time.Time { return time.Date(2025, 1, 6, 22, 30, 0, 0, time.UTC) }
+	ev := shipmentEvent(t, "", contracts.Address{Email: "jana@example.com", Phone: "+4915112345678"})
+
+	// Routing restricts the event to SMS; quiet hours hold it back
+	ev.Channels = []templates.Channel{templates.ChannelSMS}
+	if got, _ := r.Resolve(ctx, ev); len(got) != 0 {
+		t.Errorf("expected SMS held back at night, got %+v", got)
+	}
+	// unless the route is urgent
+	ev.Urgent = true
+	got, _ := r.Resolve(ctx, ev)
+	if len(got) != 1 || got[0].Channel != templates.ChannelSMS {
+		t.Errorf("expected the urgent SMS only, got %+v", got)
+	}
+}
+
+func TestUnsubscribeLink(t *testing.T) {
+	store := NewMemoryStore()
+	unsub := NewUnsubscriber("secret", "https://comms.test/")
+	mux := http.NewServeMux()
+	NewHandler(store, store, unsub).RegisterPublic(mux)
+
+	link := unsub.URL("tenant-1", "jana@example.com", templates.ChannelEmail)
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := u.Query().Get("token")
+
+	// A tampered token is rejected
+	if _, _, _, err := unsub.Verify(strings.Replace(token, token[:4], "AAAA", 1)); err == nil {
+		t.Error("tampered token accepted")
+	}
+
+	// GET only shows the form; mail scanners must not unsubscribe anyone
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", rec.Code)
+	}
+	if p, _ := store.GetPreference(context.Background(), "tenant-1", "jana@example.com", templates.ChannelEmail); p != nil {
+		t.Fatalf("GET must not opt out, got %+v", p)
+	}
+
+	// One-click POST
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, u.RequestURI(), strings.NewReader("List-Unsubscribe=One-Click")))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST status = %d", rec.Code)
+	}
+	r := NewResolver(store, store)
+	if out, _ := r.OptedOut(context.Background(), "tenant-1", "jana@example.com", templates.ChannelEmail); !out {
+		t.Error("address not opted out after POST")
+	}
+	// Other tenants are unaffected
+	if out, _ := r.OptedOut(context.Background(), "tenant-2", "jana@example.com", templates.ChannelEmail); out {
+		t.Error("opt-out leaked to another tenant")
+	}
+}
+
+func TestContactAPIIsScopedToCaller(t *testing.T) {
+	store := NewMemoryStore()
+	public, tenantAPI := http.NewServeMux(), http.NewServeMux()
+	h := NewHandler(store, store, NewUnsubscriber("secret", "https://comms.test/"))
+	h.RegisterPublic(public)
+	h.Register(tenantAPI)
+	do := func(mux *http.ServeMux, tenantID, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if tenantID != "" {
+			req = req.WithContext(identity.NewContext(req.Context(), identity.Identity{UserID: "u-1", TenantID: tenantID, Role: "admin"}))
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(tenantAPI, "tenant-1", http.MethodPut, "/v1/tenants/tenant-1/contacts/c-1", `{"name":"Jana","email":"jana@example.com"}`); rec.Code != http.StatusOK {
+		t.Fatalf("own contact: %d %s", rec.Code, rec.Body)
+	}
+	for _, req := range [][3]string{
+		{http.MethodGet, "/v1/tenants/tenant-1/contacts", ""},
+		{http.MethodPut, "/v1/tenants/tenant-1/contacts/c-1", `{"name":"Mallory","email":"m@evil.example"}`},
+		{http.MethodDelete, "/v1/tenants/tenant-1/contacts/c-1", ""},
+		{http.MethodPut, "/v1/tenants/tenant-1/preferences", `{"address":"jana@example.com","channel":"email","opted_out":true}`},
+	} {
+		if rec := do(tenantAPI, "tenant-2", req[0], req[1], req[2]); rec.Code != http.StatusForbidden {
+			t.Errorf("tenant-2 %s %s: %d", req[0], req[1], rec.Code)
+		}
+	}
+	// The public mux has no contact routes at all
+	if rec := do(public, "", http.MethodGet, "/v1/tenants/tenant-1/contacts", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("public contact list: %d", rec.Code)
+	}
+	if list, _ := store.ListContacts(context.Background(), "tenant-1"); len(list) != 1 || list[0].Name != "Jana" {
+		t.Errorf("tenant-1 contacts = %+v", list)
+	}
+}
@@ -0,0 +1,75 @@
+// services/communications-service/internal/recipients/unsubscribe.recipients.go
+
+package recipients
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// Unsubscriber signs and verifies the tokens in unsubscribe links. A token
+// names exactly one (tenant, address, channel), so a link cannot be edited to
+// unsubscribe someone else. Tokens do not expire: an old email's link must
+// keep working.
+type Unsubscriber struct {
+	secret  []byte
+	baseURL string // public URL of this service, e.g. https://comms.logisynapse.io
+}
+
+func NewUnsubscriber(secret, baseURL string) *Unsubscriber {
+	return &Unsubscriber{secret: []byte(secret), baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+type unsubscribeClaims struct {
+	TenantID string            `json:"t,omitempty"`
+	Address  string            `json:"a"`
+	Channel  templates.Channel `json:"c"`
+}
+
+// Token returns the signed token for one address and channel.
+func (u *Unsubscriber) Token(tenantID, address string, channel templates.Channel) string {
+	payload, _ := json.Marshal(unsubscribeClaims{TenantID: tenantID, Address: address, Channel: channel})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(u.sign(encoded))
+}
+
+// URL returns the link placed in emails (and the List-Unsubscribe header).
+func (u *Unsubscriber) URL(tenantID, address string, channel templates.Channel) string {
+	return u.baseURL + "/v1/unsubscribe?token=" + url.QueryEscape(u.Token(tenantID, address, channel))
+}
+
+// Verify checks the signature and returns what the token unsubscribes.
+func (u *Unsubscriber) Verify(token string) (tenantID, address string, channel templates.Channel, err error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", "", ErrInvalidToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, u.sign(encoded)) {
+		return "", "", "", ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", "", ErrInvalidToken
+	}
+	var claims unsubscribeClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Address == "" || !claims.Channel.IsValid() {
+		return "", "", "", ErrInvalidToken
+	}
+	return claims.TenantID, claims.Address, claims.Channel, nil
+}
+
+func (u *Unsubscriber) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
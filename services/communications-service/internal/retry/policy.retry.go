@@ -0,0 +1,134 @@
+// services/communications-service/internal/retry/policy.retry.go
+
+package retry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Tiers are the delay queues declared next to every work queue. A failed job
+// is parked in one of them and dead-lettered back to the work queue when its
+// TTL expires, so a retry actually waits instead of spinning.
+//
+// Policies can only use these delays: each tier is a real queue with a fixed
+// TTL (per-message TTLs would block behind longer messages at the queue head).
+var Tiers = []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}
+
+// DelayQueueName is the tier queue for a work queue, e.g. "sms_jobs.retry.1m0s".
+func DelayQueueName(queue string, delay time.Duration) string {
+	return queue + ".retry." + delay.String()
+}
+
+// TierFor returns the shortest tier of at least d, or the longest tier when d
+// is longer than all of them.
+func TierFor(d time.Duration) time.Duration {
+	longest := Tiers[0]
+	for _, t := range Tiers {
+		if t >= d {
+			return t
+		}
+		if t > longest {
+			longest = t
+		}
+	}
+	return longest
+}
+
+// Policy is the wait before each retry. len(Delays) is the number of retries;
+// after that the job goes to the DLQ.
+type Policy struct {
+	Delays []time.Duration
+}
+
+// Next returns the delay before retry number retryCount+1, or false when the
+// job has used all its retries.
+func (p Policy) Next(retryCount int32) (time.Duration, bool) {
+	if int(retryCount) >= len(p.Delays) {
+		return 0, false
+	}
+	return p.Delays[retryCount], true
+}
+
+// DefaultPolicy: 10s, 1m, 10m. Enough to ride out a provider blip without
+// holding a notification for so long it becomes useless.
+var DefaultPolicy = Policy{Delays: []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}}
+
+// Policies maps job types ("<event>/<channel>", or just "<channel>") to policies.
+type Policies struct {
+	Default Policy
+	ByType  map[string]Policy
+}
+
+// For returns the most specific policy for a job: event/channel, then channel, then default.
+func (ps Policies) For(eventType, channel string) Policy {
+	if p, ok := ps.ByType[eventType+"/"+channel]; ok {
+		return p
+	}
+	if p, ok := ps.ByType[channel]; ok {
+		return p
+	}
+	return ps.Default
+}
+
+// ParsePolicies reads COMMS_RETRY_POLICIES:
+//
+//	"sms=10s,1m; pickup.reminder/sms=10s,10s,10s; shipment.created/email=10s,1m,10m,10m; default=10s,1m"
+//
+// An empty list ("pickup.reminder/sms=") means no retries. Every delay must be one of Tiers.
+func ParsePolicies(s string) (Policies, error) {
+	ps := Policies{Default: DefaultPolicy, ByType: make(map[string]Policy)}
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(entry, "=")
+		if !ok {
+			return Policies{}, fmt.Errorf("retry policy %q: want <job type>=<delay>,<delay>", entry)
+		}
+		var p Policy
+		for _, raw := range strings.Split(list, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return Policies{}, fmt.Errorf("retry policy %q: %v", entry, err)
+			}
+			if !isTier(d) {
+				return Policies{}, fmt.Errorf("retry policy %q: %s is not a delay tier (have %s)", entry, d, tierList())
+			}
+			p.Delays = append(p.Delays, d)
+		}
+		name = strings.TrimSpace(name)
+		if name == "default" {
+			ps.Default = p
+		} else {
+			ps.ByType[name] = p
+		}
+	}
+	return ps, nil
+}
+
+func isTier(d time.Duration) bool {
+	for _, t := range Tiers {
+		if t == d {
+			return true
+		}
+	}
+	return false
+}
+
+func tierList() string {
+	tiers := append([]time.Duration(nil), Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i] < tiers[j] })
+	names := make([]string, len(tiers))
+	for i, t := range tiers {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
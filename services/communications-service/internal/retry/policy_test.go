@@ -0,0 +1,60 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePolicies(t *testing.T) {
+	ps, err := ParsePolicies("sms=10s,1m; pickup.reminder/sms=10s,10s,10s; shipment.created/email=; default=1m")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := ps.For("pickup.reminder", "sms")
+	if len(p.Delays) != 3 || p.Delays[2] != 10*time.Second {
+		t.Errorf("pickup.reminder/sms = %v", p.Delays)
+	}
+	if p := ps.For("shipment.created", "sms"); len(p.Delays) != 2 {
+		t.Errorf("channel fallback = %v", p.Delays)
+	}
+	if _, ok := ps.For("shipment.created", "email").Next(0); ok {
+		t.Error("empty policy should not retry")
+	}
+	if p := ps.For("pickup.reminder", "email"); len(p.Delays) != 1 || p.Delays[0] != time.Minute {
+		t.Errorf("default = %v", p.Delays)
+	}
+
+	if _, err := ParsePolicies("sms=30s"); err == nil {
+		t.Error("30s is not a tier and should be rejected")
+	}
+	if _, err := ParsePolicies("sms"); err == nil {
+		t.Error("entry without '=' should be rejected")
+	}
+}
+
+func TestPolicyNext(t *testing.T) {
+	for retry, want := range []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute} {
+		got, ok := DefaultPolicy.Next(int32(retry))
+		if !ok || got != want {
+			t.Errorf("Next(%d) = %v, %v; want %v", retry, got, ok, want)
+		}
+	}
+	if _, ok := DefaultPolicy.Next(3); ok {
+		t.Error("default policy should stop after 3 retries")
+	}
+}
+
+func TestTierFor(t *testing.T) {
+	cases := map[time.Duration]time.Duration{
+		time.Second:      10 * time.Second,
+		10 * time.Second: 10 * time.Second,
+		30 * time.Second: time.Minute,
+		time.Hour:        10 * time.Minute,
+	}
+	for d, want := range cases {
+		if got := TierFor(d); got != want {
+			t.Errorf("TierFor(%s) = %s; want %s", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// services/communications-service/internal/router/router.go
+
+// Package router decides what the bridge does with a Kafka event: which
+// channels it goes out on, with which template and at what priority. The
+// rules are data (routes.yaml) so a new event is a config change, not a code
+// change.
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// Route is the decision for one event.
+type Route struct {
+	Channels []templates.Channel
+	Template string
+	Priority Priority
+}
+
+type Router struct {
+	rules []Rule
+}
+
+func New(cfg *Config) *Router {
+	return &Router{rules: cfg.Rules}
+}
+
+// Route returns the first rule matching the event. ok is false when no rule
+// matches or the matching rule has no channels; the bridge drops those events.
+func (r *Router) Route(eventType string, payload []byte) (Route, bool) {
+	var doc interface{}
+	decoded := false
+	for _, rule := range r.rules {
+		if rule.Event != eventType {
+			continue
+		}
+		if len(rule.When) > 0 && !decoded {
+			// A payload we cannot read fails every predicate
+			if json.Unmarshal(payload, &doc) != nil {
+				doc = nil
+			}
+			decoded = true
+		}
+		if !rule.matches(doc) {
+			continue
+		}
+		if len(rule.Channels) == 0 {
+			return Route{}, false
+		}
+		return Route{Channels: rule.Channels, Template: rule.Template, Priority: rule.Priority}, true
+	}
+	return Route{}, false
+}
+
+func (r Rule) matches(doc interface{}) bool {
+	for _, p := range r.When {
+		v, found := lookup(doc, p.Field)
+		switch {
+		case p.Exists != nil:
+			if found != *p.Exists {
+				return false
+			}
+		case p.Equals != nil:
+			if !found || v != *p.Equals {
+				return false
+			}
+		case p.NotEquals != nil:
+			if found && v == *p.NotEquals {
+				return false
+			}
+		case p.In != nil:
+			if !found || !contains(p.In, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lookup follows a dot path through decoded JSON objects and returns the
+// value as a string. Missing fields and JSON null are not found.
+func lookup(doc interface{}, path string) (string, bool) {
+	cur := doc
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if cur, ok = obj[part]; !ok || cur == nil {
+			return "", false
+		}
+	}
+	switch v := cur.(type) {
+	case string:
+		return v, true
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
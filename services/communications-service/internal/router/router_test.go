@@ -0,0 +1,136 @@
+package router
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+const testRoutes = `
+topics: [shipment-events, billing-events]
+rules:
+  # test shipments never notify anyone
+  - event: shipment.created
+    when:
+      - field: Carrier.Name
+        equals: TEST
+    channels: []
+
+  - event: shipment.created
+    when:
+      - field: ToAddress.Country
+        in: [DE, AT]
+    channels: [email]
+    priority: low
+
+  - event: shipment.created
+    channels: [email, sms]
+
+  - event: pickup.reminder
+    when:
+      - field: ConfirmationNumber
+        exists: true
+      - field: Status
+        not_equals: CANCELLED
+    channels: [sms]
+    priority: high
+`
+
+func mustRouter(t *testing.T, src string) *Router {
+	t.Helper()
+	cfg, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(cfg)
+}
+
+func TestRoute(t *testing.T) {
+	r := mustRouter(t, testRoutes)
+	cases := []struct {
+		name     string
+		event    string
+		payload  string
+		ok       bool
+		channels []templates.Channel
+		priority Priority
+	}{
+		{"first matching rule wins", "shipment.created", `{"ToAddress":{"Country":"DE"}}`, true, []templates.Channel{"email"}, PriorityLow},
+		{"falls through to catch-all", "shipment.created", `{"ToAddress":{"Country":"BD"}}`, true, []templates.Channel{"email", "sms"}, PriorityNormal},
+		{"rule without channels drops", "shipment.created", `{"Carrier":{"Name":"TEST"},"ToAddress":{"Country":"DE"}}`, false, nil, ""},
+		{"all predicates must hold", "pickup.reminder", `{"ConfirmationNumber":"C-1","Status":"SCHEDULED"}`, true, []templates.Channel{"sms"}, PriorityHigh},
+		{"not_equals rejects", "pickup.reminder", `{"ConfirmationNumber":"C-1","Status":"CANCELLED"}`, false, nil, ""},
+		{"null is not set", "pickup.reminder", `{"ConfirmationNumber":null,"Status":"SCHEDULED"}`, false, nil, ""},
+		{"unknown event", "invoice.finalized", `{}`, false, nil, ""},
+		{"unreadable payload fails predicates", "pickup.reminder", `not json`, false, nil, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := r.Route(tc.event, []byte(tc.payload))
+			if ok != tc.ok {
+				t.Fatalf("ok = %v, want %v (route %+v)", ok, tc.ok, got)
+			}
+			if !ok {
+				return
+			}
+			if len(got.Channels) != len(tc.channels) {
+				t.Fatalf("channels = %v, want %v", got.Channels, tc.channels)
+			}
+			for i := range got.Channels {
+				if got.Channels[i] != tc.channels[i] {
+					t.Fatalf("channels = %v, want %v", got.Channels, tc.channels)
+				}
+			}
+			if got.Priority != tc.priority || got.Template != tc.event {
+				t.Errorf("route = %+v", got)
+			}
+		})
+	}
+}
+
+func TestNumbersCompareAsStrings(t *testing.T) {
+	r := mustRouter(t, `
+rules:
+  - event: shipment.created
+    when: [{field: Weight, equals: "2"}]
+    channels: [email]
+`)
+	if _, ok := r.Route("shipment.created", []byte(`{"Weight":2}`)); !ok {
+		t.Error("2 should equal \"2\"")
+	}
+}
+
+func TestParseRejectsBadRules(t *testing.T) {
+	bad := map[string]string{
+		"missing event":    `rules: [{channels: [email]}]`,
+		"unknown template": `rules: [{event: invoice.finalized, channels: [email]}]`,
+		"unknown channel":  `rules: [{event: shipment.created, channels: [fax]}]`,
+		"unknown priority": `rules: [{event: shipment.created, channels: [email], priority: urgent}]`,
+		"two operators":    `rules: [{event: shipment.created, channels: [email], when: [{field: Status, equals: "1", exists: true}]}]`,
+		"no operator":      `rules: [{event: shipment.created, channels: [email], when: [{field: Status}]}]`,
+		"not yaml":         `rules: [`,
+	}
+	for name, src := range bad {
+		if _, err := Parse([]byte(src)); !errors.Is(err, ErrInvalidRules) {
+			t.Errorf("%s: err = %v, want ErrInvalidRules", name, err)
+		}
+	}
+	// Dropping an event needs no template
+	if _, err := Parse([]byte(`rules: [{event: invoice.finalized, channels: []}]`)); err != nil {
+		t.Errorf("drop rule: %v", err)
+	}
+}
+
+func TestDefaultRoutesParse(t *testing.T) {
+	cfg, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := New(cfg)
+	for event := range templates.Events {
+		if _, ok := r.Route(event, []byte(`{}`)); !ok {
+			t.Errorf("default routes do not send %s", event)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// services/communications-service/internal/router/rules.router.go
+
+package router
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidRules = errors.New("invalid routing rules")
+
+// Priority orders notifications. High priority messages are urgent enough to
+// ignore the recipient's quiet hours.
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// Predicate tests one payload field. Exactly one of Equals, NotEquals, In or
+// Exists is set. Values are compared as strings, so `equals: "3"` matches
+// both 3 and "3".
+type Predicate struct {
+	Field     string   `yaml:"field"`
+	Equals    *string  `yaml:"equals"`
+	NotEquals *string  `yaml:"not_equals"`
+	In        []string `yaml:"in"`
+	Exists    *bool    `yaml:"exists"`
+}
+
+// Rule routes one event type.
+type Rule struct {
+	Event    string              `yaml:"event"`
+	When     []Predicate         `yaml:"when"`
+	Channels []templates.Channel `yaml:"channels"`
+	Template string              `yaml:"template"`
+	Priority Priority            `yaml:"priority"`
+}
+
+// Config is the routes file: the Kafka topics to consume and the rules.
+type Config struct {
+	Topics []string `yaml:"topics"`
+	Rules  []Rule   `yaml:"rules"`
+}
+
+//go:embed routes.yaml
+var defaultRoutes []byte
+
+// Default returns the routes compiled into the binary.
+func Default() (*Config, error) {
+	return Parse(defaultRoutes)
+}
+
+// LoadFile reads a routes file.
+func LoadFile(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse decodes and validates a routes file and fills in defaults.
+func Parse(b []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].normalize(); err != nil {
+			return nil, fmt.Errorf("%w: rule %d (%s): %v", ErrInvalidRules, i+1, cfg.Rules[i].Event, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (r *Rule) normalize() error {
+	if r.Event == "" {
+		return errors.New("event is required")
+	}
+	if r.Template == "" {
+		r.Template = r.Event
+	}
+	// A rule that sends something must name a template we can render.
+	if len(r.Channels) > 0 {
+		if _, ok := templates.Events[r.Template]; !ok {
+			return fmt.Errorf("no template schema for %q", r.Template)
+		}
+	}
+	for _, c := range r.Channels {
+		if c != templates.ChannelEmail && c != templates.ChannelSMS {
+			return fmt.Errorf("unknown channel %q", c)
+		}
+	}
+	switch r.Priority {
+	case "":
+		r.Priority = PriorityNormal
+	case PriorityLow, PriorityNormal, PriorityHigh:
+	default:
+		return fmt.Errorf("unknown priority %q", r.Priority)
+	}
+	for _, p := range r.When {
+		if p.Field == "" {
+			return errors.New("predicate without field")
+		}
+		set := 0
+		for _, ok := range []bool{p.Equals != nil, p.NotEquals != nil, p.In != nil, p.Exists != nil} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("predicate on %s needs exactly one of equals, not_equals, in, exists", p.Field)
+		}
+	}
+	return nil
+}
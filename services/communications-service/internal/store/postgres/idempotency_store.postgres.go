@@ -0,0 +1,75 @@
+// services/communications-service/internal/store/postgres/idempotency_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IdempotencyStore implements idempotency.Store. Shared by every worker
+// replica, so a job redelivered to a different instance is still skipped.
+type IdempotencyStore struct {
+	db *sql.DB
+}
+
+func NewIdempotencyStore(db *sql.DB) *IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+// Claim inserts a PENDING row, or takes over one that expired, in a single
+// statement: of two workers claiming the same key at once, one gets the row
+// back and the other gets nothing.
+func (s *IdempotencyStore) Claim(ctx context.Context, key, channel string, claimedAt, sentBefore, pendingBefore time.Time) (bool, error) {
+	query := `
+		INSERT INTO sent_notifications (idempotency_key, channel, status, sent_at)
+		VALUES ($1, $2, 'PENDING', $3)
+		ON CONFLICT (idempotency_key, channel)
+		DO UPDATE SET status = 'PENDING', sent_at = EXCLUDED.sent_at
+		WHERE (sent_notifications.status = 'SENT' AND sent_notifications.sent_at < $4)
+		   OR (sent_notifications.status = 'PENDING' AND sent_notifications.sent_at < $5)
+		RETURNING true
+	`
+	var claimed bool
+	err := s.db.QueryRowContext(ctx, query, key, channel, claimedAt, sentBefore, pendingBefore).Scan(&claimed)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("db: idempotency claim failed: %w", err)
+	}
+	return claimed, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, key, channel string, sentAt time.Time) error {
+	query := `
+		INSERT INTO sent_notifications (idempotency_key, channel, status, sent_at)
+		VALUES ($1, $2, 'SENT', $3)
+		ON CONFLICT (idempotency_key, channel)
+		DO UPDATE SET status = 'SENT', sent_at = EXCLUDED.sent_at
+	`
+	if _, err := s.db.ExecContext(ctx, query, key, channel, sentAt); err != nil {
+		return fmt.Errorf("db: idempotency insert failed: %w", err)
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, key, channel string) error {
+	query := `
+		DELETE FROM sent_notifications
+		WHERE idempotency_key = $1 AND channel = $2 AND status = 'PENDING'
+	`
+	if _, err := s.db.ExecContext(ctx, query, key, channel); err != nil {
+		return fmt.Errorf("db: idempotency release failed: %w", err)
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM sent_notifications WHERE sent_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("db: idempotency expiry failed: %w", err)
+	}
+	return res.RowsAffected()
+}
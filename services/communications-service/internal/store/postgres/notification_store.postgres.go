@@ -0,0 +1,152 @@
+// services/communications-service/internal/store/postgres/notification_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/notifications"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/lib/pq"
+)
+
+// NotificationStore implements notifications.Store.
+type NotificationStore struct {
+	db *sql.DB
+}
+
+func NewNotificationStore(db *sql.DB) *NotificationStore {
+	return &NotificationStore{db: db}
+}
+
+const notificationColumns = `job_id, tenant_id, shipment_ids, event, template, channel, recipient, status,
+	provider_message_id, last_error, attempts, created_at, updated_at, sent_at, delivered_at`
+
+func (s *NotificationStore) Create(ctx context.Context, n notifications.Notification) error {
+	query := `
+		INSERT INTO notifications (job_id, tenant_id, shipment_ids, event, template, channel, recipient, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (job_id) DO NOTHING
+	`
+	_, err := s.db.ExecContext(ctx, query, n.JobID, n.TenantID, pq.Array(n.ShipmentIDs), n.Event, n.Template,
+		string(n.Channel), n.Recipient, string(n.Status), n.CreatedAt, n.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("db: notification insert failed: %w", err)
+	}
+	return nil
+}
+
+func (s *NotificationStore) Get(ctx context.Context, jobID string) (*notifications.Notification, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+notificationColumns+` FROM notifications WHERE job_id = $1`, jobID)
+	n, err := scanNotification(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notifications.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db: notification get failed: %w", err)
+	}
+	return n, nil
+}
+
+func (s *NotificationStore) List(ctx context.Context, f notifications.Filter) ([]notifications.Notification, error) {
+	f, err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE ($1 = '' OR tenant_id = $1)
+		  AND ($2 = '' OR $2 = ANY (shipment_ids))
+		  AND ($3 = '' OR recipient = $3)
+		ORDER BY created_at DESC, job_id
+		LIMIT $4
+	`
+	rows, err := s.db.QueryContext(ctx, query, f.TenantID, f.ShipmentID, f.Recipient, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: notification list failed: %w", err)
+	}
+	defer rows.Close()
+	var out []notifications.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: notification scan failed: %w", err)
+		}
+		out = append(out, *n)
+	}
+	return out, rows.Err()
+}
+
+func (s *NotificationStore) Update(ctx context.Context, jobID string, u notifications.Update) error {
+	return s.update(ctx, `SELECT `+notificationColumns+` FROM notifications WHERE job_id = $1 FOR UPDATE`, u, jobID)
+}
+
+func (s *NotificationStore) UpdateByProviderID(ctx context.Context, channel, providerMessageID string, u notifications.Update) error {
+	if providerMessageID == "" {
+		return notifications.ErrNotFound
+	}
+	return s.update(ctx, `
+		SELECT `+notificationColumns+` FROM notifications
+		WHERE channel = $1 AND provider_message_id = $2
+		LIMIT 1 FOR UPDATE`, u, channel, providerMessageID)
+}
+
+// update locks the row, applies u with the same rules as the in-memory
+// store (notifications.Notification.Apply) and writes it back.
+func (s *NotificationStore) update(ctx context.Context, selectQuery string, u notifications.Update, args ...interface{}) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	n, err := scanNotification(tx.QueryRowContext(ctx, selectQuery, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return notifications.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("db: notification lock failed: %w", err)
+	}
+	if !n.Apply(u) {
+		return nil
+	}
+	query := `
+		UPDATE notifications
+		SET status = $2, provider_message_id = $3, last_error = $4, attempts = $5,
+		    updated_at = $6, sent_at = $7, delivered_at = $8
+		WHERE job_id = $1
+	`
+	_, err = tx.ExecContext(ctx, query, n.JobID, string(n.Status), n.ProviderMessageID, n.LastError, n.Attempts,
+		n.UpdatedAt, n.SentAt, n.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("db: notification update failed: %w", err)
+	}
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNotification(row rowScanner) (*notifications.Notification, error) {
+	var n notifications.Notification
+	var channel, status string
+	var sentAt, deliveredAt sql.NullTime
+	err := row.Scan(&n.JobID, &n.TenantID, pq.Array(&n.ShipmentIDs), &n.Event, &n.Template, &channel, &n.Recipient, &status,
+		&n.ProviderMessageID, &n.LastError, &n.Attempts, &n.CreatedAt, &n.UpdatedAt, &sentAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	n.Channel = templates.Channel(channel)
+	n.Status = notifications.Status(status)
+	if sentAt.Valid {
+		n.SentAt = &sentAt.Time
+	}
+	if deliveredAt.Valid {
+		n.DeliveredAt = &deliveredAt.Time
+	}
+	return &n, nil
+}
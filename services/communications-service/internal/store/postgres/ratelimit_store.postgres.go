@@ -0,0 +1,112 @@
+// services/communications-service/internal/store/postgres/ratelimit_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/ratelimit"
+)
+
+// RateLimitStore implements ratelimit.RuleStore and ratelimit.Backend. Every
+// worker replica takes from the same bucket rows, so they share one budget.
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+func NewRateLimitStore(db *sql.DB) *RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+func (s *RateLimitStore) ListRules(ctx context.Context) ([]ratelimit.Rule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT rule_id, max_count, period_ms, burst FROM rate_limit_rules`)
+	if err != nil {
+		return nil, fmt.Errorf("db: rate limit rules query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []ratelimit.Rule
+	for rows.Next() {
+		var id string
+		var periodMS int64
+		var l ratelimit.Limit
+		if err := rows.Scan(&id, &l.Count, &periodMS, &l.Burst); err != nil {
+			return nil, fmt.Errorf("db: rate limit rule scan failed: %w", err)
+		}
+		r, err := ratelimit.ParseRuleID(id)
+		if err != nil {
+			return nil, err
+		}
+		l.Per = time.Duration(periodMS) * time.Millisecond
+		r.Limit = l
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+func (s *RateLimitStore) SaveRule(ctx context.Context, r ratelimit.Rule) error {
+	query := `
+		INSERT INTO rate_limit_rules (rule_id, max_count, period_ms, burst, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (rule_id) DO UPDATE
+		SET max_count = EXCLUDED.max_count, period_ms = EXCLUDED.period_ms,
+		    burst = EXCLUDED.burst, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := s.db.ExecContext(ctx, query, r.ID(), r.Count, r.Per.Milliseconds(), r.Burst); err != nil {
+		return fmt.Errorf("db: rate limit rule save failed: %w", err)
+	}
+	return nil
+}
+
+func (s *RateLimitStore) DeleteRule(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_rules WHERE rule_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("db: rate limit rule delete failed: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ratelimit.ErrNotFound
+	}
+	return nil
+}
+
+// Take locks the bucket row, so concurrent takes from any replica queue up
+// behind each other. The database clock is used, not the replica's.
+func (s *RateLimitStore) Take(ctx context.Context, bucket string, l ratelimit.Limit) (time.Duration, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("db: begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (bucket, tokens, updated_at)
+		VALUES ($1, $2, clock_timestamp())
+		ON CONFLICT (bucket) DO NOTHING
+	`, bucket, float64(l.Burst))
+	if err != nil {
+		return 0, fmt.Errorf("db: rate limit bucket insert failed: %w", err)
+	}
+
+	var b ratelimit.Bucket
+	var now time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, updated_at, clock_timestamp() FROM rate_limit_buckets
+		WHERE bucket = $1 FOR UPDATE
+	`, bucket).Scan(&b.Tokens, &b.Updated, &now)
+	if err != nil {
+		return 0, fmt.Errorf("db: rate limit bucket lookup failed: %w", err)
+	}
+
+	b, wait := b.Take(l, now)
+	_, err = tx.ExecContext(ctx, `UPDATE rate_limit_buckets SET tokens = $2, updated_at = $3 WHERE bucket = $1`,
+		bucket, b.Tokens, b.Updated)
+	if err != nil {
+		return 0, fmt.Errorf("db: rate limit bucket update failed: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("db: commit failed: %w", err)
+	}
+	return wait, nil
+}
@@ -0,0 +1,131 @@
+// services/communications-service/internal/store/postgres/recipient_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/recipients"
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+	"github.com/lib/pq"
+)
+
+// RecipientStore implements recipients.ContactStore and recipients.PreferenceStore.
+type RecipientStore struct {
+	db *sql.DB
+}
+
+func NewRecipientStore(db *sql.DB) *RecipientStore {
+	return &RecipientStore{db: db}
+}
+
+func (s *RecipientStore) ListContacts(ctx context.Context, tenantID string) ([]recipients.Contact, error) {
+	query := `
+		SELECT id, name, email, phone, locale, events, created_at
+		FROM merchant_contacts
+		WHERE tenant_id = $1
+		ORDER BY id
+	`
+	rows, err := s.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("db: contact list failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []recipients.Contact
+	for rows.Next() {
+		c := recipients.Contact{TenantID: tenantID}
+		if err := rows.Scan(&c.ID, &c.Name, &c.Email, &c.Phone, &c.Locale, pq.Array(&c.Events), &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("db: contact scan failed: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (s *RecipientStore) SaveContact(ctx context.Context, c recipients.Contact) error {
+	query := `
+		INSERT INTO merchant_contacts (tenant_id, id, name, email, phone, locale, events)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, id)
+		DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email, phone = EXCLUDED.phone,
+		              locale = EXCLUDED.locale, events = EXCLUDED.events
+	`
+	events := c.Events
+	if events == nil {
+		events = []string{}
+	}
+	_, err := s.db.ExecContext(ctx, query, c.TenantID, c.ID, c.Name, c.Email, c.Phone, c.Locale, pq.Array(events))
+	if err != nil {
+		return fmt.Errorf("db: contact save failed: %w", err)
+	}
+	return nil
+}
+
+func (s *RecipientStore) DeleteContact(ctx context.Context, tenantID, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM merchant_contacts WHERE tenant_id = $1 AND id = $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("db: contact delete failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return recipients.ErrContactNotFound
+	}
+	return nil
+}
+
+func (s *RecipientStore) GetPreference(ctx context.Context, tenantID, address string, channel templates.Channel) (*recipients.Preference, error) {
+	// Intervals are read back as seconds so we don't have to parse Postgres interval text
+	query := `
+		SELECT opted_out,
+		       EXTRACT(EPOCH FROM quiet_start)::BIGINT,
+		       EXTRACT(EPOCH FROM quiet_end)::BIGINT,
+		       time_zone, updated_at
+		FROM contact_preferences
+		WHERE tenant_id = $1 AND address = $2 AND channel = $3
+	`
+	p := recipients.Preference{TenantID: tenantID, Address: address, Channel: channel}
+	var quietStart, quietEnd sql.NullInt64
+	var tz string
+	err := s.db.QueryRowContext(ctx, query, tenantID, strings.ToLower(address), string(channel)).
+		Scan(&p.OptedOut, &quietStart, &quietEnd, &tz, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: preference fetch failed: %w", err)
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		p.Quiet = &recipients.QuietHours{
+			Start:    time.Duration(quietStart.Int64) * time.Second,
+			End:      time.Duration(quietEnd.Int64) * time.Second,
+			TimeZone: tz,
+		}
+	}
+	return &p, nil
+}
+
+func (s *RecipientStore) SavePreference(ctx context.Context, p recipients.Preference) error {
+	query := `
+		INSERT INTO contact_preferences (tenant_id, address, channel, opted_out, quiet_start, quiet_end, time_zone, updated_at)
+		VALUES ($1, $2, $3, $4, make_interval(secs => $5), make_interval(secs => $6), $7, NOW())
+		ON CONFLICT (tenant_id, address, channel)
+		DO UPDATE SET opted_out = EXCLUDED.opted_out, quiet_start = EXCLUDED.quiet_start,
+		              quiet_end = EXCLUDED.quiet_end, time_zone = EXCLUDED.time_zone, updated_at = NOW()
+	`
+	var quietStart, quietEnd sql.NullFloat64
+	tz := ""
+	if p.Quiet != nil {
+		quietStart = sql.NullFloat64{Float64: p.Quiet.Start.Seconds(), Valid: true}
+		quietEnd = sql.NullFloat64{Float64: p.Quiet.End.Seconds(), Valid: true}
+		tz = p.Quiet.TimeZone
+	}
+	_, err := s.db.ExecContext(ctx, query, p.TenantID, strings.ToLower(p.Address), string(p.Channel), p.OptedOut, quietStart, quietEnd, tz)
+	if err != nil {
+		return fmt.Errorf("db: preference save failed: %w", err)
+	}
+	return nil
+}
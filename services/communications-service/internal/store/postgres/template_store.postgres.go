@@ -0,0 +1,99 @@
+// services/communications-service/internal/store/postgres/template_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/templates"
+)
+
+// TemplateStore keeps tenant template overrides in notification_templates.
+// It satisfies templates.OverrideStore.
+type TemplateStore struct {
+	db *sql.DB
+}
+
+func NewTemplateStore(db *sql.DB) *TemplateStore {
+	return &TemplateStore{db: db}
+}
+
+func (s *TemplateStore) GetTemplate(ctx context.Context, key templates.Key) (*templates.Template, error) {
+	query := `
+		SELECT subject, body, updated_at
+		FROM notification_templates
+		WHERE tenant_id = $1 AND event_type = $2 AND channel = $3 AND locale = $4
+	`
+	t := templates.Template{Key: key}
+	err := s.db.QueryRowContext(ctx, query, key.TenantID, key.EventType, string(key.Channel), key.Locale).
+		Scan(&t.Subject, &t.Body, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, templates.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("db: template fetch failed: %w", err)
+	}
+	return &t, nil
+}
+
+// SaveTemplate upserts on the (tenant, event, channel, locale) key.
+func (s *TemplateStore) SaveTemplate(ctx context.Context, t templates.Template) error {
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = time.Now().UTC()
+	}
+	query := `
+		INSERT INTO notification_templates (tenant_id, event_type, channel, locale, subject, body, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, event_type, channel, locale)
+		DO UPDATE SET subject = EXCLUDED.subject, body = EXCLUDED.body, updated_at = EXCLUDED.updated_at
+	`
+	_, err := s.db.ExecContext(ctx, query, t.TenantID, t.EventType, string(t.Channel), t.Locale, t.Subject, t.Body, t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("db: template save failed: %w", err)
+	}
+	return nil
+}
+
+func (s *TemplateStore) DeleteTemplate(ctx context.Context, key templates.Key) error {
+	query := `
+		DELETE FROM notification_templates
+		WHERE tenant_id = $1 AND event_type = $2 AND channel = $3 AND locale = $4
+	`
+	res, err := s.db.ExecContext(ctx, query, key.TenantID, key.EventType, string(key.Channel), key.Locale)
+	if err != nil {
+		return fmt.Errorf("db: template delete failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return templates.ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (s *TemplateStore) ListTemplates(ctx context.Context, tenantID string) ([]templates.Template, error) {
+	query := `
+		SELECT event_type, channel, locale, subject, body, updated_at
+		FROM notification_templates
+		WHERE tenant_id = $1
+		ORDER BY event_type, channel, locale
+	`
+	rows, err := s.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("db: template list failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []templates.Template
+	for rows.Next() {
+		t := templates.Template{Key: templates.Key{TenantID: tenantID}}
+		var channel string
+		if err := rows.Scan(&t.EventType, &channel, &t.Locale, &t.Subject, &t.Body, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("db: template scan failed: %w", err)
+		}
+		t.Channel = templates.Channel(channel)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,265 @@
+// services/communications-service/internal/store/postgres/webhook_store.postgres.go
+package PostgresStore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/webhooks"
+	"github.com/lib/pq"
+)
+
+// WebhookStore implements webhooks.EndpointStore and webhooks.DeliveryStore.
+type WebhookStore struct {
+	db *sql.DB
+}
+
+func NewWebhookStore(db *sql.DB) *WebhookStore {
+	return &WebhookStore{db: db}
+}
+
+const endpointColumns = `id, tenant_id, url, secret, events, enabled, consecutive_failures, disabled_reason, created_at, updated_at`
+
+func scanEndpoint(row interface{ Scan(...interface{}) error }) (*webhooks.Endpoint, error) {
+	var e webhooks.Endpoint
+	err := row.Scan(&e.ID, &e.TenantID, &e.URL, &e.Secret, pq.Array(&e.Events), &e.Enabled,
+		&e.ConsecutiveFailures, &e.DisabledReason, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (s *WebhookStore) CreateEndpoint(ctx context.Context, e webhooks.Endpoint) error {
+	query := `
+		INSERT INTO webhook_endpoints (id, tenant_id, url, secret, events, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	`
+	_, err := s.db.ExecContext(ctx, query, e.ID, e.TenantID, e.URL, e.Secret, pq.Array(nonNil(e.Events)), e.Enabled, e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("db: webhook endpoint insert failed: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookStore) GetEndpoint(ctx context.Context, tenantID, id string) (*webhooks.Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoints WHERE tenant_id = $1 AND id::text = $2`
+	e, err := scanEndpoint(s.db.QueryRowContext(ctx, query, tenantID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhooks.ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("db: webhook endpoint fetch failed: %w", err)
+	}
+	return e, nil
+}
+
+func (s *WebhookStore) ListEndpoints(ctx context.Context, tenantID string) ([]webhooks.Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoints WHERE tenant_id = $1 ORDER BY created_at`
+	rows, err := s.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("db: webhook endpoint list failed: %w", err)
+	}
+	defer rows.Close()
+	var out []webhooks.Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: webhook endpoint scan failed: %w", err)
+		}
+		out = append(out, *e)
+	}
+	return out, rows.Err()
+}
+
+func (s *WebhookStore) UpdateEndpoint(ctx context.Context, e webhooks.Endpoint) error {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $3, events = $4, enabled = $5, consecutive_failures = $6, disabled_reason = $7, updated_at = NOW()
+		WHERE tenant_id = $1 AND id::text = $2
+	`
+	res, err := s.db.ExecContext(ctx, query, e.TenantID, e.ID, e.URL, pq.Array(nonNil(e.Events)), e.Enabled, e.ConsecutiveFailures, e.DisabledReason)
+	if err != nil {
+		return fmt.Errorf("db: webhook endpoint update failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return webhooks.ErrEndpointNotFound
+	}
+	return nil
+}
+
+func (s *WebhookStore) DeleteEndpoint(ctx context.Context, tenantID, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE tenant_id = $1 AND id::text = $2`, tenantID, id)
+	if err != nil {
+		return fmt.Errorf("db: webhook endpoint delete failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return webhooks.ErrEndpointNotFound
+	}
+	return nil
+}
+
+const deliveryColumns = `id, endpoint_id, tenant_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, updated_at`
+
+func scanDelivery(row interface{ Scan(...interface{}) error }) (*webhooks.Delivery, error) {
+	var d webhooks.Delivery
+	var payload []byte
+	err := row.Scan(&d.ID, &d.EndpointID, &d.TenantID, &d.EventID, &d.EventType, &payload, &d.Status,
+		&d.Attempts, &d.NextAttemptAt, &d.LastError, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+func (s *WebhookStore) CreateDelivery(ctx context.Context, d webhooks.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, endpoint_id, tenant_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		ON CONFLICT (endpoint_id, event_type, event_id) DO NOTHING
+	`
+	// JSONB params go over as text; lib/pq would send []byte as bytea
+	_, err := s.db.ExecContext(ctx, query, d.ID, d.EndpointID, d.TenantID, d.EventID, d.EventType, string(d.Payload),
+		string(d.Status), d.Attempts, d.NextAttemptAt, d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("db: webhook delivery insert failed: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookStore) GetDelivery(ctx context.Context, tenantID, id string) (*webhooks.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE tenant_id = $1 AND id::text = $2`
+	d, err := scanDelivery(s.db.QueryRowContext(ctx, query, tenantID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhooks.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("db: webhook delivery fetch failed: %w", err)
+	}
+	return d, nil
+}
+
+func (s *WebhookStore) ListDeliveries(ctx context.Context, tenantID, endpointID string, limit int) ([]webhooks.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE tenant_id = $1 AND ($2 = '' OR endpoint_id::text = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+	return s.queryDeliveries(ctx, query, tenantID, endpointID, limit)
+}
+
+// ClaimDue leases due rows with SKIP LOCKED so several replicas can run the
+// dispatcher without sending the same delivery twice.
+func (s *WebhookStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhooks.Delivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries w
+		SET next_attempt_at = $2
+		FROM due
+		WHERE w.id = due.id
+		RETURNING w.id, w.endpoint_id, w.tenant_id, w.event_id, w.event_type, w.payload, w.status,
+		          w.attempts, w.next_attempt_at, w.last_error, w.created_at, w.updated_at
+	`
+	return s.queryDeliveries(ctx, query, now, now.Add(lease), limit)
+}
+
+func (s *WebhookStore) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]webhooks.Delivery, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: webhook delivery query failed: %w", err)
+	}
+	defer rows.Close()
+	var out []webhooks.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: webhook delivery scan failed: %w", err)
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
+
+func (s *WebhookStore) RecordAttempt(ctx context.Context, d webhooks.Delivery, a webhooks.Attempt) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db: begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_attempts (delivery_id, attempt, status_code, error, response_body, duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, d.ID, a.Number, a.StatusCode, a.Error, a.ResponseBody, a.Duration.Milliseconds(), a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("db: webhook attempt insert failed: %w", err)
+	}
+	if err := updateDelivery(ctx, tx, d); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *WebhookStore) UpdateDelivery(ctx context.Context, d webhooks.Delivery) error {
+	return updateDelivery(ctx, s.db, d)
+}
+
+func updateDelivery(ctx context.Context, db interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+}, d webhooks.Delivery) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, updated_at = $6
+		WHERE id::text = $1
+	`, d.ID, string(d.Status), d.Attempts, d.NextAttemptAt, d.LastError, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("db: webhook delivery update failed: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return webhooks.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+func (s *WebhookStore) ListAttempts(ctx context.Context, deliveryID string) ([]webhooks.Attempt, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, attempt, status_code, error, response_body, duration_ms, created_at
+		FROM webhook_attempts
+		WHERE delivery_id::text = $1
+		ORDER BY attempt, id
+	`, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("db: webhook attempt list failed: %w", err)
+	}
+	defer rows.Close()
+	var out []webhooks.Attempt
+	for rows.Next() {
+		a := webhooks.Attempt{DeliveryID: deliveryID}
+		var ms int64
+		if err := rows.Scan(&a.ID, &a.Number, &a.StatusCode, &a.Error, &a.ResponseBody, &ms, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("db: webhook attempt scan failed: %w", err)
+		}
+		a.Duration = time.Duration(ms) * time.Millisecond
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+// services/communications-service/internal/templates/files.templates.go
+
+package templates
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// Platform default templates, shipped inside the binary so a fresh deploy can
+// send notifications before anyone has touched the database.
+//
+// Layout: defaults/<event>/<channel>/<locale>/subject.tmpl|body.tmpl
+// (SMS templates have no subject.tmpl).
+//
+//go:embed defaults
+var defaultFiles embed.FS
+
+// FileStore reads templates from a directory tree. It ignores TenantID:
+// files are platform defaults, tenant overrides live in an OverrideStore.
+type FileStore struct {
+	fsys fs.FS
+}
+
+// NewFileStore reads templates from fsys using the layout above.
+func NewFileStore(fsys fs.FS) *FileStore {
+	return &FileStore{fsys: fsys}
+}
+
+// NewDefaultFileStore returns the templates embedded in the binary.
+func NewDefaultFileStore() *FileStore {
+	sub, err := fs.Sub(defaultFiles, "defaults")
+	if err != nil {
+		// Only fails if the embed directive above is broken
+		panic(err)
+	}
+	return NewFileStore(sub)
+}
+
+func (s *FileStore) GetTemplate(ctx context.Context, key Key) (*Template, error) {
+	dir := path.Join(key.EventType, string(key.Channel), key.Locale)
+	if !fs.ValidPath(dir) {
+		return nil, ErrTemplateNotFound
+	}
+
+	body, err := fs.ReadFile(s.fsys, path.Join(dir, "body.tmpl"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", dir, err)
+	}
+	t := &Template{
+		Key:  Key{EventType: key.EventType, Channel: key.Channel, Locale: key.Locale},
+		Body: string(body),
+	}
+
+	subject, err := fs.ReadFile(s.fsys, path.Join(dir, "subject.tmpl"))
+	switch {
+	case err == nil:
+		t.Subject = string(subject)
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to read template %s: %w", dir, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,194 @@
+// services/communications-service/internal/templates/http.templates.go
+
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+)
+
+// Handler exposes template management over HTTP. It goes behind
+// caller.Authenticate; {tenant} must be the caller's tenant, and previews
+// render the caller's overrides.
+//
+//	POST   /v1/templates/preview                                  render a draft or stored template
+//	GET    /v1/tenants/{tenant}/templates                         list a tenant's overrides
+//	PUT    /v1/tenants/{tenant}/templates/{event}/{channel}/{locale}  create/replace an override
+//	DELETE /v1/tenants/{tenant}/templates/{event}/{channel}/{locale}  fall back to the default again
+type Handler struct {
+	renderer  *Renderer
+	overrides OverrideStore
+}
+
+func NewHandler(renderer *Renderer, overrides OverrideStore) *Handler {
+	return &Handler{renderer: renderer, overrides: overrides}
+}
+
+// Register mounts the routes on mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/templates/preview", h.preview)
+	mux.HandleFunc("GET /v1/tenants/{tenant}/templates", h.list)
+	mux.HandleFunc("PUT /v1/tenants/{tenant}/templates/{event}/{channel}/{locale}", h.put)
+	mux.HandleFunc("DELETE /v1/tenants/{tenant}/templates/{event}/{channel}/{locale}", h.delete)
+}
+
+type templateJSON struct {
+	TenantID  string    `json:"tenant_id,omitempty"`
+	EventType string    `json:"event"`
+	Channel   Channel   `json:"channel"`
+	Locale    string    `json:"locale"`
+	Subject   string    `json:"subject,omitempty"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+type previewRequest struct {
+	templateJSON
+	// Data is the event payload to render against; the event's sample is used when omitted.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+type renderedJSON struct {
+	Subject string       `json:"subject,omitempty"`
+	Body    string       `json:"body"`
+	HTML    bool         `json:"html"`
+	Source  templateJSON `json:"source"`
+}
+
+func (h *Handler) preview(w http.ResponseWriter, r *http.Request) {
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	tenantID, err := caller.Tenant(r.Context(), req.TenantID)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	t := fromJSON(req.templateJSON)
+	t.TenantID = tenantID
+	out, err := h.renderer.Preview(r.Context(), t, req.Data)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderedJSON{
+		Subject: out.Subject,
+		Body:    out.Body,
+		HTML:    out.HTML,
+		Source:  toJSON(Template{Key: out.Source}),
+	})
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.overrides.ListTemplates(r.Context(), tenantID)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	out := make([]templateJSON, 0, len(list))
+	for _, t := range list {
+		out = append(out, toJSON(t))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) put(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	var req templateJSON
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	// The path is authoritative; body fields for the key are ignored
+	t := Template{
+		Key:       pathKey(r, tenantID),
+		Subject:   req.Subject,
+		Body:      req.Body,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := h.renderer.SaveOverride(r.Context(), t); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toJSON(t))
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	if err := h.overrides.DeleteTemplate(r.Context(), pathKey(r, tenantID)); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func pathKey(r *http.Request, tenantID string) Key {
+	return Key{
+		TenantID:  tenantID,
+		EventType: r.PathValue("event"),
+		Channel:   Channel(r.PathValue("channel")),
+		Locale:    r.PathValue("locale"),
+	}
+}
+
+func fromJSON(t templateJSON) Template {
+	return Template{
+		Key:     Key{TenantID: t.TenantID, EventType: t.EventType, Channel: t.Channel, Locale: t.Locale},
+		Subject: t.Subject,
+		Body:    t.Body,
+	}
+}
+
+func toJSON(t Template) templateJSON {
+	return templateJSON{
+		TenantID:  t.TenantID,
+		EventType: t.EventType,
+		Channel:   t.Channel,
+		Locale:    t.Locale,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// writeTemplateError maps package errors to status codes. Anything unexpected is a 500.
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrUnknownEvent), errors.Is(err, ErrUnknownChannel), errors.Is(err, ErrInvalidTemplate):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		log.Printf("templates: request failed: %v", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("templates: failed to write response: %v", err)
+	}
+}
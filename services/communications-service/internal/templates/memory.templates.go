@@ -0,0 +1,73 @@
+// services/communications-service/internal/templates/memory.templates.go
+
+package templates
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process OverrideStore, used when no database is
+// configured (local dev) and in tests. Overrides are lost on restart.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	templates map[Key]Template
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{templates: make(map[Key]Template)}
+}
+
+func (s *MemoryStore) GetTemplate(ctx context.Context, key Key) (*Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.templates[key]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return &t, nil
+}
+
+func (s *MemoryStore) SaveTemplate(ctx context.Context, t Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = time.Now().UTC()
+	}
+	s.templates[t.Key] = t
+	return nil
+}
+
+func (s *MemoryStore) DeleteTemplate(ctx context.Context, key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.templates[key]; !ok {
+		return ErrTemplateNotFound
+	}
+	delete(s.templates, key)
+	return nil
+}
+
+func (s *MemoryStore) ListTemplates(ctx context.Context, tenantID string) ([]Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Template
+	for key, t := range s.templates {
+		if key.TenantID == tenantID {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].Key, out[j].Key
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		return a.Locale < b.Locale
+	})
+	return out, nil
+}
@@ -0,0 +1,58 @@
+// services/communications-service/internal/templates/models.templates.go
+
+package templates
+
+import (
+	"errors"
+	"time"
+)
+
+// Channel is how a notification reaches the recipient.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email" // subject + HTML body
+	ChannelSMS   Channel = "sms"   // plain text body only
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrUnknownEvent     = errors.New("unknown event type")
+	ErrUnknownChannel   = errors.New("unknown channel")
+	// ErrInvalidTemplate wraps parse errors and variables that are not in the event schema.
+	ErrInvalidTemplate = errors.New("invalid template")
+	// ErrInvalidPayload means the event JSON does not decode into the event schema.
+	ErrInvalidPayload = errors.New("invalid event payload")
+)
+
+// Key identifies one template. An empty TenantID means the platform default.
+type Key struct {
+	TenantID  string
+	EventType string // e.g. "shipment.created"
+	Channel   Channel
+	Locale    string // BCP 47, e.g. "en", "pt-BR"
+}
+
+// Template is the raw source of a notification template.
+// Subject is a text/template; Body is an html/template for email and a
+// text/template for SMS. Both are executed against the event payload.
+type Template struct {
+	Key
+	Subject   string // empty for SMS
+	Body      string
+	UpdatedAt time.Time
+}
+
+// Rendered is a template executed against one event.
+type Rendered struct {
+	Subject string
+	Body    string
+	HTML    bool // true when Body is HTML (email)
+	// Which template was used, after tenant and locale fallback.
+	Source Key
+}
+
+// IsValid reports whether the channel is one we can deliver on.
+func (c Channel) IsValid() bool {
+	return c == ChannelEmail || c == ChannelSMS
+}
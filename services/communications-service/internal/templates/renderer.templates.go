@@ -0,0 +1,240 @@
+// services/communications-service/internal/templates/renderer.templates.go
+
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// funcs are available to every template. Keep this list small: each function is
+// something tenants can depend on in their overrides.
+var funcs = map[string]interface{}{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// date formats a time with a Go layout: {{date .Window.Start "Mon 2 Jan 15:04"}}
+	"date": func(t time.Time, layout string) string { return t.Format(layout) },
+}
+
+// Renderer picks the right template for a notification and executes it.
+//
+// Lookup order for tenant T, locale "pt-BR":
+//
+//	T/pt-BR -> T/pt -> T/<default locale> -> default/pt-BR -> default/pt -> default/<default locale>
+//
+// so a tenant override in any language wins over a platform default.
+type Renderer struct {
+	defaults      TemplateStore
+	overrides     OverrideStore // optional; nil disables tenant overrides
+	defaultLocale string
+}
+
+// NewRenderer creates a renderer. overrides may be nil.
+func NewRenderer(defaults TemplateStore, overrides OverrideStore, defaultLocale string) *Renderer {
+	if defaultLocale == "" {
+		defaultLocale = "en"
+	}
+	return &Renderer{defaults: defaults, overrides: overrides, defaultLocale: defaultLocale}
+}
+
+// Render executes the template for key against a raw event payload (the JSON
+// the producer published). key.Locale may be empty.
+func (r *Renderer) Render(ctx context.Context, key Key, payload []byte) (Rendered, error) {
+	tmpl, err := r.Resolve(ctx, key)
+	if err != nil {
+		return Rendered{}, err
+	}
+	data, err := decodePayload(key.EventType, payload)
+	if err != nil {
+		return Rendered{}, err
+	}
+	return execute(*tmpl, data)
+}
+
+// Preview renders a template that has not been saved yet (or the stored one when
+// t.Body is empty) against data, or against the event's sample payload when data is nil.
+func (r *Renderer) Preview(ctx context.Context, t Template, data []byte) (Rendered, error) {
+	if t.Body == "" {
+		stored, err := r.Resolve(ctx, t.Key)
+		if err != nil {
+			return Rendered{}, err
+		}
+		t = *stored
+	} else if err := Validate(t); err != nil {
+		return Rendered{}, err
+	}
+
+	schema, ok := Events[t.EventType]
+	if !ok {
+		return Rendered{}, fmt.Errorf("%w: %q", ErrUnknownEvent, t.EventType)
+	}
+	var value interface{} = schema.Sample
+	if len(data) > 0 {
+		decoded, err := decodePayload(t.EventType, data)
+		if err != nil {
+			return Rendered{}, err
+		}
+		value = decoded
+	}
+	return execute(t, value)
+}
+
+// SaveOverride validates and stores a tenant override.
+func (r *Renderer) SaveOverride(ctx context.Context, t Template) error {
+	if r.overrides == nil {
+		return errors.New("tenant template overrides are not enabled")
+	}
+	if t.TenantID == "" {
+		return errors.New("tenant_id is required for an override")
+	}
+	if err := Validate(t); err != nil {
+		return err
+	}
+	return r.overrides.SaveTemplate(ctx, t)
+}
+
+// Resolve returns the template that would be used for key, applying tenant and locale fallback.
+func (r *Renderer) Resolve(ctx context.Context, key Key) (*Template, error) {
+	if _, ok := Events[key.EventType]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, key.EventType)
+	}
+	if !key.Channel.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownChannel, key.Channel)
+	}
+	locales := r.localeChain(key.Locale)
+
+	if key.TenantID != "" && r.overrides != nil {
+		for _, locale := range locales {
+			t, err := r.overrides.GetTemplate(ctx, Key{TenantID: key.TenantID, EventType: key.EventType, Channel: key.Channel, Locale: locale})
+			if err == nil {
+				return t, nil
+			}
+			if !errors.Is(err, ErrTemplateNotFound) {
+				return nil, err
+			}
+		}
+	}
+	for _, locale := range locales {
+		t, err := r.defaults.GetTemplate(ctx, Key{EventType: key.EventType, Channel: key.Channel, Locale: locale})
+		if err == nil {
+			return t, nil
+		}
+		if !errors.Is(err, ErrTemplateNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s/%s/%s", ErrTemplateNotFound, key.EventType, key.Channel, key.Locale)
+}
+
+// localeChain returns "pt-BR" -> [pt-BR pt en] without duplicates.
+func (r *Renderer) localeChain(locale string) []string {
+	var chain []string
+	add := func(l string) {
+		for _, existing := range chain {
+			if strings.EqualFold(existing, l) {
+				return
+			}
+		}
+		chain = append(chain, l)
+	}
+	if locale != "" {
+		add(locale)
+		if i := strings.IndexAny(locale, "-_"); i > 0 {
+			add(locale[:i])
+		}
+	}
+	add(r.defaultLocale)
+	return chain
+}
+
+// Validate parses the template and checks every variable it uses against the
+// event schema. Called before saving overrides and before previewing drafts.
+func Validate(t Template) error {
+	schema, ok := Events[t.EventType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownEvent, t.EventType)
+	}
+	if !t.Channel.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownChannel, t.Channel)
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidTemplate)
+	}
+	if t.Channel == ChannelEmail && strings.TrimSpace(t.Subject) == "" {
+		return fmt.Errorf("%w: subject is required for email", ErrInvalidTemplate)
+	}
+	for name, src := range map[string]string{"subject": t.Subject, "body": t.Body} {
+		if src == "" {
+			continue
+		}
+		parsed, err := texttemplate.New(name).Funcs(funcs).Parse(src)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if err := checkFields(parsed.Tree, schema.Type); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// execute renders subject (text) and body (HTML for email, text for SMS).
+// missingkey=error turns a typo that slipped past validation into an error, not "<no value>".
+func execute(t Template, data interface{}) (Rendered, error) {
+	out := Rendered{Source: t.Key, HTML: t.Channel == ChannelEmail}
+
+	if t.Subject != "" {
+		subject, err := texttemplate.New("subject").Funcs(funcs).Option("missingkey=error").Parse(t.Subject)
+		if err != nil {
+			return Rendered{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		var buf bytes.Buffer
+		if err := subject.Execute(&buf, data); err != nil {
+			return Rendered{}, fmt.Errorf("failed to render subject: %w", err)
+		}
+		// Subjects are a single header line
+		out.Subject = strings.Join(strings.Fields(buf.String()), " ")
+	}
+
+	var buf bytes.Buffer
+	if out.HTML {
+		body, err := htmltemplate.New("body").Funcs(funcs).Option("missingkey=error").Parse(t.Body)
+		if err != nil {
+			return Rendered{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if err := body.Execute(&buf, data); err != nil {
+			return Rendered{}, fmt.Errorf("failed to render body: %w", err)
+		}
+	} else {
+		body, err := texttemplate.New("body").Funcs(funcs).Option("missingkey=error").Parse(t.Body)
+		if err != nil {
+			return Rendered{}, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if err := body.Execute(&buf, data); err != nil {
+			return Rendered{}, fmt.Errorf("failed to render body: %w", err)
+		}
+	}
+	out.Body = strings.TrimSpace(buf.String())
+	return out, nil
+}
+
+// decodePayload turns the event JSON into the schema type so templates get typed fields.
+func decodePayload(eventType string, payload []byte) (interface{}, error) {
+	schema, ok := Events[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, eventType)
+	}
+	value := reflect.New(schema.Type)
+	if err := json.Unmarshal(payload, value.Interface()); err != nil {
+		return nil, fmt.Errorf("%w: does not match %s schema: %v", ErrInvalidPayload, eventType, err)
+	}
+	return value.Elem().Interface(), nil
+}
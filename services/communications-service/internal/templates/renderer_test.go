@@ -0,0 +1,213 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+func newTestRenderer() (*Renderer, *MemoryStore) {
+	overrides := NewMemoryStore()
+	return NewRenderer(NewDefaultFileStore(), overrides, "en"), overrides
+}
+
+func shipmentPayload(t *testing.T, s contracts.Shipment) []byte {
+	t.Helper()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDefaultTemplatesAreValid(t *testing.T) {
+	store := NewDefaultFileStore()
+	for event := range Events {
+		for _, channel := range []Channel{ChannelEmail, ChannelSMS} {
+			tmpl, err := store.GetTemplate(context.Background(), Key{EventType: event, Channel: channel, Locale: "en"})
+			if err != nil {
+				t.Fatalf("%s/%s: %v", event, channel, err)
+			}
+			if err := Validate(*tmpl); err != nil {
+				t.Errorf("%s/%s: %v", event, channel, err)
+			}
+		}
+	}
+}
+
+func TestRenderFallsBackToDefaultLocale(t *testing.T) {
+	r, _ := newTestRenderer()
+	s := Events["shipment.created"].Sample.(contracts.Shipment)
+
+	out, err := r.Render(context.Background(), Key{TenantID: "tenant-1", EventType: "shipment.created", Channel: ChannelEmail, Locale: "pt-BR"}, shipmentPayload(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Source.Locale != "en" || out.Source.TenantID != "" {
+		t.Errorf("expected the platform en template, got %+v", out.Source)
+	}
+	if out.Subject != "Your DHL shipment to Berlin is booked" {
+		t.Errorf("subject = %q", out.Subject)
+	}
+	if !out.HTML || !strings.Contains(out.Body, "1Z999") {
+		t.Errorf("body = %q", out.Body)
+	}
+}
+
+func TestTenantOverrideWinsOverDefault(t *testing.T) {
+	r, overrides := newTestRenderer()
+	ctx := context.Background()
+	override := Template{
+		Key:  Key{TenantID: "tenant-1", EventType: "shipment.created", Channel: ChannelSMS, Locale: "pt"},
+		Body: "Seu envio para {{.Destination}} foi reservado",
+	}
+	if err := r.SaveOverride(ctx, override); err != nil {
+		t.Fatal(err)
+	}
+	payload := shipmentPayload(t, contracts.Shipment{Destination: "Lisboa"})
+
+	// pt-BR falls back to the tenant's pt template before any default
+	out, err := r.Render(ctx, Key{TenantID: "tenant-1", EventType: "shipment.created", Channel: ChannelSMS, Locale: "pt-BR"}, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Body != "Seu envio para Lisboa foi reservado" {
+		t.Errorf("body = %q", out.Body)
+	}
+
+	// Other tenants still get the default
+	out, err = r.Render(ctx, Key{TenantID: "tenant-2", EventType: "shipment.created", Channel: ChannelSMS, Locale: "pt-BR"}, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Source.TenantID != "" {
+		t.Errorf("tenant-2 should not see tenant-1 override, got %+v", out.Source)
+	}
+
+	if err := overrides.DeleteTemplate(ctx, override.Key); err != nil {
+		t.Fatal(err)
+	}
+	out, err = r.Render(ctx, Key{TenantID: "tenant-1", EventType: "shipment.created", Channel: ChannelSMS, Locale: "pt"}, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Source.TenantID != "" {
+		t.Errorf("deleted override still used: %+v", out.Source)
+	}
+}
+
+func TestValidateRejectsUnknownFields(t *testing.T) {
+	cases := map[string]string{
+		"top level":    "{{.TrackingNo}}",
+		"nested":       "{{.Carrier.Phone}}",
+		"inside with":  "{{with .ToAddress}}{{.Postcode}}{{end}}",
+		"inside if":    "{{if .Eta}}{{.Delivered}}{{end}}",
+		"root in with": "{{with .Carrier}}{{$.Nope}}{{end}}",
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(Template{Key: Key{EventType: "shipment.created", Channel: ChannelSMS}, Body: body})
+			if !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("expected ErrInvalidTemplate, got %v", err)
+			}
+		})
+	}
+
+	ok := "{{with .ToAddress}}{{.City}}{{end}} {{range .Customs.Items}}{{.Description}}{{end}} {{.Status.String}} {{$.Origin}}"
+	if err := Validate(Template{Key: Key{EventType: "shipment.created", Channel: ChannelSMS}, Body: ok}); err != nil {
+		t.Errorf("valid template rejected: %v", err)
+	}
+	if err := Validate(Template{Key: Key{EventType: "shipment.created", Channel: ChannelEmail}, Body: "hi"}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("email without subject should be rejected, got %v", err)
+	}
+	if err := Validate(Template{Key: Key{EventType: "shipment.deleted", Channel: ChannelSMS}, Body: "hi"}); !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("expected ErrUnknownEvent, got %v", err)
+	}
+}
+
+func TestEmailBodyIsEscaped(t *testing.T) {
+	r, _ := newTestRenderer()
+	out, err := r.Preview(context.Background(), Template{
+		Key:     Key{EventType: "shipment.created", Channel: ChannelEmail},
+		Subject: "{{.Destination}}",
+		Body:    "<p>{{.Destination}}</p>",
+	}, shipmentPayload(t, contracts.Shipment{Destination: "<script>x</script>"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.Body, "<script>") {
+		t.Errorf("body not escaped: %q", out.Body)
+	}
+	// Subjects are plain text headers, not HTML
+	if out.Subject != "<script>x</script>" {
+		t.Errorf("subject = %q", out.Subject)
+	}
+}
+
+func TestPreviewHandlerUsesSampleData(t *testing.T) {
+	r, overrides := newTestRenderer()
+	mux := http.NewServeMux()
+	NewHandler(r, overrides).Register(mux)
+	ctx := identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: "tenant-1", Role: "admin"})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/templates/preview",
+		strings.NewReader(`{"event":"pickup.reminder","channel":"sms","locale":"en"}`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var out renderedJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.Body, "CONF-1") {
+		t.Errorf("preview did not use sample pickup: %q", out.Body)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/v1/tenants/tenant-1/templates/pickup.reminder/sms/en",
+		strings.NewReader(`{"body":"{{.Window.Begin}}"}`)).WithContext(ctx)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("invalid override: status = %d, want 422", rec.Code)
+	}
+}
+
+func TestTemplateHandlerIsScopedToCaller(t *testing.T) {
+	r, overrides := newTestRenderer()
+	mux := http.NewServeMux()
+	NewHandler(r, overrides).Register(mux)
+	do := func(tenantID, method, path, body string) *httptest.ResponseRecorder {
+		ctx := identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenantID, Role: "admin"})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)).WithContext(ctx))
+		return rec
+	}
+
+	if rec := do("tenant-1", http.MethodPut, "/v1/tenants/tenant-1/templates/pickup.reminder/sms/en", `{"body":"Acme pickup {{.ConfirmationNumber}}"}`); rec.Code != http.StatusOK {
+		t.Fatalf("own override: %d %s", rec.Code, rec.Body)
+	}
+	for _, req := range [][3]string{
+		{http.MethodGet, "/v1/tenants/tenant-1/templates", ""},
+		{http.MethodPut, "/v1/tenants/tenant-1/templates/pickup.reminder/sms/en", `{"body":"phish"}`},
+		{http.MethodDelete, "/v1/tenants/tenant-1/templates/pickup.reminder/sms/en", ""},
+		{http.MethodPost, "/v1/templates/preview", `{"tenant_id":"tenant-1","event":"pickup.reminder","channel":"sms","locale":"en"}`},
+	} {
+		if rec := do("tenant-2", req[0], req[1], req[2]); rec.Code != http.StatusForbidden {
+			t.Errorf("tenant-2 %s %s: %d", req[0], req[1], rec.Code)
+		}
+	}
+	// tenant-2's preview renders its own (default) template, not tenant-1's
+	rec := do("tenant-2", http.MethodPost, "/v1/templates/preview", `{"event":"pickup.reminder","channel":"sms","locale":"en"}`)
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "Acme pickup") {
+		t.Errorf("tenant-2 preview: %d %s", rec.Code, rec.Body)
+	}
+}
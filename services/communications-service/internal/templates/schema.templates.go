@@ -0,0 +1,179 @@
+// services/communications-service/internal/templates/schema.templates.go
+
+package templates
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template/parse"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+// EventSchema describes the payload a template is executed against.
+// Payloads are decoded into Type before rendering, so templates see typed
+// fields ({{.Window.Start.Format "Mon 15:04"}} works) and a template that
+// references a field the event does not have is rejected when it is saved,
+// not when the first customer should have been notified.
+type EventSchema struct {
+	Type   reflect.Type
+	Sample interface{} // used for previews when no data is supplied
+}
+
+// Events lists every event that can be rendered. Keep in sync with the events
+// the bridge forwards from Kafka.
+var Events = map[string]EventSchema{
+	"shipment.created": {
+		Type: reflect.TypeOf(contracts.Shipment{}),
+		Sample: contracts.Shipment{
+			ID:             "b3c1f6a2-0000-4000-8000-000000000001",
+			Origin:         "Dhaka",
+			Destination:    "Berlin",
+			Eta:            "2025-01-10",
+			Status:         proto.ShipmentStatus_PRE_TRANSIT,
+			Carrier:        contracts.Carrier{Name: "DHL", TrackingURL: "https://track.example/1Z999"},
+			TrackingNumber: "1Z999",
+			ToAddress:      contracts.Address{Name: "Jana Richter", City: "Berlin", Country: "DE"},
+		},
+	},
+	"pickup.reminder": {
+		Type: reflect.TypeOf(contracts.Pickup{}),
+		Sample: contracts.Pickup{
+			ID:                 "pickup-1",
+			Carrier:            "UPS",
+			Address:            contracts.PickupAddress{Name: "Dock 3", Street1: "1 Main St", City: "Dhaka", Zip: "1207", Country: "BD"},
+			Window:             contracts.PickupWindow{Start: time.Date(2025, 1, 6, 11, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 6, 14, 0, 0, 0, time.UTC)},
+			ShipmentIDs:        []string{"shipment-1", "shipment-2"},
+			ConfirmationNumber: "CONF-1",
+			Instructions:       "Ring bell at loading dock 3",
+			Status:             contracts.PickupScheduled,
+		},
+	},
+}
+
+// checkFields walks a parsed template and verifies that every field chain
+// starting at dot ({{.A.B}}) exists on the schema type. Methods are allowed
+// (their first return value becomes the new type). Anything the checker cannot
+// type (variables, function results) is accepted and left to execution.
+func checkFields(tree *parse.Tree, root reflect.Type) error {
+	var problems []string
+	walk(tree.Root, root, root, &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidTemplate, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func walk(node parse.Node, dot, root reflect.Type, problems *[]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walk(child, dot, root, problems)
+		}
+	case *parse.ActionNode:
+		pipeType(n.Pipe, dot, root, problems)
+	case *parse.IfNode:
+		pipeType(n.Pipe, dot, root, problems)
+		walk(n.List, dot, root, problems)
+		walk(n.ElseList, dot, root, problems)
+	case *parse.WithNode:
+		inner := pipeType(n.Pipe, dot, root, problems)
+		walk(n.List, inner, root, problems)
+		walk(n.ElseList, dot, root, problems)
+	case *parse.RangeNode:
+		var elem reflect.Type
+		if t := deref(pipeType(n.Pipe, dot, root, problems)); t != nil {
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				elem = t.Elem()
+			}
+		}
+		walk(n.List, elem, root, problems)
+		walk(n.ElseList, dot, root, problems)
+	case *parse.TemplateNode:
+		pipeType(n.Pipe, dot, root, problems)
+	}
+}
+
+// pipeType checks every field reference in the pipeline and returns the pipeline's
+// type when it is a plain field chain, or nil when it cannot tell.
+func pipeType(pipe *parse.PipeNode, dot, root reflect.Type, problems *[]string) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var result reflect.Type
+	for i, cmd := range pipe.Cmds {
+		for j, arg := range cmd.Args {
+			var t reflect.Type
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				t = resolve(dot, a.Ident, a.String(), problems)
+			case *parse.VariableNode:
+				if a.Ident[0] == "$" && len(a.Ident) > 1 {
+					t = resolve(root, a.Ident[1:], a.String(), problems)
+				}
+			case *parse.PipeNode:
+				t = pipeType(a, dot, root, problems)
+			}
+			if i == 0 && j == 0 && len(cmd.Args) == 1 && len(pipe.Cmds) == 1 {
+				result = t
+			}
+		}
+	}
+	return result
+}
+
+// resolve follows a field chain from t. A nil t means "unknown", which is accepted.
+func resolve(t reflect.Type, idents []string, expr string, problems *[]string) reflect.Type {
+	for _, ident := range idents {
+		if t == nil {
+			return nil
+		}
+		if m, ok := t.MethodByName(ident); ok {
+			t = firstOut(m.Type)
+			continue
+		}
+		if m, ok := reflect.PointerTo(t).MethodByName(ident); ok {
+			t = firstOut(m.Type)
+			continue
+		}
+		base := deref(t)
+		switch base.Kind() {
+		case reflect.Struct:
+			f, ok := base.FieldByName(ident)
+			if !ok || !f.IsExported() {
+				*problems = append(*problems, fmt.Sprintf("%s: %s has no field %s", expr, base.Name(), ident))
+				return nil
+			}
+			t = f.Type
+		case reflect.Map:
+			t = base.Elem()
+		case reflect.Interface:
+			return nil
+		default:
+			*problems = append(*problems, fmt.Sprintf("%s: cannot access %s on %s", expr, ident, base))
+			return nil
+		}
+	}
+	return t
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func firstOut(fn reflect.Type) reflect.Type {
+	if fn.NumOut() == 0 {
+		return nil
+	}
+	return fn.Out(0)
+}
@@ -0,0 +1,20 @@
+// services/communications-service/internal/templates/template_store.go
+
+package templates
+
+import "context"
+
+// TemplateStore looks up a single template by exact key (no fallback).
+// Returns ErrTemplateNotFound when there is none.
+type TemplateStore interface {
+	GetTemplate(ctx context.Context, key Key) (*Template, error)
+}
+
+// OverrideStore holds tenant-specific templates that replace the defaults.
+// Placed in the templates package to avoid import cycles between store and templates.
+type OverrideStore interface {
+	TemplateStore
+	SaveTemplate(ctx context.Context, t Template) error
+	DeleteTemplate(ctx context.Context, key Key) error
+	ListTemplates(ctx context.Context, tenantID string) ([]Template, error)
+}
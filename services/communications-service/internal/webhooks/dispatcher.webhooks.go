@@ -0,0 +1,270 @@
+// services/communications-service/internal/webhooks/dispatcher.webhooks.go
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is what the bridge hands to the dispatcher.
+type Event struct {
+	ID       string // Kafka key when set; reused as the delivery's event id
+	Type     string
+	TenantID string
+	Data     json.RawMessage
+}
+
+// Options tune retries. The zero value is not useful; use DefaultOptions.
+type Options struct {
+	// MaxAttempts per delivery before it is marked FAILED.
+	MaxAttempts int
+	// Backoff for attempt n (1-based) is BaseBackoff * 2^(n-1), capped at MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// DisableAfter consecutive failed attempts (across all deliveries) disables the endpoint.
+	DisableAfter int
+	// Timeout per HTTP request. Receivers should answer fast and process async.
+	Timeout time.Duration
+	// PollInterval between scans for due deliveries.
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultOptions retries for roughly a day: 30s, 1m, 2m, ... capped at 6h, 10 attempts.
+func DefaultOptions() Options {
+	return Options{
+		MaxAttempts:  10,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		DisableAfter: 20,
+		Timeout:      10 * time.Second,
+		PollInterval: time.Second,
+		BatchSize:    50,
+	}
+}
+
+// Dispatcher fans events out to endpoints and drives retries.
+type Dispatcher struct {
+	endpoints  EndpointStore
+	deliveries DeliveryStore
+	client     *http.Client
+	opts       Options
+	now        func() time.Time
+}
+
+func NewDispatcher(endpoints EndpointStore, deliveries DeliveryStore, client *http.Client, opts Options) *Dispatcher {
+	return &Dispatcher{endpoints: endpoints, deliveries: deliveries, client: client, opts: opts, now: time.Now}
+}
+
+// envelope is the JSON body merchants receive.
+type envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	TenantID  string          `json:"tenant_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Enqueue records one PENDING delivery per enabled endpoint subscribed to the
+// event. Nothing is sent here; Run picks the deliveries up.
+func (d *Dispatcher) Enqueue(ctx context.Context, ev Event) (int, error) {
+	if ev.TenantID == "" {
+		return 0, nil // webhooks are per tenant
+	}
+	endpoints, err := d.endpoints.ListEndpoints(ctx, ev.TenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load webhook endpoints: %w", err)
+	}
+	if ev.ID == "" {
+		ev.ID = uuid.NewString()
+	}
+	now := d.now().UTC()
+	body, err := json.Marshal(envelope{ID: ev.ID, Type: ev.Type, TenantID: ev.TenantID, CreatedAt: now, Data: ev.Data})
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, e := range endpoints {
+		if !e.Enabled || !e.Wants(ev.Type) {
+			continue
+		}
+		err := d.deliveries.CreateDelivery(ctx, Delivery{
+			ID:            uuid.NewString(),
+			EndpointID:    e.ID,
+			TenantID:      e.TenantID,
+			EventID:       ev.ID,
+			EventType:     ev.Type,
+			Payload:       body,
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			return queued, fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// Redeliver makes one attempt right now, whatever the delivery's state, and
+// returns it. Used from the dashboard after a merchant fixes their endpoint.
+// A failed redelivery of a FAILED delivery is not retried automatically.
+func (d *Dispatcher) Redeliver(ctx context.Context, tenantID, deliveryID string) (*Attempt, error) {
+	del, err := d.deliveries.GetDelivery(ctx, tenantID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := d.endpoints.GetEndpoint(ctx, tenantID, del.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Enabled {
+		return nil, ErrEndpointDisabled
+	}
+	return d.attempt(ctx, *endpoint, *del)
+}
+
+// Run polls for due deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil {
+			log.Printf("Webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue makes one attempt for every delivery that is due and returns how
+// many it attempted.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	// The lease covers the request timeout with room to spare
+	due, err := d.deliveries.ClaimDue(ctx, d.now().UTC(), 2*d.opts.Timeout+time.Minute, d.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due deliveries: %w", err)
+	}
+	for _, del := range due {
+		endpoint, err := d.endpoints.GetEndpoint(ctx, del.TenantID, del.EndpointID)
+		if err != nil {
+			log.Printf("Webhooks: delivery %s: %v", del.ID, err)
+			continue
+		}
+		if !endpoint.Enabled {
+			// Disabled while this delivery was waiting: stop retrying it
+			del.Status = DeliveryFailed
+			del.LastError = "endpoint disabled"
+			del.UpdatedAt = d.now().UTC()
+			if err := d.deliveries.UpdateDelivery(ctx, del); err != nil {
+				log.Printf("Webhooks: delivery %s: %v", del.ID, err)
+			}
+			continue
+		}
+		if _, err := d.attempt(ctx, *endpoint, del); err != nil {
+			log.Printf("Webhooks: delivery %s: %v", del.ID, err)
+		}
+	}
+	return len(due), nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, endpoint Endpoint, del Delivery) (*Attempt, error) {
+	now := d.now().UTC()
+	a := d.post(ctx, endpoint, del)
+	del.Attempts++
+	a.DeliveryID = del.ID
+	a.Number = del.Attempts
+	a.CreatedAt = now
+	del.UpdatedAt = now
+
+	if a.Succeeded() {
+		del.Status = DeliverySucceeded
+		del.LastError = ""
+		if endpoint.ConsecutiveFailures > 0 {
+			endpoint.ConsecutiveFailures = 0
+			if err := d.endpoints.UpdateEndpoint(ctx, endpoint); err != nil {
+				return &a, err
+			}
+		}
+		return &a, d.deliveries.RecordAttempt(ctx, del, a)
+	}
+
+	del.LastError = a.Error
+	if del.LastError == "" {
+		del.LastError = fmt.Sprintf("endpoint returned %d", a.StatusCode)
+	}
+	if del.Status == DeliveryPending {
+		if del.Attempts >= d.opts.MaxAttempts {
+			del.Status = DeliveryFailed
+		} else {
+			del.NextAttemptAt = now.Add(d.backoff(del.Attempts))
+		}
+	}
+
+	endpoint.ConsecutiveFailures++
+	if d.opts.DisableAfter > 0 && endpoint.ConsecutiveFailures >= d.opts.DisableAfter {
+		endpoint.Enabled = false
+		endpoint.DisabledReason = fmt.Sprintf("disabled after %d consecutive failures: %s", endpoint.ConsecutiveFailures, del.LastError)
+		log.Printf("Webhooks: endpoint %s (%s) %s", endpoint.ID, endpoint.URL, endpoint.DisabledReason)
+	}
+	if err := d.endpoints.UpdateEndpoint(ctx, endpoint); err != nil {
+		return &a, err
+	}
+	return &a, d.deliveries.RecordAttempt(ctx, del, a)
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= d.opts.MaxBackoff {
+			return d.opts.MaxBackoff
+		}
+	}
+	return wait
+}
+
+func (d *Dispatcher) post(ctx context.Context, endpoint Endpoint, del Delivery) Attempt {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return Attempt{Error: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LogiSynapse-Webhooks/1.0")
+	req.Header.Set("X-LogiSynapse-Event", del.EventType)
+	req.Header.Set("X-LogiSynapse-Event-Id", del.EventID)
+	req.Header.Set("X-LogiSynapse-Delivery-Id", del.ID)
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now(), del.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	a := Attempt{Duration: time.Since(start)}
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+	a.StatusCode = resp.StatusCode
+	a.ResponseBody = string(body)
+	return a
+}
@@ -0,0 +1,254 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+// receiver is a merchant endpoint that verifies signatures and answers with
+// the next queued status code (200 once the queue is empty).
+type receiver struct {
+	t        *testing.T
+	secret   string
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := Verify(rc.secret, r.Header.Get(SignatureHeader), body, time.Now(), 5*time.Minute); err != nil {
+		rc.t.Errorf("signature: %v", err)
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.bodies = append(rc.bodies, string(body))
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+type fixture struct {
+	store *MemoryStore
+	disp  *Dispatcher
+	clock time.Time
+	recv  *receiver
+	srv   *httptest.Server
+}
+
+func newFixture(t *testing.T, opts Options) *fixture {
+	f := &fixture{store: NewMemoryStore(), clock: time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)}
+	f.recv = &receiver{t: t, secret: "whsec_test"}
+	f.srv = httptest.NewServer(f.recv)
+	t.Cleanup(f.srv.Close)
+	f.disp = NewDispatcher(f.store, f.store, f.srv.Client(), opts)
+	// The receiver checks timestamps against the wall clock, so signatures use
+	// real time while scheduling uses the fake clock.
+	f.disp.now = func() time.Time { return f.clock }
+	return f
+}
+
+func (f *fixture) addEndpoint(t *testing.T, events ...string) Endpoint {
+	e := Endpoint{ID: "ep-1", TenantID: "tenant-1", URL: f.srv.URL, Secret: f.recv.secret, Events: events, Enabled: true, CreatedAt: f.clock}
+	if err := f.store.CreateEndpoint(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func testOptions() Options {
+	opts := DefaultOptions()
+	opts.BaseBackoff = 10 * time.Second
+	opts.MaxAttempts = 3
+	opts.DisableAfter = 100
+	return opts
+}
+
+func TestSignVerify(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"evt-1"}`)
+	header := Sign("secret", now, body)
+	if err := Verify("secret", header, body, now, time.Minute); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+	if err := Verify("secret", header, []byte(`{"id":"evt-2"}`), now, time.Minute); err == nil {
+		t.Error("tampered body accepted")
+	}
+	if err := Verify("other", header, body, now, time.Minute); err == nil {
+		t.Error("wrong secret accepted")
+	}
+	if err := Verify("secret", header, body, now.Add(10*time.Minute), time.Minute); err == nil {
+		t.Error("stale timestamp accepted")
+	}
+}
+
+func TestDeliverRetriesWithBackoffThenSucceeds(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t, testOptions())
+	f.addEndpoint(t, "shipment.created")
+	f.recv.statuses = []int{http.StatusInternalServerError, http.StatusBadGateway}
+
+	n, err := f.disp.Enqueue(ctx, Event{ID: "evt-1", Type: "shipment.created", TenantID: "tenant-1", Data: json.RawMessage(`{"ID":"s-1"}`)})
+	if err != nil || n != 1 {
+		t.Fatalf("Enqueue = %d, %v", n, err)
+	}
+	// Not subscribed: no delivery
+	if n, _ := f.disp.Enqueue(ctx, Event{Type: "pickup.reminder", TenantID: "tenant-1"}); n != 0 {
+		t.Errorf("unsubscribed event queued %d deliveries", n)
+	}
+
+	step := func(advance time.Duration, wantAttempted int) Delivery {
+		t.Helper()
+		f.clock = f.clock.Add(advance)
+		got, err := f.disp.DeliverDue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != wantAttempted {
+			t.Fatalf("attempted %d deliveries, want %d", got, wantAttempted)
+		}
+		list, _ := f.store.ListDeliveries(ctx, "tenant-1", "ep-1", 10)
+		return list[0]
+	}
+
+	d := step(0, 1) // 500
+	if d.Status != DeliveryPending || !d.NextAttemptAt.Equal(f.clock.Add(10*time.Second)) {
+		t.Fatalf("after 1st failure: %+v", d)
+	}
+	step(5*time.Second, 0)     // backoff not elapsed yet
+	d = step(5*time.Second, 1) // 502, next wait doubles
+	if !d.NextAttemptAt.Equal(f.clock.Add(20 * time.Second)) {
+		t.Fatalf("second backoff: next at %v, now %v", d.NextAttemptAt, f.clock)
+	}
+	d = step(20*time.Second, 1) // 200
+	if d.Status != DeliverySucceeded || d.Attempts != 3 {
+		t.Fatalf("expected success on 3rd attempt, got %+v", d)
+	}
+
+	attempts, _ := f.store.ListAttempts(ctx, d.ID)
+	if len(attempts) != 3 || attempts[0].StatusCode != 500 || !attempts[2].Succeeded() {
+		t.Errorf("attempt log = %+v", attempts)
+	}
+	// Every attempt carried the same event id so the merchant can dedupe
+	for _, b := range f.recv.bodies {
+		if !strings.Contains(b, `"id":"evt-1"`) {
+			t.Errorf("body without event id: %s", b)
+		}
+	}
+}
+
+func TestEndpointDisabledAfterRepeatedFailures(t *testing.T) {
+	ctx := context.Background()
+	opts := testOptions()
+	opts.DisableAfter = 2
+	opts.MaxAttempts = 10
+	f := newFixture(t, opts)
+	f.addEndpoint(t)
+	f.recv.statuses = []int{500, 500, 500}
+
+	f.disp.Enqueue(ctx, Event{Type: "shipment.created", TenantID: "tenant-1"})
+	f.disp.DeliverDue(ctx)
+	f.clock = f.clock.Add(10 * time.Second)
+	f.disp.DeliverDue(ctx)
+
+	e, _ := f.store.GetEndpoint(ctx, "tenant-1", "ep-1")
+	if e.Enabled || e.DisabledReason == "" {
+		t.Fatalf("endpoint should be disabled: %+v", e)
+	}
+	// The pending retry is abandoned, and no new deliveries are queued
+	f.clock = f.clock.Add(time.Hour)
+	f.disp.DeliverDue(ctx)
+	list, _ := f.store.ListDeliveries(ctx, "tenant-1", "ep-1", 10)
+	if list[0].Status != DeliveryFailed {
+		t.Errorf("delivery status = %s, want FAILED", list[0].Status)
+	}
+	if n, _ := f.disp.Enqueue(ctx, Event{Type: "shipment.created", TenantID: "tenant-1"}); n != 0 {
+		t.Errorf("disabled endpoint got %d new deliveries", n)
+	}
+}
+
+func TestRedeliverAndAPI(t *testing.T) {
+	ctx := context.Background()
+	opts := testOptions()
+	opts.MaxAttempts = 1
+	f := newFixture(t, opts)
+	f.recv.statuses = []int{http.StatusServiceUnavailable}
+
+	mux := http.NewServeMux()
+	NewHandler(f.store, f.store, f.disp, true).Register(mux)
+	tenant := "tenant-1"
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ctx := identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenant, Role: "admin"})
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)).WithContext(ctx))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/v1/tenants/tenant-1/webhooks", `{"url":"`+f.srv.URL+`","events":["shipment.created"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", rec.Code, rec.Body)
+	}
+	var created endpointJSON
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	if !strings.HasPrefix(created.Secret, "whsec_") {
+		t.Fatalf("create response has no secret: %+v", created)
+	}
+	f.recv.secret = created.Secret
+
+	f.disp.Enqueue(ctx, Event{Type: "shipment.created", TenantID: "tenant-1"})
+	f.disp.DeliverDue(ctx) // 503, out of attempts
+
+	rec = do(http.MethodGet, "/v1/tenants/tenant-1/webhooks/"+created.ID+"/deliveries", "")
+	var deliveries []deliveryJSON
+	json.Unmarshal(rec.Body.Bytes(), &deliveries)
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryFailed {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+
+	rec = do(http.MethodPost, "/v1/tenants/tenant-1/webhook-deliveries/"+deliveries[0].ID+"/redeliver", "")
+	var attempt attemptJSON
+	json.Unmarshal(rec.Body.Bytes(), &attempt)
+	if rec.Code != http.StatusOK || !attempt.Succeeded || attempt.Number != 2 {
+		t.Fatalf("redeliver: %d %+v", rec.Code, attempt)
+	}
+
+	rec = do(http.MethodGet, "/v1/tenants/tenant-1/webhook-deliveries/"+deliveries[0].ID+"/attempts", "")
+	var attempts []attemptJSON
+	json.Unmarshal(rec.Body.Bytes(), &attempts)
+	if len(attempts) != 2 || attempts[0].StatusCode != 503 {
+		t.Errorf("attempts = %+v", attempts)
+	}
+
+	// Other tenants cannot see or redeliver it, under their own tenant or tenant-1's
+	tenant = "tenant-2"
+	if rec := do(http.MethodGet, "/v1/tenants/tenant-2/webhook-deliveries/"+deliveries[0].ID+"/attempts", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("cross-tenant read: %d", rec.Code)
+	}
+	for _, req := range [][2]string{
+		{http.MethodGet, "/v1/tenants/tenant-1/webhooks"},
+		{http.MethodGet, "/v1/tenants/tenant-1/webhook-deliveries/" + deliveries[0].ID + "/attempts"},
+		{http.MethodPost, "/v1/tenants/tenant-1/webhook-deliveries/" + deliveries[0].ID + "/redeliver"},
+	} {
+		if rec := do(req[0], req[1], ""); rec.Code != http.StatusForbidden {
+			t.Errorf("tenant-2 %s %s: %d", req[0], req[1], rec.Code)
+		}
+	}
+	if rec := do(http.MethodPost, "/v1/tenants/tenant-1/webhooks", `{"url":"https://evil.example"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("tenant-2 registered an endpoint for tenant-1: %d", rec.Code)
+	}
+	tenant = "tenant-1"
+	if rec := do(http.MethodPost, "/v1/tenants/tenant-1/webhooks", `{"url":"ftp://example.com"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("ftp url accepted: %d", rec.Code)
+	}
+}
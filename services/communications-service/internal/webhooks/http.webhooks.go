@@ -0,0 +1,355 @@
+// services/communications-service/internal/webhooks/http.webhooks.go
+
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/communications-service/internal/caller"
+	"github.com/google/uuid"
+)
+
+// Handler is the merchant-facing webhook API. It goes behind
+// caller.Authenticate; {tenant} must be the caller's tenant.
+//
+//	POST   /v1/tenants/{tenant}/webhooks                         register an endpoint (returns the secret once)
+//	GET    /v1/tenants/{tenant}/webhooks                         list endpoints
+//	PATCH  /v1/tenants/{tenant}/webhooks/{id}                    change url/events, re-enable
+//	DELETE /v1/tenants/{tenant}/webhooks/{id}
+//	GET    /v1/tenants/{tenant}/webhooks/{id}/deliveries         delivery log for one endpoint
+//	GET    /v1/tenants/{tenant}/webhook-deliveries/{id}/attempts attempts for one delivery
+//	POST   /v1/tenants/{tenant}/webhook-deliveries/{id}/redeliver
+type Handler struct {
+	endpoints  EndpointStore
+	deliveries DeliveryStore
+	dispatcher *Dispatcher
+	// allowHTTP permits plain http:// endpoints (local development only).
+	allowHTTP bool
+}
+
+func NewHandler(endpoints EndpointStore, deliveries DeliveryStore, dispatcher *Dispatcher, allowHTTP bool) *Handler {
+	return &Handler{endpoints: endpoints, deliveries: deliveries, dispatcher: dispatcher, allowHTTP: allowHTTP}
+}
+
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/tenants/{tenant}/webhooks", h.create)
+	mux.HandleFunc("GET /v1/tenants/{tenant}/webhooks", h.list)
+	mux.HandleFunc("PATCH /v1/tenants/{tenant}/webhooks/{id}", h.update)
+	mux.HandleFunc("DELETE /v1/tenants/{tenant}/webhooks/{id}", h.delete)
+	mux.HandleFunc("GET /v1/tenants/{tenant}/webhooks/{id}/deliveries", h.listDeliveries)
+	mux.HandleFunc("GET /v1/tenants/{tenant}/webhook-deliveries/{id}/attempts", h.listAttempts)
+	mux.HandleFunc("POST /v1/tenants/{tenant}/webhook-deliveries/{id}/redeliver", h.redeliver)
+}
+
+type endpointJSON struct {
+	ID                  string    `json:"id"`
+	URL                 string    `json:"url"`
+	Secret              string    `json:"secret,omitempty"` // only in the create response
+	Events              []string  `json:"events"`
+	Enabled             bool      `json:"enabled"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DisabledReason      string    `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+type endpointRequest struct {
+	URL     *string   `json:"url"`
+	Events  *[]string `json:"events"`
+	Enabled *bool     `json:"enabled"`
+}
+
+type deliveryJSON struct {
+	ID            string         `json:"id"`
+	EndpointID    string         `json:"endpoint_id"`
+	EventID       string         `json:"event_id"`
+	EventType     string         `json:"event_type"`
+	Status        DeliveryStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt *time.Time     `json:"next_attempt_at,omitempty"`
+	LastError     string         `json:"last_error,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type attemptJSON struct {
+	Number       int       `json:"number"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	DurationMS   int64     `json:"duration_ms"`
+	Succeeded    bool      `json:"succeeded"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	var req endpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.URL == nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: url is required", ErrInvalidEndpoint))
+		return
+	}
+	if err := h.validateURL(*req.URL); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	secret, err := NewSecret()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	now := time.Now().UTC()
+	e := Endpoint{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		URL:       *req.URL,
+		Secret:    secret,
+		Enabled:   true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req.Events != nil {
+		e.Events = *req.Events
+	}
+	if err := h.endpoints.CreateEndpoint(r.Context(), e); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := toEndpointJSON(e)
+	out.Secret = e.Secret
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	list, err := h.endpoints.ListEndpoints(r.Context(), tenantID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]endpointJSON, 0, len(list))
+	for _, e := range list {
+		out = append(out, toEndpointJSON(e))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) update(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	var req endpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	e, err := h.endpoints.GetEndpoint(r.Context(), tenantID, r.PathValue("id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	if req.URL != nil {
+		if err := h.validateURL(*req.URL); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		e.URL = *req.URL
+	}
+	if req.Events != nil {
+		e.Events = *req.Events
+	}
+	if req.Enabled != nil {
+		e.Enabled = *req.Enabled
+		if e.Enabled {
+			// Re-enabling starts the failure count from scratch
+			e.ConsecutiveFailures = 0
+			e.DisabledReason = ""
+		}
+	}
+	if err := h.endpoints.UpdateEndpoint(r.Context(), *e); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEndpointJSON(*e))
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	if err := h.endpoints.DeleteEndpoint(r.Context(), tenantID, r.PathValue("id")); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 500 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 500"))
+			return
+		}
+		limit = n
+	}
+	endpointID := r.PathValue("id")
+	if _, err := h.endpoints.GetEndpoint(r.Context(), tenantID, endpointID); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	list, err := h.deliveries.ListDeliveries(r.Context(), tenantID, endpointID, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]deliveryJSON, 0, len(list))
+	for _, d := range list {
+		dj := deliveryJSON{
+			ID:         d.ID,
+			EndpointID: d.EndpointID,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Status:     d.Status,
+			Attempts:   d.Attempts,
+			LastError:  d.LastError,
+			CreatedAt:  d.CreatedAt,
+		}
+		if d.Status == DeliveryPending {
+			next := d.NextAttemptAt
+			dj.NextAttemptAt = &next
+		}
+		out = append(out, dj)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) listAttempts(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	d, err := h.deliveries.GetDelivery(r.Context(), tenantID, r.PathValue("id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	list, err := h.deliveries.ListAttempts(r.Context(), d.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]attemptJSON, 0, len(list))
+	for _, a := range list {
+		out = append(out, toAttemptJSON(a))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) redeliver(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := caller.PathTenant(w, r)
+	if !ok {
+		return
+	}
+	a, err := h.dispatcher.Redeliver(r.Context(), tenantID, r.PathValue("id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAttemptJSON(*a))
+}
+
+// validateURL only accepts absolute http(s) URLs; plain http needs allowHTTP.
+func (h *Handler) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute URL", ErrInvalidEndpoint, raw)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if h.allowHTTP {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: url must use https", ErrInvalidEndpoint)
+}
+
+func toEndpointJSON(e Endpoint) endpointJSON {
+	events := e.Events
+	if events == nil {
+		events = []string{}
+	}
+	return endpointJSON{
+		ID:                  e.ID,
+		URL:                 e.URL,
+		Events:              events,
+		Enabled:             e.Enabled,
+		ConsecutiveFailures: e.ConsecutiveFailures,
+		DisabledReason:      e.DisabledReason,
+		CreatedAt:           e.CreatedAt,
+	}
+}
+
+func toAttemptJSON(a Attempt) attemptJSON {
+	return attemptJSON{
+		Number:       a.Number,
+		StatusCode:   a.StatusCode,
+		Error:        a.Error,
+		ResponseBody: a.ResponseBody,
+		DurationMS:   a.Duration.Milliseconds(),
+		Succeeded:    a.Succeeded(),
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func writeStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrEndpointNotFound), errors.Is(err, ErrDeliveryNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, ErrEndpointDisabled):
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if status >= 500 {
+		log.Printf("webhooks: request failed: %v", err)
+		writeJSON(w, status, map[string]string{"error": "internal error"})
+		return
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("webhooks: failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+// services/communications-service/internal/webhooks/memory.webhooks.go
+
+package webhooks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements EndpointStore and DeliveryStore in process, for
+// local runs without a database and for tests.
+type MemoryStore struct {
+	mu         sync.Mutex
+	endpoints  map[string]Endpoint
+	deliveries map[string]Delivery
+	attempts   map[string][]Attempt
+	nextID     int64
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		endpoints:  make(map[string]Endpoint),
+		deliveries: make(map[string]Delivery),
+		attempts:   make(map[string][]Attempt),
+	}
+}
+
+func (s *MemoryStore) CreateEndpoint(ctx context.Context, e Endpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.endpoints[e.ID] = e
+	return nil
+}
+
+func (s *MemoryStore) GetEndpoint(ctx context.Context, tenantID, id string) (*Endpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.endpoints[id]
+	if !ok || e.TenantID != tenantID {
+		return nil, ErrEndpointNotFound
+	}
+	return &e, nil
+}
+
+func (s *MemoryStore) ListEndpoints(ctx context.Context, tenantID string) ([]Endpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Endpoint
+	for _, e := range s.endpoints {
+		if e.TenantID == tenantID {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *MemoryStore) UpdateEndpoint(ctx context.Context, e Endpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.endpoints[e.ID]; !ok {
+		return ErrEndpointNotFound
+	}
+	e.UpdatedAt = time.Now().UTC()
+	s.endpoints[e.ID] = e
+	return nil
+}
+
+func (s *MemoryStore) DeleteEndpoint(ctx context.Context, tenantID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.endpoints[id]; !ok || e.TenantID != tenantID {
+		return ErrEndpointNotFound
+	}
+	delete(s.endpoints, id)
+	return nil
+}
+
+func (s *MemoryStore) CreateDelivery(ctx context.Context, d Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.deliveries {
+		if existing.EndpointID == d.EndpointID && existing.EventType == d.EventType && existing.EventID == d.EventID {
+			return nil
+		}
+	}
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *MemoryStore) GetDelivery(ctx context.Context, tenantID, id string) (*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.deliveries[id]
+	if !ok || d.TenantID != tenantID {
+		return nil, ErrDeliveryNotFound
+	}
+	return &d, nil
+}
+
+func (s *MemoryStore) ListDeliveries(ctx context.Context, tenantID, endpointID string, limit int) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Delivery
+	for _, d := range s.deliveries {
+		if d.TenantID == tenantID && (endpointID == "" || d.EndpointID == endpointID) {
+			out = append(out, d)
+		}
+	}
+	// Newest first, like the dashboard shows them
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []Delivery
+	for _, d := range s.deliveries {
+		if d.Status == DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	for _, d := range due {
+		leased := d
+		leased.NextAttemptAt = now.Add(lease)
+		s.deliveries[d.ID] = leased
+	}
+	return due, nil
+}
+
+func (s *MemoryStore) RecordAttempt(ctx context.Context, d Delivery, a Attempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	a.ID = s.nextID
+	s.attempts[d.ID] = append(s.attempts[d.ID], a)
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *MemoryStore) UpdateDelivery(ctx context.Context, d Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.deliveries[d.ID]; !ok {
+		return ErrDeliveryNotFound
+	}
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *MemoryStore) ListAttempts(ctx context.Context, deliveryID string) ([]Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Attempt(nil), s.attempts[deliveryID]...), nil
+}
@@ -0,0 +1,34 @@
+// services/communications-service/internal/webhooks/webhook_store.go
+
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+type EndpointStore interface {
+	CreateEndpoint(ctx context.Context, e Endpoint) error
+	GetEndpoint(ctx context.Context, tenantID, id string) (*Endpoint, error)
+	ListEndpoints(ctx context.Context, tenantID string) ([]Endpoint, error)
+	// UpdateEndpoint saves Enabled, Events, URL, ConsecutiveFailures and DisabledReason.
+	UpdateEndpoint(ctx context.Context, e Endpoint) error
+	DeleteEndpoint(ctx context.Context, tenantID, id string) error
+}
+
+type DeliveryStore interface {
+	// CreateDelivery is a no-op when the endpoint already has a delivery for
+	// the same event type and event id (Kafka redelivered the event).
+	CreateDelivery(ctx context.Context, d Delivery) error
+	GetDelivery(ctx context.Context, tenantID, id string) (*Delivery, error)
+	ListDeliveries(ctx context.Context, tenantID, endpointID string, limit int) ([]Delivery, error)
+	// ClaimDue returns up to limit PENDING deliveries whose next attempt is due
+	// and pushes their next_attempt_at forward by lease, so a second dispatcher
+	// (or a crash mid-attempt) does not send the same delivery concurrently.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	// RecordAttempt appends the attempt and saves the delivery's new state in one go.
+	RecordAttempt(ctx context.Context, d Delivery, a Attempt) error
+	// UpdateDelivery saves the delivery's state without an attempt.
+	UpdateDelivery(ctx context.Context, d Delivery) error
+	ListAttempts(ctx context.Context, deliveryID string) ([]Attempt, error)
+}
@@ -0,0 +1,90 @@
+// services/communications-service/internal/webhooks/webhooks.domain.go
+
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidEndpoint  = errors.New("invalid webhook endpoint")
+	ErrEndpointDisabled = errors.New("webhook endpoint is disabled")
+)
+
+// Endpoint is a merchant URL that receives events for one tenant.
+type Endpoint struct {
+	ID       string
+	TenantID string
+	URL      string
+	// Secret signs every delivery; shown to the merchant once, at registration.
+	Secret string
+	// Events the endpoint subscribed to; empty means every event.
+	Events  []string
+	Enabled bool
+	// ConsecutiveFailures counts failed attempts since the last success.
+	// The endpoint is disabled when it reaches the dispatcher's threshold.
+	ConsecutiveFailures int
+	DisabledReason      string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Wants reports whether the endpoint subscribed to eventType.
+func (e Endpoint) Wants(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, ev := range e.Events {
+		if ev == eventType || ev == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus tracks one event's journey to one endpoint.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"   // waiting for its next attempt
+	DeliverySucceeded DeliveryStatus = "SUCCEEDED" // endpoint answered 2xx
+	DeliveryFailed    DeliveryStatus = "FAILED"    // out of attempts, or endpoint disabled
+)
+
+// Delivery is one event queued for one endpoint. The deliveries table is both
+// the retry queue (status PENDING, next_attempt_at) and the delivery log.
+type Delivery struct {
+	ID            string
+	EndpointID    string
+	TenantID      string
+	EventID       string // stable across redeliveries so receivers can dedupe
+	EventType     string
+	Payload       json.RawMessage // the exact body POSTed to the endpoint
+	Status        DeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Attempt is one HTTP request made for a delivery.
+type Attempt struct {
+	ID           int64
+	DeliveryID   string
+	Number       int // 1-based
+	StatusCode   int // 0 when no response (timeout, DNS, refused)
+	Error        string
+	ResponseBody string // first few KB, for debugging from the dashboard
+	Duration     time.Duration
+	CreatedAt    time.Time
+}
+
+// Succeeded reports whether the endpoint accepted the event.
+func (a Attempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
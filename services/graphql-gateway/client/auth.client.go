@@ -0,0 +1,279 @@
+// client/auth.client.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	authv1 "github.com/Tanmoy095/LogiSynapse/services/authentication-service/api/proto/auth/v1"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/auth"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthClient connects to authentication-service via gRPC. The caller forwarded
+// by the identity interceptor is who the service acts for; requests never name
+// the actor themselves.
+type AuthClient struct {
+	client   authv1.AuthServiceClient
+	conn     *grpc.ClientConn
+	keyCache APIKeyCache
+}
+
+// APIKeyCache is the gateway's cache of verified API keys (auth.APIKeys).
+type APIKeyCache interface {
+	Forget(keyID string)
+}
+
+// UseAPIKeyCache makes keys revoked or rotated through this client drop out
+// of cache at once, so this gateway stops accepting them without waiting for
+// the cache to expire. Call it before serving requests.
+func (c *AuthClient) UseAPIKeyCache(cache APIKeyCache) {
+	c.keyCache = cache
+}
+
+func (c *AuthClient) forgetKey(keyID string) {
+	if c.keyCache != nil {
+		c.keyCache.Forget(keyID)
+	}
+}
+
+// NewAuthClient does not wait for the connection, like NewBillingClient.
+func NewAuthClient(addr string) (*AuthClient, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up auth service client: %v", err)
+	}
+	return &AuthClient{client: authv1.NewAuthServiceClient(conn), conn: conn}, nil
+}
+
+func (c *AuthClient) Close() error {
+	return c.conn.Close()
+}
+
+// Register creates a user and returns its ID. It does not log them in.
+func (c *AuthClient) Register(ctx context.Context, email, password, firstName, lastName string) (string, error) {
+	resp, err := c.client.RegisterUser(ctx, &authv1.RegisterUserRequest{
+		Email: email, Password: password, FirstName: firstName, LastName: lastName,
+	})
+	if err != nil {
+		return "", handleGRPCError(err, "auth")
+	}
+	return resp.UserId, nil
+}
+
+func (c *AuthClient) Login(ctx context.Context, email, password, deviceFingerprint string) (models.AuthTokens, error) {
+	resp, err := c.client.LoginUser(ctx, &authv1.LoginUserRequest{
+		Email: email, Password: password, DeviceFingerprint: deviceFingerprint,
+	})
+	if err != nil {
+		return models.AuthTokens{}, handleGRPCError(err, "auth")
+	}
+	return toModelTokens(resp), nil
+}
+
+// RefreshSession swaps a refresh token for a new pair. The old token stops working.
+func (c *AuthClient) RefreshSession(ctx context.Context, refreshToken, deviceFingerprint string) (models.AuthTokens, error) {
+	resp, err := c.client.RefreshSession(ctx, &authv1.RefreshSessionRequest{
+		RefreshToken: refreshToken, DeviceFingerprint: deviceFingerprint,
+	})
+	if err != nil {
+		return models.AuthTokens{}, handleGRPCError(err, "auth")
+	}
+	return toModelTokens(resp), nil
+}
+
+func (c *AuthClient) Logout(ctx context.Context, refreshToken string) error {
+	if _, err := c.client.LogoutUser(ctx, &authv1.LogoutUserRequest{RefreshToken: refreshToken}); err != nil {
+		return handleGRPCError(err, "auth")
+	}
+	return nil
+}
+
+// RequestTenant files a tenant request for a super admin to approve; it returns the request ID.
+func (c *AuthClient) RequestTenant(ctx context.Context, name string) (string, error) {
+	resp, err := c.client.RequestTenant(ctx, &authv1.RequestTenantRequest{Name: name})
+	if err != nil {
+		return "", handleGRPCError(err, "auth")
+	}
+	return resp.RequestId, nil
+}
+
+// InviteMember invites a user by email; role is "admin" or "member".
+func (c *AuthClient) InviteMember(ctx context.Context, tenantID, email, role string) error {
+	if _, err := c.client.InviteMember(ctx, &authv1.InviteMemberRequest{TenantId: tenantID, Email: email, Role: role}); err != nil {
+		return handleGRPCError(err, "auth")
+	}
+	return nil
+}
+
+func (c *AuthClient) AcceptInvitation(ctx context.Context, tenantID string) error {
+	if _, err := c.client.AcceptInvitation(ctx, &authv1.AcceptInvitationRequest{TenantId: tenantID}); err != nil {
+		return handleGRPCError(err, "auth")
+	}
+	return nil
+}
+
+func (c *AuthClient) RevokeMember(ctx context.Context, tenantID, userID string) error {
+	if _, err := c.client.RevokeMember(ctx, &authv1.RevokeMemberRequest{TenantId: tenantID, UserId: userID}); err != nil {
+		return handleGRPCError(err, "auth")
+	}
+	return nil
+}
+
+func (c *AuthClient) TransferOwnership(ctx context.Context, tenantID, newOwnerUserID string) error {
+	if _, err := c.client.TransferOwnership(ctx, &authv1.TransferOwnershipRequest{TenantId: tenantID, NewOwnerUserId: newOwnerUserID}); err != nil {
+		return handleGRPCError(err, "auth")
+	}
+	return nil
+}
+
+// Me returns the calling user.
+func (c *AuthClient) Me(ctx context.Context) (models.User, error) {
+	resp, err := c.client.GetMe(ctx, &authv1.GetMeRequest{})
+	if err != nil {
+		return models.User{}, handleGRPCError(err, "auth")
+	}
+	u := resp.GetUser()
+	return models.User{
+		ID:           u.GetUserId(),
+		Email:        u.GetEmail(),
+		FirstName:    u.GetFirstName(),
+		LastName:     u.GetLastName(),
+		Status:       u.GetStatus(),
+		IsSuperAdmin: u.GetIsSuperAdmin(),
+		CreatedAt:    u.GetCreatedAt(),
+	}, nil
+}
+
+// MyTenants lists the tenants the caller owns or belongs to, pending invitations included.
+func (c *AuthClient) MyTenants(ctx context.Context) ([]models.TenantMembership, error) {
+	resp, err := c.client.ListMyTenants(ctx, &authv1.ListMyTenantsRequest{})
+	if err != nil {
+		return nil, handleGRPCError(err, "auth")
+	}
+	out := make([]models.TenantMembership, len(resp.Tenants))
+	for i, t := range resp.Tenants {
+		out[i] = models.TenantMembership{
+			TenantID:         t.TenantId,
+			Name:             t.Name,
+			TenantStatus:     t.TenantStatus,
+			Role:             t.Role,
+			MembershipStatus: t.MembershipStatus,
+		}
+	}
+	return out, nil
+}
+
+// TenantMembers lists a tenant's owner and members; the caller must be one of them.
+func (c *AuthClient) TenantMembers(ctx context.Context, tenantID string) ([]models.Member, error) {
+	resp, err := c.client.ListTenantMembers(ctx, &authv1.ListTenantMembersRequest{TenantId: tenantID})
+	if err != nil {
+		return nil, handleGRPCError(err, "auth")
+	}
+	out := make([]models.Member, len(resp.Members))
+	for i, m := range resp.Members {
+		out[i] = models.Member{
+			UserID:    m.UserId,
+			Email:     m.Email,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			Role:      m.Role,
+			Status:    m.Status,
+			JoinedAt:  m.JoinedAt,
+		}
+	}
+	return out, nil
+}
+
+// CreateAPIKey issues a key for the tenant and returns it with its raw value,
+// which authentication-service never returns again.
+func (c *AuthClient) CreateAPIKey(ctx context.Context, tenantID, name string, scopes []string, expiresAt string) (models.APIKey, string, error) {
+	resp, err := c.client.CreateApiKey(ctx, &authv1.CreateApiKeyRequest{
+		TenantId: tenantID, Name: name, Scopes: scopes, ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return models.APIKey{}, "", handleGRPCError(err, "auth")
+	}
+	return toModelAPIKey(resp.ApiKey), resp.Key, nil
+}
+
+// APIKeys lists the tenant's keys, revoked ones included.
+func (c *AuthClient) APIKeys(ctx context.Context, tenantID string) ([]models.APIKey, error) {
+	resp, err := c.client.ListApiKeys(ctx, &authv1.ListApiKeysRequest{TenantId: tenantID})
+	if err != nil {
+		return nil, handleGRPCError(err, "auth")
+	}
+	out := make([]models.APIKey, len(resp.ApiKeys))
+	for i, k := range resp.ApiKeys {
+		out[i] = toModelAPIKey(k)
+	}
+	return out, nil
+}
+
+func (c *AuthClient) RevokeAPIKey(ctx context.Context, tenantID, keyID string) (models.APIKey, error) {
+	resp, err := c.client.RevokeApiKey(ctx, &authv1.RevokeApiKeyRequest{TenantId: tenantID, KeyId: keyID})
+	if err != nil {
+		return models.APIKey{}, handleGRPCError(err, "auth")
+	}
+	c.forgetKey(keyID)
+	return toModelAPIKey(resp.ApiKey), nil
+}
+
+// RotateAPIKey issues the successor of a key; the old one stops after gracePeriodSeconds.
+func (c *AuthClient) RotateAPIKey(ctx context.Context, tenantID, keyID string, gracePeriodSeconds int32) (models.APIKey, string, error) {
+	resp, err := c.client.RotateApiKey(ctx, &authv1.RotateApiKeyRequest{
+		TenantId: tenantID, KeyId: keyID, GracePeriodSeconds: gracePeriodSeconds,
+	})
+	if err != nil {
+		return models.APIKey{}, "", handleGRPCError(err, "auth")
+	}
+	// The old key now has an end of grace period to pick up
+	c.forgetKey(keyID)
+	return toModelAPIKey(resp.ApiKey), resp.Key, nil
+}
+
+// ResolveAPIKey implements auth.APIKeyResolver for the gateway's middleware.
+// It is called without a caller: the key is the credential.
+func (c *AuthClient) ResolveAPIKey(ctx context.Context, rawKey string) (auth.APIKey, error) {
+	resp, err := c.client.AuthenticateApiKey(ctx, &authv1.AuthenticateApiKeyRequest{Key: rawKey})
+	if status.Code(err) == codes.Unauthenticated {
+		return auth.APIKey{}, auth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return auth.APIKey{}, fmt.Errorf("auth service: %w", err)
+	}
+	return auth.APIKey{
+		ID:        resp.KeyId,
+		TenantID:  resp.TenantId,
+		CreatedBy: resp.CreatedBy,
+		Scopes:    resp.Scopes,
+	}, nil
+}
+
+func toModelAPIKey(k *authv1.ApiKey) models.APIKey {
+	return models.APIKey{
+		ID:         k.GetKeyId(),
+		Name:       k.GetName(),
+		Prefix:     k.GetPrefix(),
+		Scopes:     k.GetScopes(),
+		CreatedBy:  k.GetCreatedBy(),
+		CreatedAt:  k.GetCreatedAt(),
+		ExpiresAt:  k.GetExpiresAt(),
+		LastUsedAt: k.GetLastUsedAt(),
+		RevokedAt:  k.GetRevokedAt(),
+		ReplacedBy: k.GetReplacedByKeyId(),
+	}
+}
+
+func toModelTokens(resp *authv1.LoginUserResponse) models.AuthTokens {
+	return models.AuthTokens{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+		TokenType:    resp.TokenType,
+	}
+}
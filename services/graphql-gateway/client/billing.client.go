@@ -0,0 +1,177 @@
+// client/billing.client.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/ratelimit"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BillingClient connects to billing-service via gRPC. The service scopes every
+// call to the tenant of the caller forwarded by the identity interceptor.
+type BillingClient struct {
+	client proto.BillingServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewBillingClient does not wait for the connection: billing fields fail on
+// their own while billing-service is down, the rest of the API keeps working.
+func NewBillingClient(addr string) (*BillingClient, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up billing service client: %v", err)
+	}
+	return &BillingClient{client: proto.NewBillingServiceClient(conn), conn: conn}, nil
+}
+
+func (c *BillingClient) Close() error {
+	return c.conn.Close()
+}
+
+// CurrentUsage returns the usage recorded so far this billing month.
+func (c *BillingClient) CurrentUsage(ctx context.Context) (models.UsageSummary, error) {
+	resp, err := c.client.GetCurrentUsage(ctx, &proto.GetCurrentUsageRequest{})
+	if err != nil {
+		return models.UsageSummary{}, handleGRPCError(err, "billing")
+	}
+	out := models.UsageSummary{Year: int(resp.Year), Month: int(resp.Month), Items: make([]models.UsageItem, len(resp.Items))}
+	for i, item := range resp.Items {
+		out.Items[i] = models.UsageItem{UsageType: item.UsageType, Quantity: item.Quantity}
+	}
+	return out, nil
+}
+
+// Plan returns the caller's tenant's plan and limits, for the rate limiter.
+func (c *BillingClient) Plan(ctx context.Context) (ratelimit.Plan, error) {
+	resp, err := c.client.GetPlan(ctx, &proto.GetPlanRequest{})
+	if err != nil {
+		return ratelimit.Plan{}, handleGRPCError(err, "billing")
+	}
+	return ratelimit.Plan{
+		Name:              resp.Plan,
+		RequestsPerMinute: int(resp.RequestsPerMinute),
+		MonthlyShipments:  resp.MonthlyShipments,
+		ShipmentsUsed:     resp.ShipmentsUsed,
+	}, nil
+}
+
+// ListInvoices returns invoice headers, newest billing period first.
+func (c *BillingClient) ListInvoices(ctx context.Context, limit int32) ([]models.Invoice, error) {
+	resp, err := c.client.ListInvoices(ctx, &proto.ListInvoicesRequest{Limit: limit})
+	if err != nil {
+		return nil, handleGRPCError(err, "billing")
+	}
+	out := make([]models.Invoice, len(resp.Invoices))
+	for i, inv := range resp.Invoices {
+		out[i] = toModelInvoice(inv)
+	}
+	return out, nil
+}
+
+// GetInvoice returns one invoice with its lines, or nil if the caller's tenant
+// has no such invoice.
+func (c *BillingClient) GetInvoice(ctx context.Context, invoiceID string) (*models.Invoice, error) {
+	resp, err := c.client.GetInvoice(ctx, &proto.GetInvoiceRequest{InvoiceId: invoiceID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, handleGRPCError(err, "billing")
+	}
+	inv := toModelInvoice(resp)
+	return &inv, nil
+}
+
+// ListLedgerEntries returns a billing month's ledger; zero year and month mean
+// the current one.
+func (c *BillingClient) ListLedgerEntries(ctx context.Context, year, month int32) ([]models.LedgerEntry, error) {
+	resp, err := c.client.ListLedgerEntries(ctx, &proto.ListLedgerEntriesRequest{Year: year, Month: month})
+	if err != nil {
+		return nil, handleGRPCError(err, "billing")
+	}
+	out := make([]models.LedgerEntry, len(resp.Entries))
+	for i, e := range resp.Entries {
+		out[i] = models.LedgerEntry{
+			ReferenceID:     e.ReferenceId,
+			TransactionType: e.TransactionType,
+			UsageType:       e.UsageType,
+			Quantity:        e.Quantity,
+			UnitPriceCents:  e.UnitPriceCents,
+			AmountCents:     e.AmountCents,
+			Currency:        e.Currency,
+			Description:     e.Description,
+			Year:            int(e.Year),
+			Month:           int(e.Month),
+			CreatedAt:       e.CreatedAt,
+		}
+	}
+	return out, nil
+}
+
+// ListPaymentAttempts returns payment attempts, newest first. A non-empty
+// invoiceID limits them to that invoice's.
+func (c *BillingClient) ListPaymentAttempts(ctx context.Context, invoiceID string, limit int32) ([]models.PaymentAttempt, error) {
+	resp, err := c.client.ListPaymentAttempts(ctx, &proto.ListPaymentAttemptsRequest{InvoiceId: invoiceID, Limit: limit})
+	if err != nil {
+		return nil, handleGRPCError(err, "billing")
+	}
+	out := make([]models.PaymentAttempt, len(resp.Attempts))
+	for i, a := range resp.Attempts {
+		out[i] = models.PaymentAttempt{
+			ID:                a.AttemptId,
+			InvoiceID:         a.InvoiceId,
+			Provider:          a.Provider,
+			ProviderPaymentID: a.ProviderPaymentId,
+			Status:            a.Status,
+			AmountCents:       a.AmountCents,
+			Currency:          a.Currency,
+			ErrorCode:         a.ErrorCode,
+			ErrorMessage:      a.ErrorMessage,
+			CreatedAt:         a.CreatedAt,
+			UpdatedAt:         a.UpdatedAt,
+		}
+	}
+	return out, nil
+}
+
+// PayInvoice charges a finalized invoice and returns it afterwards.
+func (c *BillingClient) PayInvoice(ctx context.Context, invoiceID string) (models.Invoice, error) {
+	resp, err := c.client.PayInvoice(ctx, &proto.PayInvoiceRequest{InvoiceId: invoiceID})
+	if err != nil {
+		return models.Invoice{}, handleGRPCError(err, "billing")
+	}
+	return toModelInvoice(resp), nil
+}
+
+func toModelInvoice(inv *proto.Invoice) models.Invoice {
+	out := models.Invoice{
+		ID:          inv.InvoiceId,
+		Year:        int(inv.Year),
+		Month:       int(inv.Month),
+		TotalCents:  inv.TotalCents,
+		Currency:    inv.Currency,
+		Status:      inv.Status,
+		Lines:       make([]models.InvoiceLine, len(inv.Lines)),
+		CreatedAt:   inv.CreatedAt,
+		FinalizedAt: inv.FinalizedAt,
+		PaidAt:      inv.PaidAt,
+	}
+	for i, l := range inv.Lines {
+		out.Lines[i] = models.InvoiceLine{
+			ID:             l.Id,
+			UsageType:      l.UsageType,
+			Quantity:       l.Quantity,
+			UnitPriceCents: l.UnitPriceCents,
+			LineTotalCents: l.LineTotalCents,
+			Description:    l.Description,
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+// client/notification.client.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc"
+)
+
+// NotificationClient connects to the communications service's notification
+// history via gRPC.
+type NotificationClient struct {
+	client proto.NotificationServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewNotificationClient does not wait for the connection: notification history
+// is optional, so the gateway starts even when the communications service is down.
+func NewNotificationClient(addr string) (*NotificationClient, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up communications service client: %v", err)
+	}
+	return &NotificationClient{client: proto.NewNotificationServiceClient(conn), conn: conn}, nil
+}
+
+func (c *NotificationClient) Close() error {
+	return c.conn.Close()
+}
+
+// ListByShipment returns the notifications sent about a shipment, newest first.
+// A non-empty tenantID limits them to that tenant's.
+func (c *NotificationClient) ListByShipment(ctx context.Context, tenantID, shipmentID string) ([]models.Notification, error) {
+	resp, err := c.client.ListNotifications(ctx, &proto.ListNotificationsRequest{TenantId: tenantID, ShipmentId: shipmentID})
+	if err != nil {
+		return nil, handleGRPCError(err, "communications")
+	}
+	out := make([]models.Notification, len(resp.Notifications))
+	for i, n := range resp.Notifications {
+		out[i] = models.Notification{
+			JobID:             n.JobId,
+			ShipmentIDs:       n.ShipmentIds,
+			Event:             n.Event,
+			Template:          n.Template,
+			Channel:           n.Channel,
+			Recipient:         n.Recipient,
+			Status:            n.Status,
+			ProviderMessageID: n.ProviderMessageId,
+			LastError:         n.LastError,
+			Attempts:          int(n.Attempts),
+			CreatedAt:         n.CreatedAt,
+			UpdatedAt:         n.UpdatedAt,
+			SentAt:            n.SentAt,
+			DeliveredAt:       n.DeliveredAt,
+		}
+	}
+	return out, nil
+}
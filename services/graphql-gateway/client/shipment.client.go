@@ -6,32 +6,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
 	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
-// Helper function to process gRPC errors
+// handleGRPCError turns a status from serviceName into an *apierror.Error,
+// keeping its code and field violations for the ErrorPresenter.
 func handleGRPCError(err error, serviceName string) error {
-	st, ok := status.FromError(err)
-	if !ok {
-		// Not a gRPC error, so return it as is
-		return err
-	}
-
-	// Check for specific gRPC status codes
-	switch st.Code() {
-	case codes.Unavailable:
-		return fmt.Errorf("%s service is unavailable", serviceName)
-	case codes.NotFound:
-		return fmt.Errorf("resource not found in %s service", serviceName)
-	// Add other cases as needed
-	default:
-		// Return a generic error with the original message
-		return fmt.Errorf("gRPC error from %s service: %s", serviceName, st.Message())
-	}
+	return apierror.FromGRPC(err, serviceName)
 }
 
 // ShipmentClient connects to the Shipment Service via gRPC.
@@ -42,7 +27,8 @@ type ShipmentClient struct {
 
 // NewShipmentClient initializes a gRPC client to connect to the Shipment Service.
 // It uses a timeout and blocks until connected to ensure the service is available.
-func NewShipmentClient(addr string) (*ShipmentClient, error) {
+// interceptors run after the identity one, e.g. the shipment quota.
+func NewShipmentClient(addr string, interceptors ...grpc.UnaryClientInterceptor) (*ShipmentClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	conn, err := grpc.DialContext(
@@ -50,6 +36,8 @@ func NewShipmentClient(addr string) (*ShipmentClient, error) {
 		addr,
 		grpc.WithInsecure(),
 		grpc.WithBlock(),
+		// Forward the authenticated caller (user, tenant, role) as metadata
+		grpc.WithChainUnaryInterceptor(append([]grpc.UnaryClientInterceptor{identity.UnaryClientInterceptor()}, interceptors...)...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to shipment service: %v", err)
@@ -66,6 +54,12 @@ func (c *ShipmentClient) Close() error {
 	return c.conn.Close()
 }
 
+// Service returns the underlying gRPC client, for the REST facade, which
+// passes proto messages through instead of converting them to models.
+func (c *ShipmentClient) Service() proto.ShipmentServiceClient {
+	return c.client
+}
+
 // GetShipments calls the Shipment Service's GetShipments endpoint.
 // It converts the response to local models for GraphQL.
 func (c *ShipmentClient) GetShipments(ctx context.Context, origin, destination string, status proto.ShipmentStatus, limit, offset int32) ([]models.Shipment, error) {
@@ -85,23 +79,42 @@ func (c *ShipmentClient) GetShipments(ctx context.Context, origin, destination s
 	// Convert proto.Shipment to local models.Shipment..logic same as before...
 	ModelShipments := make([]models.Shipment, len(resp.Shipments))
 	for i, shipment := range resp.Shipments {
+		ModelShipments[i] = toModelShipment(shipment)
+	}
+	return ModelShipments, err
 
-		ModelShipments[i] = models.Shipment{
-
-			ID:          shipment.Id,
-			Origin:      shipment.Origin,
-			Destination: shipment.Destination,
-			Eta:         shipment.Eta,
-			Status:      shipment.Status,
-			Carrier: models.Carrier{
-				Name:        shipment.Carrier.Name,
-				TrackingURL: shipment.Carrier.TrackingUrl,
-			},
-		}
+}
 
+// GetShipmentsByIDs fetches many shipments in one call (at most 100 IDs).
+// Unknown IDs are left out of the result.
+func (c *ShipmentClient) GetShipmentsByIDs(ctx context.Context, ids []string) ([]models.Shipment, error) {
+	resp, err := c.client.GetShipmentsByIDs(ctx, &proto.GetShipmentsByIDsRequest{Ids: ids})
+	if err != nil {
+		return nil, handleGRPCError(err, "shipment")
 	}
-	return ModelShipments, err
+	out := make([]models.Shipment, len(resp.Shipments))
+	for i, shipment := range resp.Shipments {
+		out[i] = toModelShipment(shipment)
+	}
+	return out, nil
+}
 
+// toModelShipment converts a proto.Shipment to the local model.
+func toModelShipment(shipment *proto.Shipment) models.Shipment {
+	return models.Shipment{
+		ID:          shipment.Id,
+		Origin:      shipment.Origin,
+		Destination: shipment.Destination,
+		Eta:         shipment.Eta,
+		Status:      shipment.Status,
+		Carrier: models.Carrier{
+			Name:        shipment.GetCarrier().GetName(),
+			TrackingURL: shipment.GetCarrier().GetTrackingUrl(),
+		},
+		FromAddress: toModelAddress(shipment.FromAddress),
+		ToAddress:   toModelAddress(shipment.ToAddress),
+		Customs:     toModelCustoms(shipment.Customs),
+	}
 }
 
 // CreateShipment calls the Shipment Service's CreateShipment endpoint.
@@ -117,15 +130,14 @@ func (c *ShipmentClient) CreateShipment(ctx context.Context, shipment models.Shi
 			Name:        shipment.Carrier.Name,
 			TrackingUrl: shipment.Carrier.TrackingURL,
 		},
+		FromAddress: toProtoAddress(shipment.FromAddress),
+		ToAddress:   toProtoAddress(shipment.ToAddress),
+		Customs:     toProtoCustoms(shipment.Customs),
 	}
 	resp, err := c.client.CreateShipment(ctx, req)
 	if err != nil {
-		// Convert gRPC error to status and check code
-		s, ok := status.FromError(err)
-		if ok && s.Code() == codes.Unavailable {
-			return models.Shipment{}, fmt.Errorf("shipment service is unavailable")
-		}
-		return models.Shipment{}, fmt.Errorf("failed to create shipment: %v", err)
+		// Validation failures come back with the offending fields
+		return models.Shipment{}, handleGRPCError(err, "shipment")
 	}
 	// Convert gRPC status (string) to models.ShipmentStatus
 
@@ -139,5 +151,116 @@ func (c *ShipmentClient) CreateShipment(ctx context.Context, shipment models.Shi
 			Name:        resp.Shipment.Carrier.Name,
 			TrackingURL: resp.Shipment.Carrier.TrackingUrl,
 		},
+		FromAddress: toModelAddress(resp.Shipment.FromAddress),
+		ToAddress:   toModelAddress(resp.Shipment.ToAddress),
+		Customs:     toModelCustoms(resp.Shipment.Customs),
 	}, nil
 }
+
+// ValidateAddress calls the Shipment Service's ValidateAddress endpoint.
+// An invalid address is returned as Valid=false, not as an error.
+func (c *ShipmentClient) ValidateAddress(ctx context.Context, address models.Address) (models.AddressValidation, error) {
+	resp, err := c.client.ValidateAddress(ctx, &proto.ValidateAddressRequest{Address: toProtoAddress(&address)})
+	if err != nil {
+		return models.AddressValidation{}, handleGRPCError(err, "shipment")
+	}
+	result := models.AddressValidation{
+		Valid:     resp.Valid,
+		Suggested: toModelAddress(resp.Suggested),
+	}
+	for _, issue := range resp.Issues {
+		result.Issues = append(result.Issues, models.AddressIssue{Field: issue.Field, Code: issue.Code, Message: issue.Message})
+	}
+	return result, nil
+}
+
+func toProtoAddress(a *models.Address) *proto.Address {
+	if a == nil {
+		return nil
+	}
+	return &proto.Address{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
+
+func toModelAddress(a *proto.Address) *models.Address {
+	if a == nil {
+		return nil
+	}
+	return &models.Address{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
+
+func toProtoCustoms(c *models.Customs) *proto.Customs {
+	if c == nil {
+		return nil
+	}
+	items := make([]*proto.CustomsItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = &proto.CustomsItem{
+			Description:   item.Description,
+			HsCode:        item.HSCode,
+			Quantity:      int32(item.Quantity),
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &proto.Customs{
+		ContentsType:        c.ContentsType,
+		ContentsExplanation: c.ContentsExplanation,
+		Incoterm:            c.Incoterm,
+		EelPfc:              c.EELPFC,
+		AesItn:              c.AESITN,
+		Items:               items,
+	}
+}
+
+func toModelCustoms(c *proto.Customs) *models.Customs {
+	if c == nil {
+		return nil
+	}
+	items := make([]models.CustomsItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = models.CustomsItem{
+			Description:   item.Description,
+			HSCode:        item.HsCode,
+			Quantity:      int(item.Quantity),
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &models.Customs{
+		ContentsType:        c.ContentsType,
+		ContentsExplanation: c.ContentsExplanation,
+		Incoterm:            c.Incoterm,
+		EELPFC:              c.EelPfc,
+		AESITN:              c.AesItn,
+		Items:               items,
+		DeclarationID:       c.DeclarationId,
+	}
+}
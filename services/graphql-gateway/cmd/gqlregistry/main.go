@@ -0,0 +1,132 @@
+//services/graphql-gateway/cmd/gqlregistry/main.go
+
+// Command gqlregistry keeps the gateway schema and its clients in step.
+//
+//	gqlregistry extract -out operations.json [-schema graph/schema/schema.graphqls] <client dirs...>
+//	gqlregistry check   -base old.graphqls | -base-ref origin/main [-schema graph/schema/schema.graphqls] [-operations operations.json]
+//
+// extract collects the named operations in .graphql/.gql files and gql/graphql
+// tagged templates into the manifest the gateway loads from
+// GRAPHQL_OPERATIONS_MANIFEST. check diffs the schema against the previous
+// version (a file, or the same path at a git ref), re-validates the manifest's
+// operations, and exits 1 on breaking changes or operations that no longer validate.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/persisted"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/registry"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	schemaPath := fs.String("schema", "graph/schema/schema.graphqls", "current schema")
+	out := fs.String("out", "operations.json", "extract: manifest to write")
+	base := fs.String("base", "", "check: previous schema file")
+	baseRef := fs.String("base-ref", "", "check: git ref holding the previous schema at the same path")
+	operations := fs.String("operations", "", "check: manifest whose operations must still validate")
+	fs.Parse(os.Args[2:])
+
+	switch cmd {
+	case "extract":
+		if fs.NArg() == 0 {
+			fatalf("extract: name at least one client directory")
+		}
+		ops, err := registry.Extract(fs.Args()...)
+		if err != nil {
+			fatalf("extract: %v", err)
+		}
+		if _, err := os.Stat(*schemaPath); err == nil {
+			schema := mustLoadSchema(*schemaPath, readFile(*schemaPath))
+			if errs := registry.Validate(schema, ops); len(errs) > 0 {
+				for _, err := range errs {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				fatalf("extract: %d operation(s) do not validate against %s", len(errs), *schemaPath)
+			}
+		}
+		raw, err := json.MarshalIndent(persisted.Manifest{Operations: ops}, "", "  ")
+		if err != nil {
+			fatalf("extract: %v", err)
+		}
+		if err := os.WriteFile(*out, append(raw, '\n'), 0o644); err != nil {
+			fatalf("extract: %v", err)
+		}
+		fmt.Printf("wrote %d operation(s) to %s\n", len(ops), *out)
+	case "check":
+		var previous []byte
+		switch {
+		case *base != "":
+			previous = readFile(*base)
+		case *baseRef != "":
+			// "./" makes the path relative to the working directory, not the repo root
+			raw, err := exec.Command("git", "show", *baseRef+":./"+*schemaPath).Output()
+			if err != nil {
+				fatalf("check: read %s at %s: %v", *schemaPath, *baseRef, err)
+			}
+			previous = raw
+		default:
+			fatalf("check: -base or -base-ref is required")
+		}
+		current := mustLoadSchema(*schemaPath, readFile(*schemaPath))
+		changes := registry.Diff(mustLoadSchema("base", previous), current)
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+		failed := registry.HasBreaking(changes)
+		if *operations != "" {
+			manifest, err := persisted.LoadManifest(*operations)
+			if err != nil {
+				fatalf("check: %v", err)
+			}
+			for _, err := range registry.Validate(current, manifest.Operations) {
+				fmt.Printf("BROKEN OPERATION %v\n", err)
+				failed = true
+			}
+		}
+		if failed {
+			fatalf("check: %s has breaking changes", *schemaPath)
+		}
+		fmt.Fprintf(os.Stderr, "%d change(s), none breaking\n", len(changes))
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func readFile(path string) []byte {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return raw
+}
+
+func mustLoadSchema(name string, raw []byte) *ast.Schema {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: name, Input: string(raw)})
+	if err != nil {
+		fatalf("%s: %v", name, err)
+	}
+	return schema
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: gqlregistry extract|check [flags]")
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -2,20 +2,56 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
-	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/client"          // gRPC client
-	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph"           // GraphQL resolvers
-	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/generated" // Generated GraphQL schema
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/client"             // gRPC client
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph"              // GraphQL resolvers
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/generated"    // Generated GraphQL schema
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/auth"      // Access token checks
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/live"      // Subscription fan-out
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/loader"    // Per-request batching
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/persisted" // Registered operations
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/ratelimit" // Plan limits
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/requestid" // X-Request-Id tagging
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/rest"      // REST facade
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/usage"     // Usage for billing
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	pkgkafka "github.com/Tanmoy095/LogiSynapse/shared/kafka"
+	_ "github.com/lib/pq"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // main starts the GraphQL server and connects to the Shipment Service.
 // Analogy: Opens the restaurant, sets up the waiter's intercom, and starts serving customers.
 func main() {
+	// Billing service: usage, invoices and payments for the billing fields
+	billingAddr := os.Getenv("BILLING_SERVICE_ADDR")
+	if billingAddr == "" {
+		billingAddr = "localhost:50053"
+	}
+	billingClient, err := client.NewBillingClient(billingAddr)
+	if err != nil {
+		log.Fatalf("failed to set up billing service client: %v", err)
+	}
+	defer billingClient.Close()
+
+	// Plan limits from billing-service: requests per minute and monthly
+	// shipments per tenant; what gets through is metered as usage
+	limiter := ratelimit.New(billingClient, newUsageMeter(), newRateLimitCounters())
+
 	// Get Shipment Service address from environment variable (or default)
 	addr := os.Getenv("SHIPMENT_SERVICE_ADDR")
 	if addr == "" {
@@ -24,18 +60,95 @@ func main() {
 
 	// Initialize gRPC client to connect to Shipment Service
 	// Analogy: Set up the waiter's intercom to call the kitchen
-	shipmentClient, err := client.NewShipmentClient(addr)
+	shipmentClient, err := client.NewShipmentClient(addr, ratelimit.ShipmentQuota(limiter))
 	if err != nil {
 		log.Fatalf("failed to connect to shipment service: %v", err)
 	}
 	defer shipmentClient.Close() // Close connection when server stops
 
-	// Initialize GraphQL resolver with gRPC client
-	resolver := graph.NewResolver(shipmentClient)
+	// Communications service: notification history for the notifications query
+	commsAddr := os.Getenv("COMMS_SERVICE_ADDR")
+	if commsAddr == "" {
+		commsAddr = "localhost:50055"
+	}
+	notificationClient, err := client.NewNotificationClient(commsAddr)
+	if err != nil {
+		log.Fatalf("failed to set up communications service client: %v", err)
+	}
+	defer notificationClient.Close()
+
+	// Authentication service: sign-up, sessions and tenant membership
+	authAddr := os.Getenv("AUTH_SERVICE_ADDR")
+	if authAddr == "" {
+		authAddr = "localhost:50054"
+	}
+	authClient, err := client.NewAuthClient(authAddr)
+	if err != nil {
+		log.Fatalf("failed to set up auth service client: %v", err)
+	}
+	defer authClient.Close()
+
+	// Live updates: shipment events from Kafka, fanned out to subscriptions
+	hub := live.NewHub(live.DefaultMaxPending)
+	if consumer := newShipmentEventConsumer(); consumer != nil {
+		defer consumer.Close()
+		go hub.Run(context.Background(), live.NewKafkaSource(consumer))
+	} else {
+		log.Println("KAFKA_BROKER not set: subscriptions will not receive shipment updates")
+	}
+
+	// Initialize GraphQL resolver with gRPC clients
+	resolver := graph.NewResolver(shipmentClient, notificationClient, billingClient, authClient, hub)
+
+	// Access tokens from authentication-service: verified here once, then the
+	// caller travels to the services as gRPC metadata. API keys are looked up
+	// in authentication-service and cached; keys revoked here leave the cache at once.
+	apiKeys := auth.NewAPIKeys(authClient)
+	authClient.UseAPIKeyCache(apiKeys)
+	verifier := newVerifier(apiKeys)
 
 	// Set up GraphQL endpoint at /query
 	// Analogy: Set up the dining room's service counter for customer orders
-	http.Handle("/query", handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver})))
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers: resolver,
+		Directives: generated.DirectiveRoot{
+			Auth:    graph.Auth,
+			HasRole: graph.HasRole,
+		},
+		Complexity: graph.Complexity(),
+	}))
+	// Same transports as handler.NewDefaultServer, plus token checks on the
+	// WebSocket's connection_init for subscriptions.
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              websocketAuth(verifier),
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	// Errors carry code, field, retryable and requestId extensions
+	srv.SetErrorPresenter(graph.ErrorPresenter)
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	// One nested query must not fan out into thousands of backend calls
+	srv.Use(extension.FixedComplexityLimit(envInt("GRAPHQL_MAX_COMPLEXITY", 1000)))
+	srv.Use(graph.DepthLimit{Max: envInt("GRAPHQL_MAX_DEPTH", 10)})
+	// Registered operations first, so that in allowlist mode APQ never
+	// caches a query the manifest does not contain
+	if allowlist := newAllowlist(); allowlist != nil {
+		srv.Use(graph.OperationAllowlist{List: allowlist})
+	}
+	srv.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](envInt("GRAPHQL_APQ_CACHE_SIZE", 1000))})
+	// Loaders go inside auth so their batched calls carry the caller, and so
+	// does the rate limit, which is per tenant; the request ID goes outside
+	// so even rejected tokens get one
+	http.Handle("/query", requestid.Middleware(auth.Middleware(verifier)(ratelimit.Middleware(limiter)(loader.Middleware(shipmentClient)(srv)))))
+
+	// Versioned REST API for merchant platforms: same tokens and error codes,
+	// OpenAPI document at /v1/openapi.json
+	restAPI := rest.New(shipmentClient.Service(), rest.NewMemoryIdempotencyStore(rest.IdempotencyTTL))
+	http.Handle("/v1/", requestid.Middleware(auth.MiddlewareWithReject(verifier, rest.Unauthorized)(ratelimit.MiddlewareWithReject(limiter, rest.Reject)(restAPI))))
 
 	// Set up GraphiQL playground at root (/) for easy testing
 	// Analogy: Provide a menu board for customers to write their orders
@@ -45,3 +158,141 @@ func main() {
 	log.Println("GraphiQL playground available at :8080/")
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
+
+// websocketAuth verifies the token (or API key) a subscription client sends in
+// connection_init. No token leaves the connection anonymous (and @auth
+// subscriptions refused); a bad token closes it.
+func websocketAuth(v *auth.Verifier) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		authz := payload.Authorization()
+		if authz == "" {
+			return ctx, &payload, nil
+		}
+		if !strings.Contains(authz, " ") {
+			authz = "Bearer " + authz // a bare token
+		}
+		id, err := v.Authenticate(ctx, authz)
+		if err != nil {
+			log.Printf("auth: rejected websocket credentials: %v", err)
+			return ctx, nil, errors.New("invalid or expired credentials")
+		}
+		return identity.NewContext(ctx, id), &payload, nil
+	}
+}
+
+// newShipmentEventConsumer reads the shipment events topic (KAFKA_TOPIC on
+// KAFKA_BROKER). Every replica needs every event, so the consumer group is
+// per host and starts at the newest event: updates from before the gateway
+// started are not replayed to anyone.
+func newShipmentEventConsumer() *pkgkafka.Consumer {
+	broker, topic := os.Getenv("KAFKA_BROKER"), os.Getenv("KAFKA_TOPIC")
+	if broker == "" || topic == "" {
+		return nil
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = strconv.Itoa(os.Getpid())
+	}
+	return pkgkafka.NewConsumer([]string{broker}, topic, "graphql-gateway-live-"+host,
+		pkgkafka.StartAtLatest(), pkgkafka.LowLatency(500*time.Millisecond))
+}
+
+// newUsageMeter publishes usage for billing-service to aggregate:
+//
+//	KAFKA_BROKER  broker (unset: usage is not reported, limits still apply)
+//	USAGE_TOPIC   topic billing-service reads (default usage-events)
+//
+// Usage counted in the last few seconds before the process exits is lost.
+func newUsageMeter() ratelimit.Meter {
+	broker, topic := os.Getenv("KAFKA_BROKER"), os.Getenv("USAGE_TOPIC")
+	if broker == "" {
+		log.Println("KAFKA_BROKER not set: API usage will not be reported to billing")
+		return nil
+	}
+	if topic == "" {
+		topic = "usage-events"
+	}
+	meter := usage.NewMeter(pkgkafka.NewKafkaProducer(broker, topic), usage.DefaultInterval)
+	go meter.Run(context.Background())
+	return meter
+}
+
+// newRateLimitCounters picks where plan limits are counted:
+//
+//	RATELIMIT_DATABASE_URL  Postgres with db/migrations applied, shared by all
+//	                        replicas (unset: each replica counts on its own)
+func newRateLimitCounters() ratelimit.Counters {
+	url := os.Getenv("RATELIMIT_DATABASE_URL")
+	if url == "" {
+		log.Println("RATELIMIT_DATABASE_URL not set: every gateway replica enforces plan limits on its own")
+		return nil
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		log.Fatalf("failed to open rate limit database: %v", err)
+	}
+	counters := ratelimit.NewPostgresCounters(db)
+	go counters.Run(context.Background())
+	return counters
+}
+
+// envInt reads a positive integer setting, falling back to def.
+func envInt(name string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+// newAllowlist loads the operations manifest written by gqlregistry extract:
+//
+//	GRAPHQL_OPERATIONS_MANIFEST  path of operations.json (unset: no manifest)
+//	GRAPHQL_ALLOWLIST            "enforce" rejects every operation not in it
+//
+// Without enforce the manifest only lets clients send registered hashes alone.
+func newAllowlist() *persisted.Allowlist {
+	path := os.Getenv("GRAPHQL_OPERATIONS_MANIFEST")
+	enforce := os.Getenv("GRAPHQL_ALLOWLIST") == "enforce"
+	if path == "" {
+		if enforce {
+			log.Fatal("GRAPHQL_ALLOWLIST=enforce needs GRAPHQL_OPERATIONS_MANIFEST")
+		}
+		return nil
+	}
+	manifest, err := persisted.LoadManifest(path)
+	if err != nil {
+		log.Fatalf("failed to load operations manifest: %v", err)
+	}
+	list := persisted.NewAllowlist(manifest, enforce)
+	log.Printf("loaded %d registered operation(s), allowlist enforced: %v", list.Len(), enforce)
+	return list
+}
+
+// newVerifier reads the token settings:
+//
+//	AUTH_JWT_SECRET  shared HS256 key
+//	AUTH_JWKS_URL    issuer's key set, for RS256/ES256 tokens
+//	AUTH_ISSUER      expected "iss" (optional)
+//	AUTH_AUDIENCE    expected "aud" (optional)
+//
+// With neither key every token is refused, so @auth fields are unreachable
+// rather than open. API keys ("Authorization: ApiKey <key>") are always
+// accepted and looked up in authentication-service.
+func newVerifier(keys *auth.APIKeys) *auth.Verifier {
+	cfg := auth.Config{
+		Issuer:   os.Getenv("AUTH_ISSUER"),
+		Audience: os.Getenv("AUTH_AUDIENCE"),
+		Leeway:   30 * time.Second,
+		APIKeys:  keys,
+	}
+	if secret := os.Getenv("AUTH_JWT_SECRET"); secret != "" {
+		cfg.SharedKey = []byte(secret)
+	}
+	if url := os.Getenv("AUTH_JWKS_URL"); url != "" {
+		cfg.JWKS = auth.NewJWKS(url, &http.Client{Timeout: 5 * time.Second})
+	}
+	if cfg.SharedKey == nil && cfg.JWKS == nil {
+		log.Println("AUTH_JWT_SECRET and AUTH_JWKS_URL not set: every access token will be refused")
+	}
+	return auth.NewVerifier(cfg)
+}
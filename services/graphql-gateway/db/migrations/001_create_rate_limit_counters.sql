@@ -0,0 +1,17 @@
+-- services/graphql-gateway/db/migrations/001_create_rate_limit_counters.sql
+
+-- Plan limit counters shared by the gateway replicas (ratelimit.PostgresCounters).
+-- A key names a tenant and the window it counts in, e.g.
+-- 'requests/<tenant>/2025-07-01T10:00:00Z' or 'shipments/<tenant>/2025-07'.
+-- Rows are deleted once expires_at has passed. The table is UNLOGGED: a crash
+-- empties it, and shipment counts are raised back to billing-service's on the
+-- next plan lookup.
+
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_counters (
+    key         TEXT PRIMARY KEY,
+    count       BIGINT NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires_at
+ON rate_limit_counters (expires_at);
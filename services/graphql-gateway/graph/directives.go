@@ -0,0 +1,47 @@
+package graph
+
+// graph/directives.go
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/model"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/auth"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Auth implements @auth: the field resolves only for a caller the auth
+// middleware authenticated.
+// Analogy: The waiter only takes orders from guests who showed their reservation.
+func Auth(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, ok := identity.FromContext(ctx); !ok {
+		return nil, unauthenticated()
+	}
+	return next(ctx)
+}
+
+// HasRole implements @hasRole(role): the caller must hold at least role in
+// their tenant.
+func HasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role model.Role) (interface{}, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, unauthenticated()
+	}
+	if !auth.HasRole(caller, string(role)) {
+		return nil, &gqlerror.Error{
+			Message:    "requires the " + string(role) + " role",
+			Extensions: map[string]interface{}{"code": apierror.CodeForbidden},
+		}
+	}
+	return next(ctx)
+}
+
+func unauthenticated() error {
+	return &gqlerror.Error{
+		Message:    "authentication required",
+		Extensions: map[string]interface{}{"code": apierror.CodeUnauthenticated},
+	}
+}
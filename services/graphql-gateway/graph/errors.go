@@ -0,0 +1,18 @@
+package graph
+
+// graph/errors.go
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter gives every error in a response the extensions described in
+// the schema header (code, field, retryable, requestId, ...).
+// Analogy: Whatever went wrong in the kitchen, the guest hears it in the same words.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	return apierror.Present(ctx, graphql.DefaultErrorPresenter(ctx, err), err)
+}
@@ -0,0 +1,74 @@
+package graph
+
+// graph/limits.go
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/generated"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/model"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/limits"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// DepthLimit refuses operations nested deeper than Max fields before anything
+// is resolved. The complexity limit bounds how much a query asks for; this
+// bounds how far it can chain nested lookups.
+// Analogy: The waiter won't take an order for "the side of the side of the side".
+type DepthLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+	if depth := limits.Depth(opCtx.Operation.SelectionSet); depth > d.Max {
+		return &gqlerror.Error{
+			Message:    fmt.Sprintf("operation is %d levels deep, the limit is %d", depth, d.Max),
+			Extensions: map[string]interface{}{"code": "DEPTH_LIMIT_EXCEEDED"},
+		}
+	}
+	return nil
+}
+
+// Complexity weighs list fields by the number of items they may return, so
+// the complexity limit counts what a page of results will cost downstream
+// rather than one per field.
+func Complexity() generated.ComplexityRoot {
+	var c generated.ComplexityRoot
+	c.Query.Shipments = func(childComplexity int, origin *string, status *model.ShipmentStatus, destination *string, limit *int, offset *int) int {
+		return listComplexity(childComplexity, limit, 10)
+	}
+	c.Query.Invoices = func(childComplexity int, limit *int) int {
+		return listComplexity(childComplexity, limit, 12)
+	}
+	c.Query.PaymentAttempts = func(childComplexity int, invoiceID *string, limit *int) int {
+		return listComplexity(childComplexity, limit, 50)
+	}
+	return c
+}
+
+// listComplexity is one for the field plus its children once per item.
+func listComplexity(childComplexity int, limit *int, defaultLimit int) int {
+	n := defaultLimit
+	if limit != nil && *limit > 0 {
+		n = *limit
+	}
+	return 1 + n*childComplexity
+}
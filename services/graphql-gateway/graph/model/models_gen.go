@@ -9,6 +9,64 @@ import (
 	"strconv"
 )
 
+type APIKey struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	CreatedBy  string        `json:"createdBy"`
+	CreatedAt  string        `json:"createdAt"`
+	ExpiresAt  *string       `json:"expiresAt,omitempty"`
+	LastUsedAt *string       `json:"lastUsedAt,omitempty"`
+	RevokedAt  *string       `json:"revokedAt,omitempty"`
+	ReplacedBy *string       `json:"replacedBy,omitempty"`
+}
+
+type Address struct {
+	Name    string `json:"name"`
+	Company string `json:"company"`
+	Street1 string `json:"street1"`
+	Street2 string `json:"street2"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	Zip     string `json:"zip"`
+	Country string `json:"country"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+}
+
+type AddressInput struct {
+	Name    *string `json:"name,omitempty"`
+	Company *string `json:"company,omitempty"`
+	Street1 string  `json:"street1"`
+	Street2 *string `json:"street2,omitempty"`
+	City    string  `json:"city"`
+	State   *string `json:"state,omitempty"`
+	Zip     *string `json:"zip,omitempty"`
+	Country string  `json:"country"`
+	Phone   *string `json:"phone,omitempty"`
+	Email   *string `json:"email,omitempty"`
+}
+
+type AddressIssue struct {
+	Field   *string `json:"field,omitempty"`
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+}
+
+type AddressValidation struct {
+	Valid     bool            `json:"valid"`
+	Issues    []*AddressIssue `json:"issues"`
+	Suggested *Address        `json:"suggested,omitempty"`
+}
+
+type AuthPayload struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+	TokenType    string `json:"tokenType"`
+}
+
 type Carrier struct {
 	Name        string `json:"name"`
 	TrackingURL string `json:"trackingUrl"`
@@ -19,6 +77,104 @@ type CarrierInput struct {
 	TrackingURL string `json:"trackingUrl"`
 }
 
+type CreateAPIKeyInput struct {
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	ExpiresAt *string       `json:"expiresAt,omitempty"`
+}
+
+type Customs struct {
+	ContentsType        string         `json:"contentsType"`
+	ContentsExplanation string         `json:"contentsExplanation"`
+	Incoterm            string         `json:"incoterm"`
+	EelPfc              string         `json:"eelPfc"`
+	AesItn              string         `json:"aesItn"`
+	Items               []*CustomsItem `json:"items"`
+	DeclarationID       string         `json:"declarationId"`
+}
+
+type CustomsInput struct {
+	ContentsType        string              `json:"contentsType"`
+	ContentsExplanation *string             `json:"contentsExplanation,omitempty"`
+	Incoterm            *string             `json:"incoterm,omitempty"`
+	EelPfc              *string             `json:"eelPfc,omitempty"`
+	AesItn              *string             `json:"aesItn,omitempty"`
+	Items               []*CustomsItemInput `json:"items"`
+}
+
+type CustomsItem struct {
+	Description   string  `json:"description"`
+	HsCode        string  `json:"hsCode"`
+	Quantity      int     `json:"quantity"`
+	ValueAmount   float64 `json:"valueAmount"`
+	ValueCurrency string  `json:"valueCurrency"`
+	OriginCountry string  `json:"originCountry"`
+	NetWeight     float64 `json:"netWeight"`
+	MassUnit      string  `json:"massUnit"`
+}
+
+type CustomsItemInput struct {
+	Description   string  `json:"description"`
+	HsCode        string  `json:"hsCode"`
+	Quantity      int     `json:"quantity"`
+	ValueAmount   float64 `json:"valueAmount"`
+	ValueCurrency string  `json:"valueCurrency"`
+	OriginCountry string  `json:"originCountry"`
+	NetWeight     float64 `json:"netWeight"`
+	MassUnit      string  `json:"massUnit"`
+}
+
+type Invoice struct {
+	ID          string         `json:"id"`
+	Year        int            `json:"year"`
+	Month       int            `json:"month"`
+	TotalCents  int            `json:"totalCents"`
+	Currency    string         `json:"currency"`
+	Status      InvoiceStatus  `json:"status"`
+	Lines       []*InvoiceLine `json:"lines"`
+	CreatedAt   string         `json:"createdAt"`
+	FinalizedAt *string        `json:"finalizedAt,omitempty"`
+	PaidAt      *string        `json:"paidAt,omitempty"`
+}
+
+type InvoiceLine struct {
+	ID             string `json:"id"`
+	UsageType      string `json:"usageType"`
+	Quantity       int    `json:"quantity"`
+	UnitPriceCents int    `json:"unitPriceCents"`
+	LineTotalCents int    `json:"lineTotalCents"`
+	Description    string `json:"description"`
+}
+
+type IssuedAPIKey struct {
+	APIKey *APIKey `json:"apiKey"`
+	Key    string  `json:"key"`
+}
+
+type LedgerEntry struct {
+	ReferenceID     string `json:"referenceId"`
+	TransactionType string `json:"transactionType"`
+	UsageType       string `json:"usageType"`
+	Quantity        int    `json:"quantity"`
+	UnitPriceCents  int    `json:"unitPriceCents"`
+	AmountCents     int    `json:"amountCents"`
+	Currency        string `json:"currency"`
+	Description     string `json:"description"`
+	Year            int    `json:"year"`
+	Month           int    `json:"month"`
+	CreatedAt       string `json:"createdAt"`
+}
+
+type Member struct {
+	UserID    string           `json:"userId"`
+	Email     string           `json:"email"`
+	FirstName string           `json:"firstName"`
+	LastName  string           `json:"lastName"`
+	Role      Role             `json:"role"`
+	Status    MembershipStatus `json:"status"`
+	JoinedAt  *string          `json:"joinedAt,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -28,11 +184,51 @@ type NewShipmentInput struct {
 	Destination string         `json:"destination"`
 	Eta         string         `json:"eta"`
 	Carrier     *CarrierInput  `json:"carrier"`
+	FromAddress *AddressInput  `json:"fromAddress,omitempty"`
+	ToAddress   *AddressInput  `json:"toAddress,omitempty"`
+	Customs     *CustomsInput  `json:"customs,omitempty"`
+}
+
+type Notification struct {
+	JobID             string              `json:"jobId"`
+	Event             string              `json:"event"`
+	Template          string              `json:"template"`
+	Channel           NotificationChannel `json:"channel"`
+	Recipient         string              `json:"recipient"`
+	Status            NotificationStatus  `json:"status"`
+	ProviderMessageID *string             `json:"providerMessageId,omitempty"`
+	LastError         *string             `json:"lastError,omitempty"`
+	Attempts          int                 `json:"attempts"`
+	CreatedAt         string              `json:"createdAt"`
+	UpdatedAt         string              `json:"updatedAt"`
+	SentAt            *string             `json:"sentAt,omitempty"`
+	DeliveredAt       *string             `json:"deliveredAt,omitempty"`
+}
+
+type PaymentAttempt struct {
+	ID                string               `json:"id"`
+	InvoiceID         string               `json:"invoiceId"`
+	Provider          string               `json:"provider"`
+	ProviderPaymentID *string              `json:"providerPaymentId,omitempty"`
+	Status            PaymentAttemptStatus `json:"status"`
+	AmountCents       int                  `json:"amountCents"`
+	Currency          string               `json:"currency"`
+	ErrorCode         *string              `json:"errorCode,omitempty"`
+	ErrorMessage      *string              `json:"errorMessage,omitempty"`
+	CreatedAt         string               `json:"createdAt"`
+	UpdatedAt         string               `json:"updatedAt"`
 }
 
 type Query struct {
 }
 
+type RegisterInput struct {
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
 type Shipment struct {
 	ID          string         `json:"id"`
 	Status      ShipmentStatus `json:"status"`
@@ -40,25 +236,476 @@ type Shipment struct {
 	Destination string         `json:"destination"`
 	Eta         string         `json:"eta"`
 	Carrier     *Carrier       `json:"carrier"`
+	FromAddress *Address       `json:"fromAddress,omitempty"`
+	ToAddress   *Address       `json:"toAddress,omitempty"`
+	Customs     *Customs       `json:"customs,omitempty"`
+}
+
+type ShipmentFilter struct {
+	IDs         []string        `json:"ids,omitempty"`
+	Origin      *string         `json:"origin,omitempty"`
+	Destination *string         `json:"destination,omitempty"`
+	Status      *ShipmentStatus `json:"status,omitempty"`
+}
+
+type Subscription struct {
+}
+
+type TenantMembership struct {
+	TenantID     string           `json:"tenantId"`
+	Name         string           `json:"name"`
+	TenantStatus TenantStatus     `json:"tenantStatus"`
+	Role         Role             `json:"role"`
+	Status       MembershipStatus `json:"status"`
+}
+
+type UsageItem struct {
+	UsageType string `json:"usageType"`
+	Quantity  int    `json:"quantity"`
+}
+
+type UsageSummary struct {
+	Year  int          `json:"year"`
+	Month int          `json:"month"`
+	Items []*UsageItem `json:"items"`
+}
+
+type User struct {
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	FirstName    string     `json:"firstName"`
+	LastName     string     `json:"lastName"`
+	Status       UserStatus `json:"status"`
+	IsSuperAdmin bool       `json:"isSuperAdmin"`
+	CreatedAt    string     `json:"createdAt"`
+}
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeShipmentsRead  APIKeyScope = "SHIPMENTS_READ"
+	APIKeyScopeShipmentsWrite APIKeyScope = "SHIPMENTS_WRITE"
+)
+
+var AllAPIKeyScope = []APIKeyScope{
+	APIKeyScopeShipmentsRead,
+	APIKeyScopeShipmentsWrite,
+}
+
+func (e APIKeyScope) IsValid() bool {
+	switch e {
+	case APIKeyScopeShipmentsRead, APIKeyScopeShipmentsWrite:
+		return true
+	}
+	return false
+}
+
+func (e APIKeyScope) String() string {
+	return string(e)
+}
+
+func (e *APIKeyScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeyScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APIKeyScope", str)
+	}
+	return nil
+}
+
+func (e APIKeyScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *APIKeyScope) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e APIKeyScope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft     InvoiceStatus = "DRAFT"
+	InvoiceStatusFinalized InvoiceStatus = "FINALIZED"
+	InvoiceStatusPaid      InvoiceStatus = "PAID"
+	InvoiceStatusVoid      InvoiceStatus = "VOID"
+)
+
+var AllInvoiceStatus = []InvoiceStatus{
+	InvoiceStatusDraft,
+	InvoiceStatusFinalized,
+	InvoiceStatusPaid,
+	InvoiceStatusVoid,
+}
+
+func (e InvoiceStatus) IsValid() bool {
+	switch e {
+	case InvoiceStatusDraft, InvoiceStatusFinalized, InvoiceStatusPaid, InvoiceStatusVoid:
+		return true
+	}
+	return false
+}
+
+func (e InvoiceStatus) String() string {
+	return string(e)
+}
+
+func (e *InvoiceStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InvoiceStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InvoiceStatus", str)
+	}
+	return nil
+}
+
+func (e InvoiceStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *InvoiceStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e InvoiceStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MembershipStatus string
+
+const (
+	MembershipStatusActive  MembershipStatus = "ACTIVE"
+	MembershipStatusPending MembershipStatus = "PENDING"
+)
+
+var AllMembershipStatus = []MembershipStatus{
+	MembershipStatusActive,
+	MembershipStatusPending,
+}
+
+func (e MembershipStatus) IsValid() bool {
+	switch e {
+	case MembershipStatusActive, MembershipStatusPending:
+		return true
+	}
+	return false
+}
+
+func (e MembershipStatus) String() string {
+	return string(e)
+}
+
+func (e *MembershipStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MembershipStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MembershipStatus", str)
+	}
+	return nil
+}
+
+func (e MembershipStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MembershipStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MembershipStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+	NotificationChannelSms   NotificationChannel = "SMS"
+)
+
+var AllNotificationChannel = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelSms,
+}
+
+func (e NotificationChannel) IsValid() bool {
+	switch e {
+	case NotificationChannelEmail, NotificationChannelSms:
+		return true
+	}
+	return false
+}
+
+func (e NotificationChannel) String() string {
+	return string(e)
+}
+
+func (e *NotificationChannel) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationChannel(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationChannel", str)
+	}
+	return nil
+}
+
+func (e NotificationChannel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationChannel) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationChannel) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationStatus string
+
+const (
+	NotificationStatusQueued       NotificationStatus = "QUEUED"
+	NotificationStatusSent         NotificationStatus = "SENT"
+	NotificationStatusDelivered    NotificationStatus = "DELIVERED"
+	NotificationStatusFailed       NotificationStatus = "FAILED"
+	NotificationStatusDeadLettered NotificationStatus = "DEAD_LETTERED"
+	NotificationStatusBounced      NotificationStatus = "BOUNCED"
+	NotificationStatusSkipped      NotificationStatus = "SKIPPED"
+)
+
+var AllNotificationStatus = []NotificationStatus{
+	NotificationStatusQueued,
+	NotificationStatusSent,
+	NotificationStatusDelivered,
+	NotificationStatusFailed,
+	NotificationStatusDeadLettered,
+	NotificationStatusBounced,
+	NotificationStatusSkipped,
+}
+
+func (e NotificationStatus) IsValid() bool {
+	switch e {
+	case NotificationStatusQueued, NotificationStatusSent, NotificationStatusDelivered, NotificationStatusFailed, NotificationStatusDeadLettered, NotificationStatusBounced, NotificationStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func (e NotificationStatus) String() string {
+	return string(e)
+}
+
+func (e *NotificationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationStatus", str)
+	}
+	return nil
+}
+
+func (e NotificationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PaymentAttemptStatus string
+
+const (
+	PaymentAttemptStatusPending        PaymentAttemptStatus = "PENDING"
+	PaymentAttemptStatusSucceeded      PaymentAttemptStatus = "SUCCEEDED"
+	PaymentAttemptStatusFailed         PaymentAttemptStatus = "FAILED"
+	PaymentAttemptStatusRequiresAction PaymentAttemptStatus = "REQUIRES_ACTION"
+)
+
+var AllPaymentAttemptStatus = []PaymentAttemptStatus{
+	PaymentAttemptStatusPending,
+	PaymentAttemptStatusSucceeded,
+	PaymentAttemptStatusFailed,
+	PaymentAttemptStatusRequiresAction,
+}
+
+func (e PaymentAttemptStatus) IsValid() bool {
+	switch e {
+	case PaymentAttemptStatusPending, PaymentAttemptStatusSucceeded, PaymentAttemptStatusFailed, PaymentAttemptStatusRequiresAction:
+		return true
+	}
+	return false
+}
+
+func (e PaymentAttemptStatus) String() string {
+	return string(e)
+}
+
+func (e *PaymentAttemptStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PaymentAttemptStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PaymentAttemptStatus", str)
+	}
+	return nil
+}
+
+func (e PaymentAttemptStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PaymentAttemptStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PaymentAttemptStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleMember Role = "MEMBER"
+	RoleAdmin  Role = "ADMIN"
+	RoleOwner  Role = "OWNER"
+)
+
+var AllRole = []Role{
+	RoleMember,
+	RoleAdmin,
+	RoleOwner,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleMember, RoleAdmin, RoleOwner:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
 
 type ShipmentStatus string
 
 const (
-	ShipmentStatusInTransit ShipmentStatus = "IN_TRANSIT"
-	ShipmentStatusDelivered ShipmentStatus = "DELIVERED"
-	ShipmentStatusPending   ShipmentStatus = "PENDING"
+	ShipmentStatusInTransit  ShipmentStatus = "IN_TRANSIT"
+	ShipmentStatusDelivered  ShipmentStatus = "DELIVERED"
+	ShipmentStatusPending    ShipmentStatus = "PENDING"
+	ShipmentStatusPreTransit ShipmentStatus = "PRE_TRANSIT"
+	ShipmentStatusCancelled  ShipmentStatus = "CANCELLED"
 )
 
 var AllShipmentStatus = []ShipmentStatus{
 	ShipmentStatusInTransit,
 	ShipmentStatusDelivered,
 	ShipmentStatusPending,
+	ShipmentStatusPreTransit,
+	ShipmentStatusCancelled,
 }
 
 func (e ShipmentStatus) IsValid() bool {
 	switch e {
-	case ShipmentStatusInTransit, ShipmentStatusDelivered, ShipmentStatusPending:
+	case ShipmentStatusInTransit, ShipmentStatusDelivered, ShipmentStatusPending, ShipmentStatusPreTransit, ShipmentStatusCancelled:
 		return true
 	}
 	return false
@@ -98,3 +745,115 @@ func (e ShipmentStatus) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type TenantStatus string
+
+const (
+	TenantStatusActive    TenantStatus = "ACTIVE"
+	TenantStatusSuspended TenantStatus = "SUSPENDED"
+)
+
+var AllTenantStatus = []TenantStatus{
+	TenantStatusActive,
+	TenantStatusSuspended,
+}
+
+func (e TenantStatus) IsValid() bool {
+	switch e {
+	case TenantStatusActive, TenantStatusSuspended:
+		return true
+	}
+	return false
+}
+
+func (e TenantStatus) String() string {
+	return string(e)
+}
+
+func (e *TenantStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TenantStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TenantStatus", str)
+	}
+	return nil
+}
+
+func (e TenantStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TenantStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TenantStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "ACTIVE"
+	UserStatusSuspended UserStatus = "SUSPENDED"
+	UserStatusDeleted   UserStatus = "DELETED"
+)
+
+var AllUserStatus = []UserStatus{
+	UserStatusActive,
+	UserStatusSuspended,
+	UserStatusDeleted,
+}
+
+func (e UserStatus) IsValid() bool {
+	switch e {
+	case UserStatusActive, UserStatusSuspended, UserStatusDeleted:
+		return true
+	}
+	return false
+}
+
+func (e UserStatus) String() string {
+	return string(e)
+}
+
+func (e *UserStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserStatus", str)
+	}
+	return nil
+}
+
+func (e UserStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package graph
+
+// graph/persisted.go
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/persisted"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// OperationAllowlist serves the operations of the manifest written by
+// gqlregistry extract. Use it before the APQ extension: a hash from the
+// manifest then needs no registration round-trip, and in enforce mode an
+// unregistered query is refused before APQ could cache it.
+type OperationAllowlist struct {
+	List *persisted.Allowlist
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = OperationAllowlist{}
+
+func (OperationAllowlist) ExtensionName() string {
+	return "OperationAllowlist"
+}
+
+func (OperationAllowlist) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a OperationAllowlist) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	query, err := a.List.Resolve(params.Query, params.OperationName, apqHash(params.Extensions))
+	if err != nil {
+		return &gqlerror.Error{
+			Message:    err.Error(),
+			Extensions: map[string]interface{}{"code": "OPERATION_NOT_ALLOWED"},
+		}
+	}
+	params.Query = query
+	return nil
+}
+
+// apqHash returns extensions.persistedQuery.sha256Hash, as sent by APQ clients.
+func apqHash(extensions map[string]interface{}) string {
+	pq, _ := extensions["persistedQuery"].(map[string]interface{})
+	hash, _ := pq["sha256Hash"].(string)
+	return hash
+}
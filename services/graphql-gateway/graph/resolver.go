@@ -3,18 +3,25 @@ package graph
 // Resolver serves as dependency injection container for your app
 // graph/resolver.go
 
+//go:generate go run github.com/99designs/gqlgen generate --config ../gqlgen.yml
+
 import (
 	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/client" // gRPC client
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/live"
 )
 
 // Resolver holds dependencies for GraphQL resolvers.
 // Analogy: The waiter, who uses the intercom (gRPC client) to talk to the kitchen.
 type Resolver struct {
-	shipmentClient *client.ShipmentClient
+	shipmentClient     *client.ShipmentClient
+	notificationClient *client.NotificationClient // communications service: delivery history
+	billingClient      *client.BillingClient      // billing service: usage, invoices, payments
+	authClient         *client.AuthClient         // authentication service: users, sessions, tenants
+	hub                *live.Hub                  // shipment events for subscriptions
 }
 
 // NewResolver initializes the resolver with a gRPC client.
 // Analogy: Hires a waiter and gives them the intercom to contact the kitchen.
-func NewResolver(shipmentClient *client.ShipmentClient) *Resolver {
-	return &Resolver{shipmentClient: shipmentClient}
+func NewResolver(shipmentClient *client.ShipmentClient, notificationClient *client.NotificationClient, billingClient *client.BillingClient, authClient *client.AuthClient, hub *live.Hub) *Resolver {
+	return &Resolver{shipmentClient: shipmentClient, notificationClient: notificationClient, billingClient: billingClient, authClient: authClient, hub: hub}
 }
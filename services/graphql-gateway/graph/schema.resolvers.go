@@ -6,9 +6,14 @@ package graph
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/generated"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/live"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/loader"
 	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
 
 	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/graph/model" // Generated GraphQL models
@@ -42,6 +47,10 @@ func ToProtoShipmentStatus(s string) proto.ShipmentStatus {
 		return proto.ShipmentStatus_DELIVERED
 	case "PENDING":
 		return proto.ShipmentStatus_PENDING
+	case "PRE_TRANSIT":
+		return proto.ShipmentStatus_PRE_TRANSIT
+	case "CANCELLED":
+		return proto.ShipmentStatus_CANCELLED
 	default:
 		return proto.ShipmentStatus(0) // or handle error/unknown
 	}
@@ -60,6 +69,9 @@ func (r *mutationResolver) CreateShipment(ctx context.Context, input model.NewSh
 			Name:        input.Carrier.Name,
 			TrackingURL: input.Carrier.TrackingURL,
 		},
+		FromAddress: toModelAddress(input.FromAddress),
+		ToAddress:   toModelAddress(input.ToAddress),
+		Customs:     toModelCustoms(input.Customs),
 	}
 
 	// Call the gRPC client to create the shipment
@@ -79,11 +91,179 @@ func (r *mutationResolver) CreateShipment(ctx context.Context, input model.NewSh
 			Name:        created.Carrier.Name,
 			TrackingURL: created.Carrier.TrackingURL,
 		},
+		FromAddress: toGraphQLAddress(created.FromAddress),
+		ToAddress:   toGraphQLAddress(created.ToAddress),
+		Customs:     toGraphQLCustoms(created.Customs),
 	}
 	// Analogy: Waiter serves the prepared dish to the customer
 	return result, nil
 }
 
+// PayInvoice charges one of the caller's tenant's invoices.
+// Analogy: Waiter takes the customer's card to the till and brings back the receipt.
+func (r *mutationResolver) PayInvoice(ctx context.Context, id string) (*model.Invoice, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.PayInvoice")
+	defer span.End()
+
+	inv, err := r.billingClient.PayInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toGraphQLInvoice(inv), nil
+}
+
+// Register creates a user; the client logs in afterwards.
+// Analogy: Front desk writes a new guest into the book but doesn't seat them yet.
+func (r *mutationResolver) Register(ctx context.Context, input model.RegisterInput) (string, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.Register")
+	defer span.End()
+
+	return r.authClient.Register(ctx, input.Email, input.Password, input.FirstName, input.LastName)
+}
+
+// Login exchanges email and password for a token pair.
+// Analogy: Guest shows their reservation and gets a wristband (access) and a claim ticket (refresh).
+func (r *mutationResolver) Login(ctx context.Context, email string, password string, deviceFingerprint *string) (*model.AuthPayload, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.Login")
+	defer span.End()
+
+	tokens, err := r.authClient.Login(ctx, email, password, derefString(deviceFingerprint))
+	if err != nil {
+		return nil, err
+	}
+	return toGraphQLAuthPayload(tokens), nil
+}
+
+// RefreshSession swaps a refresh token for a new pair.
+func (r *mutationResolver) RefreshSession(ctx context.Context, refreshToken string, deviceFingerprint *string) (*model.AuthPayload, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.RefreshSession")
+	defer span.End()
+
+	tokens, err := r.authClient.RefreshSession(ctx, refreshToken, derefString(deviceFingerprint))
+	if err != nil {
+		return nil, err
+	}
+	return toGraphQLAuthPayload(tokens), nil
+}
+
+// Logout ends the login a refresh token belongs to.
+func (r *mutationResolver) Logout(ctx context.Context, refreshToken string) (bool, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.Logout")
+	defer span.End()
+
+	if err := r.authClient.Logout(ctx, refreshToken); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RequestTenant asks the platform for a new tenant owned by the caller.
+func (r *mutationResolver) RequestTenant(ctx context.Context, name string) (string, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.RequestTenant")
+	defer span.End()
+
+	return r.authClient.RequestTenant(ctx, name)
+}
+
+// InviteMember invites a registered user into a tenant.
+// Analogy: A regular vouches for a friend at the door; the friend still has to walk in.
+func (r *mutationResolver) InviteMember(ctx context.Context, tenantID string, email string, role model.Role) (bool, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.InviteMember")
+	defer span.End()
+
+	if err := r.authClient.InviteMember(ctx, tenantID, email, strings.ToLower(string(role))); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AcceptInvitation activates the caller's pending membership.
+func (r *mutationResolver) AcceptInvitation(ctx context.Context, tenantID string) (bool, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.AcceptInvitation")
+	defer span.End()
+
+	if err := r.authClient.AcceptInvitation(ctx, tenantID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RevokeMember removes a member from a tenant.
+func (r *mutationResolver) RevokeMember(ctx context.Context, tenantID string, userID string) (bool, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.RevokeMember")
+	defer span.End()
+
+	if err := r.authClient.RevokeMember(ctx, tenantID, userID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TransferOwnership hands a tenant to another member.
+func (r *mutationResolver) TransferOwnership(ctx context.Context, tenantID string, newOwnerUserID string) (bool, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.TransferOwnership")
+	defer span.End()
+
+	if err := r.authClient.TransferOwnership(ctx, tenantID, newOwnerUserID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateAPIKey issues a tenant API key; the raw key is in the result only this once.
+// Analogy: Cutting a spare key for the delivery company; the locksmith keeps no copy.
+func (r *mutationResolver) CreateAPIKey(ctx context.Context, tenantID string, input model.CreateAPIKeyInput) (*model.IssuedAPIKey, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.CreateAPIKey")
+	defer span.End()
+
+	scopes := make([]string, len(input.Scopes))
+	for i, s := range input.Scopes {
+		scopes[i] = apiKeyScope(s)
+	}
+	expiresAt := ""
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+	key, raw, err := r.authClient.CreateAPIKey(ctx, tenantID, input.Name, scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &model.IssuedAPIKey{APIKey: toGraphAPIKey(key), Key: raw}, nil
+}
+
+// RotateAPIKey issues a key's successor and winds the old key down.
+func (r *mutationResolver) RotateAPIKey(ctx context.Context, tenantID string, id string, gracePeriodSeconds *int) (*model.IssuedAPIKey, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.RotateAPIKey")
+	defer span.End()
+
+	grace := 0
+	if gracePeriodSeconds != nil {
+		grace = *gracePeriodSeconds
+	}
+	if grace < 0 || grace > 86400 {
+		e := apierror.New(apierror.CodeBadUserInput, "gracePeriodSeconds must be from 0 to 86400")
+		e.Fields = []apierror.FieldError{{Field: "gracePeriodSeconds", Message: e.Message}}
+		return nil, e
+	}
+	key, raw, err := r.authClient.RotateAPIKey(ctx, tenantID, id, int32(grace))
+	if err != nil {
+		return nil, err
+	}
+	return &model.IssuedAPIKey{APIKey: toGraphAPIKey(key), Key: raw}, nil
+}
+
+// RevokeAPIKey stops a key at once.
+func (r *mutationResolver) RevokeAPIKey(ctx context.Context, tenantID string, id string) (*model.APIKey, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "mutation.RevokeAPIKey")
+	defer span.End()
+
+	key, err := r.authClient.RevokeAPIKey(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toGraphAPIKey(key), nil
+}
+
 type queryResolver struct{ *Resolver }
 
 // Shipments handles the GraphQL query for fetching shipments.
@@ -133,12 +313,43 @@ func (r *queryResolver) Shipments(ctx context.Context, origin *string, status *m
 				Name:        s.Carrier.Name,
 				TrackingURL: s.Carrier.TrackingURL,
 			},
+			FromAddress: toGraphQLAddress(s.FromAddress),
+			ToAddress:   toGraphQLAddress(s.ToAddress),
+			Customs:     toGraphQLCustoms(s.Customs),
 		}
 	}
 	// Analogy: Waiter puts the kitchen's dishes on fancy plates for the customer
 	return result, nil
 }
 
+// Shipment returns one shipment through the request's shipment loader.
+// Analogy: Waiter collects every "how's my order?" at the table and asks the kitchen once.
+func (r *queryResolver) Shipment(ctx context.Context, id string) (*model.Shipment, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.Shipment")
+	defer span.End()
+
+	var s *models.Shipment
+	if loaders := loader.For(ctx); loaders != nil {
+		var err error
+		if s, err = loaders.Shipment.Load(ctx, id); err != nil {
+			return nil, err
+		}
+	} else {
+		// No request loaders (WebSocket): look it up directly
+		found, err := r.shipmentClient.GetShipmentsByIDs(ctx, []string{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 0 {
+			s = &found[0]
+		}
+	}
+	if s == nil {
+		return nil, nil
+	}
+	return toGraphQLShipment(*s), nil
+}
+
 // Health checks the status of the GraphQL Gateway.
 // Analogy: Waiter confirms the dining room is open and can contact the kitchen.
 func (r *queryResolver) Health(ctx context.Context) (string, error) {
@@ -150,6 +361,526 @@ func (r *queryResolver) Health(ctx context.Context) (string, error) {
 	return "OK", nil
 }
 
+// ValidateAddress checks an address without creating anything.
+// Analogy: Waiter asks the kitchen whether they can deliver to this address before taking the order.
+func (r *queryResolver) ValidateAddress(ctx context.Context, input model.AddressInput) (*model.AddressValidation, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.ValidateAddress")
+	defer span.End()
+
+	validation, err := r.shipmentClient.ValidateAddress(ctx, *toModelAddress(&input))
+	if err != nil {
+		return nil, err
+	}
+	result := &model.AddressValidation{
+		Valid:     validation.Valid,
+		Issues:    make([]*model.AddressIssue, len(validation.Issues)),
+		Suggested: toGraphQLAddress(validation.Suggested),
+	}
+	for i, issue := range validation.Issues {
+		result.Issues[i] = &model.AddressIssue{Code: issue.Code, Message: issue.Message}
+		if issue.Field != "" {
+			field := issue.Field
+			result.Issues[i].Field = &field
+		}
+	}
+	return result, nil
+}
+
+// Notifications returns the delivery history for a shipment.
+// Analogy: Waiter checks with the front desk whether the customer was told about their order.
+func (r *queryResolver) Notifications(ctx context.Context, shipmentID string) ([]*model.Notification, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.Notifications")
+	defer span.End()
+
+	// Scoped to the caller's tenant; super admins see every tenant's.
+	caller, _ := identity.FromContext(ctx)
+	tenantID := caller.TenantID
+	if caller.SuperAdmin {
+		tenantID = ""
+	}
+	list, err := r.notificationClient.ListByShipment(ctx, tenantID, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Notification, len(list))
+	for i, n := range list {
+		out[i] = &model.Notification{
+			JobID:             n.JobID,
+			Event:             n.Event,
+			Template:          n.Template,
+			Channel:           model.NotificationChannel(strings.ToUpper(n.Channel)),
+			Recipient:         n.Recipient,
+			Status:            model.NotificationStatus(strings.ToUpper(n.Status)),
+			ProviderMessageID: optionalString(n.ProviderMessageID),
+			LastError:         optionalString(n.LastError),
+			Attempts:          n.Attempts,
+			CreatedAt:         n.CreatedAt,
+			UpdatedAt:         n.UpdatedAt,
+			SentAt:            optionalString(n.SentAt),
+			DeliveredAt:       optionalString(n.DeliveredAt),
+		}
+	}
+	return out, nil
+}
+
+// CurrentUsage returns the tenant's usage so far this billing month.
+// Analogy: Waiter reads out the running tab.
+func (r *queryResolver) CurrentUsage(ctx context.Context) (*model.UsageSummary, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.CurrentUsage")
+	defer span.End()
+
+	usage, err := r.billingClient.CurrentUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &model.UsageSummary{Year: usage.Year, Month: usage.Month, Items: make([]*model.UsageItem, len(usage.Items))}
+	for i, item := range usage.Items {
+		out.Items[i] = &model.UsageItem{UsageType: item.UsageType, Quantity: int(item.Quantity)}
+	}
+	return out, nil
+}
+
+// Invoices lists the tenant's invoices without their lines.
+func (r *queryResolver) Invoices(ctx context.Context, limit *int) ([]*model.Invoice, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.Invoices")
+	defer span.End()
+
+	l := int32(0) // billing-service default
+	if limit != nil {
+		l = int32(*limit)
+	}
+	list, err := r.billingClient.ListInvoices(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Invoice, len(list))
+	for i, inv := range list {
+		out[i] = toGraphQLInvoice(inv)
+	}
+	return out, nil
+}
+
+// Invoice returns one invoice with its lines, or null.
+func (r *queryResolver) Invoice(ctx context.Context, id string) (*model.Invoice, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.Invoice")
+	defer span.End()
+
+	inv, err := r.billingClient.GetInvoice(ctx, id)
+	if err != nil || inv == nil {
+		return nil, err
+	}
+	return toGraphQLInvoice(*inv), nil
+}
+
+// LedgerEntries returns the ledger of one billing month.
+func (r *queryResolver) LedgerEntries(ctx context.Context, year *int, month *int) ([]*model.LedgerEntry, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.LedgerEntries")
+	defer span.End()
+
+	var y, m int32 // zero: the current month
+	if year != nil {
+		y = int32(*year)
+	}
+	if month != nil {
+		m = int32(*month)
+	}
+	entries, err := r.billingClient.ListLedgerEntries(ctx, y, m)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.LedgerEntry, len(entries))
+	for i, e := range entries {
+		out[i] = &model.LedgerEntry{
+			ReferenceID:     e.ReferenceID,
+			TransactionType: e.TransactionType,
+			UsageType:       e.UsageType,
+			Quantity:        int(e.Quantity),
+			UnitPriceCents:  int(e.UnitPriceCents),
+			AmountCents:     int(e.AmountCents),
+			Currency:        e.Currency,
+			Description:     e.Description,
+			Year:            e.Year,
+			Month:           e.Month,
+			CreatedAt:       e.CreatedAt,
+		}
+	}
+	return out, nil
+}
+
+// PaymentAttempts returns the tenant's payment attempts, newest first.
+func (r *queryResolver) PaymentAttempts(ctx context.Context, invoiceID *string, limit *int) ([]*model.PaymentAttempt, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.PaymentAttempts")
+	defer span.End()
+
+	id, l := "", int32(0)
+	if invoiceID != nil {
+		id = *invoiceID
+	}
+	if limit != nil {
+		l = int32(*limit)
+	}
+	list, err := r.billingClient.ListPaymentAttempts(ctx, id, l)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.PaymentAttempt, len(list))
+	for i, a := range list {
+		out[i] = &model.PaymentAttempt{
+			ID:                a.ID,
+			InvoiceID:         a.InvoiceID,
+			Provider:          a.Provider,
+			ProviderPaymentID: optionalString(a.ProviderPaymentID),
+			Status:            model.PaymentAttemptStatus(a.Status),
+			AmountCents:       int(a.AmountCents),
+			Currency:          a.Currency,
+			ErrorCode:         optionalString(a.ErrorCode),
+			ErrorMessage:      optionalString(a.ErrorMessage),
+			CreatedAt:         a.CreatedAt,
+			UpdatedAt:         a.UpdatedAt,
+		}
+	}
+	return out, nil
+}
+
+// Me returns the calling user.
+// Analogy: Guest asks the front desk what name their reservation is under.
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.Me")
+	defer span.End()
+
+	u, err := r.authClient.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.User{
+		ID:           u.ID,
+		Email:        u.Email,
+		FirstName:    u.FirstName,
+		LastName:     u.LastName,
+		Status:       model.UserStatus(strings.ToUpper(u.Status)),
+		IsSuperAdmin: u.IsSuperAdmin,
+		CreatedAt:    u.CreatedAt,
+	}, nil
+}
+
+// MyTenants lists the tenants the caller owns or belongs to.
+func (r *queryResolver) MyTenants(ctx context.Context) ([]*model.TenantMembership, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.MyTenants")
+	defer span.End()
+
+	tenants, err := r.authClient.MyTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.TenantMembership, len(tenants))
+	for i, t := range tenants {
+		out[i] = &model.TenantMembership{
+			TenantID:     t.TenantID,
+			Name:         t.Name,
+			TenantStatus: model.TenantStatus(strings.ToUpper(t.TenantStatus)),
+			Role:         model.Role(strings.ToUpper(t.Role)),
+			Status:       model.MembershipStatus(strings.ToUpper(t.MembershipStatus)),
+		}
+	}
+	return out, nil
+}
+
+// TenantMembers lists a tenant's owner and members.
+func (r *queryResolver) TenantMembers(ctx context.Context, tenantID string) ([]*model.Member, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.TenantMembers")
+	defer span.End()
+
+	members, err := r.authClient.TenantMembers(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Member, len(members))
+	for i, m := range members {
+		out[i] = &model.Member{
+			UserID:    m.UserID,
+			Email:     m.Email,
+			FirstName: m.FirstName,
+			LastName:  m.LastName,
+			Role:      model.Role(strings.ToUpper(m.Role)),
+			Status:    model.MembershipStatus(strings.ToUpper(m.Status)),
+			JoinedAt:  optionalString(m.JoinedAt),
+		}
+	}
+	return out, nil
+}
+
+// APIKeys lists a tenant's API keys.
+func (r *queryResolver) APIKeys(ctx context.Context, tenantID string) ([]*model.APIKey, error) {
+	ctx, span := otel.Tracer("graphql-gateway").Start(ctx, "query.APIKeys")
+	defer span.End()
+
+	keys, err := r.authClient.APIKeys(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.APIKey, len(keys))
+	for i, k := range keys {
+		out[i] = toGraphAPIKey(k)
+	}
+	return out, nil
+}
+
+// Subscription returns the SubscriptionResolver implementation.
+// Analogy: Defines the waiter's job of calling out to customers when their order changes.
+func (r *Resolver) Subscription() generated.SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+// ShipmentUpdated pushes one shipment whenever it changes.
+func (r *subscriptionResolver) ShipmentUpdated(ctx context.Context, id string) (<-chan *model.Shipment, error) {
+	return r.subscribe(ctx, live.Filter{IDs: []string{id}})
+}
+
+// ShipmentsUpdated pushes every changed shipment that matches the filter.
+func (r *subscriptionResolver) ShipmentsUpdated(ctx context.Context, filter *model.ShipmentFilter) (<-chan *model.Shipment, error) {
+	var f live.Filter
+	if filter != nil {
+		f.IDs = filter.IDs
+		if filter.Origin != nil {
+			f.Origin = *filter.Origin
+		}
+		if filter.Destination != nil {
+			f.Destination = *filter.Destination
+		}
+		if filter.Status != nil {
+			status := ToProtoShipmentStatus(string(*filter.Status))
+			f.Status = &status
+		}
+	}
+	return r.subscribe(ctx, f)
+}
+
+// subscribe registers with the hub (which applies the tenant check) and
+// converts its updates until the client goes away.
+func (r *subscriptionResolver) subscribe(ctx context.Context, f live.Filter) (<-chan *model.Shipment, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, unauthenticated()
+	}
+	updates := r.hub.Subscribe(ctx, caller, f)
+	out := make(chan *model.Shipment)
+	go func() {
+		defer close(out)
+		for u := range updates {
+			select {
+			case out <- toGraphQLShipment(u.Shipment):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// toGraphQLShipment converts a shipment from the local model.
+func toGraphQLShipment(s models.Shipment) *model.Shipment {
+	return &model.Shipment{
+		ID:          s.ID,
+		Origin:      s.Origin,
+		Destination: s.Destination,
+		Eta:         s.Eta,
+		Status:      model.ShipmentStatus(s.Status.String()),
+		Carrier: &model.Carrier{
+			Name:        s.Carrier.Name,
+			TrackingURL: s.Carrier.TrackingURL,
+		},
+		FromAddress: toGraphQLAddress(s.FromAddress),
+		ToAddress:   toGraphQLAddress(s.ToAddress),
+		Customs:     toGraphQLCustoms(s.Customs),
+	}
+}
+
+// toGraphQLInvoice converts an invoice from the local model.
+func toGraphQLInvoice(inv models.Invoice) *model.Invoice {
+	out := &model.Invoice{
+		ID:          inv.ID,
+		Year:        inv.Year,
+		Month:       inv.Month,
+		TotalCents:  int(inv.TotalCents),
+		Currency:    inv.Currency,
+		Status:      model.InvoiceStatus(inv.Status),
+		Lines:       make([]*model.InvoiceLine, len(inv.Lines)),
+		CreatedAt:   inv.CreatedAt,
+		FinalizedAt: optionalString(inv.FinalizedAt),
+		PaidAt:      optionalString(inv.PaidAt),
+	}
+	for i, l := range inv.Lines {
+		out.Lines[i] = &model.InvoiceLine{
+			ID:             l.ID,
+			UsageType:      l.UsageType,
+			Quantity:       int(l.Quantity),
+			UnitPriceCents: int(l.UnitPriceCents),
+			LineTotalCents: int(l.LineTotalCents),
+			Description:    l.Description,
+		}
+	}
+	return out
+}
+
+// toGraphQLAuthPayload converts a token pair from the local model.
+func toGraphQLAuthPayload(t models.AuthTokens) *model.AuthPayload {
+	return &model.AuthPayload{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		ExpiresIn:    int(t.ExpiresIn),
+		TokenType:    t.TokenType,
+	}
+}
+
+// derefString maps a null GraphQL argument to "".
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// optionalString maps "" to a null GraphQL field.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// apiKeyScope spells a GraphQL scope (SHIPMENTS_WRITE) the service's way (shipments:write).
+func apiKeyScope(s model.APIKeyScope) string {
+	return strings.ToLower(strings.Replace(string(s), "_", ":", 1))
+}
+
+func toGraphAPIKey(k models.APIKey) *model.APIKey {
+	scopes := make([]model.APIKeyScope, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = model.APIKeyScope(strings.ToUpper(strings.Replace(s, ":", "_", 1)))
+	}
+	return &model.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  optionalString(k.ExpiresAt),
+		LastUsedAt: optionalString(k.LastUsedAt),
+		RevokedAt:  optionalString(k.RevokedAt),
+		ReplacedBy: optionalString(k.ReplacedBy),
+	}
+}
+
+// toModelAddress converts an optional GraphQL address input to the local model.
+func toModelAddress(in *model.AddressInput) *models.Address {
+	if in == nil {
+		return nil
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return &models.Address{
+		Name:    deref(in.Name),
+		Company: deref(in.Company),
+		Street1: in.Street1,
+		Street2: deref(in.Street2),
+		City:    in.City,
+		State:   deref(in.State),
+		Zip:     deref(in.Zip),
+		Country: in.Country,
+		Phone:   deref(in.Phone),
+		Email:   deref(in.Email),
+	}
+}
+
+// toGraphQLAddress converts a local address to the GraphQL type (nil stays nil).
+func toGraphQLAddress(a *models.Address) *model.Address {
+	if a == nil {
+		return nil
+	}
+	return &model.Address{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
+
+// toModelCustoms converts the optional GraphQL customs input to the local model.
+func toModelCustoms(in *model.CustomsInput) *models.Customs {
+	if in == nil {
+		return nil
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	items := make([]models.CustomsItem, len(in.Items))
+	for i, item := range in.Items {
+		items[i] = models.CustomsItem{
+			Description:   item.Description,
+			HSCode:        item.HsCode,
+			Quantity:      item.Quantity,
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &models.Customs{
+		ContentsType:        in.ContentsType,
+		ContentsExplanation: deref(in.ContentsExplanation),
+		Incoterm:            deref(in.Incoterm),
+		EELPFC:              deref(in.EelPfc),
+		AESITN:              deref(in.AesItn),
+		Items:               items,
+	}
+}
+
+// toGraphQLCustoms converts local customs data to the GraphQL type (nil stays nil).
+func toGraphQLCustoms(c *models.Customs) *model.Customs {
+	if c == nil {
+		return nil
+	}
+	items := make([]*model.CustomsItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = &model.CustomsItem{
+			Description:   item.Description,
+			HsCode:        item.HSCode,
+			Quantity:      item.Quantity,
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &model.Customs{
+		ContentsType:        c.ContentsType,
+		ContentsExplanation: c.ContentsExplanation,
+		Incoterm:            c.Incoterm,
+		EelPfc:              c.EELPFC,
+		AesItn:              c.AESITN,
+		Items:               items,
+		DeclarationID:       c.DeclarationID,
+	}
+}
+
 // //  In-memory store for shipments, initialized with hardcoded data
 // // var shipments = []*model.Shipment{
 // // 	{
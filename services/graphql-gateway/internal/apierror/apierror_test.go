@@ -0,0 +1,109 @@
+package apierror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/requestid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromGRPCBadRequest(t *testing.T) {
+	st, _ := status.New(codes.InvalidArgument, "invalid address").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "from_address.zip", Description: "zip is required"},
+			{Field: "customs.items[0].hs_code", Description: "hs code is required"},
+		},
+	})
+	var apiErr *Error
+	if !errors.As(FromGRPC(st.Err(), "shipment"), &apiErr) {
+		t.Fatal("want *Error")
+	}
+	if apiErr.Code != CodeBadUserInput || apiErr.Retryable || apiErr.Message != "invalid address" {
+		t.Fatalf("got %+v", apiErr)
+	}
+	want := []string{"fromAddress.zip", "customs.items[0].hsCode"}
+	if len(apiErr.Fields) != len(want) {
+		t.Fatalf("fields = %+v", apiErr.Fields)
+	}
+	for i, f := range want {
+		if apiErr.Fields[i].Field != f {
+			t.Errorf("field %d = %q, want %q", i, apiErr.Fields[i].Field, f)
+		}
+	}
+}
+
+func TestFromGRPCCodes(t *testing.T) {
+	cases := []struct {
+		code      codes.Code
+		want      string
+		retryable bool
+		msg       string
+	}{
+		{codes.NotFound, CodeNotFound, false, "pickup not found"},
+		{codes.AlreadyExists, CodeConflict, false, "pickup not found"},
+		{codes.Aborted, CodeConflict, true, "pickup not found"},
+		{codes.ResourceExhausted, CodeRateLimited, true, "pickup not found"},
+		{codes.Unavailable, CodeServiceUnavailable, true, "shipment service is unavailable"},
+		{codes.DeadlineExceeded, CodeTimeout, true, "shipment service timed out"},
+		{codes.Internal, CodeInternal, false, "shipment service error"},
+		{codes.Unknown, CodeInternal, false, "shipment service error"},
+	}
+	for _, c := range cases {
+		var apiErr *Error
+		if !errors.As(FromGRPC(status.Error(c.code, "pickup not found"), "shipment"), &apiErr) {
+			t.Fatalf("%v: want *Error", c.code)
+		}
+		if apiErr.Code != c.want || apiErr.Retryable != c.retryable || apiErr.Message != c.msg {
+			t.Errorf("%v: got %+v", c.code, apiErr)
+		}
+	}
+}
+
+func TestFromGRPCPassesOtherErrors(t *testing.T) {
+	plain := errors.New("boom")
+	if got := FromGRPC(plain, "shipment"); got != plain {
+		t.Fatalf("got %v", got)
+	}
+	if FromGRPC(nil, "shipment") != nil {
+		t.Fatal("want nil")
+	}
+}
+
+func TestPresent(t *testing.T) {
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	err := fmt.Errorf("resolver: %w", &Error{
+		Code: CodeBadUserInput, Message: "bad window",
+		Fields: []FieldError{{Field: "windowStart", Message: "invalid"}},
+	})
+	got := Present(ctx, &gqlerror.Error{Message: err.Error()}, err)
+	if got.Message != "bad window" {
+		t.Errorf("message = %q", got.Message)
+	}
+	ext := got.Extensions
+	if ext["code"] != CodeBadUserInput || ext["field"] != "windowStart" || ext["retryable"] != false || ext["requestId"] != "req-1" {
+		t.Errorf("extensions = %v", ext)
+	}
+
+	// Directive errors keep their code
+	forbidden := &gqlerror.Error{Message: "requires the ADMIN role", Extensions: map[string]interface{}{"code": CodeForbidden}}
+	got = Present(ctx, forbidden, forbidden)
+	if got.Extensions["code"] != CodeForbidden || got.Extensions["retryable"] != false {
+		t.Errorf("extensions = %v", got.Extensions)
+	}
+
+	plain := errors.New("boom")
+	got = Present(context.Background(), &gqlerror.Error{Message: "boom"}, plain)
+	if got.Extensions["code"] != CodeInternal {
+		t.Errorf("extensions = %v", got.Extensions)
+	}
+	if _, ok := got.Extensions["requestId"]; ok {
+		t.Error("requestId set outside a request")
+	}
+}
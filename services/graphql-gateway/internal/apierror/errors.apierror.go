@@ -0,0 +1,174 @@
+// internal/apierror/errors.apierror.go
+
+// Package apierror defines the extensions every GraphQL error of the gateway
+// carries and maps backend gRPC statuses onto them:
+//
+//	code       machine-readable error class (BAD_USER_INPUT, NOT_FOUND, ...)
+//	field      the first offending input field, if any
+//	fields     every offending input field with its message
+//	reason     the service's specific reason (e.g. EMAIL_TAKEN), if it sent one
+//	retryable  whether the same request may succeed if sent again later
+//	requestId  the X-Request-Id of the HTTP request, for support and logs
+//
+// Clients switch on code and retryable; they never need to parse messages.
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Values of the "code" extension.
+const (
+	CodeBadUserInput       = "BAD_USER_INPUT"
+	CodeUnauthenticated    = "UNAUTHENTICATED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeFailedPrecondition = "FAILED_PRECONDITION"
+	CodeRateLimited        = "RATE_LIMITED"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeTimeout            = "TIMEOUT"
+	CodeCancelled          = "CANCELLED"
+	CodeInternal           = "INTERNAL"
+)
+
+// FieldError is one offending input field. Field is the path inside the
+// operation's input in GraphQL spelling, e.g. "fromAddress.zip" or
+// "customs.items[0].hsCode".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error the gateway shows to clients as is. The ErrorPresenter
+// turns it into extensions; its message is always safe to show.
+type Error struct {
+	Code      string
+	Message   string
+	Reason    string
+	Fields    []FieldError
+	Retryable bool
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// New returns an Error with the given code. Retryable follows the code.
+func New(code, message string) *Error {
+	return &Error{Code: code, Message: message, Retryable: retryableCodes[code]}
+}
+
+// httpStatus is the HTTP status the REST API answers each code with.
+var httpStatus = map[string]int{
+	CodeBadUserInput:       http.StatusBadRequest,
+	CodeUnauthenticated:    http.StatusUnauthorized,
+	CodeForbidden:          http.StatusForbidden,
+	CodeNotFound:           http.StatusNotFound,
+	CodeConflict:           http.StatusConflict,
+	CodeFailedPrecondition: http.StatusConflict,
+	CodeRateLimited:        http.StatusTooManyRequests,
+	CodeServiceUnavailable: http.StatusServiceUnavailable,
+	CodeTimeout:            http.StatusGatewayTimeout,
+	CodeCancelled:          499, // client closed request
+}
+
+// HTTPStatus returns the HTTP status for code; unknown codes are 500.
+func HTTPStatus(code string) int {
+	if s, ok := httpStatus[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// grpcCodes maps gRPC codes onto the code extension. Codes not listed here
+// (Unknown, Internal, DataLoss, Unimplemented) become INTERNAL.
+var grpcCodes = map[codes.Code]string{
+	codes.InvalidArgument:    CodeBadUserInput,
+	codes.OutOfRange:         CodeBadUserInput,
+	codes.Unauthenticated:    CodeUnauthenticated,
+	codes.PermissionDenied:   CodeForbidden,
+	codes.NotFound:           CodeNotFound,
+	codes.AlreadyExists:      CodeConflict,
+	codes.Aborted:            CodeConflict,
+	codes.FailedPrecondition: CodeFailedPrecondition,
+	codes.ResourceExhausted:  CodeRateLimited,
+	codes.Unavailable:        CodeServiceUnavailable,
+	codes.DeadlineExceeded:   CodeTimeout,
+	codes.Canceled:           CodeCancelled,
+}
+
+// retryableCodes are the codes where sending the same request again later can
+// succeed. Aborted (a concurrent update won) is retryable too, although it
+// shares CONFLICT with AlreadyExists, which is not.
+var retryableCodes = map[string]bool{
+	CodeRateLimited:        true,
+	CodeServiceUnavailable: true,
+	CodeTimeout:            true,
+}
+
+// FromGRPC turns an error from a call to service into an *Error. Messages the
+// service wrote for the caller (bad input, not found, conflicts, ...) are
+// kept; for unavailable and internal failures only a generic message naming
+// the service goes out. Errors that are not gRPC statuses are returned as is.
+func FromGRPC(err error, service string) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	code, known := grpcCodes[st.Code()]
+	out := &Error{Code: code, Message: st.Message(), Retryable: retryableCodes[code]}
+	switch {
+	case !known:
+		// Never pass internal messages on
+		out.Code, out.Message = CodeInternal, fmt.Sprintf("%s service error", service)
+	case st.Code() == codes.Unavailable:
+		out.Message = fmt.Sprintf("%s service is unavailable", service)
+	case st.Code() == codes.DeadlineExceeded:
+		out.Message = fmt.Sprintf("%s service timed out", service)
+	case st.Code() == codes.Aborted:
+		out.Retryable = true
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			out.Reason = d.Reason
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				out.Fields = append(out.Fields, FieldError{Field: graphQLPath(v.Field), Message: v.Description})
+			}
+		case *errdetails.RetryInfo:
+			out.Retryable = true
+		}
+	}
+	return out
+}
+
+// graphQLPath rewrites a service field path ("customs.items[0].hs_code")
+// into the spelling of the GraphQL input ("customs.items[0].hsCode").
+func graphQLPath(field string) string {
+	if !strings.Contains(field, "_") {
+		return field
+	}
+	var b strings.Builder
+	upper := false
+	for _, r := range field {
+		switch {
+		case r == '_':
+			upper = true
+			continue
+		case upper && r >= 'a' && r <= 'z':
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+// internal/apierror/present.apierror.go
+
+package apierror
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/requestid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Present fills in the extensions of gqlErr, the gqlgen rendering of err.
+// An *Error anywhere in err's chain decides code, message and fields. Other
+// errors keep a code they already carry (the directives, query validation and
+// the limits set one) and are INTERNAL otherwise.
+func Present(ctx context.Context, gqlErr *gqlerror.Error, err error) *gqlerror.Error {
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	ext := gqlErr.Extensions
+	id := requestid.FromContext(ctx)
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		gqlErr.Message = apiErr.Message
+		ext["code"] = apiErr.Code
+		ext["retryable"] = apiErr.Retryable
+		if apiErr.Reason != "" {
+			ext["reason"] = apiErr.Reason
+		}
+		if len(apiErr.Fields) > 0 {
+			ext["field"] = apiErr.Fields[0].Field
+			ext["fields"] = apiErr.Fields
+		}
+	} else {
+		code, _ := ext["code"].(string)
+		if code == "" {
+			code = CodeInternal
+			ext["code"] = code
+			log.Printf("request %s: unclassified error: %v", id, err)
+		}
+		if _, ok := ext["retryable"]; !ok {
+			ext["retryable"] = retryableCodes[code]
+		}
+	}
+	if id != "" {
+		ext["requestId"] = id
+	}
+	return gqlErr
+}
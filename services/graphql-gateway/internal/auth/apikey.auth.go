@@ -0,0 +1,135 @@
+// internal/auth/apikey.auth.go
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+var (
+	// ErrInvalidAPIKey: the key is unknown, revoked or expired.
+	ErrInvalidAPIKey = errors.New("invalid, revoked or expired API key")
+	// ErrAPIKeysDisabled: the gateway was started without an API key resolver.
+	ErrAPIKeysDisabled = errors.New("API keys are not accepted")
+)
+
+// APIKey is what authentication-service knows about a valid key.
+type APIKey struct {
+	ID        string
+	TenantID  string
+	CreatedBy string // the admin who issued it; audit only, the key does not act as them
+	Scopes    []string
+}
+
+// ScopeShipmentsWrite lets a key create and cancel shipments. Keys without it
+// are read-only.
+const ScopeShipmentsWrite = "shipments:write"
+
+// Identity is the caller a request made with the key acts as: the key
+// itself, with no UserID, so nothing downstream mistakes it for the admin who
+// issued it. Keys act for their tenant as its member at most, so
+// @hasRole(ADMIN) fields always need a person; read-only keys hold no role
+// and only reach fields that need no more than @auth. authentication-service
+// refuses key callers altogether, so keys never manage members, tenants or
+// other keys.
+func (k APIKey) Identity() identity.Identity {
+	id := identity.Identity{TenantID: k.TenantID, APIKeyID: k.ID}
+	for _, s := range k.Scopes {
+		if s == ScopeShipmentsWrite {
+			id.Role = "member"
+		}
+	}
+	return id
+}
+
+// APIKeyResolver looks a raw key up. It returns ErrInvalidAPIKey (possibly
+// wrapped) for keys that will never work, and any other error when the
+// answer is unknown (e.g. authentication-service is down).
+type APIKeyResolver interface {
+	ResolveAPIKey(ctx context.Context, rawKey string) (APIKey, error)
+}
+
+// APIKeys caches resolved keys so a busy merchant backend costs one
+// authentication-service call per key every apiKeyTTL instead of one per
+// request. Keys revoked or rotated through this gateway are forgotten at once
+// (see Forget); on other replicas a revoked key keeps working for up to
+// apiKeyTTL. Invalid keys are remembered too, briefly, so a client retrying a
+// bad key in a loop does not hammer the service.
+type APIKeys struct {
+	resolver APIKeyResolver
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]apiKeyEntry
+}
+
+type apiKeyEntry struct {
+	key     APIKey
+	err     error
+	expires time.Time
+}
+
+const (
+	apiKeyTTL        = 5 * time.Second
+	apiKeyInvalidTTL = 5 * time.Second
+	apiKeyCacheMax   = 10000
+)
+
+func NewAPIKeys(r APIKeyResolver) *APIKeys {
+	return &APIKeys{resolver: r, now: time.Now, entries: map[[sha256.Size]byte]apiKeyEntry{}}
+}
+
+// Resolve returns the key rawKey belongs to.
+func (a *APIKeys) Resolve(ctx context.Context, rawKey string) (APIKey, error) {
+	// Entries are keyed by hash so the cache holds no usable secrets
+	h := sha256.Sum256([]byte(rawKey))
+	now := a.now()
+	a.mu.Lock()
+	e, ok := a.entries[h]
+	a.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.key, e.err
+	}
+
+	key, err := a.resolver.ResolveAPIKey(ctx, rawKey)
+	switch {
+	case err == nil:
+		e = apiKeyEntry{key: key, expires: now.Add(apiKeyTTL)}
+	case errors.Is(err, ErrInvalidAPIKey):
+		e = apiKeyEntry{err: ErrInvalidAPIKey, expires: now.Add(apiKeyInvalidTTL)}
+	default:
+		return APIKey{}, err
+	}
+	a.mu.Lock()
+	if len(a.entries) >= apiKeyCacheMax {
+		for k, old := range a.entries {
+			if !now.Before(old.expires) {
+				delete(a.entries, k)
+			}
+		}
+		if len(a.entries) >= apiKeyCacheMax {
+			a.entries = map[[sha256.Size]byte]apiKeyEntry{}
+		}
+	}
+	a.entries[h] = e
+	a.mu.Unlock()
+	return e.key, e.err
+}
+
+// Forget drops the cached verification of key keyID, so the next request with
+// it asks authentication-service again. Called after the key is revoked or
+// rotated.
+func (a *APIKeys) Forget(keyID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for h, e := range a.entries {
+		if e.err == nil && e.key.ID == keyID {
+			delete(a.entries, h)
+		}
+	}
+}
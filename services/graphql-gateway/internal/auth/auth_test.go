@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/authtoken"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+var now = time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+func claimsFor(mut func(map[string]interface{})) map[string]interface{} {
+	c := map[string]interface{}{
+		"sub":       "user-1",
+		"email":     "ops@acme.test",
+		"tenant_id": "tenant-1",
+		"role":      "admin",
+		"iss":       "https://auth.logisynapse.local",
+		"aud":       []string{"graphql-gateway"},
+		"exp":       now.Add(15 * time.Minute).Unix(),
+		"iat":       now.Unix(),
+	}
+	if mut != nil {
+		mut(c)
+	}
+	return c
+}
+
+func segment(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signHS256(key []byte, claims map[string]interface{}) string {
+	signed := segment(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signWith(alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	signed := segment(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, _ = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		r, s, _ := ecdsa.Sign(rand.Reader, k, digest[:])
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func newVerifier(cfg Config) *Verifier {
+	cfg.Issuer = "https://auth.logisynapse.local"
+	cfg.Audience = "graphql-gateway"
+	cfg.Leeway = 30 * time.Second
+	v := NewVerifier(cfg)
+	v.now = func() time.Time { return now }
+	return v
+}
+
+func TestVerifySharedKey(t *testing.T) {
+	key := []byte("shared-secret")
+	v := newVerifier(Config{SharedKey: key})
+	ctx := context.Background()
+
+	c, err := v.Verify(ctx, signHS256(key, claimsFor(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := identity.Identity{UserID: "user-1", Email: "ops@acme.test", TenantID: "tenant-1", Role: "admin"}
+	if c.Identity() != want {
+		t.Errorf("identity = %+v", c.Identity())
+	}
+
+	cases := map[string]struct {
+		token string
+		want  error
+	}{
+		"wrong key": {signHS256([]byte("other"), claimsFor(nil)), ErrBadSignature},
+		"expired": {signHS256(key, claimsFor(func(c map[string]interface{}) {
+			c["exp"] = now.Add(-time.Minute).Unix()
+		})), ErrExpired},
+		"no expiry": {signHS256(key, claimsFor(func(c map[string]interface{}) { delete(c, "exp") })), ErrExpired},
+		"not yet valid": {signHS256(key, claimsFor(func(c map[string]interface{}) {
+			c["nbf"] = now.Add(time.Minute).Unix()
+		})), ErrNotYetValid},
+		"issuer":      {signHS256(key, claimsFor(func(c map[string]interface{}) { c["iss"] = "https://evil.test" })), ErrWrongIssuer},
+		"audience":    {signHS256(key, claimsFor(func(c map[string]interface{}) { c["aud"] = "billing" })), ErrWrongAud},
+		"no subject":  {signHS256(key, claimsFor(func(c map[string]interface{}) { delete(c, "sub") })), ErrNoSubject},
+		"alg none":    {segment(map[string]string{"alg": "none"}) + "." + segment(claimsFor(nil)) + ".", ErrBadSignature},
+		"not a token": {"abc.def", ErrMalformed},
+	}
+	for name, tc := range cases {
+		if _, err := v.Verify(ctx, tc.token); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v; want %v", name, err, tc.want)
+		}
+	}
+
+	// Within the leeway an expired token is still accepted
+	late := signHS256(key, claimsFor(func(c map[string]interface{}) { c["exp"] = now.Add(-10 * time.Second).Unix() }))
+	if _, err := v.Verify(ctx, late); err != nil {
+		t.Errorf("token 10s past expiry should be inside the leeway: %v", err)
+	}
+}
+
+// TestVerifyAuthServiceToken checks the gateway reads the tenant and role
+// authentication-service signs (both use authtoken.Claims).
+func TestVerifyAuthServiceToken(t *testing.T) {
+	key := []byte("shared-secret-shared-secret-1234")
+	v := newVerifier(Config{SharedKey: key})
+	token, err := authtoken.SignHS256(authtoken.Claims{
+		Subject:   "user-1",
+		Email:     "ops@acme.test",
+		TenantID:  "tenant-1",
+		Role:      "member",
+		Issuer:    "https://auth.logisynapse.local",
+		Audience:  authtoken.Audience{"graphql-gateway"},
+		ExpiresAt: now.Add(15 * time.Minute).Unix(),
+		IssuedAt:  now.Unix(),
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := v.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := identity.Identity{UserID: "user-1", Email: "ops@acme.test", TenantID: "tenant-1", Role: "member"}
+	if c.Identity() != want {
+		t.Errorf("identity = %+v", c.Identity())
+	}
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{
+			{"kid": "rsa-1", "kty": "RSA", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		}})
+	}))
+	defer srv.Close()
+
+	v := newVerifier(Config{JWKS: NewJWKS(srv.URL, srv.Client())})
+	ctx := context.Background()
+	if _, err := v.Verify(ctx, signWith("RS256", "rsa-1", rsaKey, claimsFor(nil))); err != nil {
+		t.Errorf("RS256: %v", err)
+	}
+	if _, err := v.Verify(ctx, signWith("ES256", "ec-1", ecKey, claimsFor(nil))); err != nil {
+		t.Errorf("ES256: %v", err)
+	}
+	if _, err := v.Verify(ctx, signWith("RS256", "ec-1", rsaKey, claimsFor(nil))); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("RS256 token naming the EC key: err = %v", err)
+	}
+	// An unknown kid refetches, but not more than once a minute
+	if _, err := v.Verify(ctx, signWith("RS256", "rsa-2", rsaKey, claimsFor(nil))); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unknown kid: err = %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("JWKS fetched %d times; want 1", fetches)
+	}
+	// HS256 tokens are refused when only JWKS is configured
+	if _, err := v.Verify(ctx, signHS256([]byte("x"), claimsFor(nil))); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("HS256 without a shared key: err = %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	key := []byte("shared-secret")
+	v := newVerifier(Config{SharedKey: key})
+	var got identity.Identity
+	var authenticated bool
+	h := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, authenticated = identity.FromContext(r.Context())
+	}))
+
+	serve := func(authz string) int {
+		authenticated = false
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		if authz != "" {
+			req.Header.Set("Authorization", authz)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(""); code != http.StatusOK || authenticated {
+		t.Errorf("anonymous request: code %d, authenticated %v", code, authenticated)
+	}
+	if code := serve("Bearer " + signHS256(key, claimsFor(nil))); code != http.StatusOK || !authenticated || got.TenantID != "tenant-1" {
+		t.Errorf("valid token: code %d, identity %+v", code, got)
+	}
+	if code := serve("Bearer " + signHS256([]byte("other"), claimsFor(nil))); code != http.StatusUnauthorized {
+		t.Errorf("bad token: code %d; want 401", code)
+	}
+	if code := serve("Basic dXNlcjpwYXNz"); code != http.StatusUnauthorized {
+		t.Errorf("basic auth: code %d; want 401", code)
+	}
+}
+
+func TestHasRole(t *testing.T) {
+	admin := identity.Identity{UserID: "u", TenantID: "t", Role: "admin"}
+	if !HasRole(admin, "member") || !HasRole(admin, "ADMIN") || HasRole(admin, "owner") {
+		t.Error("admin should include member and not owner")
+	}
+	if HasRole(identity.Identity{UserID: "u", Role: "owner"}, "member") {
+		t.Error("a role without a tenant grants nothing")
+	}
+	if !HasRole(identity.Identity{UserID: "u", SuperAdmin: true}, "owner") {
+		t.Error("super admins hold every role")
+	}
+}
+
+type fakeKeyResolver struct {
+	calls int
+	keys  map[string]APIKey
+	down  bool
+}
+
+func (f *fakeKeyResolver) ResolveAPIKey(_ context.Context, raw string) (APIKey, error) {
+	f.calls++
+	if f.down {
+		return APIKey{}, errors.New("connection refused")
+	}
+	if k, ok := f.keys[raw]; ok {
+		return k, nil
+	}
+	return APIKey{}, ErrInvalidAPIKey
+}
+
+func TestMiddlewareAPIKeys(t *testing.T) {
+	resolver := &fakeKeyResolver{keys: map[string]APIKey{
+		"lsk_rw": {ID: "k-1", TenantID: "tenant-1", CreatedBy: "user-1", Scopes: []string{"shipments:read", "shipments:write"}},
+		"lsk_ro": {ID: "k-2", TenantID: "tenant-1", CreatedBy: "user-1", Scopes: []string{"shipments:read"}},
+	}}
+	keys := NewAPIKeys(resolver)
+	clock := now
+	keys.now = func() time.Time { return clock }
+	v := newVerifier(Config{SharedKey: []byte("k"), APIKeys: keys})
+
+	var got identity.Identity
+	h := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = identity.FromContext(r.Context())
+	}))
+	serve := func(authz string) int {
+		got = identity.Identity{}
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		req.Header.Set("Authorization", authz)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve("ApiKey lsk_rw"); code != http.StatusOK || got.APIKeyID != "k-1" || got.UserID != "" || got.TenantID != "tenant-1" || !HasRole(got, "member") || HasRole(got, "admin") {
+		t.Errorf("read-write key: code %d, identity %+v", code, got)
+	}
+	if code := serve("apikey lsk_ro"); code != http.StatusOK || got.APIKeyID != "k-2" || HasRole(got, "member") {
+		t.Errorf("read-only key: code %d, identity %+v", code, got)
+	}
+	serve("ApiKey lsk_rw")
+	if resolver.calls != 2 {
+		t.Errorf("resolver called %d times; want 2 (one per key)", resolver.calls)
+	}
+
+	if code := serve("ApiKey lsk_nope"); code != http.StatusUnauthorized {
+		t.Errorf("unknown key: code %d", code)
+	}
+	serve("ApiKey lsk_nope")
+	if resolver.calls != 3 {
+		t.Errorf("invalid keys should be remembered: %d calls", resolver.calls)
+	}
+
+	// Cached keys survive an outage of the resolver until they expire
+	resolver.down = true
+	if code := serve("ApiKey lsk_rw"); code != http.StatusOK {
+		t.Errorf("cached key while resolver is down: code %d", code)
+	}
+	clock = clock.Add(apiKeyTTL)
+	if code := serve("ApiKey lsk_rw"); code != http.StatusUnauthorized {
+		t.Errorf("expired cache entry while resolver is down: code %d", code)
+	}
+
+	// A revoked key is forgotten right away instead of after apiKeyTTL
+	resolver.down = false
+	serve("ApiKey lsk_rw")
+	delete(resolver.keys, "lsk_rw")
+	if code := serve("ApiKey lsk_rw"); code != http.StatusOK {
+		t.Errorf("cached key before Forget: code %d", code)
+	}
+	keys.Forget("k-1")
+	if code := serve("ApiKey lsk_rw"); code != http.StatusUnauthorized {
+		t.Errorf("revoked key after Forget: code %d; want 401", code)
+	}
+
+	noKeys := Middleware(newVerifier(Config{SharedKey: []byte("k")}))(http.NotFoundHandler())
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	req.Header.Set("Authorization", "ApiKey lsk_rw")
+	noKeys.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("API keys disabled: code %d", rec.Code)
+	}
+}
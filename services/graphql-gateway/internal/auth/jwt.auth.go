@@ -0,0 +1,161 @@
+// internal/auth/jwt.auth.go
+
+// Package auth verifies the access tokens issued by authentication-service and
+// puts the caller into the request context.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/authtoken"
+)
+
+var (
+	ErrMalformed    = errors.New("malformed token")
+	ErrBadSignature = errors.New("invalid token signature")
+	ErrExpired      = errors.New("token expired")
+	ErrNotYetValid  = errors.New("token not valid yet")
+	ErrWrongIssuer  = errors.New("token issuer not accepted")
+	ErrWrongAud     = errors.New("token audience not accepted")
+	ErrNoSubject    = errors.New("token has no subject")
+)
+
+// Claims are the access token claims authentication-service signs.
+type Claims = authtoken.Claims
+
+// Config says which tokens the gateway accepts. SharedKey verifies HS256
+// tokens, JWKS verifies RS256 and ES256; at least one must be set.
+// Issuer and Audience are checked when non-empty. APIKeys, when set, also
+// lets tenant API keys in ("Authorization: ApiKey <key>").
+type Config struct {
+	SharedKey []byte
+	JWKS      *JWKS
+	Issuer    string
+	Audience  string
+	// Leeway absorbs clock skew between the issuer and the gateway.
+	Leeway  time.Duration
+	APIKeys *APIKeys
+}
+
+// Verifier checks access tokens.
+type Verifier struct {
+	cfg Config
+	now func() time.Time
+}
+
+func NewVerifier(cfg Config) *Verifier {
+	return &Verifier{cfg: cfg, now: time.Now}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the token's signature and claims and returns the claims.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if err := v.verifySignature(ctx, h, parts[0]+"."+parts[1], sig); err != nil {
+		return Claims{}, err
+	}
+
+	var c Claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	now := v.now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(v.cfg.Leeway)) {
+		return Claims{}, ErrExpired
+	}
+	if c.NotBefore != 0 && now.Add(v.cfg.Leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return Claims{}, ErrNotYetValid
+	}
+	if v.cfg.Issuer != "" && c.Issuer != v.cfg.Issuer {
+		return Claims{}, ErrWrongIssuer
+	}
+	if v.cfg.Audience != "" && !c.Audience.Contains(v.cfg.Audience) {
+		return Claims{}, ErrWrongAud
+	}
+	if c.Subject == "" {
+		return Claims{}, ErrNoSubject
+	}
+	return c, nil
+}
+
+// verifySignature picks the key by algorithm: the shared key only ever
+// verifies HS256 and JWKS keys only RS256/ES256, so a token cannot switch a
+// public key into an HMAC secret (and "none" is never accepted).
+func (v *Verifier) verifySignature(ctx context.Context, h header, signed string, sig []byte) error {
+	switch h.Alg {
+	case "HS256":
+		if len(v.cfg.SharedKey) == 0 {
+			return fmt.Errorf("%w: HS256 tokens are not accepted", ErrBadSignature)
+		}
+		mac := hmac.New(sha256.New, v.cfg.SharedKey)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrBadSignature
+		}
+		return nil
+	case "RS256", "ES256":
+		if v.cfg.JWKS == nil {
+			return fmt.Errorf("%w: %s tokens are not accepted", ErrBadSignature, h.Alg)
+		}
+		key, err := v.cfg.JWKS.Key(ctx, h.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signed))
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			if h.Alg != "RS256" || rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) != nil {
+				return ErrBadSignature
+			}
+		case *ecdsa.PublicKey:
+			// JWS ES256 signatures are r||s, 32 bytes each
+			if h.Alg != "ES256" || len(sig) != 64 {
+				return ErrBadSignature
+			}
+			r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+			if !ecdsa.Verify(k, digest[:], r, s) {
+				return ErrBadSignature
+			}
+		default:
+			return ErrBadSignature
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: algorithm %q is not accepted", ErrBadSignature, h.Alg)
+	}
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,40 @@
+// internal/limits/depth.limits.go
+
+// Package limits measures GraphQL operations so the gateway can refuse the
+// ones that would fan out into too many backend calls.
+package limits
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Depth is how many fields deep the selection set nests: { shipments { id } }
+// is 2. Fragments count where they are spread. Introspection fields (__schema,
+// __type, ...) are not counted: they never reach a backend, and the standard
+// introspection query alone is deeper than any sensible limit.
+func Depth(set ast.SelectionSet) int {
+	deepest := 0
+	for _, sel := range set {
+		d := 0
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			d = 1 + Depth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			d = Depth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			// Definition is filled in by validation, which rejects fragment cycles
+			if sel.Definition != nil {
+				d = Depth(sel.Definition.SelectionSet)
+			}
+		}
+		if d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
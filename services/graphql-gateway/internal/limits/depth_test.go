@@ -0,0 +1,39 @@
+package limits
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var schema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+type Query { shipments: [Shipment!]! }
+type Shipment { id: ID! carrier: Carrier! related: [Shipment!]! }
+type Carrier { name: String! }
+`})
+
+func depthOf(t *testing.T, query string) int {
+	t.Helper()
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	return Depth(doc.Operations[0].SelectionSet)
+}
+
+func TestDepth(t *testing.T) {
+	cases := map[string]int{
+		`{ shipments { id } }`:                                               2,
+		`{ shipments { id carrier { name } } }`:                              3,
+		`{ shipments { related { related { id } } } }`:                       4,
+		`{ shipments { ...f } } fragment f on Shipment { carrier { name } }`: 3,
+		`{ shipments { ... on Shipment { related { id } } } }`:               3,
+		`{ __schema { types { fields { type { ofType { name } } } } } }`:     0,
+	}
+	for query, want := range cases {
+		if got := depthOf(t, query); got != want {
+			t.Errorf("Depth(%s) = %d; want %d", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// internal/live/hub.live.go
+
+// Package live fans shipment events out to GraphQL subscribers. One source
+// (Kafka in production) feeds a Hub; every subscription gets the updates its
+// tenant may see and its filter matches.
+package live
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+// Update is one shipment change.
+type Update struct {
+	Event    string // e.g. "shipment.created"
+	TenantID string // empty on events published before shipments had tenants
+	Shipment models.Shipment
+}
+
+// Filter narrows a subscription. Zero fields match everything.
+type Filter struct {
+	IDs         []string
+	Origin      string
+	Destination string
+	Status      *proto.ShipmentStatus
+}
+
+// Matches reports whether s passes the filter.
+func (f Filter) Matches(s models.Shipment) bool {
+	if len(f.IDs) > 0 {
+		found := false
+		for _, id := range f.IDs {
+			if id == s.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Origin != "" && f.Origin != s.Origin {
+		return false
+	}
+	if f.Destination != "" && f.Destination != s.Destination {
+		return false
+	}
+	return f.Status == nil || *f.Status == s.Status
+}
+
+// Source feeds updates to a hub.
+type Source interface {
+	// Run calls emit for every update until ctx is done.
+	Run(ctx context.Context, emit func(Update))
+}
+
+// Hub fans updates out to subscribers. Publishing never blocks on a
+// subscriber: each one keeps only the latest pending update per shipment, so
+// a slow client skips intermediate statuses instead of holding everyone up.
+// A client that falls more than maxPending shipments behind is disconnected;
+// it resubscribes and reloads with the shipments query.
+type Hub struct {
+	maxPending int
+
+	mu   sync.RWMutex
+	subs map[*subscriber]struct{}
+}
+
+// DefaultMaxPending is how many distinct shipments a subscriber may fall behind by.
+const DefaultMaxPending = 1000
+
+func NewHub(maxPending int) *Hub {
+	return &Hub{maxPending: maxPending, subs: make(map[*subscriber]struct{})}
+}
+
+// Run publishes everything src emits until ctx is done.
+func (h *Hub) Run(ctx context.Context, src Source) {
+	src.Run(ctx, h.Publish)
+}
+
+// Publish hands u to every subscriber allowed to see it.
+func (h *Hub) Publish(u Update) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs {
+		if visible(s.caller, u) && s.filter.Matches(u.Shipment) {
+			s.offer(u, h.maxPending)
+		}
+	}
+}
+
+// visible is the tenant check: callers see their own tenant's shipments,
+// super admins see every tenant's. Events without a tenant reach super
+// admins only.
+func visible(caller identity.Identity, u Update) bool {
+	if caller.SuperAdmin {
+		return true
+	}
+	return u.TenantID != "" && u.TenantID == caller.TenantID
+}
+
+// Subscribe returns the updates caller may see that match f. The channel is
+// closed when ctx is done or the subscriber falls too far behind.
+func (h *Hub) Subscribe(ctx context.Context, caller identity.Identity, f Filter) <-chan Update {
+	s := &subscriber{
+		caller:  caller,
+		filter:  f,
+		out:     make(chan Update),
+		wake:    make(chan struct{}, 1),
+		pending: make(map[string]Update),
+	}
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		defer func() {
+			h.mu.Lock()
+			delete(h.subs, s)
+			h.mu.Unlock()
+			close(s.out)
+		}()
+		s.deliver(ctx)
+	}()
+	return s.out
+}
+
+// Subscribers is the number of open subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+type subscriber struct {
+	caller identity.Identity
+	filter Filter
+	out    chan Update
+	wake   chan struct{}
+
+	mu       sync.Mutex
+	pending  map[string]Update // latest update per shipment ID
+	order    []string          // shipment IDs in the order they first became pending
+	overflow bool
+}
+
+func (s *subscriber) offer(u Update, maxPending int) {
+	s.mu.Lock()
+	if s.overflow {
+		s.mu.Unlock()
+		return
+	}
+	if _, queued := s.pending[u.Shipment.ID]; !queued {
+		if len(s.order) >= maxPending {
+			s.overflow = true
+			s.mu.Unlock()
+			s.signal()
+			return
+		}
+		s.order = append(s.order, u.Shipment.ID)
+	}
+	s.pending[u.Shipment.ID] = u
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *subscriber) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // already signalled
+	}
+}
+
+// deliver sends pending updates until ctx is done or the subscriber overflows.
+func (s *subscriber) deliver(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		}
+
+		s.mu.Lock()
+		if s.overflow {
+			s.mu.Unlock()
+			log.Printf("live: dropping subscriber %s (tenant %s): more than its pending limit behind", s.caller.UserID, s.caller.TenantID)
+			return
+		}
+		batch, order := s.pending, s.order
+		s.pending, s.order = make(map[string]Update), nil
+		s.mu.Unlock()
+
+		for _, id := range order {
+			select {
+			case s.out <- batch[id]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
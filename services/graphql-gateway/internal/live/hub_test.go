@@ -0,0 +1,211 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+var (
+	acme   = identity.Identity{UserID: "u-acme", TenantID: "acme", Role: "member"}
+	globex = identity.Identity{UserID: "u-globex", TenantID: "globex", Role: "member"}
+	ops    = identity.Identity{UserID: "u-ops", SuperAdmin: true}
+)
+
+func update(tenant, id string, status proto.ShipmentStatus) Update {
+	return Update{Event: "shipment.updated", TenantID: tenant, Shipment: models.Shipment{ID: id, Origin: "Dhaka", Status: status}}
+}
+
+func receive(t *testing.T, ch <-chan Update) Update {
+	t.Helper()
+	select {
+	case u, ok := <-ch:
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		return u
+	case <-time.After(time.Second):
+		t.Fatal("no update")
+	}
+	return Update{}
+}
+
+func expectNothing(t *testing.T, ch <-chan Update) {
+	t.Helper()
+	select {
+	case u, ok := <-ch:
+		if ok {
+			t.Fatalf("unexpected update %+v", u)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func startHub(t *testing.T, maxPending int) (*Hub, *MemorySource, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	hub, src := NewHub(maxPending), NewMemorySource()
+	go hub.Run(ctx, src)
+	return hub, src, ctx
+}
+
+func TestHubTenantFiltering(t *testing.T) {
+	hub, src, ctx := startHub(t, DefaultMaxPending)
+	acmeSub := hub.Subscribe(ctx, acme, Filter{})
+	globexSub := hub.Subscribe(ctx, globex, Filter{})
+	opsSub := hub.Subscribe(ctx, ops, Filter{})
+
+	src.Send(update("acme", "s-1", proto.ShipmentStatus_IN_TRANSIT))
+	if u := receive(t, acmeSub); u.Shipment.ID != "s-1" {
+		t.Errorf("acme got %+v", u)
+	}
+	if u := receive(t, opsSub); u.Shipment.ID != "s-1" {
+		t.Errorf("super admin got %+v", u)
+	}
+	expectNothing(t, globexSub)
+
+	// Tenantless events go to super admins only
+	src.Send(update("", "s-legacy", proto.ShipmentStatus_PENDING))
+	if u := receive(t, opsSub); u.Shipment.ID != "s-legacy" {
+		t.Errorf("super admin got %+v", u)
+	}
+	expectNothing(t, acmeSub)
+}
+
+// TestHubPublishedEvent runs an event encoded the way the workflow
+// orchestrator writes it to the outbox through decoding and tenant filtering.
+func TestHubPublishedEvent(t *testing.T) {
+	hub, _, ctx := startHub(t, DefaultMaxPending)
+	acmeSub := hub.Subscribe(ctx, acme, Filter{})
+	globexSub := hub.Subscribe(ctx, globex, Filter{})
+
+	value, err := json.Marshal(contracts.NewShipmentEvent("shipment.created", contracts.Shipment{
+		ID:       "s-9",
+		TenantID: "acme",
+		Origin:   "Dhaka",
+		Status:   proto.ShipmentStatus_PRE_TRANSIT,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, ok, err := DecodeEvent(value)
+	if err != nil || !ok {
+		t.Fatalf("decode %s: %v, %v", value, ok, err)
+	}
+	hub.Publish(u)
+
+	if u := receive(t, acmeSub); u.Shipment.ID != "s-9" || u.TenantID != "acme" {
+		t.Errorf("acme got %+v", u)
+	}
+	expectNothing(t, globexSub)
+}
+
+func TestHubFilter(t *testing.T) {
+	hub, src, ctx := startHub(t, DefaultMaxPending)
+	delivered := proto.ShipmentStatus_DELIVERED
+	one := hub.Subscribe(ctx, acme, Filter{IDs: []string{"s-2"}})
+	done := hub.Subscribe(ctx, acme, Filter{Status: &delivered})
+
+	src.Send(update("acme", "s-1", proto.ShipmentStatus_DELIVERED))
+	src.Send(update("acme", "s-2", proto.ShipmentStatus_IN_TRANSIT))
+
+	if u := receive(t, one); u.Shipment.ID != "s-2" {
+		t.Errorf("id filter got %+v", u)
+	}
+	if u := receive(t, done); u.Shipment.ID != "s-1" {
+		t.Errorf("status filter got %+v", u)
+	}
+	expectNothing(t, one)
+	expectNothing(t, done)
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	hub, src, ctx := startHub(t, 3)
+	slow := hub.Subscribe(ctx, acme, Filter{})
+	fast := hub.Subscribe(ctx, acme, Filter{IDs: []string{"s-9"}})
+
+	// Nobody reads slow: the first update is held by its delivery loop,
+	// later ones for the same shipment replace each other.
+	src.Send(update("acme", "s-1", proto.ShipmentStatus_PENDING))
+	time.Sleep(20 * time.Millisecond)
+	src.Send(update("acme", "s-1", proto.ShipmentStatus_IN_TRANSIT))
+	src.Send(update("acme", "s-1", proto.ShipmentStatus_DELIVERED))
+	src.Send(update("acme", "s-2", proto.ShipmentStatus_IN_TRANSIT))
+
+	// A slow subscriber does not hold up the others
+	src.Send(update("acme", "s-9", proto.ShipmentStatus_IN_TRANSIT))
+	if u := receive(t, fast); u.Shipment.ID != "s-9" {
+		t.Errorf("fast subscriber got %+v", u)
+	}
+
+	var got []proto.ShipmentStatus
+	for i := 0; i < 4; i++ { // s-1 twice, s-2, s-9
+		u := receive(t, slow)
+		if u.Shipment.ID == "s-1" {
+			got = append(got, u.Shipment.Status)
+		}
+	}
+	if len(got) != 2 || got[1] != proto.ShipmentStatus_DELIVERED {
+		t.Errorf("s-1 statuses = %v; want PENDING then the latest, DELIVERED", got)
+	}
+
+	// Falling more than maxPending shipments behind closes the subscription
+	src.Send(update("acme", "s-3", proto.ShipmentStatus_PENDING))
+	time.Sleep(20 * time.Millisecond)
+	for _, id := range []string{"s-4", "s-5", "s-6", "s-7"} {
+		src.Send(update("acme", id, proto.ShipmentStatus_PENDING))
+	}
+	receive(t, slow) // s-3, held before the overflow
+	select {
+	case _, ok := <-slow:
+		if ok {
+			t.Fatal("overflowed subscription should be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("overflowed subscription was not closed")
+	}
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub, _, ctx := startHub(t, DefaultMaxPending)
+	subCtx, cancel := context.WithCancel(ctx)
+	ch := hub.Subscribe(subCtx, acme, Filter{})
+	if hub.Subscribers() != 1 {
+		t.Fatalf("subscribers = %d", hub.Subscribers())
+	}
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should close when the subscription ends")
+	}
+	if hub.Subscribers() != 0 {
+		t.Errorf("subscribers after cancel = %d", hub.Subscribers())
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	u, ok, err := DecodeEvent([]byte(`{"event":"shipment.created","tenant_id":"acme",
+		"payload":{"ID":"s-1","Origin":"Dhaka","Destination":"Berlin","Status":1,
+		"Carrier":{"Name":"DHL","TrackingURL":"https://track.example/1"},"FromAddress":{"City":"Dhaka","Country":"BD"}}}`))
+	if err != nil || !ok {
+		t.Fatalf("decode: %v, %v", ok, err)
+	}
+	if u.TenantID != "acme" || u.Shipment.Status != proto.ShipmentStatus_DELIVERED || u.Shipment.Carrier.Name != "DHL" {
+		t.Errorf("update = %+v", u)
+	}
+	if u.Shipment.FromAddress == nil || u.Shipment.FromAddress.Country != "BD" || u.Shipment.ToAddress != nil {
+		t.Errorf("addresses = %+v, %+v", u.Shipment.FromAddress, u.Shipment.ToAddress)
+	}
+
+	if _, ok, err := DecodeEvent([]byte(`{"event":"pickup.reminder","payload":{}}`)); ok || err != nil {
+		t.Errorf("pickup event: ok %v, err %v", ok, err)
+	}
+	if _, _, err := DecodeEvent([]byte(`not json`)); err == nil {
+		t.Error("garbage should not decode")
+	}
+}
@@ -0,0 +1,135 @@
+// internal/live/source.live.go
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	pkgkafka "github.com/Tanmoy095/LogiSynapse/shared/kafka"
+)
+
+// KafkaSource reads shipment events from Kafka. Every gateway replica needs
+// every event (each holds its own subscribers), so each uses its own consumer
+// group, starting at the newest event.
+type KafkaSource struct {
+	consumer *pkgkafka.Consumer
+}
+
+func NewKafkaSource(consumer *pkgkafka.Consumer) *KafkaSource {
+	return &KafkaSource{consumer: consumer}
+}
+
+func (k *KafkaSource) Run(ctx context.Context, emit func(Update)) {
+	k.consumer.Start(ctx, func(ctx context.Context, key, value []byte) error {
+		u, ok, err := DecodeEvent(value)
+		if err != nil {
+			// A malformed event will not get better on redelivery
+			log.Printf("live: skipping undecodable event %q: %v", key, err)
+			return nil
+		}
+		if ok {
+			emit(u)
+		}
+		return nil
+	})
+}
+
+// DecodeEvent reads one contracts.ShipmentEvent from the shipment topic. It reports false for
+// events that are not about a shipment (pickups share the topic).
+func DecodeEvent(value []byte) (Update, bool, error) {
+	var head struct {
+		Event string `json:"event"`
+	}
+	if err := json.Unmarshal(value, &head); err != nil {
+		return Update{}, false, err
+	}
+	if !strings.HasPrefix(head.Event, "shipment.") {
+		return Update{}, false, nil
+	}
+	var e contracts.ShipmentEvent
+	if err := json.Unmarshal(value, &e); err != nil {
+		return Update{}, false, err
+	}
+	if e.Payload.ID == "" {
+		return Update{}, false, nil
+	}
+	tenantID := e.TenantID
+	if tenantID == "" {
+		tenantID = e.Payload.TenantID
+	}
+	return Update{Event: e.Event, TenantID: tenantID, Shipment: fromContract(e.Payload)}, true, nil
+}
+
+func fromContract(s contracts.Shipment) models.Shipment {
+	out := models.Shipment{
+		ID:          s.ID,
+		Origin:      s.Origin,
+		Destination: s.Destination,
+		Eta:         s.Eta,
+		Status:      s.Status,
+		Carrier:     models.Carrier{Name: s.Carrier.Name, TrackingURL: s.Carrier.TrackingURL},
+		FromAddress: fromContractAddress(s.FromAddress),
+		ToAddress:   fromContractAddress(s.ToAddress),
+	}
+	if c := s.Customs; c != nil {
+		out.Customs = &models.Customs{
+			ContentsType:        string(c.ContentsType),
+			ContentsExplanation: c.ContentsExplanation,
+			Incoterm:            string(c.Incoterm),
+			EELPFC:              string(c.EELPFC),
+			AESITN:              c.AESITN,
+			DeclarationID:       c.DeclarationID,
+		}
+		for _, item := range c.Items {
+			out.Customs.Items = append(out.Customs.Items, models.CustomsItem{
+				Description:   item.Description,
+				HSCode:        item.HSCode,
+				Quantity:      item.Quantity,
+				ValueAmount:   item.ValueAmount,
+				ValueCurrency: item.ValueCurrency,
+				OriginCountry: item.OriginCountry,
+				NetWeight:     item.NetWeight,
+				MassUnit:      item.MassUnit,
+			})
+		}
+	}
+	return out
+}
+
+func fromContractAddress(a contracts.Address) *models.Address {
+	if a.IsZero() {
+		return nil
+	}
+	m := models.Address(a)
+	return &m
+}
+
+// MemorySource emits whatever is sent to it. For tests and local runs
+// without Kafka.
+type MemorySource struct {
+	updates chan Update
+}
+
+func NewMemorySource() *MemorySource {
+	return &MemorySource{updates: make(chan Update)}
+}
+
+// Send blocks until the hub has taken u.
+func (m *MemorySource) Send(u Update) {
+	m.updates <- u
+}
+
+func (m *MemorySource) Run(ctx context.Context, emit func(Update)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u := <-m.updates:
+			emit(u)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// internal/loader/batch.loader.go
+
+// Package loader batches and caches backend lookups for one GraphQL request,
+// DataLoader style: every Load made while resolving a request within a short
+// window becomes one batch call, and each key is fetched at most once.
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches many keys in one backend call. Keys missing from the map
+// load as the zero value (nil for pointers); an error fails the whole batch.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects keys for Wait, then fetches them with one BatchFunc call
+// (or sooner once MaxBatch keys are waiting). It caches every result,
+// errors included, for its lifetime, so it must not outlive the request.
+type Loader[K comparable, V any] struct {
+	ctx      context.Context // the request's: batches run with its caller and deadline
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V] // keys waiting to be fetched, nil when none
+}
+
+type result[V any] struct {
+	done chan struct{} // closed once val and err are set
+	val  V
+	err  error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+}
+
+// New returns a loader for the request ctx.
+func New[K comparable, V any](ctx context.Context, fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joins.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.cache[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.cache[key] = r
+		l.enqueue(key, r)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.val, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds key to the waiting batch, starting one if needed. l.mu is held.
+func (l *Loader[K, V]) enqueue(key K, r *result[V]) {
+	if l.batch == nil {
+		b := &batch[K, V]{}
+		l.batch = b
+		time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			if l.batch != b {
+				// Already sent because it filled up
+				l.mu.Unlock()
+				return
+			}
+			l.batch = nil
+			l.mu.Unlock()
+			l.run(b)
+		})
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, r)
+	if len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.run(b)
+	}
+}
+
+func (l *Loader[K, V]) run(b *batch[K, V]) {
+	values, err := l.fetch(l.ctx, b.keys)
+	for i, key := range b.keys {
+		r := b.results[i]
+		if err != nil {
+			r.err = err
+		} else {
+			r.val = values[key]
+		}
+		close(r.done)
+	}
+}
@@ -0,0 +1,141 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+)
+
+// countingFetch records every batch it is asked for.
+type countingFetch struct {
+	mu      sync.Mutex
+	batches [][]string
+	err     error
+}
+
+func (f *countingFetch) fetch(_ context.Context, keys []string) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	f.batches = append(f.batches, sorted)
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if k != "missing" {
+			out[k] = "v-" + k
+		}
+	}
+	return out, nil
+}
+
+func loadAll(l *Loader[string, string], keys ...string) ([]string, []error) {
+	vals, errs := make([]string, len(keys)), make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func(i int, k string) {
+			defer wg.Done()
+			vals[i], errs[i] = l.Load(context.Background(), k)
+		}(i, k)
+	}
+	wg.Wait()
+	return vals, errs
+}
+
+func TestConcurrentLoadsShareOneBatch(t *testing.T) {
+	f := &countingFetch{}
+	l := New(context.Background(), f.fetch, 20*time.Millisecond, 100)
+
+	vals, errs := loadAll(l, "a", "b", "a", "missing")
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if vals[0] != "v-a" || vals[1] != "v-b" || vals[2] != "v-a" || vals[3] != "" {
+		t.Errorf("values = %q", vals)
+	}
+	if len(f.batches) != 1 || fmt.Sprint(f.batches[0]) != "[a b missing]" {
+		t.Errorf("batches = %v", f.batches)
+	}
+
+	// Cached: no second call
+	if v, _ := l.Load(context.Background(), "b"); v != "v-b" || len(f.batches) != 1 {
+		t.Errorf("cached load = %q after %d batches", v, len(f.batches))
+	}
+}
+
+func TestFullBatchGoesEarly(t *testing.T) {
+	f := &countingFetch{}
+	l := New(context.Background(), f.fetch, time.Hour, 2)
+
+	done := make(chan struct{})
+	go func() {
+		loadAll(l, "a", "b")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a full batch waited for the timer")
+	}
+}
+
+func TestBatchErrorReachesEveryKey(t *testing.T) {
+	f := &countingFetch{err: errors.New("shipment service is unavailable")}
+	l := New(context.Background(), f.fetch, time.Millisecond, 100)
+
+	_, errs := loadAll(l, "a", "b")
+	for _, err := range errs {
+		if err == nil || err.Error() != "shipment service is unavailable" {
+			t.Errorf("err = %v", err)
+		}
+	}
+}
+
+type fakeShipments struct{ calls int }
+
+func (f *fakeShipments) GetShipmentsByIDs(_ context.Context, ids []string) ([]models.Shipment, error) {
+	f.calls++
+	out := make([]models.Shipment, len(ids))
+	for i, id := range ids {
+		out[i] = models.Shipment{ID: id}
+	}
+	return out, nil
+}
+
+func TestMiddlewareInstallsLoadersPerRequest(t *testing.T) {
+	shipments := &fakeShipments{}
+	var seen []*Loaders
+	h := Middleware(shipments)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, For(r.Context()))
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/query", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/query", nil))
+	ws := httptest.NewRequest("GET", "/query", nil)
+	ws.Header.Set("Upgrade", "websocket")
+	h.ServeHTTP(httptest.NewRecorder(), ws)
+
+	if seen[0] == nil || seen[1] == nil || seen[0] == seen[1] {
+		t.Fatalf("requests must get their own loaders: %v", seen)
+	}
+	if seen[2] != nil {
+		t.Error("websocket connections must not get a request cache")
+	}
+	s, err := seen[0].Shipment.Load(context.Background(), "s-1")
+	if err != nil || s == nil || s.ID != "s-1" {
+		t.Errorf("shipment = %+v, err %v", s, err)
+	}
+}
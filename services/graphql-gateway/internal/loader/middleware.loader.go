@@ -0,0 +1,70 @@
+// internal/loader/middleware.loader.go
+
+package loader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/models"
+)
+
+const (
+	// DefaultWait is how long a loader collects keys before fetching them.
+	DefaultWait = 2 * time.Millisecond
+	// MaxShipmentBatch matches shipment-service's per-request cap.
+	MaxShipmentBatch = 100
+)
+
+// ShipmentFetcher is the batch RPC behind the shipment loader (client.ShipmentClient).
+type ShipmentFetcher interface {
+	GetShipmentsByIDs(ctx context.Context, ids []string) ([]models.Shipment, error)
+}
+
+// Loaders are the loaders of one request.
+type Loaders struct {
+	Shipment *Loader[string, *models.Shipment]
+}
+
+type ctxKey struct{}
+
+// NewLoaders returns fresh loaders for the request ctx.
+func NewLoaders(ctx context.Context, shipments ShipmentFetcher) *Loaders {
+	fetchShipments := func(ctx context.Context, ids []string) (map[string]*models.Shipment, error) {
+		found, err := shipments.GetShipmentsByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*models.Shipment, len(found))
+		for i := range found {
+			byID[found[i].ID] = &found[i]
+		}
+		return byID, nil
+	}
+	return &Loaders{Shipment: New(ctx, fetchShipments, DefaultWait, MaxShipmentBatch)}
+}
+
+// Middleware gives every HTTP request its own loaders. It runs after the auth
+// middleware so batches carry the caller. WebSocket connections get none: a
+// cache as long-lived as the connection would serve stale shipments, so
+// resolvers fall back to direct calls there (see For).
+func Middleware(shipments ShipmentFetcher) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := context.WithValue(r.Context(), ctxKey{}, NewLoaders(r.Context(), shipments))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// For returns the request's loaders, or nil outside Middleware.
+func For(ctx context.Context) *Loaders {
+	l, _ := ctx.Value(ctxKey{}).(*Loaders)
+	return l
+}
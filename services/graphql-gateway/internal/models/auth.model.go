@@ -0,0 +1,56 @@
+package models
+
+// Identity and tenant data from authentication-service. Statuses and roles are
+// the service's lowercase values (active, pending, owner, ...); timestamps are RFC3339.
+
+// AuthTokens is a fresh access/refresh token pair.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64 // seconds until AccessToken expires
+	TokenType    string
+}
+
+type User struct {
+	ID           string
+	Email        string
+	FirstName    string
+	LastName     string
+	Status       string // active, suspended, deleted
+	IsSuperAdmin bool
+	CreatedAt    string
+}
+
+// TenantMembership is a tenant the caller owns or belongs to.
+type TenantMembership struct {
+	TenantID         string
+	Name             string
+	TenantStatus     string // active, suspended
+	Role             string // owner, admin, member
+	MembershipStatus string // active, pending
+}
+
+// Member is one person in a tenant.
+type Member struct {
+	UserID    string
+	Email     string
+	FirstName string
+	LastName  string
+	Role      string // owner, admin, member
+	Status    string // active, pending
+	JoinedAt  string
+}
+
+// APIKey is a tenant API key as listed; the secret itself is never part of it.
+type APIKey struct {
+	ID         string
+	Name       string
+	Prefix     string   // e.g. lsk_3f9a1c2e
+	Scopes     []string // shipments:read, shipments:write
+	CreatedBy  string
+	CreatedAt  string
+	ExpiresAt  string // empty: never
+	LastUsedAt string // empty: never used
+	RevokedAt  string // empty: active
+	ReplacedBy string // ID of the key it was rotated into
+}
@@ -0,0 +1,67 @@
+package models
+
+// Billing data from billing-service, always for the caller's tenant. Amounts
+// are in cents of Currency; timestamps are RFC3339 and empty until they happen.
+
+// UsageSummary is the usage recorded so far in the current billing month.
+type UsageSummary struct {
+	Year  int
+	Month int
+	Items []UsageItem
+}
+
+type UsageItem struct {
+	UsageType string // e.g. SHIPMENT_CREATED
+	Quantity  int64
+}
+
+type Invoice struct {
+	ID          string
+	Year        int
+	Month       int
+	TotalCents  int64
+	Currency    string
+	Status      string        // DRAFT, FINALIZED, PAID, VOID
+	Lines       []InvoiceLine // empty in invoice lists
+	CreatedAt   string
+	FinalizedAt string
+	PaidAt      string
+}
+
+type InvoiceLine struct {
+	ID             string
+	UsageType      string
+	Quantity       int64
+	UnitPriceCents int64
+	LineTotalCents int64
+	Description    string
+}
+
+// LedgerEntry is one charge (positive) or payment (negative).
+type LedgerEntry struct {
+	ReferenceID     string
+	TransactionType string
+	UsageType       string
+	Quantity        int64
+	UnitPriceCents  int64
+	AmountCents     int64
+	Currency        string
+	Description     string
+	Year            int
+	Month           int
+	CreatedAt       string
+}
+
+type PaymentAttempt struct {
+	ID                string
+	InvoiceID         string
+	Provider          string
+	ProviderPaymentID string
+	Status            string // PENDING, SUCCEEDED, FAILED, REQUIRES_ACTION
+	AmountCents       int64
+	Currency          string
+	ErrorCode         string
+	ErrorMessage      string
+	CreatedAt         string
+	UpdatedAt         string
+}
@@ -0,0 +1,21 @@
+package models
+
+// Notification is one email or text message from the communications service's
+// delivery history. Timestamps are RFC3339; SentAt and DeliveredAt are empty
+// until then.
+type Notification struct {
+	JobID             string
+	ShipmentIDs       []string
+	Event             string
+	Template          string
+	Channel           string // email | sms
+	Recipient         string
+	Status            string // queued, sent, delivered, failed, dead_lettered, bounced, skipped
+	ProviderMessageID string
+	LastError         string
+	Attempts          int
+	CreatedAt         string
+	UpdatedAt         string
+	SentAt            string
+	DeliveredAt       string
+}
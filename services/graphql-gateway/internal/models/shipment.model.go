@@ -24,6 +24,60 @@ type Shipment struct {
 	Eta         string
 	Status      proto.ShipmentStatus
 	Carrier     Carrier
+	FromAddress *Address // nil for city-only shipments
+	ToAddress   *Address
+	Customs     *Customs // nil for domestic shipments
+}
+
+// Customs is the declaration data for cross-border shipments
+type Customs struct {
+	ContentsType        string
+	ContentsExplanation string
+	Incoterm            string
+	EELPFC              string
+	AESITN              string
+	Items               []CustomsItem
+	DeclarationID       string
+}
+
+// CustomsItem is one declared line (HS code, value, country of origin)
+type CustomsItem struct {
+	Description   string
+	HSCode        string
+	Quantity      int
+	ValueAmount   float64
+	ValueCurrency string
+	OriginCountry string
+	NetWeight     float64
+	MassUnit      string
+}
+
+// Address is a full postal address (country is ISO 3166-1 alpha-2)
+type Address struct {
+	Name    string
+	Company string
+	Street1 string
+	Street2 string
+	City    string
+	State   string
+	Zip     string
+	Country string
+	Phone   string
+	Email   string
+}
+
+// AddressIssue is one problem the shipment service found with an address
+type AddressIssue struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// AddressValidation is the shipment service's answer to ValidateAddress
+type AddressValidation struct {
+	Valid     bool
+	Issues    []AddressIssue
+	Suggested *Address
 }
 
 // CreateShipmentInput defines the input for creating a shipment (for GraphQL)
@@ -0,0 +1,77 @@
+// internal/persisted/allowlist.persisted.go
+
+package persisted
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotAllowed rejects an operation that is not in the manifest.
+var ErrNotAllowed = errors.New("operation is not registered")
+
+// maxCanonicalCache bounds the memo of query texts already found in the
+// manifest, so that clients sending endless variations cannot grow it.
+const maxCanonicalCache = 1000
+
+// Allowlist serves the operations of a manifest by hash. With enforce set it
+// also rejects every operation that is not in the manifest.
+// Analogy: The kitchen only cooks what is on the printed menu; regulars can order by number.
+type Allowlist struct {
+	byHash  map[string]Operation
+	enforce bool
+
+	mu      sync.RWMutex
+	allowed map[string]bool // query text + operation name known to be in the manifest
+}
+
+// NewAllowlist indexes the manifest's operations by hash.
+func NewAllowlist(m *Manifest, enforce bool) *Allowlist {
+	a := &Allowlist{byHash: make(map[string]Operation, len(m.Operations)), enforce: enforce, allowed: map[string]bool{}}
+	for _, op := range m.Operations {
+		a.byHash[op.Hash] = op
+	}
+	return a
+}
+
+// Len returns the number of registered operations.
+func (a *Allowlist) Len() int { return len(a.byHash) }
+
+// Resolve returns the query text to execute for a request carrying query
+// and/or an APQ hash. A request with only a registered hash gets the manifest's
+// text. Without enforce, anything else passes through unchanged (to APQ).
+func (a *Allowlist) Resolve(query, operationName, hash string) (string, error) {
+	if query == "" {
+		if op, ok := a.byHash[hash]; ok {
+			return op.Query, nil
+		}
+		if a.enforce {
+			return "", ErrNotAllowed
+		}
+		return query, nil
+	}
+	if !a.enforce {
+		return query, nil
+	}
+
+	key := operationName + "\x00" + query
+	a.mu.RLock()
+	ok := a.allowed[key]
+	a.mu.RUnlock()
+	if ok {
+		return query, nil
+	}
+	canonical, err := Canonical(query, operationName)
+	if err != nil {
+		return "", ErrNotAllowed
+	}
+	if _, ok := a.byHash[Hash(canonical)]; !ok {
+		return "", ErrNotAllowed
+	}
+	a.mu.Lock()
+	if len(a.allowed) < maxCanonicalCache {
+		a.allowed[key] = true
+	}
+	a.mu.Unlock()
+	return query, nil
+}
@@ -0,0 +1,131 @@
+// internal/persisted/manifest.persisted.go
+
+// Package persisted holds the operations clients are known to send. The
+// gqlregistry CLI extracts them from client code into a manifest; the gateway
+// loads it to serve them by hash and, in allowlist mode, to reject anything else.
+//
+// Operations are compared in canonical form: the operation plus the fragments
+// it uses (sorted by name), printed by the gqlparser formatter. Whitespace,
+// comments, fragment order and unrelated operations in the same document do
+// not change it. The hash of an operation is the hex SHA-256 of that text, so
+// a client can send it as an APQ sha256Hash with no query at all.
+package persisted
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Operation is one entry of the manifest.
+type Operation struct {
+	Name   string `json:"name"`
+	Hash   string `json:"hash"`
+	Query  string `json:"query"`
+	Source string `json:"source,omitempty"` // file the operation was extracted from
+}
+
+// Manifest is the file the CLI writes and the gateway reads.
+type Manifest struct {
+	Operations []Operation `json:"operations"`
+}
+
+// LoadManifest reads a manifest written by gqlregistry extract.
+func LoadManifest(path string) (*Manifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	for _, op := range m.Operations {
+		if Hash(op.Query) != op.Hash {
+			return nil, fmt.Errorf("%s: operation %q does not match its hash; regenerate the manifest", path, op.Name)
+		}
+	}
+	return &m, nil
+}
+
+// Hash returns the hex SHA-256 of a canonical query.
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Canonical returns the canonical form of the operation named operationName in
+// query. operationName may be empty when the document has one operation.
+func Canonical(query, operationName string) (string, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return "", err
+	}
+	var op *ast.OperationDefinition
+	switch {
+	case operationName != "":
+		op = doc.Operations.ForName(operationName)
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	default:
+		return "", fmt.Errorf("operation name is required for a document with %d operations", len(doc.Operations))
+	}
+	if op == nil {
+		return "", fmt.Errorf("unknown operation %q", operationName)
+	}
+	return CanonicalOperation(op, doc.Fragments)
+}
+
+// CanonicalOperation prints op with the fragments it needs from fragments.
+func CanonicalOperation(op *ast.OperationDefinition, fragments ast.FragmentDefinitionList) (string, error) {
+	used := map[string]*ast.FragmentDefinition{}
+	var visit func(ast.SelectionSet) error
+	visit = func(set ast.SelectionSet) error {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				if err := visit(sel.SelectionSet); err != nil {
+					return err
+				}
+			case *ast.InlineFragment:
+				if err := visit(sel.SelectionSet); err != nil {
+					return err
+				}
+			case *ast.FragmentSpread:
+				if used[sel.Name] != nil {
+					continue
+				}
+				def := fragments.ForName(sel.Name)
+				if def == nil {
+					return fmt.Errorf("unknown fragment %q", sel.Name)
+				}
+				used[sel.Name] = def
+				if err := visit(def.SelectionSet); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := visit(op.SelectionSet); err != nil {
+		return "", err
+	}
+
+	doc := &ast.QueryDocument{Operations: ast.OperationList{op}}
+	for _, def := range used {
+		doc.Fragments = append(doc.Fragments, def)
+	}
+	sort.Slice(doc.Fragments, func(i, j int) bool { return doc.Fragments[i].Name < doc.Fragments[j].Name })
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatQueryDocument(doc)
+	return buf.String(), nil
+}
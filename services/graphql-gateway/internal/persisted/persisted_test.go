@@ -0,0 +1,91 @@
+package persisted
+
+import (
+	"errors"
+	"testing"
+)
+
+const listShipments = `
+# the list screen
+query ListShipments($limit: Int) {
+  shipments(limit: $limit) { id ...Parts }
+}
+fragment Parts on Shipment { status }
+`
+
+func TestCanonicalIgnoresFormattingAndUnusedDefinitions(t *testing.T) {
+	a, err := Canonical(listShipments, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Canonical(`fragment Unused on Shipment { id }
+fragment Parts on Shipment {
+	status
+}
+query Other { shipments { id } }
+query ListShipments($limit: Int) { shipments(limit: $limit) { id ...Parts } }`, "ListShipments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Fatalf("canonical forms differ:\n%s\n---\n%s", a, b)
+	}
+}
+
+func TestCanonicalErrors(t *testing.T) {
+	for name, q := range map[string]string{
+		"ambiguous":        `query A { a } query B { b }`,
+		"unknown fragment": `query A { ...Missing }`,
+		"syntax":           `query {`,
+	} {
+		if _, err := Canonical(q, ""); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
+
+func newList(t *testing.T, enforce bool) (*Allowlist, Operation) {
+	t.Helper()
+	query, err := Canonical(listShipments, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := Operation{Name: "ListShipments", Hash: Hash(query), Query: query}
+	return NewAllowlist(&Manifest{Operations: []Operation{op}}, enforce), op
+}
+
+func TestAllowlistServesRegisteredHash(t *testing.T) {
+	list, op := newList(t, false)
+	got, err := list.Resolve("", "", op.Hash)
+	if err != nil || got != op.Query {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	// Unknown hashes are left to APQ
+	if got, err := list.Resolve("", "", "abc"); err != nil || got != "" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if got, err := list.Resolve("{ shipments { id } }", "", ""); err != nil || got != "{ shipments { id } }" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+}
+
+func TestAllowlistEnforce(t *testing.T) {
+	list, op := newList(t, true)
+	if got, err := list.Resolve("", "", op.Hash); err != nil || got != op.Query {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	// Same operation, different formatting
+	for i := 0; i < 2; i++ { // second round hits the memo
+		if _, err := list.Resolve(listShipments, "ListShipments", ""); err != nil {
+			t.Fatalf("registered query refused: %v", err)
+		}
+	}
+	for _, q := range []string{`{ shipments { id status } }`, `query ListShipments { shipments { id } }`, `query {`} {
+		if _, err := list.Resolve(q, "", ""); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("%q: got %v, want ErrNotAllowed", q, err)
+		}
+	}
+	if _, err := list.Resolve("", "", "abc"); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("unknown hash: got %v", err)
+	}
+}
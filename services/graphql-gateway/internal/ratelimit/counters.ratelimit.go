@@ -0,0 +1,79 @@
+// internal/ratelimit/counters.ratelimit.go
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Counters keeps the counts limits are checked against. Every key names a
+// tenant and the window it counts in (a minute, a billing month), so a key
+// is not used again once its window is over; the store may drop it after ttl.
+//
+// Gateway replicas that share Counters share the limits; with MemoryCounters
+// each replica enforces them on its own.
+type Counters interface {
+	// Add adds n to key unless that takes it over limit (zero: no limit). It
+	// returns the count after adding, and whether n was added.
+	Add(ctx context.Context, key string, n, limit int64, ttl time.Duration) (int64, bool, error)
+	// Raise makes key at least floor.
+	Raise(ctx context.Context, key string, floor int64, ttl time.Duration) error
+}
+
+// MemoryCounters keeps counts in this process.
+type MemoryCounters struct {
+	mu        sync.Mutex
+	counts    map[string]*memoryCount
+	lastSweep time.Time
+}
+
+type memoryCount struct {
+	n       int64
+	expires time.Time
+}
+
+// NewMemoryCounters returns empty in-process Counters.
+func NewMemoryCounters() *MemoryCounters {
+	return &MemoryCounters{counts: map[string]*memoryCount{}}
+}
+
+func (m *MemoryCounters) Add(_ context.Context, key string, n, limit int64, ttl time.Duration) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.count(key, ttl)
+	if limit > 0 && c.n+n > limit {
+		return c.n, false, nil
+	}
+	c.n += n
+	return c.n, true, nil
+}
+
+func (m *MemoryCounters) Raise(_ context.Context, key string, floor int64, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c := m.count(key, ttl); c.n < floor {
+		c.n = floor
+	}
+	return nil
+}
+
+// count returns key's count, creating it; m.mu is held. Expired keys are
+// dropped at most once a window, when a new one is created.
+func (m *MemoryCounters) count(key string, ttl time.Duration) *memoryCount {
+	if c := m.counts[key]; c != nil {
+		return c
+	}
+	now := time.Now()
+	if now.Sub(m.lastSweep) >= window {
+		for k, c := range m.counts {
+			if now.After(c.expires) {
+				delete(m.counts, k)
+			}
+		}
+		m.lastSweep = now
+	}
+	c := &memoryCount{expires: now.Add(ttl)}
+	m.counts[key] = c
+	return c
+}
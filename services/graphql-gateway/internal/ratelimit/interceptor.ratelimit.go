@@ -0,0 +1,29 @@
+// internal/ratelimit/interceptor.ratelimit.go
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc"
+)
+
+// ShipmentQuota is a client interceptor for the shipment-service connection
+// that holds CreateShipment calls to the monthly shipment quota. Sitting on
+// the connection, it covers the GraphQL resolvers and the REST API alike;
+// REST replays of an Idempotency-Key never reach it and are not counted twice.
+// Over the quota the call fails with a RATE_LIMITED *apierror.Error.
+func ShipmentQuota(l *Limiter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method != proto.ShipmentService_CreateShipment_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		done, err := l.reserveShipment(ctx)
+		if err != nil {
+			return err
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(err == nil)
+		return err
+	}
+}
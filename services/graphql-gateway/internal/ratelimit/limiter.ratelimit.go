@@ -0,0 +1,275 @@
+// internal/ratelimit/limiter.ratelimit.go
+
+// Package ratelimit enforces the limits of each tenant's billing plan at the
+// gateway: API requests per minute (GraphQL and REST alike) and shipments
+// created per billing month. Limits come from billing-service; requests that
+// are let through are reported to it as API_REQUEST usage.
+//
+// The counts are kept in Counters. Replicas sharing PostgresCounters share
+// the limits; with the in-process default each replica allows the full rate.
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"golang.org/x/sync/singleflight"
+)
+
+// Values of the "reason" extension of RATE_LIMITED errors.
+const (
+	ReasonRateLimit     = "RATE_LIMIT_EXCEEDED"
+	ReasonShipmentQuota = "SHIPMENT_QUOTA_EXCEEDED"
+)
+
+// Plan is a tenant's plan as billing-service reports it. Limits of zero are
+// unlimited.
+type Plan struct {
+	Name              string
+	RequestsPerMinute int
+	MonthlyShipments  int64
+	ShipmentsUsed     int64 // as of the lookup; billing-service lags a little
+}
+
+// PlanSource looks up the plan of the caller's tenant (the identity in ctx).
+type PlanSource interface {
+	Plan(ctx context.Context) (Plan, error)
+}
+
+// Meter receives the usage to bill (contracts.UsageAPIRequest, ...).
+type Meter interface {
+	Record(tenantID, usageType string, quantity int64)
+}
+
+const (
+	window = time.Minute
+	// quotaTTL outlasts a billing month, so a reservation can still be given
+	// back after the month is over.
+	quotaTTL = 35 * 24 * time.Hour
+	// planTTL is how long a plan is trusted. An upgrade takes effect within
+	// it, and shipments billing-service counted elsewhere become visible.
+	planTTL = time.Minute
+	// planErrorTTL: while billing-service cannot be asked, tenants are not
+	// limited, and it is asked again after this long.
+	planErrorTTL = 10 * time.Second
+	// planTimeout bounds the lookup a request waits for.
+	planTimeout = 2 * time.Second
+)
+
+// TenantlessRequestsPerMinute is the per-minute limit of callers without a
+// tenant, which have no plan: anonymous requests count per client address,
+// super admins acting outside a tenant per user.
+const TenantlessRequestsPerMinute = 60
+
+// Limiter counts requests and shipments per tenant.
+// Analogy: The doorman knows each guest's membership and how often they've been in.
+type Limiter struct {
+	plans          PlanSource
+	meter          Meter
+	counters       Counters
+	tenantlessRate int
+	now            func() time.Time
+
+	lookups singleflight.Group // one plan lookup per tenant at a time
+
+	mu    sync.Mutex
+	cache map[string]cachedPlan
+}
+
+type cachedPlan struct {
+	plan    Plan
+	expires time.Time
+}
+
+// New returns a Limiter; meter may be nil, then no usage is reported, and
+// counters may be nil, then counts are kept in this process.
+func New(plans PlanSource, meter Meter, counters Counters) *Limiter {
+	if counters == nil {
+		counters = NewMemoryCounters()
+	}
+	return &Limiter{
+		plans:          plans,
+		meter:          meter,
+		counters:       counters,
+		tenantlessRate: TenantlessRequestsPerMinute,
+		now:            time.Now,
+		cache:          map[string]cachedPlan{},
+	}
+}
+
+// Decision is the outcome of counting one request.
+type Decision struct {
+	Allowed   bool
+	Limit     int // requests per minute; zero when the tenant is not limited
+	Remaining int
+	Reset     time.Duration // until the current window ends
+}
+
+// Allow counts a request of the caller in ctx against their tenant's
+// per-minute limit. client is the address the request came from; callers
+// without a tenant (anonymous requests, super admins acting outside a tenant)
+// are held to TenantlessRequestsPerMinute by it, and are not metered.
+func (l *Limiter) Allow(ctx context.Context, client string) Decision {
+	now := l.now()
+	tenantID := callerTenant(ctx)
+	if tenantID == "" {
+		return l.count(ctx, tenantlessKey(ctx, client), l.tenantlessRate, now)
+	}
+	plan := l.plan(ctx, tenantID, now)
+	d := l.count(ctx, "requests/"+tenantID, plan.RequestsPerMinute, now)
+	if d.Allowed && l.meter != nil {
+		l.meter.Record(tenantID, contracts.UsageAPIRequest, 1)
+	}
+	return d
+}
+
+// count counts a request against limit (zero: none) in the minute of now.
+func (l *Limiter) count(ctx context.Context, bucket string, limit int, now time.Time) Decision {
+	start := now.Truncate(window)
+	d := Decision{Allowed: true, Limit: limit, Reset: start.Add(window).Sub(now)}
+	if limit <= 0 {
+		return d
+	}
+	count, ok, err := l.counters.Add(ctx, requestsKey(bucket, start), 1, int64(limit), 2*window)
+	switch {
+	case err != nil:
+		// Fail open, as for billing below
+		log.Printf("ratelimit: not limiting %s: %v", bucket, err)
+		d.Limit = 0
+	case !ok:
+		d.Allowed = false
+	default:
+		d.Remaining = limit - int(count)
+	}
+	return d
+}
+
+// reserveShipment takes one shipment of the caller's monthly quota. The
+// returned func is called with whether the shipment was created: a failed
+// creation gives the reservation back to the month it was taken from, a
+// successful one is metered.
+func (l *Limiter) reserveShipment(ctx context.Context) (func(created bool), error) {
+	tenantID := callerTenant(ctx)
+	if tenantID == "" {
+		return func(bool) {}, nil
+	}
+	now := l.now()
+	plan := l.plan(ctx, tenantID, now)
+	created := func(bool) {}
+	if l.meter != nil {
+		created = func(ok bool) {
+			if ok {
+				l.meter.Record(tenantID, contracts.UsageShipmentCreated, 1)
+			}
+		}
+	}
+
+	quota := plan.MonthlyShipments
+	if quota <= 0 {
+		return created, nil
+	}
+	key := shipmentsKey(tenantID, now)
+	_, ok, err := l.counters.Add(ctx, key, 1, quota, quotaTTL)
+	if err != nil {
+		log.Printf("ratelimit: not holding tenant %s to its shipment quota: %v", tenantID, err)
+		return created, nil
+	}
+	if !ok {
+		err := apierror.New(apierror.CodeRateLimited, "monthly shipment quota of the "+plan.Name+" plan is used up")
+		err.Reason = ReasonShipmentQuota
+		err.Retryable = false // not before next month, or an upgrade
+		return nil, err
+	}
+	return func(ok bool) {
+		created(ok)
+		if ok {
+			return
+		}
+		// The call may have been cancelled; giving back must not be
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), planTimeout)
+		defer cancel()
+		if _, _, err := l.counters.Add(releaseCtx, key, -1, 0, quotaTTL); err != nil {
+			log.Printf("ratelimit: failed to give back a shipment of tenant %s: %v", tenantID, err)
+		}
+	}, nil
+}
+
+// plan returns tenantID's current plan. The lookup holds no lock: concurrent
+// requests of the tenant share one billing call, other tenants go on.
+func (l *Limiter) plan(ctx context.Context, tenantID string, now time.Time) Plan {
+	if plan, ok := l.cached(tenantID, now); ok {
+		return plan
+	}
+	v, _, _ := l.lookups.Do(tenantID, func() (interface{}, error) {
+		// Another lookup may have finished just now
+		if plan, ok := l.cached(tenantID, now); ok {
+			return plan, nil
+		}
+		// Shared with the other waiting requests, so not cancelled with this one
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), planTimeout)
+		defer cancel()
+		plan, err := l.plans.Plan(lookupCtx)
+		if err != nil {
+			// Fail open: a billing outage must not take the API down with it
+			log.Printf("ratelimit: plan of tenant %s unavailable, not limiting it: %v", tenantID, err)
+			l.store(tenantID, cachedPlan{expires: now.Add(planErrorTTL)})
+			return Plan{}, nil
+		}
+		// plan.ShipmentsUsed includes shipments created before this gateway
+		// counted them, or through other paths; the count is at least that
+		if plan.MonthlyShipments > 0 {
+			if err := l.counters.Raise(lookupCtx, shipmentsKey(tenantID, now), plan.ShipmentsUsed, quotaTTL); err != nil {
+				log.Printf("ratelimit: %v", err)
+			}
+		}
+		l.store(tenantID, cachedPlan{plan: plan, expires: now.Add(planTTL)})
+		return plan, nil
+	})
+	return v.(Plan)
+}
+
+func (l *Limiter) cached(tenantID string, now time.Time) (Plan, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.cache[tenantID]
+	return c.plan, ok && now.Before(c.expires)
+}
+
+func (l *Limiter) store(tenantID string, c cachedPlan) {
+	l.mu.Lock()
+	l.cache[tenantID] = c
+	l.mu.Unlock()
+}
+
+// requestsKey names bucket's request count in the minute from start.
+func requestsKey(bucket string, start time.Time) string {
+	return bucket + "/" + start.UTC().Format(time.RFC3339)
+}
+
+// tenantlessKey is the bucket of a caller without a tenant: their user when
+// signed in, the client address otherwise.
+func tenantlessKey(ctx context.Context, client string) string {
+	if id, ok := identity.FromContext(ctx); ok && id.UserID != "" {
+		return "requests-user/" + id.UserID
+	}
+	return "requests-client/" + client
+}
+
+// shipmentsKey names the tenant's shipment count in the billing month of
+// now, which billing-service takes in UTC.
+func shipmentsKey(tenantID string, now time.Time) string {
+	return "shipments/" + tenantID + "/" + now.UTC().Format("2006-01")
+}
+
+func callerTenant(ctx context.Context) string {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return id.TenantID
+}
@@ -0,0 +1,77 @@
+// internal/ratelimit/middleware.ratelimit.go
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Response headers, as in the IETF RateLimit header fields draft. Reset and
+// Retry-After are in seconds.
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Middleware applies the per-minute limit to requests whose caller the auth
+// middleware has put into the context, so it goes inside it; requests without
+// a tenant are limited per client address. Limited callers get the RateLimit
+// headers on every response; requests over the limit are answered with 429
+// and a RATE_LIMITED error in GraphQL's response shape.
+func Middleware(l *Limiter) func(http.Handler) http.Handler {
+	return MiddlewareWithReject(l, rejectGraphQL)
+}
+
+// MiddlewareWithReject is Middleware answering limited requests with reject,
+// for APIs whose error bodies are not GraphQL-shaped.
+func MiddlewareWithReject(l *Limiter, reject func(w http.ResponseWriter, r *http.Request, err error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d := l.Allow(r.Context(), clientAddr(r))
+			if d.Limit > 0 {
+				reset := strconv.Itoa(int(math.Ceil(d.Reset.Seconds())))
+				w.Header().Set(HeaderLimit, strconv.Itoa(d.Limit))
+				w.Header().Set(HeaderRemaining, strconv.Itoa(d.Remaining))
+				w.Header().Set(HeaderReset, reset)
+				if !d.Allowed {
+					w.Header().Set(HeaderRetryAfter, reset)
+				}
+			}
+			if !d.Allowed {
+				err := apierror.New(apierror.CodeRateLimited, fmt.Sprintf("rate limit of %d requests per minute exceeded", d.Limit))
+				err.Reason = ReasonRateLimit
+				reject(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientAddr is the host the request came from. Forwarding headers are not
+// trusted: behind a proxy, tenantless callers share the proxy's bucket.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rejectGraphQL answers like the ErrorPresenter would, so clients handle it
+// like any other error.
+func rejectGraphQL(w http.ResponseWriter, r *http.Request, err error) {
+	gqlErr := apierror.Present(r.Context(), &gqlerror.Error{Message: err.Error()}, err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errors": []*gqlerror.Error{gqlErr}})
+}
@@ -0,0 +1,73 @@
+// internal/ratelimit/postgres.ratelimit.go
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PostgresCounters keeps counts in the rate_limit_counters table
+// (db/migrations), for replicas to share. Each Add is a single statement, so
+// replicas racing for the last request of a window cannot both get it.
+type PostgresCounters struct {
+	db *sql.DB
+}
+
+func NewPostgresCounters(db *sql.DB) *PostgresCounters {
+	return &PostgresCounters{db: db}
+}
+
+func (p *PostgresCounters) Add(ctx context.Context, key string, n, limit int64, ttl time.Duration) (int64, bool, error) {
+	if limit > 0 && n > limit {
+		return 0, false, nil
+	}
+	// The WHERE leaves the row alone when the limit would be passed, and
+	// then nothing is returned
+	var count int64
+	err := p.db.QueryRowContext(ctx, `
+	INSERT INTO rate_limit_counters (key, count, expires_at)
+	VALUES ($1, $2, NOW() + $4::float8 * INTERVAL '1 second')
+	ON CONFLICT (key) DO UPDATE SET count = rate_limit_counters.count + EXCLUDED.count
+	WHERE $3::bigint = 0 OR rate_limit_counters.count + EXCLUDED.count <= $3::bigint
+	RETURNING count
+	`, key, n, limit, ttl.Seconds()).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return limit, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to add to rate limit counter %s: %w", key, err)
+	}
+	return count, true, nil
+}
+
+func (p *PostgresCounters) Raise(ctx context.Context, key string, floor int64, ttl time.Duration) error {
+	_, err := p.db.ExecContext(ctx, `
+	INSERT INTO rate_limit_counters (key, count, expires_at)
+	VALUES ($1, $2, NOW() + $3::float8 * INTERVAL '1 second')
+	ON CONFLICT (key) DO UPDATE SET count = GREATEST(rate_limit_counters.count, EXCLUDED.count)
+	`, key, floor, ttl.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to raise rate limit counter %s: %w", key, err)
+	}
+	return nil
+}
+
+// Run deletes expired counters every minute until ctx is done.
+func (p *PostgresCounters) Run(ctx context.Context) {
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.db.ExecContext(ctx, `DELETE FROM rate_limit_counters WHERE expires_at < NOW()`); err != nil {
+				log.Printf("ratelimit: failed to delete expired counters: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/grpc"
+)
+
+type fakePlans struct {
+	plans   map[string]Plan
+	err     error
+	lookups int
+}
+
+func (f *fakePlans) Plan(ctx context.Context) (Plan, error) {
+	f.lookups++
+	if f.err != nil {
+		return Plan{}, f.err
+	}
+	id, _ := identity.FromContext(ctx)
+	return f.plans[id.TenantID], nil
+}
+
+type fakeMeter map[string]int64
+
+func (m fakeMeter) Record(tenantID, usageType string, quantity int64) {
+	m[tenantID+":"+usageType] += quantity
+}
+
+func as(tenantID string) context.Context {
+	return identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenantID, Role: "member"})
+}
+
+func newTestLimiter(plans *fakePlans, meter Meter) (*Limiter, *time.Time) {
+	clock := time.Date(2025, 7, 1, 10, 0, 15, 0, time.UTC)
+	l := New(plans, meter, nil)
+	l.now = func() time.Time { return clock }
+	return l, &clock
+}
+
+func TestMiddlewareLimitsPerTenant(t *testing.T) {
+	plans := &fakePlans{plans: map[string]Plan{
+		"acme":   {Name: "FREE", RequestsPerMinute: 2},
+		"globex": {Name: "ENTERPRISE"},
+	}}
+	meter := fakeMeter{}
+	l, clock := newTestLimiter(plans, meter)
+	h := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	call := func(ctx context.Context) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", nil).WithContext(ctx))
+		return rec
+	}
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		rec := call(as("acme"))
+		if rec.Code != http.StatusOK || rec.Header().Get(HeaderLimit) != "2" || rec.Header().Get(HeaderRemaining) != wantRemaining {
+			t.Fatalf("request %d: %d %v", i, rec.Code, rec.Header())
+		}
+	}
+	rec := call(as("acme"))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get(HeaderRetryAfter) != "45" || rec.Header().Get(HeaderReset) != "45" {
+		t.Fatalf("third request: %d %v", rec.Code, rec.Header())
+	}
+	var body struct {
+		Errors []struct {
+			Extensions map[string]interface{} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || len(body.Errors) != 1 {
+		t.Fatalf("body %s: %v", rec.Body, err)
+	}
+	if ext := body.Errors[0].Extensions; ext["code"] != apierror.CodeRateLimited || ext["reason"] != ReasonRateLimit || ext["retryable"] != true {
+		t.Errorf("extensions = %v", ext)
+	}
+
+	// Other tenants and unlimited plans are unaffected; anonymous callers
+	// have a bucket of their own
+	if rec := call(as("globex")); rec.Code != http.StatusOK || rec.Header().Get(HeaderLimit) != "" {
+		t.Errorf("unlimited tenant: %d %v", rec.Code, rec.Header())
+	}
+	if rec := call(context.Background()); rec.Code != http.StatusOK || rec.Header().Get(HeaderLimit) != "60" {
+		t.Errorf("anonymous: %d %v", rec.Code, rec.Header())
+	}
+
+	// The next minute starts afresh, without asking billing again
+	*clock = clock.Add(45 * time.Second)
+	if rec := call(as("acme")); rec.Code != http.StatusOK || rec.Header().Get(HeaderRemaining) != "1" {
+		t.Errorf("next minute: %d %v", rec.Code, rec.Header())
+	}
+	if plans.lookups != 2 {
+		t.Errorf("plan lookups = %d; want one per tenant", plans.lookups)
+	}
+	if len(meter) != 2 || meter["acme:"+contracts.UsageAPIRequest] != 3 || meter["globex:"+contracts.UsageAPIRequest] != 1 {
+		t.Errorf("metered %v; rejected and anonymous requests are not usage", meter)
+	}
+}
+
+func TestTenantlessCallersAreLimited(t *testing.T) {
+	plans := &fakePlans{}
+	l, clock := newTestLimiter(plans, fakeMeter{})
+	l.tenantlessRate = 2
+	h := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	call := func(ctx context.Context, remoteAddr string) int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/query", nil).WithContext(ctx)
+		req.RemoteAddr = remoteAddr
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Anonymous callers count per address, whatever their port
+	for _, addr := range []string{"198.51.100.7:4100", "198.51.100.7:4101"} {
+		if code := call(context.Background(), addr); code != http.StatusOK {
+			t.Fatalf("anonymous from %s: %d", addr, code)
+		}
+	}
+	if code := call(context.Background(), "198.51.100.7:4102"); code != http.StatusTooManyRequests {
+		t.Errorf("third anonymous request: %d", code)
+	}
+	if code := call(context.Background(), "203.0.113.9:4100"); code != http.StatusOK {
+		t.Errorf("another address: %d", code)
+	}
+
+	// A super admin outside a tenant counts per user, not per address
+	operator := identity.NewContext(context.Background(), identity.Identity{UserID: "op-1", SuperAdmin: true})
+	for i := 0; i < 2; i++ {
+		if code := call(operator, "198.51.100.7:4100"); code != http.StatusOK {
+			t.Fatalf("operator request %d: %d", i, code)
+		}
+	}
+	if code := call(operator, "203.0.113.9:4100"); code != http.StatusTooManyRequests {
+		t.Errorf("operator from another address: %d", code)
+	}
+
+	*clock = clock.Add(window)
+	if code := call(context.Background(), "198.51.100.7:4100"); code != http.StatusOK {
+		t.Errorf("next minute: %d", code)
+	}
+	if plans.lookups != 0 {
+		t.Errorf("plan lookups = %d; tenantless callers have no plan", plans.lookups)
+	}
+}
+
+func TestBillingOutageFailsOpen(t *testing.T) {
+	plans := &fakePlans{err: errors.New("billing service is unavailable")}
+	l, clock := newTestLimiter(plans, nil)
+	for i := 0; i < 100; i++ {
+		if d := l.Allow(as("acme"), "192.0.2.1"); !d.Allowed || d.Limit != 0 {
+			t.Fatalf("request %d: %+v", i, d)
+		}
+	}
+	if plans.lookups != 1 {
+		t.Errorf("plan lookups = %d; failures should be remembered briefly", plans.lookups)
+	}
+	plans.err = nil
+	plans.plans = map[string]Plan{"acme": {RequestsPerMinute: 1}}
+	*clock = clock.Add(planErrorTTL)
+	if d := l.Allow(as("acme"), "192.0.2.1"); d.Limit != 1 {
+		t.Errorf("after the outage: %+v", d)
+	}
+}
+
+func TestShipmentQuota(t *testing.T) {
+	plans := &fakePlans{plans: map[string]Plan{"acme": {Name: "FREE", MonthlyShipments: 100, ShipmentsUsed: 98}}}
+	meter := fakeMeter{}
+	l, _ := newTestLimiter(plans, meter)
+	intercept := ShipmentQuota(l)
+
+	var backendErr error
+	calls := 0
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return backendErr
+	}
+	create := func() error {
+		return intercept(as("acme"), proto.ShipmentService_CreateShipment_FullMethodName, nil, nil, nil, invoker)
+	}
+
+	backendErr = errors.New("invalid address")
+	if err := create(); err == nil {
+		t.Fatal("backend error lost")
+	}
+	backendErr = nil
+	for i := 0; i < 2; i++ {
+		if err := create(); err != nil {
+			t.Fatalf("shipment %d: %v", i, err)
+		}
+	}
+	err := create()
+	var apiErr *apierror.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != apierror.CodeRateLimited || apiErr.Reason != ReasonShipmentQuota || apiErr.Retryable {
+		t.Fatalf("over quota: err = %#v", err)
+	}
+	if calls != 3 {
+		t.Errorf("shipment-service called %d times; the rejected call should not reach it", calls)
+	}
+	if meter["acme:"+contracts.UsageShipmentCreated] != 2 {
+		t.Errorf("metered %v; want the two created shipments", meter)
+	}
+	// Other calls are not counted
+	if err := intercept(as("acme"), proto.ShipmentService_GetShipments_FullMethodName, nil, nil, nil, invoker); err != nil {
+		t.Errorf("GetShipments: %v", err)
+	}
+}
+
+func TestReplicasShareCounters(t *testing.T) {
+	plans := &fakePlans{plans: map[string]Plan{"acme": {RequestsPerMinute: 3}}}
+	shared := NewMemoryCounters()
+	clock := time.Date(2025, 7, 1, 10, 0, 15, 0, time.UTC)
+	replicas := []*Limiter{New(plans, nil, shared), New(plans, nil, shared)}
+	for _, l := range replicas {
+		l.now = func() time.Time { return clock }
+	}
+
+	for i := 0; i < 3; i++ {
+		if d := replicas[i%2].Allow(as("acme"), "192.0.2.1"); !d.Allowed || d.Remaining != 2-i {
+			t.Fatalf("request %d: %+v", i, d)
+		}
+	}
+	if d := replicas[1].Allow(as("acme"), "192.0.2.1"); d.Allowed {
+		t.Errorf("fourth request across replicas allowed: %+v", d)
+	}
+}
+
+func TestReservationGoesBackToItsMonth(t *testing.T) {
+	plans := &fakePlans{plans: map[string]Plan{"acme": {Name: "FREE", MonthlyShipments: 1}}}
+	l, clock := newTestLimiter(plans, nil)
+	*clock = time.Date(2025, 7, 31, 23, 59, 59, 0, time.UTC)
+
+	july, err := l.reserveShipment(as("acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(planTTL) // August, and a fresh plan
+	august, err := l.reserveShipment(as("acme"))
+	if err != nil {
+		t.Fatalf("first shipment of August: %v", err)
+	}
+	// July's creation fails only now; that must not free August's quota
+	july(false)
+	if _, err := l.reserveShipment(as("acme")); err == nil {
+		t.Fatal("second shipment of August allowed")
+	}
+	august(true)
+}
+
+// blockingPlans holds lookups until release is closed.
+type blockingPlans struct {
+	plan    Plan
+	release chan struct{}
+	lookups atomic.Int32
+}
+
+func (b *blockingPlans) Plan(ctx context.Context) (Plan, error) {
+	b.lookups.Add(1)
+	select {
+	case <-b.release:
+		return b.plan, nil
+	case <-ctx.Done():
+		return Plan{}, ctx.Err()
+	}
+}
+
+func TestPlanLookupHoldsNoLock(t *testing.T) {
+	plans := &blockingPlans{plan: Plan{MonthlyShipments: 10}, release: make(chan struct{})}
+	close(plans.release)
+	l, clock := newTestLimiter(nil, nil)
+	l.plans = plans
+	done, err := l.reserveShipment(as("acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The plan expires, and the next lookup hangs
+	plans.release = make(chan struct{})
+	*clock = clock.Add(planTTL)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Allow(as("acme"), "192.0.2.1")
+		}()
+	}
+	for plans.lookups.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Meanwhile the tenant's shipment can still be given back
+	released := make(chan struct{})
+	go func() {
+		done(false)
+		close(released)
+	}()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("giving back a shipment waited for the plan lookup")
+	}
+
+	close(plans.release)
+	wg.Wait()
+	if n := plans.lookups.Load(); n != 2 {
+		t.Errorf("plan lookups = %d; concurrent requests should share one", n)
+	}
+}
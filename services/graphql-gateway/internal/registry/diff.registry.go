@@ -0,0 +1,190 @@
+// internal/registry/diff.registry.go
+
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity says how a schema change affects existing clients.
+type Severity string
+
+const (
+	// Breaking changes make valid queries fail or change their results' shape.
+	Breaking Severity = "BREAKING"
+	// Dangerous changes keep queries valid but may surprise clients, e.g. a
+	// new enum value a client's switch does not handle.
+	Dangerous Severity = "DANGEROUS"
+)
+
+// Change is one difference between two schemas that clients can notice.
+// Additions that are always safe (new types, fields, optional arguments) are not reported.
+type Change struct {
+	Severity Severity
+	Path     string // e.g. "Shipment.carrier" or "Query.shipments(limit)"
+	Message  string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Severity, c.Path, c.Message)
+}
+
+// Diff lists the changes from old to new that clients can notice, sorted by path.
+func Diff(old, new *ast.Schema) []Change {
+	var changes []Change
+	add := func(sev Severity, path, format string, args ...interface{}) {
+		changes = append(changes, Change{Severity: sev, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for name, before := range old.Types {
+		if before.BuiltIn || strings.HasPrefix(name, "__") {
+			continue
+		}
+		after := new.Types[name]
+		if after == nil {
+			add(Breaking, name, "type removed")
+			continue
+		}
+		if before.Kind != after.Kind {
+			add(Breaking, name, "changed from %s to %s", before.Kind, after.Kind)
+			continue
+		}
+
+		switch before.Kind {
+		case ast.Object, ast.Interface:
+			for _, iface := range before.Interfaces {
+				if !contains(after.Interfaces, iface) {
+					add(Breaking, name, "no longer implements %s", iface)
+				}
+			}
+			for _, f := range before.Fields {
+				if strings.HasPrefix(f.Name, "__") {
+					continue
+				}
+				path := name + "." + f.Name
+				g := after.Fields.ForName(f.Name)
+				if g == nil {
+					add(Breaking, path, "field removed")
+					continue
+				}
+				if !safeOutput(f.Type, g.Type) {
+					add(Breaking, path, "type changed from %s to %s", f.Type, g.Type)
+				}
+				diffArguments(path, f.Arguments, g.Arguments, add)
+			}
+		case ast.InputObject:
+			for _, f := range before.Fields {
+				path := name + "." + f.Name
+				g := after.Fields.ForName(f.Name)
+				if g == nil {
+					add(Breaking, path, "input field removed")
+					continue
+				}
+				if !safeInput(f.Type, g.Type) {
+					add(Breaking, path, "type changed from %s to %s", f.Type, g.Type)
+				}
+			}
+			for _, g := range after.Fields {
+				if before.Fields.ForName(g.Name) == nil && g.Type.NonNull && g.DefaultValue == nil {
+					add(Breaking, name+"."+g.Name, "new required input field")
+				}
+			}
+		case ast.Enum:
+			for _, v := range before.EnumValues {
+				if after.EnumValues.ForName(v.Name) == nil {
+					add(Breaking, name+"."+v.Name, "enum value removed")
+				}
+			}
+			for _, v := range after.EnumValues {
+				if before.EnumValues.ForName(v.Name) == nil {
+					add(Dangerous, name+"."+v.Name, "enum value added")
+				}
+			}
+		case ast.Union:
+			for _, t := range before.Types {
+				if !contains(after.Types, t) {
+					add(Breaking, name, "union member %s removed", t)
+				}
+			}
+			for _, t := range after.Types {
+				if !contains(before.Types, t) {
+					add(Dangerous, name, "union member %s added", t)
+				}
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].Message < changes[j].Message
+	})
+	return changes
+}
+
+// HasBreaking reports whether changes contains a breaking change.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Severity == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func diffArguments(path string, before, after ast.ArgumentDefinitionList, add func(Severity, string, string, ...interface{})) {
+	for _, a := range before {
+		argPath := path + "(" + a.Name + ")"
+		b := after.ForName(a.Name)
+		if b == nil {
+			add(Breaking, argPath, "argument removed")
+			continue
+		}
+		if !safeInput(a.Type, b.Type) {
+			add(Breaking, argPath, "type changed from %s to %s", a.Type, b.Type)
+		}
+	}
+	for _, b := range after {
+		if before.ForName(b.Name) == nil && b.Type.NonNull && b.DefaultValue == nil {
+			add(Breaking, path+"("+b.Name+")", "new required argument")
+		}
+	}
+}
+
+// safeOutput reports whether a field of type old can become new without
+// breaking readers: the type stays the same and may only get stricter (non-null).
+func safeOutput(old, new *ast.Type) bool {
+	if old.NonNull && !new.NonNull {
+		return false
+	}
+	if old.Elem != nil || new.Elem != nil {
+		return old.Elem != nil && new.Elem != nil && safeOutput(old.Elem, new.Elem)
+	}
+	return old.NamedType == new.NamedType
+}
+
+// safeInput reports whether an argument or input field of type old can become
+// new without breaking senders: the type stays the same and may only get looser (nullable).
+func safeInput(old, new *ast.Type) bool {
+	if !old.NonNull && new.NonNull {
+		return false
+	}
+	if old.Elem != nil || new.Elem != nil {
+		return old.Elem != nil && new.Elem != nil && safeInput(old.Elem, new.Elem)
+	}
+	return old.NamedType == new.NamedType
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// internal/registry/extract.registry.go
+
+// Package registry backs the gqlregistry CLI: it extracts the operations
+// clients send from their source code and checks schema changes against the
+// previous schema and those operations.
+package registry
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/persisted"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// skipDirs are never searched for operations.
+var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true, "build": true, "dist": true}
+
+// taggedTemplate matches gql`...` and graphql`...` literals in JS/TS code.
+var taggedTemplate = regexp.MustCompile("(?s)\\b(?:gql|graphql)\\s*`(.*?)`")
+
+// interpolation matches ${...} inside a template literal; Apollo code uses it
+// to pull in fragments, which are extracted from their own literal.
+var interpolation = regexp.MustCompile(`\$\{[^}]*\}`)
+
+// Extract finds the operations in .graphql/.gql files and gql/graphql tagged
+// templates under roots. Every operation must be named, and a name must mean
+// the same operation everywhere. Fragments may live in other files than the
+// operations that use them. The result is sorted by name.
+func Extract(roots ...string) ([]persisted.Operation, error) {
+	type source struct {
+		path string
+		doc  *ast.QueryDocument
+	}
+	var docs []source
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if skipDirs[d.Name()] && path != root {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			texts, err := documentsIn(path)
+			if err != nil {
+				return err
+			}
+			for _, text := range texts {
+				doc, err := parser.ParseQuery(&ast.Source{Name: path, Input: text})
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				docs = append(docs, source{path: path, doc: doc})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fragments ast.FragmentDefinitionList
+	for _, src := range docs {
+		for _, frag := range src.doc.Fragments {
+			if prev := fragments.ForName(frag.Name); prev != nil {
+				return nil, fmt.Errorf("%s: fragment %q is also defined in %s", src.path, frag.Name, prev.Position.Src.Name)
+			}
+			fragments = append(fragments, frag)
+		}
+	}
+
+	byName := map[string]persisted.Operation{}
+	for _, src := range docs {
+		for _, op := range src.doc.Operations {
+			if op.Name == "" {
+				return nil, fmt.Errorf("%s: operations must be named", src.path)
+			}
+			query, err := persisted.CanonicalOperation(op, fragments)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", src.path, op.Name, err)
+			}
+			entry := persisted.Operation{Name: op.Name, Hash: persisted.Hash(query), Query: query, Source: src.path}
+			if prev, ok := byName[op.Name]; ok && prev.Hash != entry.Hash {
+				return nil, fmt.Errorf("%s: operation %q differs from the one in %s", src.path, op.Name, prev.Source)
+			}
+			byName[op.Name] = entry
+		}
+	}
+
+	ops := make([]persisted.Operation, 0, len(byName))
+	for _, op := range byName {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+	return ops, nil
+}
+
+// documentsIn returns the GraphQL documents in the file at path.
+func documentsIn(path string) ([]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".graphql", ".gql", ".js", ".jsx", ".ts", ".tsx":
+	default:
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext == ".graphql" || ext == ".gql" {
+		return []string{string(raw)}, nil
+	}
+	var out []string
+	for _, m := range taggedTemplate.FindAllStringSubmatch(string(raw), -1) {
+		out = append(out, interpolation.ReplaceAllString(m[1], ""))
+	}
+	return out, nil
+}
+
+// Validate checks ops against schema and returns one error per operation that
+// no longer validates, e.g. because it selects a removed field.
+func Validate(schema *ast.Schema, ops []persisted.Operation) []error {
+	var errs []error
+	for _, op := range ops {
+		if _, list := gqlparser.LoadQuery(schema, op.Query); len(list) > 0 {
+			errs = append(errs, fmt.Errorf("%s (%s): %v", op.Name, op.Source, list))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "src/fragments.ts", "export const PARTS = gql`fragment Parts on Shipment { status }`;")
+	write(t, dir, "src/list.tsx", "const Q = gql`\n  query ListShipments { shipments { id ...Parts } }\n  ${PARTS}\n`;")
+	write(t, dir, "ios/Track.graphql", "query Track($id: ID!) { shipment(id: $id) { id } }")
+	write(t, dir, "node_modules/lib/x.graphql", "query Ignored { a }")
+	write(t, dir, "README.md", "query NotCode { a }")
+
+	ops, err := Extract(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 2 || ops[0].Name != "ListShipments" || ops[1].Name != "Track" {
+		t.Fatalf("ops = %+v", ops)
+	}
+	if !strings.Contains(ops[0].Query, "fragment Parts on Shipment") {
+		t.Errorf("fragment not included:\n%s", ops[0].Query)
+	}
+}
+
+func TestExtractRejectsAnonymousAndConflicting(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "a.graphql", "{ shipments { id } }")
+	if _, err := Extract(dir); err == nil {
+		t.Error("anonymous operation accepted")
+	}
+
+	dir = t.TempDir()
+	write(t, dir, "a.graphql", "query Q { shipments { id } }")
+	write(t, dir, "b.graphql", "query Q { shipments { status } }")
+	if _, err := Extract(dir); err == nil {
+		t.Error("conflicting operations accepted")
+	}
+}
+
+const before = `
+type Query { shipments(limit: Int, status: Status): [Shipment!]! shipment(id: ID!): Shipment }
+type Shipment { id: ID! eta: String carrier: String! }
+enum Status { PENDING DELIVERED }
+input NewShipment { origin: String! note: String }
+type Mutation { create(input: NewShipment!): Shipment! }
+`
+
+func load(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	s, err := gqlparser.LoadSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	after := `
+type Query { shipments(limit: Int!, page: Int): [Shipment!]! shipment(id: ID!, tenant: ID!): Shipment }
+type Shipment { id: ID! eta: String! carrier: String }
+enum Status { PENDING IN_TRANSIT }
+input NewShipment { origin: String note: String! }
+type Mutation { create(input: NewShipment!): Shipment! }
+`
+	var got []string
+	for _, c := range Diff(load(t, before), load(t, after)) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"BREAKING NewShipment.note: type changed from String to String!",
+		"BREAKING Query.shipment(tenant): new required argument",
+		"BREAKING Query.shipments(limit): type changed from Int to Int!",
+		"BREAKING Query.shipments(status): argument removed",
+		"BREAKING Shipment.carrier: type changed from String! to String",
+		"BREAKING Status.DELIVERED: enum value removed",
+		"DANGEROUS Status.IN_TRANSIT: enum value added",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDiffSafeChanges(t *testing.T) {
+	after := before + `
+extend type Query { invoices: [String!]! }
+extend type Shipment { origin: String }
+`
+	changes := Diff(load(t, before), load(t, after))
+	if len(changes) != 0 || HasBreaking(changes) {
+		t.Fatalf("changes = %v", changes)
+	}
+	if !HasBreaking(Diff(load(t, after), load(t, before))) {
+		t.Fatal("removing fields is breaking")
+	}
+}
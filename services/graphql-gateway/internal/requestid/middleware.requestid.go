@@ -0,0 +1,59 @@
+// internal/requestid/middleware.requestid.go
+
+// Package requestid tags every HTTP request with an ID that is echoed in the
+// X-Request-Id response header and in the requestId extension of GraphQL errors.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Header carries the request ID in both directions.
+const Header = "X-Request-Id"
+
+// maxLen caps IDs taken from the client; longer ones are replaced.
+const maxLen = 128
+
+type ctxKey struct{}
+
+// Middleware keeps a well-formed X-Request-Id sent by the client (or a proxy in
+// front of the gateway) and generates one otherwise.
+// Analogy: Every order gets a ticket number the guest can quote if something goes wrong.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// NewContext returns ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID, or "" outside a request.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// valid accepts printable ASCII without spaces, so a client cannot inject
+// anything into our headers or logs.
+func valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
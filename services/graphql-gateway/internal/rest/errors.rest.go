@@ -0,0 +1,74 @@
+// internal/rest/errors.rest.go
+
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/requestid"
+)
+
+// errorBody is the body of every non-2xx response:
+//
+//	{"error": {"code": "BAD_USER_INPUT", "message": "...", "field": "toAddress.zip",
+//	           "fields": [{"field": "toAddress.zip", "message": "..."}],
+//	           "retryable": false, "requestId": "..."}}
+//
+// The members mean the same as the extensions of GraphQL errors.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code      string                `json:"code"`
+	Message   string                `json:"message"`
+	Field     string                `json:"field,omitempty"`
+	Fields    []apierror.FieldError `json:"fields,omitempty"`
+	Reason    string                `json:"reason,omitempty"`
+	Retryable bool                  `json:"retryable"`
+	RequestID string                `json:"requestId,omitempty"`
+}
+
+// writeError answers with err's code and the matching HTTP status. Errors that
+// are not *apierror.Error are logged and shown as INTERNAL.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var apiErr *apierror.Error
+	if !errors.As(err, &apiErr) {
+		log.Printf("request %s: rest: %v", requestid.FromContext(r.Context()), err)
+		apiErr = apierror.New(apierror.CodeInternal, "internal error")
+	}
+	detail := errorDetail{
+		Code:      apiErr.Code,
+		Message:   apiErr.Message,
+		Fields:    apiErr.Fields,
+		Reason:    apiErr.Reason,
+		Retryable: apiErr.Retryable,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+	if len(apiErr.Fields) > 0 {
+		detail.Field = apiErr.Fields[0].Field
+	}
+	writeJSON(w, apierror.HTTPStatus(apiErr.Code), errorBody{Error: detail})
+}
+
+// Unauthorized answers a rejected access token or API key; pass it to auth.MiddlewareWithReject.
+func Unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	writeJSON(w, http.StatusUnauthorized, errorBody{Error: errorDetail{Code: apierror.CodeUnauthenticated, Message: message}})
+}
+
+// Reject answers a request refused before it reached the API; pass it to
+// ratelimit.MiddlewareWithReject.
+func Reject(w http.ResponseWriter, r *http.Request, err error) {
+	writeError(w, r, err)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,208 @@
+// internal/rest/idempotency.rest.go
+
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+const (
+	// IdempotencyHeader names the client's key for a POST.
+	IdempotencyHeader = "Idempotency-Key"
+	// IdempotencyTTL is how long a key's response is replayed.
+	IdempotencyTTL = 24 * time.Hour
+
+	maxIdempotencyKeyLen = 255
+	maxRequestBody       = 1 << 20
+)
+
+// StoredResponse is what a key replays.
+type StoredResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+var (
+	// ErrKeyInFlight: another request with the key has not finished yet.
+	ErrKeyInFlight = errors.New("idempotency key in flight")
+	// ErrKeyMismatch: the key was used for a different request.
+	ErrKeyMismatch = errors.New("idempotency key reused")
+)
+
+// IdempotencyStore remembers the response to each key. Begin claims a key: it
+// returns nil for a new key, the stored response for a finished one, or
+// ErrKeyInFlight / ErrKeyMismatch. Every nil result must be followed by
+// Complete or Release.
+type IdempotencyStore interface {
+	Begin(key, fingerprint string) (*StoredResponse, error)
+	Complete(key string, resp StoredResponse)
+	Release(key string)
+}
+
+// MemoryIdempotencyStore keeps keys in process memory. Keys are only honoured
+// by the gateway instance that saw them first, which is enough while one
+// instance serves REST traffic.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+type idempotencyEntry struct {
+	fingerprint string
+	done        bool
+	resp        StoredResponse
+	expires     time.Time
+}
+
+// NewMemoryIdempotencyStore returns an empty store whose keys live for ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, now: time.Now, entries: map[string]*idempotencyEntry{}}
+}
+
+func (s *MemoryIdempotencyStore) Begin(key, fingerprint string) (*StoredResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		switch {
+		case e.fingerprint != fingerprint:
+			return nil, ErrKeyMismatch
+		case !e.done:
+			return nil, ErrKeyInFlight
+		}
+		resp := e.resp
+		return &resp, nil
+	}
+	s.entries[key] = &idempotencyEntry{fingerprint: fingerprint, expires: now.Add(s.ttl)}
+	return nil, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(key string, resp StoredResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		e.done, e.resp = true, resp
+	}
+}
+
+func (s *MemoryIdempotencyStore) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+// idempotent makes a POST handler honour Idempotency-Key: the first request
+// with a key runs, later ones with the same key, caller and body get its
+// response again with "Idempotent-Replayed: true". Responses that invite a
+// retry (429, 5xx) are not kept, so the retry runs for real.
+// Analogy: A reprinted receipt, not a second charge.
+func idempotent(store IdempotencyStore, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			writeError(w, r, badInput(IdempotencyHeader, "must be at most 255 characters"))
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+		if err != nil {
+			writeError(w, r, badInput("", "request body too large or unreadable"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are per caller: two tenants may well pick the same one
+		caller, _ := identity.FromContext(r.Context())
+		principal := caller.UserID
+		if caller.APIKeyID != "" {
+			principal = "key:" + caller.APIKeyID
+		}
+		scoped := caller.TenantID + "/" + principal + "/" + key
+		sum := sha256.Sum256(append([]byte(r.Method+" "+r.URL.Path+"\n"), body...))
+
+		stored, err := store.Begin(scoped, hex.EncodeToString(sum[:]))
+		switch {
+		case errors.Is(err, ErrKeyMismatch):
+			e := apierror.New(apierror.CodeBadUserInput, "Idempotency-Key was already used for a different request")
+			e.Reason = "IDEMPOTENCY_KEY_REUSED"
+			writeError(w, r, e)
+			return
+		case errors.Is(err, ErrKeyInFlight):
+			e := apierror.New(apierror.CodeConflict, "a request with this Idempotency-Key is still running")
+			e.Reason, e.Retryable = "IDEMPOTENCY_KEY_IN_USE", true
+			writeError(w, r, e)
+			return
+		case err != nil:
+			writeError(w, r, err)
+			return
+		case stored != nil:
+			for k, v := range stored.Header {
+				w.Header()[k] = v
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.Status)
+			w.Write(stored.Body)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			if rec.status == http.StatusTooManyRequests || rec.status >= 500 {
+				store.Release(scoped)
+				return
+			}
+			header := http.Header{}
+			if ct := w.Header().Get("Content-Type"); ct != "" {
+				header.Set("Content-Type", ct)
+			}
+			if loc := w.Header().Get("Location"); loc != "" {
+				header.Set("Location", loc)
+			}
+			store.Complete(scoped, StoredResponse{Status: rec.status, Header: header, Body: rec.body.Bytes()})
+		}()
+		next(rec, r)
+	}
+}
+
+// recorder passes a response through while keeping a copy.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,236 @@
+// internal/rest/openapi.rest.go
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPI returns the OpenAPI 3 document of the REST API. Request and response
+// schemas come from the proto descriptors compiled into shared/proto, so they
+// always match the JSON the handlers read and write (protojson: lowerCamelCase
+// names, enums as strings, 64-bit integers as strings).
+func OpenAPI() map[string]interface{} {
+	schemas := map[string]interface{}{
+		"Error": errorSchema(),
+	}
+	paths := map[string]interface{}{}
+	for _, rt := range routes() {
+		op := map[string]interface{}{
+			"operationId": rt.operationID,
+			"summary":     rt.summary,
+			"responses": map[string]interface{}{
+				fmt.Sprint(rt.status): map[string]interface{}{
+					"description": http.StatusText(rt.status),
+					"headers":     rateLimitHeaders(false),
+					"content":     jsonContent(addSchema(schemas, rt.response)),
+				},
+				"429": map[string]interface{}{
+					"description": "RATE_LIMITED: the plan's requests per minute (reason RATE_LIMIT_EXCEEDED) or monthly shipments (SHIPMENT_QUOTA_EXCEEDED) are used up",
+					"headers":     rateLimitHeaders(true),
+					"content":     jsonContent(ref("Error")),
+				},
+				"default": map[string]interface{}{
+					"description": "Error; see code and retryable",
+					"content":     jsonContent(ref("Error")),
+				},
+			},
+		}
+		if rt.role != "" {
+			op["description"] = fmt.Sprintf("Requires the %s role or higher.", rt.role)
+		}
+		var params []interface{}
+		for _, name := range pathParams(rt.path) {
+			params = append(params, map[string]interface{}{
+				"name": name, "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"},
+			})
+		}
+		if rt.query != nil {
+			fields := rt.query.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				params = append(params, map[string]interface{}{
+					"name": fd.JSONName(), "in": "query", "schema": fieldSchema(schemas, fd),
+				})
+			}
+		}
+		if rt.idempotent {
+			params = append(params, map[string]interface{}{
+				"name":        IdempotencyHeader,
+				"in":          "header",
+				"description": "Replays the first response for 24 hours when the same caller sends the same request with this key.",
+				"schema":      map[string]interface{}{"type": "string", "maxLength": maxIdempotencyKeyLen},
+			})
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if rt.body != nil {
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(addSchema(schemas, rt.body)),
+			}
+		}
+
+		item, _ := paths[rt.path].(map[string]interface{})
+		if item == nil {
+			item = map[string]interface{}{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "LogiSynapse REST API",
+			"version": "v1",
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearerAuth": []interface{}{}},
+			map[string]interface{}{"apiKeyAuth": []interface{}{}},
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"headers": map[string]interface{}{
+				"RateLimitLimit":     intHeader("Requests the tenant may make per minute (RateLimit-Limit)"),
+				"RateLimitRemaining": intHeader("Requests left in the current minute (RateLimit-Remaining)"),
+				"RateLimitReset":     intHeader("Seconds until the current minute ends (RateLimit-Reset)"),
+				"RetryAfter":         intHeader("Seconds to wait before sending requests again"),
+			},
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"apiKeyAuth": map[string]interface{}{
+					"type": "apiKey", "in": "header", "name": "Authorization",
+					"description": "A tenant API key sent as \"ApiKey lsk_...\". Keys with shipments:write may create and cancel shipments.",
+				},
+			},
+		},
+	}
+}
+
+// addSchema adds md (and every message it refers to) to schemas and returns a reference to it.
+func addSchema(schemas map[string]interface{}, md protoreflect.MessageDescriptor) map[string]interface{} {
+	name := string(md.FullName())
+	if _, ok := schemas[name]; !ok {
+		props := map[string]interface{}{}
+		schemas[name] = map[string]interface{}{"type": "object", "properties": props}
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			props[fd.JSONName()] = fieldSchema(schemas, fd)
+		}
+	}
+	return ref(name)
+}
+
+func fieldSchema(schemas map[string]interface{}, fd protoreflect.FieldDescriptor) map[string]interface{} {
+	var s map[string]interface{}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s = map[string]interface{}{"type": "string"}
+	case protoreflect.BoolKind:
+		s = map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s = map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		s = map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.DoubleKind:
+		s = map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.FloatKind:
+		s = map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.BytesKind:
+		s = map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]interface{}, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		s = map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.IsMap() {
+			return map[string]interface{}{"type": "object", "additionalProperties": fieldSchema(schemas, fd.MapValue())}
+		}
+		s = addSchema(schemas, fd.Message())
+	}
+	if fd.IsList() {
+		return map[string]interface{}{"type": "array", "items": s}
+	}
+	return s
+}
+
+func errorSchema() map[string]interface{} {
+	str := map[string]interface{}{"type": "string"}
+	fieldError := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"field": str, "message": str},
+	}
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"error"},
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"code", "message", "retryable"},
+				"properties": map[string]interface{}{
+					"code":      str,
+					"message":   str,
+					"field":     str,
+					"fields":    map[string]interface{}{"type": "array", "items": fieldError},
+					"reason":    str,
+					"retryable": map[string]interface{}{"type": "boolean"},
+					"requestId": str,
+				},
+			},
+		},
+	}
+}
+
+// rateLimitHeaders refers to the headers sent to tenants whose plan limits
+// their requests per minute; Retry-After comes with 429s only.
+func rateLimitHeaders(retryAfter bool) map[string]interface{} {
+	h := map[string]interface{}{
+		"RateLimit-Limit":     headerRef("RateLimitLimit"),
+		"RateLimit-Remaining": headerRef("RateLimitRemaining"),
+		"RateLimit-Reset":     headerRef("RateLimitReset"),
+	}
+	if retryAfter {
+		h["Retry-After"] = headerRef("RetryAfter")
+	}
+	return h
+}
+
+func headerRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/headers/" + name}
+}
+
+func intHeader(description string) map[string]interface{} {
+	return map[string]interface{}{"description": description, "schema": map[string]interface{}{"type": "integer"}}
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+// pathParams returns the {names} in an OpenAPI path template.
+func pathParams(path string) []string {
+	var out []string
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			out = append(out, seg[1:len(seg)-1])
+		}
+	}
+	return out
+}
@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var update = flag.Bool("update", false, "rewrite api/openapi.json")
+
+// fakeShipments implements the RPCs the REST API calls.
+type fakeShipments struct {
+	proto.ShipmentServiceClient
+	creates int
+	byID    map[string]*proto.Shipment
+}
+
+func (f *fakeShipments) CreateShipment(ctx context.Context, in *proto.CreateShipmentRequest, _ ...grpc.CallOption) (*proto.CreateShipmentResponse, error) {
+	if in.Origin == "" {
+		st, _ := status.New(codes.InvalidArgument, "missing required fields").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "origin", Description: "origin is required"}},
+		})
+		return nil, st.Err()
+	}
+	f.creates++
+	return &proto.CreateShipmentResponse{Shipment: &proto.Shipment{Id: "s-1", Origin: in.Origin, Destination: in.Destination, Status: proto.ShipmentStatus_PRE_TRANSIT}}, nil
+}
+
+func (f *fakeShipments) GetShipmentsByIDs(ctx context.Context, in *proto.GetShipmentsByIDsRequest, _ ...grpc.CallOption) (*proto.GetShipmentsResponse, error) {
+	resp := &proto.GetShipmentsResponse{}
+	for _, id := range in.Ids {
+		if s, ok := f.byID[id]; ok {
+			resp.Shipments = append(resp.Shipments, s)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeShipments) CancelShipment(ctx context.Context, in *proto.CancelShipmentRequest, _ ...grpc.CallOption) (*proto.CancelShipmentResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "can only cancel PRE_TRANSIT shipments")
+}
+
+func (f *fakeShipments) GetShipments(ctx context.Context, in *proto.GetShipmentsRequest, _ ...grpc.CallOption) (*proto.GetShipmentsResponse, error) {
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+	r = r.WithContext(identity.NewContext(r.Context(), identity.Identity{UserID: "u1", TenantID: "t1", Role: "member"}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decodeError(t *testing.T, w *httptest.ResponseRecorder) errorDetail {
+	t.Helper()
+	var body errorBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("error body %q: %v", w.Body.String(), err)
+	}
+	return body.Error
+}
+
+func TestCreateShipmentIsIdempotent(t *testing.T) {
+	fake := &fakeShipments{}
+	h := New(fake, NewMemoryIdempotencyStore(IdempotencyTTL))
+	key := map[string]string{IdempotencyHeader: "k1"}
+
+	first := do(t, h, "POST", "/v1/shipments", `{"origin":"NYC","destination":"DAC"}`, key)
+	if first.Code != http.StatusCreated || first.Header().Get("Location") != "/v1/shipments/s-1" {
+		t.Fatalf("first: %d %v %s", first.Code, first.Header(), first.Body)
+	}
+	again := do(t, h, "POST", "/v1/shipments", `{"origin":"NYC","destination":"DAC"}`, key)
+	if again.Code != http.StatusCreated || again.Header().Get("Idempotent-Replayed") != "true" || !bytes.Equal(again.Body.Bytes(), first.Body.Bytes()) {
+		t.Fatalf("replay: %d %v %s", again.Code, again.Header(), again.Body)
+	}
+	if fake.creates != 1 {
+		t.Fatalf("created %d shipments, want 1", fake.creates)
+	}
+
+	reused := do(t, h, "POST", "/v1/shipments", `{"origin":"LAX","destination":"DAC"}`, key)
+	if reused.Code != http.StatusBadRequest || decodeError(t, reused).Reason != "IDEMPOTENCY_KEY_REUSED" {
+		t.Fatalf("reuse: %d %s", reused.Code, reused.Body)
+	}
+}
+
+func TestErrorBodies(t *testing.T) {
+	h := New(&fakeShipments{byID: map[string]*proto.Shipment{}}, NewMemoryIdempotencyStore(IdempotencyTTL))
+
+	w := do(t, h, "POST", "/v1/shipments", `{"destination":"DAC"}`, nil)
+	e := decodeError(t, w)
+	if w.Code != http.StatusBadRequest || e.Code != "BAD_USER_INPUT" || e.Field != "origin" || e.Retryable {
+		t.Errorf("validation: %d %+v", w.Code, e)
+	}
+
+	w = do(t, h, "POST", "/v1/shipments", `{"orign":"NYC"}`, nil)
+	if e := decodeError(t, w); w.Code != http.StatusBadRequest || e.Code != "BAD_USER_INPUT" {
+		t.Errorf("unknown member: %d %+v", w.Code, e)
+	}
+
+	w = do(t, h, "GET", "/v1/shipments/nope", "", nil)
+	if e := decodeError(t, w); w.Code != http.StatusNotFound || e.Code != "NOT_FOUND" {
+		t.Errorf("not found: %d %+v", w.Code, e)
+	}
+
+	w = do(t, h, "POST", "/v1/shipments/s-1/cancel", "", nil)
+	if e := decodeError(t, w); w.Code != http.StatusConflict || e.Code != "FAILED_PRECONDITION" {
+		t.Errorf("cancel: %d %+v", w.Code, e)
+	}
+
+	w = do(t, h, "GET", "/v1/shipments?limit=500", "", nil)
+	if e := decodeError(t, w); w.Code != http.StatusBadRequest || e.Field != "limit" {
+		t.Errorf("limit: %d %+v", w.Code, e)
+	}
+
+	w = do(t, h, "GET", "/v1/shipments", "", nil)
+	if e := decodeError(t, w); w.Code != http.StatusServiceUnavailable || !e.Retryable || e.Message != "shipment service is unavailable" {
+		t.Errorf("unavailable: %d %+v", w.Code, e)
+	}
+
+	anonymous := httptest.NewRecorder()
+	h.ServeHTTP(anonymous, httptest.NewRequest("GET", "/v1/shipments/s-1", nil))
+	if e := decodeError(t, anonymous); anonymous.Code != http.StatusUnauthorized || e.Code != "UNAUTHENTICATED" {
+		t.Errorf("anonymous: %d %+v", anonymous.Code, e)
+	}
+}
+
+func TestOpenAPIUpToDate(t *testing.T) {
+	got, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+	const path = "../../api/openapi.json"
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("api/openapi.json is stale; run go test ./internal/rest -update")
+	}
+}
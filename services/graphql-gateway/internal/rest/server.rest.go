@@ -0,0 +1,246 @@
+// internal/rest/server.rest.go
+
+// Package rest is the versioned REST/JSON API for merchant platforms that
+// cannot call GraphQL. It is a thin facade over the same shipment-service RPCs
+// the resolvers use: bodies are the proto messages in JSON form (see OpenAPI),
+// errors have the same codes as GraphQL errors, and POSTs accept an
+// Idempotency-Key. Callers authenticate with the same access tokens, or with
+// a tenant API key ("Authorization: ApiKey <key>"), which is what merchant
+// backends are meant to use. The plan limits of internal/ratelimit apply as
+// they do to GraphQL, with 429 and the RateLimit headers.
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/apierror"
+	"github.com/Tanmoy095/LogiSynapse/services/graphql-gateway/internal/auth"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MaxPageSize caps the limit of GET /v1/shipments.
+const MaxPageSize = 100
+
+var (
+	marshal   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshal = protojson.UnmarshalOptions{}
+)
+
+// Server serves /v1.
+type Server struct {
+	shipments proto.ShipmentServiceClient
+	idem      IdempotencyStore
+	openapi   []byte
+}
+
+// route is one endpoint. The same table drives the mux and the OpenAPI document.
+type route struct {
+	method, path string // path is an OpenAPI template, also valid for http.ServeMux
+	operationID  string
+	summary      string
+	role         string // least tenant role required; "" lets any authenticated caller in
+	query        protoreflect.MessageDescriptor
+	body         protoreflect.MessageDescriptor
+	response     protoreflect.MessageDescriptor
+	status       int
+	idempotent   bool // honours Idempotency-Key
+	handle       func(s *Server, r *http.Request) (protobuf.Message, error)
+}
+
+func routes() []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/v1/shipments", operationID: "listShipments",
+			summary:  "List shipments",
+			query:    (&proto.GetShipmentsRequest{}).ProtoReflect().Descriptor(),
+			response: (&proto.GetShipmentsResponse{}).ProtoReflect().Descriptor(),
+			status:   http.StatusOK, handle: (*Server).listShipments,
+		},
+		{
+			method: http.MethodPost, path: "/v1/shipments", operationID: "createShipment",
+			summary: "Create a shipment", role: "MEMBER",
+			body:     (&proto.CreateShipmentRequest{}).ProtoReflect().Descriptor(),
+			response: (&proto.Shipment{}).ProtoReflect().Descriptor(),
+			status:   http.StatusCreated, idempotent: true, handle: (*Server).createShipment,
+		},
+		{
+			method: http.MethodGet, path: "/v1/shipments/{id}", operationID: "getShipment",
+			summary:  "Get one shipment",
+			response: (&proto.Shipment{}).ProtoReflect().Descriptor(),
+			status:   http.StatusOK, handle: (*Server).getShipment,
+		},
+		{
+			method: http.MethodPost, path: "/v1/shipments/{id}/cancel", operationID: "cancelShipment",
+			summary: "Cancel a PRE_TRANSIT shipment; cancelling twice is not an error", role: "MEMBER",
+			response: (&proto.Shipment{}).ProtoReflect().Descriptor(),
+			status:   http.StatusOK, idempotent: true, handle: (*Server).cancelShipment,
+		},
+		{
+			method: http.MethodPost, path: "/v1/rates", operationID: "getRates",
+			summary:  "Quote carrier rates for a parcel",
+			body:     (&proto.GetRatesRequest{}).ProtoReflect().Descriptor(),
+			response: (&proto.GetRatesResponse{}).ProtoReflect().Descriptor(),
+			status:   http.StatusOK, idempotent: true, handle: (*Server).getRates,
+		},
+	}
+}
+
+// New returns the /v1 handler. Mount it behind auth.MiddlewareWithReject(v, Unauthorized).
+// Analogy: A second counter for customers who order by form instead of by talking to the waiter.
+func New(shipments proto.ShipmentServiceClient, idem IdempotencyStore) http.Handler {
+	s := &Server{shipments: shipments, idem: idem}
+	s.openapi, _ = json.MarshalIndent(OpenAPI(), "", "  ")
+
+	mux := http.NewServeMux()
+	for _, rt := range routes() {
+		h := s.endpoint(rt)
+		if rt.idempotent {
+			h = idempotent(idem, h)
+		}
+		mux.Handle(rt.method+" "+rt.path, h)
+	}
+	mux.HandleFunc("GET /v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.openapi)
+	})
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, apierror.New(apierror.CodeNotFound, "no such endpoint: "+r.Method+" "+r.URL.Path))
+	})
+	return mux
+}
+
+// endpoint checks the caller, runs rt.handle and writes its result.
+func (s *Server) endpoint(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		caller, ok := identity.FromContext(r.Context())
+		if !ok {
+			writeError(w, r, apierror.New(apierror.CodeUnauthenticated, "authentication required"))
+			return
+		}
+		if rt.role != "" && !auth.HasRole(caller, rt.role) {
+			writeError(w, r, apierror.New(apierror.CodeForbidden, "requires the "+rt.role+" role"))
+			return
+		}
+		msg, err := rt.handle(s, r)
+		if err != nil {
+			writeError(w, r, apierror.FromGRPC(err, "shipment"))
+			return
+		}
+		body, err := marshal.Marshal(msg)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if rt.status == http.StatusCreated {
+			if sh, ok := msg.(*proto.Shipment); ok {
+				w.Header().Set("Location", "/v1/shipments/"+sh.Id)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(rt.status)
+		w.Write(body)
+	}
+}
+
+func (s *Server) listShipments(r *http.Request) (protobuf.Message, error) {
+	q := r.URL.Query()
+	req := &proto.GetShipmentsRequest{
+		Origin:      q.Get("origin"),
+		Destination: q.Get("destination"),
+		Limit:       10,
+	}
+	if v := q.Get("status"); v != "" {
+		status, ok := proto.ShipmentStatus_value[v]
+		if !ok {
+			return nil, badInput("status", "unknown status "+strconv.Quote(v))
+		}
+		req.Status = proto.ShipmentStatus(status)
+	}
+	var err error
+	if req.Limit, err = intParam(q.Get("limit"), "limit", req.Limit, 1, MaxPageSize); err != nil {
+		return nil, err
+	}
+	if req.Offset, err = intParam(q.Get("offset"), "offset", 0, 0, 1<<30); err != nil {
+		return nil, err
+	}
+	return s.shipments.GetShipments(r.Context(), req)
+}
+
+func (s *Server) createShipment(r *http.Request) (protobuf.Message, error) {
+	req := &proto.CreateShipmentRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	resp, err := s.shipments.CreateShipment(r.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Shipment, nil
+}
+
+func (s *Server) getShipment(r *http.Request) (protobuf.Message, error) {
+	id := r.PathValue("id")
+	resp, err := s.shipments.GetShipmentsByIDs(r.Context(), &proto.GetShipmentsByIDsRequest{Ids: []string{id}})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Shipments) == 0 {
+		return nil, apierror.New(apierror.CodeNotFound, "shipment not found")
+	}
+	return resp.Shipments[0], nil
+}
+
+func (s *Server) cancelShipment(r *http.Request) (protobuf.Message, error) {
+	resp, err := s.shipments.CancelShipment(r.Context(), &proto.CancelShipmentRequest{Id: r.PathValue("id")})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Shipment, nil
+}
+
+func (s *Server) getRates(r *http.Request) (protobuf.Message, error) {
+	req := &proto.GetRatesRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return nil, err
+	}
+	return s.shipments.GetRates(r.Context(), req)
+}
+
+// decodeBody reads a JSON body into msg; unknown members are an error, so typos do not go unnoticed.
+func decodeBody(r *http.Request, msg protobuf.Message) error {
+	raw, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBody))
+	if err != nil {
+		return badInput("", "request body too large or unreadable")
+	}
+	if err := unmarshal.Unmarshal(raw, msg); err != nil {
+		return badInput("", "invalid JSON body: "+err.Error())
+	}
+	return nil
+}
+
+func intParam(v, name string, def, min, max int32) (int32, error) {
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || int32(n) < min || int32(n) > max {
+		return 0, badInput(name, name+" must be an integer from "+strconv.Itoa(int(min))+" to "+strconv.Itoa(int(max)))
+	}
+	return int32(n), nil
+}
+
+// badInput is a BAD_USER_INPUT error, about field when it is not empty.
+func badInput(field, message string) *apierror.Error {
+	e := apierror.New(apierror.CodeBadUserInput, message)
+	if field != "" {
+		e.Fields = []apierror.FieldError{{Field: field, Message: message}}
+	}
+	return e
+}
@@ -0,0 +1,68 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+type fakePublisher struct {
+	events []contracts.UsageEvent
+	err    error
+}
+
+func (p *fakePublisher) Publish(_ context.Context, key string, value interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	event := value.(contracts.UsageEvent)
+	if key != event.TenantID {
+		return errors.New("events must be keyed by tenant")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *fakePublisher) Close() error { return nil }
+
+func TestMeterAggregatesPerTenantAndType(t *testing.T) {
+	pub := &fakePublisher{err: errors.New("kafka down")}
+	m := NewMeter(pub, time.Second)
+	for i := 0; i < 5; i++ {
+		m.Record("acme", contracts.UsageAPIRequest, 1)
+	}
+	m.Record("acme", contracts.UsageShipmentCreated, 1)
+	m.Record("globex", contracts.UsageAPIRequest, 2)
+	m.Record("", contracts.UsageAPIRequest, 1)
+	m.Record("globex", contracts.UsageAPIRequest, 0)
+
+	// Nothing is lost while Kafka is down
+	m.Flush(context.Background())
+	pub.err = nil
+	m.Record("acme", contracts.UsageAPIRequest, 1)
+	m.Flush(context.Background())
+
+	got := map[string]int64{}
+	ids := map[string]bool{}
+	for _, e := range pub.events {
+		got[e.TenantID+":"+e.UsageType] += e.Quantity
+		ids[e.EventID] = true
+	}
+	want := map[string]int64{"acme:API_REQUEST": 6, "acme:SHIPMENT_CREATED": 1, "globex:API_REQUEST": 2}
+	if len(pub.events) != len(want) || len(ids) != len(want) {
+		t.Errorf("events = %+v; want one per tenant and type, each with its own ID", pub.events)
+	}
+	for k, n := range want {
+		if got[k] != n {
+			t.Errorf("%s = %d; want %d", k, got[k], n)
+		}
+	}
+
+	m.Flush(context.Background())
+	if len(pub.events) != len(want) {
+		t.Errorf("a flush with nothing counted published %d events", len(pub.events)-len(want))
+	}
+}
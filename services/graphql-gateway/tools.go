@@ -0,0 +1,10 @@
+//go:build tools
+
+// services/graphql-gateway/tools.go
+// Pins the code generators this service uses, so `go generate` runs the
+// version in go.mod instead of whatever is installed.
+package tools
+
+import (
+	_ "github.com/99designs/gqlgen"
+)
@@ -5,12 +5,16 @@ import (
 	"log/slog"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/config"
 	grpcServer "github.com/Tanmoy095/LogiSynapse/services/shipment-service/handler/grpc"
 	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/service"
 	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/address"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
 	"go.temporal.io/sdk/client"
 	"google.golang.org/grpc"
@@ -52,6 +56,14 @@ func main() {
 	// Initialize the ShipmentService with Temporal client
 	// It uses the PostgresStore and Temporal for workflow orchestration
 	svc := service.NewShipmentService(store, temporalClient)
+	// Pickups share the same Postgres store and Temporal client
+	pickupSvc := service.NewPickupService(store, store, temporalClient)
+	// Offline rules always run; Shippo verification is added when a key is configured
+	validator := address.Chain{address.NewRulesValidator()}
+	if key := os.Getenv("SHIPPO_API_KEY"); key != "" {
+		validator = append(validator, address.NewShippoValidator(key, &http.Client{Timeout: 10 * time.Second}))
+	}
+	addressSvc := service.NewAddressService(validator)
 
 	// Create a TCP listener on port 50051 for the gRPC server
 	// This is where the service will listen for incoming gRPC requests
@@ -62,12 +74,13 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// Create a new gRPC server instance
-	s := grpc.NewServer()
+	// Create a new gRPC server instance. The interceptor puts the caller the
+	// gateway authenticated (user, tenant, role metadata) into each request's context.
+	s := grpc.NewServer(grpc.UnaryInterceptor(identity.UnaryServerInterceptor()))
 
 	// Register the ShipmentService with the gRPC server
 	// The grpcServer.NewShipmentServer wraps the ShipmentService to handle gRPC requests
-	proto.RegisterShipmentServiceServer(s, grpcServer.NewShipmentServer(svc))
+	proto.RegisterShipmentServiceServer(s, grpcServer.NewShipmentServer(svc, pickupSvc, addressSvc))
 
 	// Log that the gRPC server is starting
 	logger.Info("gRPC server running", "port", 50051)
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Carrier pickups: a warehouse asks a carrier to collect one or more shipments
+-- inside a time window. The SchedulePickupWorkflow fills in the confirmation number.
+CREATE TABLE IF NOT EXISTS pickups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    carrier TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('REQUESTED', 'SCHEDULED', 'CANCELLED', 'COMPLETED')),
+
+    -- Warehouse address and contact (used for reminders)
+    address_name TEXT NOT NULL,
+    address_company TEXT,
+    address_street1 TEXT NOT NULL,
+    address_street2 TEXT,
+    address_city TEXT NOT NULL,
+    address_state TEXT,
+    address_zip TEXT NOT NULL,
+    address_country TEXT NOT NULL,
+    contact_phone TEXT,
+    contact_email TEXT,
+
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL CHECK (window_end > window_start),
+
+    shipment_ids TEXT[] NOT NULL,                 -- Shipments the carrier collects
+    confirmation_number TEXT,                     -- Carrier confirmation (nullable until booked)
+    instructions TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pickups_window_start ON pickups (window_start);
+
+-- +goose Down
+DROP TABLE IF EXISTS pickups;
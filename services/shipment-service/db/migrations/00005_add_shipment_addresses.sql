@@ -0,0 +1,11 @@
+-- +goose Up
+-- Full from/to addresses, validated by CreateShipmentWorkflow before booking.
+-- Nullable: shipments created with only origin/destination city names have none.
+ALTER TABLE shipments
+    ADD COLUMN IF NOT EXISTS from_address JSONB,
+    ADD COLUMN IF NOT EXISTS to_address JSONB;
+
+-- +goose Down
+ALTER TABLE shipments
+    DROP COLUMN IF EXISTS from_address,
+    DROP COLUMN IF EXISTS to_address;
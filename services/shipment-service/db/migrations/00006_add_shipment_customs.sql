@@ -0,0 +1,9 @@
+-- +goose Up
+-- Customs declaration data for shipments whose from/to countries differ
+-- (contents type, incoterm, EEL/PFC, line items and the carrier declaration ID).
+ALTER TABLE shipments
+    ADD COLUMN IF NOT EXISTS customs JSONB;
+
+-- +goose Down
+ALTER TABLE shipments
+    DROP COLUMN IF EXISTS customs;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- The carrier's own ID of a booking, needed to cancel it, and a FAILED state for
+-- pickups the carrier could not book (or re-book after a reschedule).
+ALTER TABLE pickups ADD COLUMN IF NOT EXISTS carrier_pickup_id TEXT;
+
+ALTER TABLE pickups DROP CONSTRAINT IF EXISTS pickups_status_check;
+ALTER TABLE pickups ADD CONSTRAINT pickups_status_check
+    CHECK (status IN ('REQUESTED', 'SCHEDULED', 'CANCELLED', 'COMPLETED', 'FAILED'));
+
+-- +goose Down
+ALTER TABLE pickups DROP CONSTRAINT IF EXISTS pickups_status_check;
+ALTER TABLE pickups ADD CONSTRAINT pickups_status_check
+    CHECK (status IN ('REQUESTED', 'SCHEDULED', 'CANCELLED', 'COMPLETED'));
+ALTER TABLE pickups DROP COLUMN IF EXISTS carrier_pickup_id;
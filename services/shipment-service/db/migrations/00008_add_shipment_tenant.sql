@@ -0,0 +1,10 @@
+-- +goose Up
+-- The tenant that created the shipment; every read by a tenant is filtered on it.
+-- Nullable: shipments created before tenants existed belong to nobody and are
+-- only visible to platform operators.
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS tenant_id UUID;
+CREATE INDEX IF NOT EXISTS shipments_tenant_id_idx ON shipments (tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS shipments_tenant_id_idx;
+ALTER TABLE shipments DROP COLUMN IF EXISTS tenant_id;
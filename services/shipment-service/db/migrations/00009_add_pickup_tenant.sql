@@ -0,0 +1,10 @@
+-- +goose Up
+-- The tenant that scheduled the pickup; tenants only see and change their own.
+-- Nullable like shipments.tenant_id: older pickups belong to nobody and are
+-- only visible to platform operators.
+ALTER TABLE pickups ADD COLUMN IF NOT EXISTS tenant_id UUID;
+CREATE INDEX IF NOT EXISTS pickups_tenant_id_idx ON pickups (tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS pickups_tenant_id_idx;
+ALTER TABLE pickups DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,64 @@
+// shipment-service/handler/grpc/address.handler.grpc.go
+package grpcServer
+
+import (
+	"context"
+
+	models "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+// ValidateAddress handles the gRPC ValidateAddress request.
+// An invalid address is a normal response (valid=false with issues), not a gRPC error.
+func (s *ShipmentServer) ValidateAddress(ctx context.Context, req *proto.ValidateAddressRequest) (*proto.ValidateAddressResponse, error) {
+	result, err := s.addressService.ValidateAddress(ctx, toModelAddress(req.Address))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &proto.ValidateAddressResponse{Valid: result.Valid}
+	for _, issue := range result.Issues {
+		resp.Issues = append(resp.Issues, &proto.AddressIssue{Field: issue.Field, Code: issue.Code, Message: issue.Message})
+	}
+	if result.Suggested != nil {
+		resp.Suggested = toProtoAddress(*result.Suggested)
+	}
+	return resp, nil
+}
+
+func toModelAddress(a *proto.Address) models.Address {
+	if a == nil {
+		return models.Address{}
+	}
+	return models.Address{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
+
+// toProtoAddress returns nil for a zero address so city-only shipments don't
+// come back with an empty address object.
+func toProtoAddress(a models.Address) *proto.Address {
+	if a.IsZero() {
+		return nil
+	}
+	return &proto.Address{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
@@ -0,0 +1,65 @@
+// shipment-service/handler/grpc/customs.handler.grpc.go
+package grpcServer
+
+import (
+	models "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+// toModelCustoms converts the optional customs block of a request (nil stays nil).
+func toModelCustoms(c *proto.Customs) *models.Customs {
+	if c == nil {
+		return nil
+	}
+	items := make([]models.CustomsItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = models.CustomsItem{
+			Description:   item.Description,
+			HSCode:        item.HsCode,
+			Quantity:      int(item.Quantity),
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &models.Customs{
+		ContentsType:        models.ContentsType(c.ContentsType),
+		ContentsExplanation: c.ContentsExplanation,
+		Incoterm:            models.Incoterm(c.Incoterm),
+		EELPFC:              models.EELPFC(c.EelPfc),
+		AESITN:              c.AesItn,
+		Items:               items,
+		// DeclarationID is output only; the workflow sets it
+	}
+}
+
+// toProtoCustoms converts stored customs data for a response (nil stays nil).
+func toProtoCustoms(c *models.Customs) *proto.Customs {
+	if c == nil {
+		return nil
+	}
+	items := make([]*proto.CustomsItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = &proto.CustomsItem{
+			Description:   item.Description,
+			HsCode:        item.HSCode,
+			Quantity:      int32(item.Quantity),
+			ValueAmount:   item.ValueAmount,
+			ValueCurrency: item.ValueCurrency,
+			OriginCountry: item.OriginCountry,
+			NetWeight:     item.NetWeight,
+			MassUnit:      item.MassUnit,
+		}
+	}
+	return &proto.Customs{
+		ContentsType:        string(c.ContentsType),
+		ContentsExplanation: c.ContentsExplanation,
+		Incoterm:            string(c.Incoterm),
+		EelPfc:              string(c.EELPFC),
+		AesItn:              c.AESITN,
+		Items:               items,
+		DeclarationId:       c.DeclarationID,
+	}
+}
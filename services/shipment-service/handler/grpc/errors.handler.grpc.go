@@ -0,0 +1,73 @@
+// shipment-service/handler/grpc/errors.handler.grpc.go
+package grpcServer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/service"
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	models "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps a service error onto the gRPC status the gateway sees.
+// Validation failures become InvalidArgument with an errdetails.BadRequest listing
+// every offending field, so clients can highlight them instead of parsing messages.
+// Anything we do not recognise is logged and returned as a bare Internal error.
+// Analogy: The chef translates "we're out of basil" into a note the waiter can read to the table.
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err // already a status (e.g. from a downstream gRPC call)
+	}
+
+	var invalid *models.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		return badRequest(err.Error(), invalid.Violations)
+	case errors.Is(err, service.ErrInvalidAddress),
+		errors.Is(err, service.ErrEmptyAddress),
+		errors.Is(err, models.ErrMissingCustomsData):
+		// Validation errors the workflow reported without field details.
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrNoTenant):
+		return status.Error(codes.PermissionDenied, "select a tenant first")
+	case errors.Is(err, store.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPickupNotActive), errors.Is(err, service.ErrNotCancellable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrRateProvider):
+		slog.WarnContext(ctx, "rate provider failed", "error", err)
+		return status.Error(codes.Unavailable, "carrier rates are unavailable")
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	slog.ErrorContext(ctx, "unhandled shipment-service error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// badRequest builds an InvalidArgument status carrying one BadRequest field violation per problem.
+func badRequest(msg string, violations []models.FieldViolation) error {
+	st := status.New(codes.InvalidArgument, msg)
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if len(br.FieldViolations) == 0 {
+		return st.Err()
+	}
+	if detailed, err := st.WithDetails(br); err == nil {
+		return detailed.Err()
+	}
+	return st.Err()
+}
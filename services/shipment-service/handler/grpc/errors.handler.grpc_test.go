@@ -0,0 +1,25 @@
+package grpcServer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/service"
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatusHidesOtherTenants(t *testing.T) {
+	cases := map[error]codes.Code{
+		// Another tenant's shipment is reported exactly like a missing one
+		fmt.Errorf("failed to get shipment: %w", fmt.Errorf("shipment %w", store.ErrNotFound)): codes.NotFound,
+		service.ErrNoTenant: codes.PermissionDenied,
+	}
+	for err, want := range cases {
+		if got := status.Code(toStatus(context.Background(), err)); got != want {
+			t.Errorf("toStatus(%v) = %v; want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// shipment-service/handler/grpc/pickup.handler.grpc.go
+package grpcServer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/service"
+	models "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+// SchedulePickup handles the gRPC SchedulePickup request.
+// The returned pickup is REQUESTED; the workflow books it with the carrier in the background.
+func (s *ShipmentServer) SchedulePickup(ctx context.Context, req *proto.SchedulePickupRequest) (*proto.PickupResponse, error) {
+	window, err := parsePickupWindow(req.WindowStart, req.WindowEnd)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	pickup := models.Pickup{
+		Carrier:      req.Carrier,
+		Address:      toModelPickupAddress(req.Address),
+		Window:       window,
+		ShipmentIDs:  req.ShipmentIds,
+		Instructions: req.Instructions,
+	}
+	created, err := s.pickupService.SchedulePickup(ctx, pickup)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.PickupResponse{Pickup: toProtoPickup(created)}, nil
+}
+
+// CancelPickup handles the gRPC CancelPickup request.
+func (s *ShipmentServer) CancelPickup(ctx context.Context, req *proto.CancelPickupRequest) (*proto.PickupResponse, error) {
+	if err := s.pickupService.CancelPickup(ctx, req.Id); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	// The workflow applies the cancellation asynchronously; return the current stored state.
+	current, err := s.pickupService.GetPickup(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.PickupResponse{Pickup: toProtoPickup(current)}, nil
+}
+
+// ReschedulePickup handles the gRPC ReschedulePickup request.
+func (s *ShipmentServer) ReschedulePickup(ctx context.Context, req *proto.ReschedulePickupRequest) (*proto.PickupResponse, error) {
+	window, err := parsePickupWindow(req.WindowStart, req.WindowEnd)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := s.pickupService.ReschedulePickup(ctx, req.Id, window); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	current, err := s.pickupService.GetPickup(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.PickupResponse{Pickup: toProtoPickup(current)}, nil
+}
+
+// parsePickupWindow converts the RFC3339 strings from the request into a PickupWindow.
+// Both bounds are checked so the client hears about every malformed field at once.
+func parsePickupWindow(start, end string) (models.PickupWindow, error) {
+	var violations []models.FieldViolation
+	s, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		violations = append(violations, models.FieldViolation{Field: "window_start", Description: fmt.Sprintf("invalid window_start: %v", err)})
+	}
+	e, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		violations = append(violations, models.FieldViolation{Field: "window_end", Description: fmt.Sprintf("invalid window_end: %v", err)})
+	}
+	if len(violations) > 0 {
+		return models.PickupWindow{}, models.Invalid(service.ErrInvalidPickupWindow, violations...)
+	}
+	return models.PickupWindow{Start: s, End: e}, nil
+}
+
+func toModelPickupAddress(a *proto.PickupAddress) models.PickupAddress {
+	if a == nil {
+		return models.PickupAddress{}
+	}
+	return models.PickupAddress{
+		Name:    a.Name,
+		Company: a.Company,
+		Street1: a.Street1,
+		Street2: a.Street2,
+		City:    a.City,
+		State:   a.State,
+		Zip:     a.Zip,
+		Country: a.Country,
+		Phone:   a.Phone,
+		Email:   a.Email,
+	}
+}
+
+// toProtoPickup converts an internal models.Pickup to a gRPC proto.Pickup.
+func toProtoPickup(p models.Pickup) *proto.Pickup {
+	return &proto.Pickup{
+		Id:      p.ID,
+		Carrier: p.Carrier,
+		Address: &proto.PickupAddress{
+			Name:    p.Address.Name,
+			Company: p.Address.Company,
+			Street1: p.Address.Street1,
+			Street2: p.Address.Street2,
+			City:    p.Address.City,
+			State:   p.Address.State,
+			Zip:     p.Address.Zip,
+			Country: p.Address.Country,
+			Phone:   p.Address.Phone,
+			Email:   p.Address.Email,
+		},
+		WindowStart:        p.Window.Start.Format(time.RFC3339),
+		WindowEnd:          p.Window.End.Format(time.RFC3339),
+		ShipmentIds:        p.ShipmentIDs,
+		ConfirmationNumber: p.ConfirmationNumber,
+		Instructions:       p.Instructions,
+		Status:             string(p.Status),
+	}
+}
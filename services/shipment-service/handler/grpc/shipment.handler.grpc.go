@@ -21,12 +21,14 @@ type ShipmentServiceClient interface {
 type ShipmentServer struct {
 	proto.UnimplementedShipmentServiceServer                          // Embeds the default implementation to satisfy the interface
 	service                                  *service.ShipmentService // Reference to the business logic layer
+	pickupService                            *service.PickupService   // Carrier pickup scheduling
+	addressService                           *service.AddressService  // Standalone address validation
 }
 
 // NewShipmentServer creates a new ShipmentServer instance, injecting the business logic service.
 // Analogy: Sets up a chef (handler) in the kitchen, giving them access to the recipe book (service).
-func NewShipmentServer(svc *service.ShipmentService) *ShipmentServer {
-	return &ShipmentServer{service: svc}
+func NewShipmentServer(svc *service.ShipmentService, pickupSvc *service.PickupService, addressSvc *service.AddressService) *ShipmentServer {
+	return &ShipmentServer{service: svc, pickupService: pickupSvc, addressService: addressSvc}
 }
 
 // GetShipments handles the gRPC GetShipments request.
@@ -38,7 +40,7 @@ func (s *ShipmentServer) GetShipments(ctx context.Context, req *proto.GetShipmen
 	// and pagination (limit, offset). The service returns internal models.Shipment structs.
 	shipments, err := s.service.GetShipments(ctx, req.Origin, req.Status, req.Destination, req.Limit, req.Offset)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(ctx, err)
 
 	}
 	//Convert internal models.Shipment to proto.Shipment for gRPC response
@@ -53,6 +55,19 @@ func (s *ShipmentServer) GetShipments(ctx context.Context, req *proto.GetShipmen
 	return &proto.GetShipmentsResponse{Shipments: proitoShipments}, nil
 }
 
+// GetShipmentsByIDs handles the gateway's batched shipment lookups.
+func (s *ShipmentServer) GetShipmentsByIDs(ctx context.Context, req *proto.GetShipmentsByIDsRequest) (*proto.GetShipmentsResponse, error) {
+	shipments, err := s.service.GetShipmentsByIDs(ctx, req.Ids)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	protoShipments := make([]*proto.Shipment, len(shipments))
+	for i, shipment := range shipments {
+		protoShipments[i] = toProtoShipment(shipment)
+	}
+	return &proto.GetShipmentsResponse{Shipments: protoShipments}, nil
+}
+
 // CreateShipment handles the gRPC CreateShipment request.
 // It receives a new shipment request, converts it to the internal model,
 // calls the business logic to create it, and returns the created shipment in gRPC format
@@ -63,7 +78,7 @@ func (s *ShipmentServer) CreateShipment(ctx context.Context, req *proto.CreateSh
 	// Call the business logic to create the shipment (includes validation and storage)
 	created, err := s.service.CreateShipment(ctx, shipment)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(ctx, err)
 
 	}
 	// Convert the created shipment back to proto.Shipment for the gRPC response
@@ -71,6 +86,28 @@ func (s *ShipmentServer) CreateShipment(ctx context.Context, req *proto.CreateSh
 
 }
 
+// CancelShipment handles the gRPC CancelShipment request.
+func (s *ShipmentServer) CancelShipment(ctx context.Context, req *proto.CancelShipmentRequest) (*proto.CancelShipmentResponse, error) {
+	cancelled, err := s.service.CancelShipment(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.CancelShipmentResponse{Shipment: toProtoShipment(cancelled)}, nil
+}
+
+// GetRates handles the gRPC GetRates request.
+func (s *ShipmentServer) GetRates(ctx context.Context, req *proto.GetRatesRequest) (*proto.GetRatesResponse, error) {
+	rates, err := s.service.GetRates(ctx, req.Origin, req.Destination, req.Length, req.Width, req.Height, req.Weight, req.Unit)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	resp := &proto.GetRatesResponse{Rates: make([]*proto.Rate, len(rates))}
+	for i, r := range rates {
+		resp.Rates[i] = &proto.Rate{Carrier: r.Carrier, Service: r.Service, Amount: r.Amount, EstimatedDays: int32(r.EstimatedDays)}
+	}
+	return resp, nil
+}
+
 // toProtoShipment converts an internal models.Shipment to a gRPC proto.Shipment.
 // This ensures the response uses the gRPC contract defined in shipment.proto.
 func toProtoShipment(s models.Shipment) *proto.Shipment {
@@ -84,6 +121,9 @@ func toProtoShipment(s models.Shipment) *proto.Shipment {
 			Name:        s.Carrier.Name,
 			TrackingUrl: s.Carrier.TrackingURL,
 		},
+		FromAddress: toProtoAddress(s.FromAddress),
+		ToAddress:   toProtoAddress(s.ToAddress),
+		Customs:     toProtoCustoms(s.Customs),
 	}
 }
 
@@ -105,6 +145,9 @@ func toModelShipment(req *proto.CreateShipmentRequest) models.Shipment {
 		Eta:         req.Eta,
 		Status:      req.Status,
 		Carrier:     carrier,
+		FromAddress: toModelAddress(req.FromAddress),
+		ToAddress:   toModelAddress(req.ToAddress),
+		Customs:     toModelCustoms(req.Customs),
 	}
 }
 
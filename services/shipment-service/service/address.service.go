@@ -0,0 +1,38 @@
+//shipment-service/service/address.service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/address"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.opentelemetry.io/otel"
+)
+
+var ErrEmptyAddress = errors.New("address is required")
+
+// AddressService answers standalone ValidateAddress requests, e.g. a checkout
+// form checking the address before the shipment is created.
+// CreateShipmentWorkflow runs the same validator as its first step, so an address
+// that passes here will not be rejected there for format reasons.
+type AddressService struct {
+	validator address.Validator
+}
+
+// NewAddressService creates a new address service.
+func NewAddressService(validator address.Validator) *AddressService {
+	return &AddressService{validator: validator}
+}
+
+// ValidateAddress validates a single address and returns any suggested correction.
+func (s *AddressService) ValidateAddress(ctx context.Context, addr contracts.Address) (contracts.AddressValidation, error) {
+	ctx, span := otel.Tracer("shipment-service").Start(ctx, "AddressService.ValidateAddress")
+	defer span.End()
+
+	if addr.IsZero() {
+		return contracts.AddressValidation{}, ErrEmptyAddress
+	}
+	return s.validator.Validate(ctx, addr)
+}
@@ -0,0 +1,203 @@
+//shipment-service/service/pickup.service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.temporal.io/sdk/client"
+)
+
+var (
+	ErrInvalidPickupWindow = errors.New("pickup window must end after it starts and lie in the future")
+	ErrPickupNotActive     = errors.New("pickup is already cancelled, completed or failed")
+	ErrUnknownShipment     = errors.New("shipment not found")
+
+	errMissingPickupID = contracts.Invalid(ErrMissingFields, contracts.FieldViolation{Field: "id", Description: "id is required"})
+)
+
+// PickupService accepts pickup requests and hands the carrier booking to Temporal.
+// Like CreateShipment, the heavy lifting (carrier API, reminders) happens in the worker.
+type PickupService struct {
+	store          store.PickupStore
+	shipments      store.ShipmentStore
+	temporalClient client.Client
+	logger         *slog.Logger
+	clock          func() time.Time
+}
+
+// NewPickupService creates a new pickup service. shipments is where it checks
+// that a pickup only collects the caller's own shipments.
+func NewPickupService(store store.PickupStore, shipments store.ShipmentStore, temporalClient client.Client) *PickupService {
+	return &PickupService{
+		store:          store,
+		shipments:      shipments,
+		temporalClient: temporalClient,
+		logger:         slog.Default(),
+		clock:          time.Now,
+	}
+}
+
+// SchedulePickup stores the request and starts the booking workflow.
+// It does not wait for the carrier: the pickup is returned in REQUESTED state and
+// moves to SCHEDULED once the workflow has a confirmation number.
+func (s *PickupService) SchedulePickup(ctx context.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+	ctx, span := otel.Tracer("shipment-service").Start(ctx, "PickupService.SchedulePickup")
+	defer span.End()
+
+	var missing []contracts.FieldViolation
+	required := func(field, value string) {
+		if value == "" {
+			missing = append(missing, contracts.FieldViolation{Field: field, Description: field + " is required"})
+		}
+	}
+	required("carrier", pickup.Carrier)
+	if len(pickup.ShipmentIDs) == 0 {
+		missing = append(missing, contracts.FieldViolation{Field: "shipment_ids", Description: "at least one shipment is required"})
+	}
+	a := pickup.Address
+	required("address.name", a.Name)
+	required("address.street1", a.Street1)
+	required("address.city", a.City)
+	required("address.zip", a.Zip)
+	required("address.country", a.Country)
+	if len(missing) > 0 {
+		return contracts.Pickup{}, contracts.Invalid(ErrMissingFields, missing...)
+	}
+	if err := s.validateWindow(pickup.Window); err != nil {
+		return contracts.Pickup{}, err
+	}
+	// The pickup belongs to the caller's tenant, and can only collect its shipments
+	tenantID, err := ownerTenant(ctx)
+	if err != nil {
+		return contracts.Pickup{}, err
+	}
+	if err := s.checkShipmentsOwned(ctx, tenantID, pickup.ShipmentIDs); err != nil {
+		return contracts.Pickup{}, err
+	}
+	pickup.TenantID = tenantID
+
+	pickup.Status = contracts.PickupRequested
+	pickup.ConfirmationNumber = ""
+	created, err := s.store.CreatePickup(ctx, pickup)
+	if err != nil {
+		return contracts.Pickup{}, err
+	}
+
+	// One workflow per pickup, so cancel/reschedule can find it by ID.
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        pickupWorkflowID(created.ID),
+		TaskQueue: contracts.ShipmentTaskQueue,
+	}
+	s.logger.InfoContext(ctx, "starting pickup workflow", "workflow_id", workflowOptions.ID, "carrier", created.Carrier)
+	if _, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, contracts.SchedulePickupWorkflowName, created); err != nil {
+		// No workflow will ever book it; don't leave it REQUESTED forever
+		created.Status = contracts.PickupFailed
+		if updErr := s.store.UpdatePickup(context.WithoutCancel(ctx), created); updErr != nil {
+			s.logger.ErrorContext(ctx, "failed to mark pickup failed", "pickup_id", created.ID, "error", updErr)
+		}
+		return contracts.Pickup{}, err
+	}
+	return created, nil
+}
+
+// checkShipmentsOwned rejects shipment IDs that are not tenantID's shipments,
+// the same way whether they belong to another tenant or do not exist.
+func (s *PickupService) checkShipmentsOwned(ctx context.Context, tenantID string, ids []string) error {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	owned := make(map[string]bool, len(valid))
+	if len(valid) > 0 {
+		shipments, err := s.shipments.GetShipmentsByIDs(ctx, tenantID, valid)
+		if err != nil {
+			return fmt.Errorf("failed to get pickup shipments: %w", err)
+		}
+		for _, sh := range shipments {
+			owned[sh.ID] = true
+		}
+	}
+	var unknown []contracts.FieldViolation
+	for _, id := range ids {
+		if !owned[id] {
+			unknown = append(unknown, contracts.FieldViolation{Field: "shipment_ids", Description: fmt.Sprintf("shipment %q not found", id)})
+		}
+	}
+	if len(unknown) > 0 {
+		return contracts.Invalid(ErrUnknownShipment, unknown...)
+	}
+	return nil
+}
+
+// CancelPickup signals the running workflow to cancel the carrier booking.
+func (s *PickupService) CancelPickup(ctx context.Context, id string) error {
+	if id == "" {
+		return errMissingPickupID
+	}
+	if _, err := s.activePickup(ctx, id); err != nil {
+		return err
+	}
+	return s.temporalClient.SignalWorkflow(ctx, pickupWorkflowID(id), "", contracts.CancelPickupSignal, nil)
+}
+
+// ReschedulePickup signals the running workflow to move the pickup to a new window.
+// The workflow cancels the old booking and books again with the carrier.
+func (s *PickupService) ReschedulePickup(ctx context.Context, id string, window contracts.PickupWindow) error {
+	if id == "" {
+		return errMissingPickupID
+	}
+	if err := s.validateWindow(window); err != nil {
+		return err
+	}
+	if _, err := s.activePickup(ctx, id); err != nil {
+		return err
+	}
+	return s.temporalClient.SignalWorkflow(ctx, pickupWorkflowID(id), "", contracts.ReschedulePickupSignal, window)
+}
+
+// GetPickup returns the stored pickup.
+func (s *PickupService) GetPickup(ctx context.Context, id string) (contracts.Pickup, error) {
+	if id == "" {
+		return contracts.Pickup{}, errMissingPickupID
+	}
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return contracts.Pickup{}, err
+	}
+	return s.store.GetPickup(ctx, tenantID, id)
+}
+
+func (s *PickupService) activePickup(ctx context.Context, id string) (contracts.Pickup, error) {
+	pickup, err := s.GetPickup(ctx, id)
+	if err != nil {
+		return contracts.Pickup{}, fmt.Errorf("failed to get pickup: %w", err)
+	}
+	switch pickup.Status {
+	case contracts.PickupCancelled, contracts.PickupCompleted, contracts.PickupFailed:
+		// The workflow has ended; there is nothing left to signal
+		return contracts.Pickup{}, ErrPickupNotActive
+	}
+	return pickup, nil
+}
+
+func (s *PickupService) validateWindow(w contracts.PickupWindow) error {
+	if w.Start.IsZero() || w.End.IsZero() || !w.End.After(w.Start) || !w.Start.After(s.clock()) {
+		return contracts.Invalid(ErrInvalidPickupWindow, contracts.FieldViolation{Field: "window_start", Description: ErrInvalidPickupWindow.Error()})
+	}
+	return nil
+}
+
+func pickupWorkflowID(pickupID string) string {
+	return "pickup-" + pickupID
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.temporal.io/sdk/client"
+)
+
+// memPickups keeps pickups in memory and scopes reads the way PostgresStore does.
+type memPickups struct {
+	byID map[string]contracts.Pickup
+}
+
+func (s *memPickups) CreatePickup(_ context.Context, p contracts.Pickup) (contracts.Pickup, error) {
+	p.ID = "pickup-1"
+	s.byID[p.ID] = p
+	return p, nil
+}
+
+func (s *memPickups) GetPickup(_ context.Context, tenantID, id string) (contracts.Pickup, error) {
+	p, ok := s.byID[id]
+	if !ok || (tenantID != "" && p.TenantID != tenantID) {
+		return contracts.Pickup{}, store.ErrNotFound
+	}
+	return p, nil
+}
+
+func (s *memPickups) UpdatePickup(_ context.Context, p contracts.Pickup) error {
+	s.byID[p.ID] = p
+	return nil
+}
+
+// fakeTemporal records signals and fails workflow starts when startErr is set.
+type fakeTemporal struct {
+	client.Client
+	startErr error
+	signals  []string
+}
+
+func (f *fakeTemporal) ExecuteWorkflow(context.Context, client.StartWorkflowOptions, interface{}, ...interface{}) (client.WorkflowRun, error) {
+	return nil, f.startErr
+}
+
+func (f *fakeTemporal) SignalWorkflow(_ context.Context, workflowID, _, signal string, _ interface{}) error {
+	f.signals = append(f.signals, workflowID+" "+signal)
+	return nil
+}
+
+func newTestPickupService(tc *fakeTemporal) (*PickupService, *memPickups) {
+	pickups := &memPickups{byID: map[string]contracts.Pickup{}}
+	shipments := newMemStore(
+		contracts.Shipment{ID: shipmentA, TenantID: tenantA},
+		contracts.Shipment{ID: shipmentB, TenantID: tenantB},
+	)
+	svc := NewPickupService(pickups, shipments, tc)
+	svc.clock = func() time.Time { return time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC) }
+	return svc, pickups
+}
+
+func pickupRequest(shipmentIDs ...string) contracts.Pickup {
+	start := time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)
+	return contracts.Pickup{
+		Carrier:     "ups",
+		Address:     contracts.PickupAddress{Name: "Dock 3", Street1: "Main St 1", City: "Berlin", Zip: "10115", Country: "DE"},
+		Window:      contracts.PickupWindow{Start: start, End: start.Add(4 * time.Hour)},
+		ShipmentIDs: shipmentIDs,
+		TenantID:    tenantB, // ignored: the caller's tenant wins
+	}
+}
+
+func TestPickupsAreTenantScoped(t *testing.T) {
+	tc := &fakeTemporal{}
+	svc, pickups := newTestPickupService(tc)
+
+	// Only the caller's own shipments can be collected
+	for _, ids := range [][]string{{shipmentB}, {shipmentA, shipmentB}, {"not-a-uuid"}} {
+		if _, err := svc.SchedulePickup(as(tenantA), pickupRequest(ids...)); !errors.Is(err, ErrUnknownShipment) {
+			t.Errorf("tenant A collecting %v: err = %v", ids, err)
+		}
+	}
+	if len(pickups.byID) != 0 {
+		t.Fatalf("rejected pickups were stored: %+v", pickups.byID)
+	}
+
+	created, err := svc.SchedulePickup(as(tenantA), pickupRequest(shipmentA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.TenantID != tenantA {
+		t.Errorf("pickup tenant = %q", created.TenantID)
+	}
+
+	// Tenant B cannot read, cancel or move it
+	if _, err := svc.GetPickup(as(tenantB), created.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("tenant B read: err = %v", err)
+	}
+	if err := svc.CancelPickup(as(tenantB), created.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("tenant B cancel: err = %v", err)
+	}
+	if err := svc.ReschedulePickup(as(tenantB), created.ID, pickupRequest().Window); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("tenant B reschedule: err = %v", err)
+	}
+	if _, err := svc.GetPickup(as(""), created.ID); !errors.Is(err, ErrNoTenant) {
+		t.Errorf("tenantless read: err = %v", err)
+	}
+	if len(tc.signals) != 0 {
+		t.Fatalf("other tenants signalled the workflow: %v", tc.signals)
+	}
+
+	if err := svc.CancelPickup(as(tenantA), created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(tc.signals) != 1 {
+		t.Errorf("signals = %v", tc.signals)
+	}
+}
+
+func TestPickupMarkedFailedWhenWorkflowDoesNotStart(t *testing.T) {
+	svc, pickups := newTestPickupService(&fakeTemporal{startErr: errors.New("temporal unavailable")})
+
+	if _, err := svc.SchedulePickup(as(tenantA), pickupRequest(shipmentA)); err == nil {
+		t.Fatal("expected the workflow start error")
+	}
+	if p := pickups.byID["pickup-1"]; p.Status != contracts.PickupFailed {
+		t.Errorf("pickup left as %q, want FAILED", p.Status)
+	}
+}
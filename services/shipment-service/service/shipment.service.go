@@ -9,19 +9,44 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
 	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+	"github.com/google/uuid"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 	"go.opentelemetry.io/otel"
 )
 
+// ErrInvalidAddress is returned by CreateShipment when address validation rejects the from/to address.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ErrMissingFields is the kind of validation error for requests without their required fields.
+var ErrMissingFields = errors.New("missing required fields")
+
+// ErrNotCancellable is returned by CancelShipment once a shipment has left PRE_TRANSIT.
+var ErrNotCancellable = errors.New("can only cancel PRE_TRANSIT shipments")
+
+// ErrInvalidRateInput is the kind of validation error GetRates returns.
+var ErrInvalidRateInput = errors.New("invalid rate input")
+
+// ErrRateProvider wraps failures of the carrier rate API.
+var ErrRateProvider = errors.New("rate provider error")
+
+// MaxShipmentsPerBatch caps GetShipmentsByIDs; the gateway's loader never asks for more.
+const MaxShipmentsPerBatch = 100
+
+// ErrTooManyIDs is the kind of validation error GetShipmentsByIDs returns for more than MaxShipmentsPerBatch IDs.
+var ErrTooManyIDs = errors.New("too many shipment IDs")
+
 // ShipmentService handles business logic.
 //
 //	We removed 'producer', 'httpClient', and 'shippoKey' from this struct.
@@ -34,11 +59,6 @@ type ShipmentService struct {
 	logger         *slog.Logger
 }
 
-const (
-	shipmentTaskQueue      = "SHIPMENT_TASK_QUEUE"
-	createShipmentWorkflow = "CreateShipmentWorkflow"
-)
-
 // NewShipmentService creates a new service.
 // We now pass the Temporal Client instead of the Kafka Producer.
 func NewShipmentService(store store.ShipmentStore, temporalClient client.Client) *ShipmentService {
@@ -55,9 +75,27 @@ func (s *ShipmentService) CreateShipment(ctx context.Context, shipment contracts
 	ctx, span := otel.Tracer("shipment-service").Start(ctx, "ShipmentService.CreateShipment")
 	defer span.End()
 	// catch bad data *before* starting a workflow to save resources.
-	if shipment.Origin == "" || shipment.Destination == "" {
-		return contracts.Shipment{}, errors.New("missing required fields")
+	var missing []contracts.FieldViolation
+	if shipment.Origin == "" {
+		missing = append(missing, contracts.FieldViolation{Field: "origin", Description: "origin is required"})
+	}
+	if shipment.Destination == "" {
+		missing = append(missing, contracts.FieldViolation{Field: "destination", Description: "destination is required"})
+	}
+	if len(missing) > 0 {
+		return contracts.Shipment{}, contracts.Invalid(ErrMissingFields, missing...)
+	}
+	// Cross-border shipments need a complete customs declaration; reject now rather
+	// than after the addresses have been validated and the workflow has started.
+	if err := shipment.ValidateCustoms(); err != nil {
+		return contracts.Shipment{}, err
 	}
+	// The shipment belongs to the caller's tenant, whatever the request said
+	tenantID, err := ownerTenant(ctx)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
+	shipment.TenantID = tenantID
 
 	// Define Workflow Options
 	// TaskQueue: This MUST match the queue name defined in your Worker (workflow-orchestrator/cmd/main.go).
@@ -65,7 +103,7 @@ func (s *ShipmentService) CreateShipment(ctx context.Context, shipment contracts
 	workflowID := "shipment-create-" + stableCreateKey(shipment)
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
-		TaskQueue: shipmentTaskQueue,
+		TaskQueue: contracts.ShipmentTaskQueue,
 	}
 
 	// Execute the Workflow
@@ -73,7 +111,7 @@ func (s *ShipmentService) CreateShipment(ctx context.Context, shipment contracts
 	// the gRPC client (frontend) is waiting for a response (the Tracking Number).
 	// This call sends the inputs to the Temporal Server.
 	s.logger.InfoContext(ctx, "starting shipment create workflow", "workflow_id", workflowID, "origin", shipment.Origin, "destination", shipment.Destination)
-	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, createShipmentWorkflow, shipment)
+	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, contracts.CreateShipmentWorkflowName, shipment)
 	if err != nil {
 		return contracts.Shipment{}, err
 	}
@@ -83,12 +121,50 @@ func (s *ShipmentService) CreateShipment(ctx context.Context, shipment contracts
 	var result contracts.Shipment
 	err = we.Get(ctx, &result)
 	if err != nil {
+		// The first workflow step rejects bad addresses; surface that as a client error.
+		// Callers can use ValidateAddress to get the issues and suggested corrections.
+		var appErr *temporal.ApplicationError
+		if errors.As(err, &appErr) {
+			switch appErr.Type() {
+			case contracts.InvalidAddressErrorType:
+				var check contracts.ShipmentAddressValidation
+				if appErr.Details(&check) == nil {
+					if violations := addressViolations(check); len(violations) > 0 {
+						return contracts.Shipment{}, contracts.Invalid(ErrInvalidAddress, violations...)
+					}
+				}
+				return contracts.Shipment{}, fmt.Errorf("%w: %s", ErrInvalidAddress, appErr.Error())
+			case contracts.MissingCustomsErrorType:
+				return contracts.Shipment{}, fmt.Errorf("%w: %s", contracts.ErrMissingCustomsData, appErr.Error())
+			}
+		}
 		return contracts.Shipment{}, err
 	}
 
 	return result, nil
 }
 
+// addressViolations turns the workflow's address check into field violations
+// on from_address / to_address.
+func addressViolations(check contracts.ShipmentAddressValidation) []contracts.FieldViolation {
+	var out []contracts.FieldViolation
+	add := func(side string, result *contracts.AddressValidation) {
+		if result == nil || result.Valid {
+			return
+		}
+		for _, issue := range result.Issues {
+			field := side
+			if issue.Field != "" {
+				field += "." + issue.Field
+			}
+			out = append(out, contracts.FieldViolation{Field: field, Description: issue.Message})
+		}
+	}
+	add("from_address", check.From)
+	add("to_address", check.To)
+	return out
+}
+
 // Updateshipment updates shipment details in DB.
 // 🟡 REFACTOR STATUS: PARTIAL
 // We kept the DB update because we still have access to 's.store'.
@@ -100,7 +176,11 @@ func (s *ShipmentService) Updateshipment(ctx context.Context, shipment contracts
 	}
 
 	// Validate existence and status (Read-only check)
-	current, err := s.store.GetShipment(ctx, shipment.ID)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
+	current, err := s.store.GetShipment(ctx, tenantID, shipment.ID)
 	if err != nil {
 		return contracts.Shipment{}, errors.New("failed to get shipment: " + err.Error())
 	}
@@ -112,6 +192,7 @@ func (s *ShipmentService) Updateshipment(ctx context.Context, shipment contracts
 	// Merge logic (Keep existing values if new ones are empty)
 	updatedShipment := contracts.Shipment{
 		ID:             shipment.ID,
+		TenantID:       current.TenantID,
 		Origin:         ifEmpty(shipment.Origin, current.Origin),
 		Destination:    ifEmpty(shipment.Destination, current.Destination),
 		Eta:            ifEmpty(shipment.Eta, current.Eta),
@@ -123,6 +204,9 @@ func (s *ShipmentService) Updateshipment(ctx context.Context, shipment contracts
 		Height:         ifZero(shipment.Height, current.Height),
 		Weight:         ifZero(shipment.Weight, current.Weight),
 		Unit:           ifEmpty(shipment.Unit, current.Unit),
+		FromAddress:    current.FromAddress, // addresses were validated at creation; not editable here
+		ToAddress:      current.ToAddress,
+		Customs:        current.Customs,
 	}
 	//Execute Workflow (Worker handles DB Update + Kafka Event)
 
@@ -137,20 +221,39 @@ func (s *ShipmentService) Updateshipment(ctx context.Context, shipment contracts
 // DeleteShipment starts the CancelShipmentWorkflow
 // We DISABLED the Shippo API call because we removed 's.httpClient' and 's.shippoKey'.
 func (s *ShipmentService) DeleteShipment(ctx context.Context, id string) error {
+	_, err := s.CancelShipment(ctx, id)
+	return err
+}
+
+// CancelShipment marks a PRE_TRANSIT shipment CANCELLED and returns it.
+// A shipment that is already cancelled is returned unchanged, so retries are safe.
+func (s *ShipmentService) CancelShipment(ctx context.Context, id string) (contracts.Shipment, error) {
+	ctx, span := otel.Tracer("shipment-service").Start(ctx, "ShipmentService.CancelShipment")
+	defer span.End()
+
 	if id == "" {
-		return errors.New("missing shipment id")
+		return contracts.Shipment{}, contracts.Invalid(ErrMissingFields, contracts.FieldViolation{Field: "id", Description: "id is required"})
 	}
-	shipment, err := s.store.GetShipment(ctx, id)
+	tenantID, err := tenantScope(ctx)
 	if err != nil {
-		return errors.New("failed to get shipment: " + err.Error())
+		return contracts.Shipment{}, err
 	}
-
-	if shipment.Status != proto.ShipmentStatus_PRE_TRANSIT {
-		return errors.New("can only delete PRE_TRANSIT shipments")
+	shipment, err := s.store.GetShipment(ctx, tenantID, id)
+	if err != nil {
+		return contracts.Shipment{}, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	switch shipment.Status {
+	case proto.ShipmentStatus_CANCELLED:
+		return shipment, nil
+	case proto.ShipmentStatus_PRE_TRANSIT:
+	default:
+		return contracts.Shipment{}, ErrNotCancellable
 	}
 	shipment.Status = proto.ShipmentStatus_CANCELLED
-	return s.store.UpdateShipment(ctx, shipment)
-
+	if err := s.store.UpdateShipment(ctx, shipment); err != nil {
+		return contracts.Shipment{}, err
+	}
+	return shipment, nil
 }
 
 // GetRates fetches carrier rates.
@@ -161,8 +264,20 @@ func (s *ShipmentService) DeleteShipment(ctx context.Context, id string) error {
 // Enables clients to compare shipping options, like Amazon’s checkout.
 // Note: Doesn’t use store since it’s an API call.
 func (s *ShipmentService) GetRates(ctx context.Context, origin, destination string, length, width, height, weight float64, unit string) ([]contracts.Rate, error) {
-	if origin == "" || destination == "" || length <= 0 || width <= 0 || height <= 0 || weight <= 0 || unit == "" {
-		return nil, errors.New("invalid rate input")
+	var invalid []contracts.FieldViolation
+	for field, value := range map[string]string{"origin": origin, "destination": destination, "unit": unit} {
+		if value == "" {
+			invalid = append(invalid, contracts.FieldViolation{Field: field, Description: field + " is required"})
+		}
+	}
+	for field, value := range map[string]float64{"length": length, "width": width, "height": height, "weight": weight} {
+		if value <= 0 {
+			invalid = append(invalid, contracts.FieldViolation{Field: field, Description: field + " must be greater than 0"})
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Slice(invalid, func(i, j int) bool { return invalid[i].Field < invalid[j].Field })
+		return nil, contracts.Invalid(ErrInvalidRateInput, invalid...)
 	}
 
 	// 🟢 NEW: Create a local HTTP client just for this request
@@ -198,12 +313,12 @@ func (s *ShipmentService) GetRates(ctx context.Context, origin, destination stri
 	// Use the LOCAL client
 	resp, err := localClient.Do(req)
 	if err != nil {
-		return nil, errors.New("shippo api error: " + err.Error())
+		return nil, fmt.Errorf("%w: shippo api error: %v", ErrRateProvider, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("shippo status error: " + resp.Status)
+		return nil, fmt.Errorf("%w: shippo status error: %s", ErrRateProvider, resp.Status)
 	}
 
 	var rateResp struct {
@@ -231,9 +346,42 @@ func (s *ShipmentService) GetRates(ctx context.Context, origin, destination stri
 	return rates, nil
 }
 
-// GetShipments just calls the store, so it still works fine.
+// GetShipments lists the caller's tenant's shipments.
 func (s *ShipmentService) GetShipments(ctx context.Context, origin string, status proto.ShipmentStatus, destination string, limit, offset int32) ([]contracts.Shipment, error) {
-	return s.store.GetShipments(ctx, origin, status, destination, limit, offset)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetShipments(ctx, tenantID, origin, status, destination, limit, offset)
+}
+
+// GetShipmentsByIDs looks up many shipments in one query. Duplicate IDs are
+// asked for once, and IDs that are not UUIDs cannot match a shipment, so they
+// are left out like unknown ones instead of failing the whole batch. Other
+// tenants' shipments are left out the same way.
+func (s *ShipmentService) GetShipmentsByIDs(ctx context.Context, ids []string) ([]contracts.Shipment, error) {
+	if len(ids) > MaxShipmentsPerBatch {
+		return nil, contracts.Invalid(ErrTooManyIDs, contracts.FieldViolation{
+			Field:       "ids",
+			Description: fmt.Sprintf("at most %d shipment IDs per request", MaxShipmentsPerBatch),
+		})
+	}
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(ids))
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	return s.store.GetShipmentsByIDs(ctx, tenantID, valid)
 }
 
 // Helper functions remain unchanged
@@ -251,7 +399,14 @@ func ifZero(newValue, oldValue float64) float64 {
 }
 
 func stableCreateKey(shipment contracts.Shipment) string {
-	raw := shipment.Origin + "|" + shipment.Destination + "|" + shipment.Eta + "|" + shipment.Carrier.Name
+	// The tenant is part of the key: two tenants sending the same parcel are two shipments.
+	raw := shipment.TenantID + "|" + shipment.Origin + "|" + shipment.Destination + "|" + shipment.Eta + "|" + shipment.Carrier.Name
+	// Only mixed in when present so city-only requests keep their existing workflow IDs.
+	for _, a := range []contracts.Address{shipment.FromAddress, shipment.ToAddress} {
+		if !a.IsZero() {
+			raw += "|" + a.Street1 + "|" + a.Street2 + "|" + a.Zip + "|" + a.Country
+		}
+	}
 	sum := sha1.Sum([]byte(raw))
 	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/services/shipment-service/store"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+	"github.com/Tanmoy095/LogiSynapse/shared/proto"
+)
+
+const (
+	tenantA = "7b0c6f0e-1111-4c3a-9d55-000000000001"
+	tenantB = "7b0c6f0e-2222-4c3a-9d55-000000000002"
+
+	shipmentA = "5f3e8a7c-aaaa-4b1d-8e2f-00000000000a"
+	shipmentB = "5f3e8a7c-bbbb-4b1d-8e2f-00000000000b"
+)
+
+// memStore keeps shipments in memory and scopes reads the way PostgresStore does.
+type memStore struct {
+	store.ShipmentStore
+	byID map[string]contracts.Shipment
+}
+
+func newMemStore(shipments ...contracts.Shipment) *memStore {
+	s := &memStore{byID: map[string]contracts.Shipment{}}
+	for _, sh := range shipments {
+		s.byID[sh.ID] = sh
+	}
+	return s
+}
+
+func visible(tenantID string, sh contracts.Shipment) bool {
+	return tenantID == "" || sh.TenantID == tenantID
+}
+
+func (s *memStore) GetShipments(_ context.Context, tenantID, _ string, _ proto.ShipmentStatus, _ string, _, _ int32) ([]contracts.Shipment, error) {
+	var out []contracts.Shipment
+	for _, sh := range s.byID {
+		if visible(tenantID, sh) {
+			out = append(out, sh)
+		}
+	}
+	return out, nil
+}
+
+func (s *memStore) GetShipment(_ context.Context, tenantID, id string) (contracts.Shipment, error) {
+	sh, ok := s.byID[id]
+	if !ok || !visible(tenantID, sh) {
+		return contracts.Shipment{}, store.ErrNotFound
+	}
+	return sh, nil
+}
+
+func (s *memStore) GetShipmentsByIDs(_ context.Context, tenantID string, ids []string) ([]contracts.Shipment, error) {
+	var out []contracts.Shipment
+	for _, id := range ids {
+		if sh, ok := s.byID[id]; ok && visible(tenantID, sh) {
+			out = append(out, sh)
+		}
+	}
+	return out, nil
+}
+
+func (s *memStore) UpdateShipment(_ context.Context, sh contracts.Shipment) error {
+	s.byID[sh.ID] = sh
+	return nil
+}
+
+func as(tenantID string) context.Context {
+	return identity.NewContext(context.Background(), identity.Identity{UserID: "u-1", TenantID: tenantID, Role: "admin"})
+}
+
+func TestGetShipmentsIsTenantScoped(t *testing.T) {
+	svc := NewShipmentService(newMemStore(
+		contracts.Shipment{ID: "a-1", TenantID: tenantA},
+		contracts.Shipment{ID: "b-1", TenantID: tenantB},
+	), nil)
+
+	got, err := svc.GetShipments(as(tenantA), "", 0, "", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "a-1" {
+		t.Errorf("tenant A sees %+v", got)
+	}
+
+	if _, err := svc.GetShipments(as(""), "", 0, "", 10, 0); !errors.Is(err, ErrNoTenant) {
+		t.Errorf("tenantless caller: err = %v", err)
+	}
+	if _, err := svc.GetShipments(context.Background(), "", 0, "", 10, 0); !errors.Is(err, ErrNoTenant) {
+		t.Errorf("anonymous caller: err = %v", err)
+	}
+
+	operator := identity.NewContext(context.Background(), identity.Identity{UserID: "op", SuperAdmin: true})
+	if got, _ := svc.GetShipments(operator, "", 0, "", 10, 0); len(got) != 2 {
+		t.Errorf("operator sees %d shipments; want all 2", len(got))
+	}
+}
+
+func TestUpdateShipmentKeepsTenant(t *testing.T) {
+	svc := NewShipmentService(newMemStore(
+		contracts.Shipment{ID: "a-1", TenantID: tenantA, Origin: "Dhaka", Status: proto.ShipmentStatus_PRE_TRANSIT},
+	), nil)
+
+	if _, err := svc.Updateshipment(as(tenantB), contracts.Shipment{ID: "a-1", Origin: "Chittagong"}); err == nil {
+		t.Fatal("tenant B updated tenant A's shipment")
+	}
+	updated, err := svc.Updateshipment(as(tenantA), contracts.Shipment{ID: "a-1", Origin: "Chittagong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.TenantID != tenantA || updated.Origin != "Chittagong" {
+		t.Errorf("updated = %+v", updated)
+	}
+}
+
+func TestGetShipmentsByIDsLeavesOutOtherTenants(t *testing.T) {
+	svc := NewShipmentService(newMemStore(
+		contracts.Shipment{ID: shipmentA, TenantID: tenantA},
+		contracts.Shipment{ID: shipmentB, TenantID: tenantB},
+	), nil)
+
+	got, err := svc.GetShipmentsByIDs(as(tenantA), []string{shipmentA, shipmentB, shipmentA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != shipmentA {
+		t.Errorf("tenant A's batch = %+v", got)
+	}
+	if _, err := svc.GetShipmentsByIDs(as(""), []string{shipmentA}); !errors.Is(err, ErrNoTenant) {
+		t.Errorf("tenantless caller: err = %v", err)
+	}
+}
+
+func TestCancelShipmentOfAnotherTenantIsNotFound(t *testing.T) {
+	st := newMemStore(contracts.Shipment{ID: shipmentA, TenantID: tenantA, Status: proto.ShipmentStatus_PRE_TRANSIT})
+	svc := NewShipmentService(st, nil)
+
+	// Same answer as for an ID that does not exist: no hint the shipment is there
+	for _, id := range []string{shipmentA, shipmentB} {
+		if _, err := svc.CancelShipment(as(tenantB), id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("tenant B cancelling %s: err = %v", id, err)
+		}
+	}
+	if st.byID[shipmentA].Status != proto.ShipmentStatus_PRE_TRANSIT {
+		t.Fatal("tenant B cancelled tenant A's shipment")
+	}
+
+	cancelled, err := svc.CancelShipment(as(tenantA), shipmentA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != proto.ShipmentStatus_CANCELLED {
+		t.Errorf("status = %v", cancelled.Status)
+	}
+}
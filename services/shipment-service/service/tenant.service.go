@@ -0,0 +1,36 @@
+//shipment-service/service/tenant.service.go
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+// ErrNoTenant is returned when a caller without a tenant reads or creates tenant data.
+var ErrNoTenant = errors.New("caller has no tenant")
+
+// tenantScope is the tenant whose shipments the caller may see. Platform
+// operators without a tenant get "" (no filter); anyone else needs one.
+func tenantScope(ctx context.Context) (string, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return "", ErrNoTenant
+	}
+	if caller.TenantID == "" && !caller.SuperAdmin {
+		return "", ErrNoTenant
+	}
+	return caller.TenantID, nil
+}
+
+// ownerTenant is the tenant a new shipment belongs to: always the caller's,
+// even for platform operators.
+func ownerTenant(ctx context.Context) (string, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok || caller.TenantID == "" {
+		return "", ErrNoTenant
+	}
+	return caller.TenantID, nil
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/lib/pq"
+)
+
+// CreatePickup inserts a new pickup in REQUESTED state and returns it with its generated ID.
+// The carrier booking happens later in the SchedulePickupWorkflow.
+func (s *PostgresStore) CreatePickup(ctx context.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+	query := `
+		INSERT INTO pickups (carrier, status, address_name, address_company, address_street1, address_street2,
+			address_city, address_state, address_zip, address_country, contact_phone, contact_email,
+			window_start, window_end, shipment_ids, confirmation_number, carrier_pickup_id, instructions, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, NULLIF($19, '')::uuid)
+		RETURNING id`
+
+	if pickup.Status == "" {
+		pickup.Status = contracts.PickupRequested
+	}
+	a := pickup.Address
+	err := s.db.QueryRowContext(ctx, query,
+		pickup.Carrier,
+		string(pickup.Status),
+		a.Name, a.Company, a.Street1, a.Street2,
+		a.City, a.State, a.Zip, a.Country, a.Phone, a.Email,
+		pickup.Window.Start,
+		pickup.Window.End,
+		pq.Array(pickup.ShipmentIDs),
+		pickup.ConfirmationNumber,
+		pickup.CarrierPickupID,
+		pickup.Instructions,
+		pickup.TenantID,
+	).Scan(&pickup.ID)
+	if err != nil {
+		return contracts.Pickup{}, fmt.Errorf("failed to insert pickup: %w", err)
+	}
+	return pickup, nil
+}
+
+// GetPickup retrieves a pickup by ID. Another tenant's pickup is not found,
+// the same as one that does not exist.
+func (s *PostgresStore) GetPickup(ctx context.Context, tenantID, id string) (contracts.Pickup, error) {
+	query := `
+		SELECT id, carrier, status, address_name, address_company, address_street1, address_street2,
+			address_city, address_state, address_zip, address_country, contact_phone, contact_email,
+			window_start, window_end, shipment_ids, confirmation_number, carrier_pickup_id, instructions,
+			COALESCE(tenant_id::text, '')
+		FROM pickups WHERE id = $1 AND ($2 = '' OR tenant_id = NULLIF($2, '')::uuid)`
+
+	var p contracts.Pickup
+	var status string
+	// Optional columns may be NULL
+	var company, street2, state, phone, email, confirmation, carrierPickupID, instructions sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id, tenantID).Scan(
+		&p.ID, &p.Carrier, &status,
+		&p.Address.Name, &company, &p.Address.Street1, &street2,
+		&p.Address.City, &state, &p.Address.Zip, &p.Address.Country, &phone, &email,
+		&p.Window.Start, &p.Window.End,
+		pq.Array(&p.ShipmentIDs),
+		&confirmation, &carrierPickupID, &instructions,
+		&p.TenantID,
+	)
+	if err == sql.ErrNoRows {
+		return contracts.Pickup{}, fmt.Errorf("pickup %w", ErrNotFound)
+	}
+	if err != nil {
+		return contracts.Pickup{}, err
+	}
+	p.Status = contracts.PickupStatus(status)
+	p.Address.Company = company.String
+	p.Address.Street2 = street2.String
+	p.Address.State = state.String
+	p.Address.Phone = phone.String
+	p.Address.Email = email.String
+	p.ConfirmationNumber = confirmation.String
+	p.CarrierPickupID = carrierPickupID.String
+	p.Instructions = instructions.String
+	return p, nil
+}
+
+// UpdatePickup overwrites the mutable fields of a pickup (window, status, confirmation, carrier booking ID).
+// Called by the workflow after booking, rescheduling or cancelling with the carrier.
+func (s *PostgresStore) UpdatePickup(ctx context.Context, pickup contracts.Pickup) error {
+	query := `
+		UPDATE pickups
+		SET status = $1, window_start = $2, window_end = $3, confirmation_number = $4,
+			carrier_pickup_id = $5, instructions = $6, updated_at = NOW()
+		WHERE id = $7`
+
+	res, err := s.db.ExecContext(ctx, query,
+		string(pickup.Status),
+		pickup.Window.Start,
+		pickup.Window.End,
+		pickup.ConfirmationNumber,
+		pickup.CarrierPickupID,
+		pickup.Instructions,
+		pickup.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update pickup: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("pickup %w", ErrNotFound)
+	}
+	return nil
+}
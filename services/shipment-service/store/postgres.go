@@ -3,11 +3,12 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresStore manages database operations for the Shipment Service
@@ -49,8 +50,8 @@ func (s *PostgresStore) CreateShipment(ctx context.Context, shipment contracts.S
 	// SQL query to insert shipment and return generated ID
 	// Why: Stores all fields, including package details and tracking
 	query := `
-		INSERT INTO shipments (origin, destination, status, eta, carrier_name, carrier_tracking_url, tracking_number, length, width, height, weight, unit)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO shipments (origin, destination, status, eta, carrier_name, carrier_tracking_url, tracking_number, length, width, height, weight, unit, from_address, to_address, customs, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, '')::uuid)
 		RETURNING id`
 
 	// Execute the query with the shipment data and scan the returned ID into shipment.ID
@@ -58,7 +59,15 @@ func (s *PostgresStore) CreateShipment(ctx context.Context, shipment contracts.S
 	// Why: Saves data and retrieves UUID
 	// Convert proto enum to string for DB storage
 	statusStr := shipment.Status.String()
-	err := s.db.QueryRowContext(ctx, query,
+	fromAddr, toAddr, err := addressColumns(shipment)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
+	customs, err := customsColumn(shipment.Customs)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
+	err = s.db.QueryRowContext(ctx, query,
 		shipment.Origin,              // Shipment origin (e.g., "New York")
 		shipment.Destination,         // Shipment destination (e.g., "London")
 		statusStr,                    // Shipment status as string
@@ -71,6 +80,10 @@ func (s *PostgresStore) CreateShipment(ctx context.Context, shipment contracts.S
 		shipment.Height,
 		shipment.Weight,
 		shipment.Unit,
+		fromAddr, // JSONB, NULL for city-only shipments
+		toAddr,
+		customs, // JSONB, NULL for domestic shipments
+		shipment.TenantID,
 	).Scan(&shipment.ID)
 
 	// Check for errors during the query execution
@@ -98,10 +111,18 @@ func (s *PostgresStore) CreateShipmentWithOutbox(ctx context.Context, shipment c
 	}()
 
 	insertShipment := `
-		INSERT INTO shipments (origin, destination, status, eta, carrier_name, carrier_tracking_url, tracking_number, length, width, height, weight, unit)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO shipments (origin, destination, status, eta, carrier_name, carrier_tracking_url, tracking_number, length, width, height, weight, unit, from_address, to_address, customs, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, '')::uuid)
 		RETURNING id`
 	statusStr := shipment.Status.String()
+	fromAddr, toAddr, err := addressColumns(shipment)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
+	customs, err := customsColumn(shipment.Customs)
+	if err != nil {
+		return contracts.Shipment{}, err
+	}
 	if err = tx.QueryRowContext(ctx, insertShipment,
 		shipment.Origin,
 		shipment.Destination,
@@ -115,6 +136,10 @@ func (s *PostgresStore) CreateShipmentWithOutbox(ctx context.Context, shipment c
 		shipment.Height,
 		shipment.Weight,
 		shipment.Unit,
+		fromAddr, // JSONB, NULL for city-only shipments
+		toAddr,
+		customs, // JSONB, NULL for domestic shipments
+		shipment.TenantID,
 	).Scan(&shipment.ID); err != nil {
 		return contracts.Shipment{}, fmt.Errorf("failed to insert shipment in tx: %w", err)
 	}
@@ -134,26 +159,28 @@ func (s *PostgresStore) CreateShipmentWithOutbox(ctx context.Context, shipment c
 
 // GetShipment retrieves a shipment by ID.
 // Why: Needed for UpdateShipment and DeleteShipment to check status and preserve data.
-func (s *PostgresStore) GetShipment(ctx context.Context, id string) (contracts.Shipment, error) {
+func (s *PostgresStore) GetShipment(ctx context.Context, tenantID, id string) (contracts.Shipment, error) {
 	// SQL query to fetch shipment with all fields
 	// Why: Retrieves complete data, including dimensions
+	// Another tenant's shipment is not found, the same as one that does not exist
 	query := `
 		SELECT id, origin, destination, status, eta, carrier_name, carrier_tracking_url, tracking_number,
-   		length, width, height, weight, unit
-		FROM shipments WHERE id = $1`
+   		length, width, height, weight, unit, from_address, to_address, customs, COALESCE(tenant_id::text, '')
+		FROM shipments WHERE id = $1 AND ($2 = '' OR tenant_id = NULLIF($2, '')::uuid)`
 	var shipment contracts.Shipment
 	// Use sql.Null* for nullable fields
 	// Why: Handles nullable database fields safely
 	var statusStr, eta, carrierName, trackingURL, trackingNumber, unit sql.NullString
 	var length, width, height, weight sql.NullFloat64
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	var fromAddr, toAddr, customs []byte
+	err := s.db.QueryRowContext(ctx, query, id, tenantID).Scan(
 		&shipment.ID, &shipment.Origin, &shipment.Destination, &statusStr,
 		&eta, &carrierName, &trackingURL, &trackingNumber,
-		&length, &width, &height, &weight, &unit,
+		&length, &width, &height, &weight, &unit, &fromAddr, &toAddr, &customs, &shipment.TenantID,
 	)
 	// Handle not found error
 	if err == sql.ErrNoRows {
-		return contracts.Shipment{}, fmt.Errorf("shipment not found")
+		return contracts.Shipment{}, fmt.Errorf("shipment %w", ErrNotFound)
 	}
 	if err != nil {
 		return contracts.Shipment{}, err
@@ -168,6 +195,12 @@ func (s *PostgresStore) GetShipment(ctx context.Context, id string) (contracts.S
 	shipment.Height = height.Float64
 	shipment.Weight = weight.Float64
 	shipment.Unit = unit.String
+	if err := scanAddresses(&shipment, fromAddr, toAddr); err != nil {
+		return contracts.Shipment{}, err
+	}
+	if shipment.Customs, err = scanCustoms(customs); err != nil {
+		return contracts.Shipment{}, err
+	}
 	// parse status string into proto enum
 	shipment.Status = parseStatusStringToProto(statusStr.String)
 	return shipment, nil
@@ -176,30 +209,55 @@ func (s *PostgresStore) GetShipment(ctx context.Context, id string) (contracts.S
 // GetShipments retrieves shipments from the database with optional filtering and pagination
 // Filters by origin, status, and destination (empty string means no filter)
 // Uses limit and offset for pagination
-func (s *PostgresStore) GetShipments(ctx context.Context, origin string, status proto.ShipmentStatus, destination string, limit, offset int32) ([]contracts.Shipment, error) {
+func (s *PostgresStore) GetShipments(ctx context.Context, tenantID, origin string, status proto.ShipmentStatus, destination string, limit, offset int32) ([]contracts.Shipment, error) {
 	// Define the SQL query to select shipments with filters and pagination
 	//sql querry with filter and pagination
 	query := `
         SELECT id, origin, destination, status, eta, carrier_name, carrier_tracking_url,
-		tracking_number,length,width,height, weight, unit 
+		tracking_number,length,width,height, weight, unit, from_address, to_address, customs, COALESCE(tenant_id::text, '')
         FROM shipments
         WHERE ($1 = '' OR origin = $1)
           AND ($2 = '' OR status = $2)
           AND ($3 = '' OR destination = $3)
+          AND ($6 = '' OR tenant_id = NULLIF($6, '')::uuid)
         ORDER BY eta ASC
         LIMIT $4 OFFSET $5`
 
 	// Execute the query with the provided filters and pagination parameters
 	// convert status proto enum to string for DB query
 	statusStr := status.String()
-	rows, err := s.db.QueryContext(ctx, query, origin, statusStr, destination, limit, offset)
+	rows, err := s.db.QueryContext(ctx, query, origin, statusStr, destination, limit, offset, tenantID)
 	if err != nil {
 		// Return an error if the query fails
 		return nil, err
 	}
 	// Ensure rows are closed to free resources
 	defer rows.Close()
+	return scanShipments(rows)
+}
 
+// GetShipmentsByIDs retrieves the shipments with the given IDs in one query.
+// IDs with no shipment, or with another tenant's, are left out of the result.
+func (s *PostgresStore) GetShipmentsByIDs(ctx context.Context, tenantID string, ids []string) ([]contracts.Shipment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `
+        SELECT id, origin, destination, status, eta, carrier_name, carrier_tracking_url,
+		tracking_number,length,width,height, weight, unit, from_address, to_address, customs, COALESCE(tenant_id::text, '')
+        FROM shipments
+        WHERE id = ANY($1::uuid[])
+          AND ($2 = '' OR tenant_id = NULLIF($2, '')::uuid)`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(ids), tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanShipments(rows)
+}
+
+// scanShipments reads shipment rows selected with the column list GetShipments uses.
+func scanShipments(rows *sql.Rows) ([]contracts.Shipment, error) {
 	// Initialize a slice to store the retrieved shipments
 	var shipments []contracts.Shipment
 
@@ -210,6 +268,7 @@ func (s *PostgresStore) GetShipments(ctx context.Context, origin string, status
 		// Use sql.NullString for nullable fields (eta, carrier_name, carrier_tracking_url)
 		var statusStr, eta, carrierName, trackingURL, trackingNumber, unit sql.NullString
 		var length, width, height, weight sql.NullFloat64
+		var fromAddr, toAddr, customs []byte // JSONB, nil when NULL
 
 		// Scan the row data into the Shipment struct and nullable fields
 		if err := rows.Scan(
@@ -226,6 +285,10 @@ func (s *PostgresStore) GetShipments(ctx context.Context, origin string, status
 			&height,
 			&weight,
 			&unit,
+			&fromAddr,
+			&toAddr,
+			&customs,
+			&sh.TenantID,
 		); err != nil {
 			// Return an error if scanning fails
 			return nil, err
@@ -243,6 +306,13 @@ func (s *PostgresStore) GetShipments(ctx context.Context, origin string, status
 		sh.Height = height.Float64
 		sh.Weight = weight.Float64
 		sh.Unit = unit.String
+		if err := scanAddresses(&sh, fromAddr, toAddr); err != nil {
+			return nil, err
+		}
+		var err error
+		if sh.Customs, err = scanCustoms(customs); err != nil {
+			return nil, err
+		}
 		sh.Status = parseStatusStringToProto(statusStr.String)
 
 		// Append the shipment to the results slice
@@ -263,25 +333,30 @@ func (s *PostgresStore) GetShipments(ctx context.Context, origin string, status
 
 // Sql query to Update all fields
 // Persists changes including status for deleteShipment
+// The row must still belong to shipment.TenantID (the tenant it was read for),
+// so an update can never move or touch another tenant's shipment.
 func (s *PostgresStore) UpdateShipment(ctx context.Context, shipment contracts.Shipment) error {
 	//sql query to update all fields
 	query := `
 UPDATE shipments
 SET origin = $1, destination = $2, status = $3, eta = $4,carrier_name = $5, carrier_tracking_url = $6, tracking_number =    $7,length = $8, width = $9, height = $10, weight = $11, unit = $12 
-WHERE id = $13`
+WHERE id = $13 AND tenant_id IS NOT DISTINCT FROM NULLIF($14, '')::uuid`
 	//Execute update
 	//Save updated shipment data
 	// convert enum to string for DB
 	statusStr := shipment.Status.String()
-	_, err := s.db.ExecContext(ctx, query,
+	res, err := s.db.ExecContext(ctx, query,
 		shipment.Origin, shipment.Destination, statusStr, shipment.Eta,
 		shipment.Carrier.Name, shipment.Carrier.TrackingURL, shipment.TrackingNumber,
 		shipment.Length, shipment.Width, shipment.Height, shipment.Weight, shipment.Unit,
-		shipment.ID,
+		shipment.ID, shipment.TenantID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update shipment: %v", err)
 	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("shipment %w", ErrNotFound)
+	}
 	return nil
 
 }
@@ -319,6 +394,70 @@ func (s *PostgresStore) MarkOutboxEventPublished(ctx context.Context, eventID st
 	return nil
 }
 
+// addressColumns encodes the shipment's addresses for the JSONB columns.
+// A zero address is stored as NULL so city-only shipments stay distinguishable.
+func addressColumns(shipment contracts.Shipment) (interface{}, interface{}, error) {
+	from, err := addressColumn(shipment.FromAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := addressColumn(shipment.ToAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func addressColumn(a contracts.Address) (interface{}, error) {
+	if a.IsZero() {
+		return nil, nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode address: %w", err)
+	}
+	return string(b), nil // lib/pq would send []byte as bytea
+}
+
+// scanAddresses decodes the JSONB address columns into the shipment.
+func scanAddresses(shipment *contracts.Shipment, from, to []byte) error {
+	if len(from) > 0 {
+		if err := json.Unmarshal(from, &shipment.FromAddress); err != nil {
+			return fmt.Errorf("failed to decode from_address: %w", err)
+		}
+	}
+	if len(to) > 0 {
+		if err := json.Unmarshal(to, &shipment.ToAddress); err != nil {
+			return fmt.Errorf("failed to decode to_address: %w", err)
+		}
+	}
+	return nil
+}
+
+// customsColumn encodes customs data for the JSONB column (NULL for domestic shipments).
+func customsColumn(c *contracts.Customs) (interface{}, error) {
+	if c == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode customs: %w", err)
+	}
+	return string(b), nil
+}
+
+// scanCustoms decodes the JSONB customs column.
+func scanCustoms(raw []byte) (*contracts.Customs, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var c contracts.Customs
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode customs: %w", err)
+	}
+	return &c, nil
+}
+
 // parseStatusStringToProto converts status string (stored in DB or from Shippo)
 // into the proto.ShipmentStatus enum. Unknown values map to PENDING.
 func parseStatusStringToProto(status string) proto.ShipmentStatus {
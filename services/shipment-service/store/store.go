@@ -3,11 +3,16 @@ package store
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	"github.com/Tanmoy095/LogiSynapse/shared/proto"
 )
 
+// ErrNotFound is wrapped by lookups of a single row that does not exist
+// ("shipment not found", "pickup not found").
+var ErrNotFound = errors.New("not found")
+
 // ShipmentStore defines the interface for the storage layer.
 // It specifies methods for retrieving and creating shipments.
 // Specifies Method for crud operations
@@ -15,10 +20,13 @@ import (
 type ShipmentStore interface {
 
 	// ctx allows cancellation and timeouts for database operations.
+	// tenantID scopes reads to one tenant's shipments; "" reads across tenants (platform operators).
 	//GetShipments retrieves shipments filtered by origin, status, or destination with pagination.
-	GetShipments(ctx context.Context, origin string, status proto.ShipmentStatus, destination string, limit, offset int32) ([]contracts.Shipment, error)
-	//get
-	GetShipment(ctx context.Context, id string) (contracts.Shipment, error)
+	GetShipments(ctx context.Context, tenantID, origin string, status proto.ShipmentStatus, destination string, limit, offset int32) ([]contracts.Shipment, error)
+	//get; another tenant's shipment is ErrNotFound
+	GetShipment(ctx context.Context, tenantID, id string) (contracts.Shipment, error)
+	// GetShipmentsByIDs is the batch lookup: one query for many IDs, unknown and other tenants' IDs left out.
+	GetShipmentsByIDs(ctx context.Context, tenantID string, ids []string) ([]contracts.Shipment, error)
 
 	// CreateShipment adds a new shipment to the store.
 	CreateShipment(ctx context.Context, shipment contracts.Shipment) (contracts.Shipment, error)
@@ -27,3 +35,12 @@ type ShipmentStore interface {
 	PopPendingOutboxEvent(ctx context.Context, aggregateID string) (string, []byte, error)
 	MarkOutboxEventPublished(ctx context.Context, eventID string) error
 }
+
+// PickupStore persists carrier pickups.
+// Kept separate from ShipmentStore so the workflow worker can depend on just what it needs.
+type PickupStore interface {
+	CreatePickup(ctx context.Context, pickup contracts.Pickup) (contracts.Pickup, error)
+	// GetPickup is scoped like GetShipment: another tenant's pickup is ErrNotFound, "" reads any
+	GetPickup(ctx context.Context, tenantID, id string) (contracts.Pickup, error)
+	UpdatePickup(ctx context.Context, pickup contracts.Pickup) error
+}
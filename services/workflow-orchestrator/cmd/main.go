@@ -11,15 +11,17 @@ import (
 
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
-	temporalworkflow "go.temporal.io/sdk/workflow"
 
 	// 1. Local Imports (Workflow & Activities)
 	"github.com/Tanmoy095/LogiSynapse/services/workflow-orchestrator/internal/activities"
+	"github.com/Tanmoy095/LogiSynapse/services/workflow-orchestrator/internal/registry"
 	"github.com/Tanmoy095/LogiSynapse/services/workflow-orchestrator/internal/workflow"
 
 	// 2. Shared Infrastructure Imports
 	// We use the 'CommonConfig' and 'kafka' from shared
+	"github.com/Tanmoy095/LogiSynapse/shared/address"
 	"github.com/Tanmoy095/LogiSynapse/shared/config"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	pkgkafka "github.com/Tanmoy095/LogiSynapse/shared/kafka"
 
 	// 3. Domain Imports (Reusing Store from shipment-service)
@@ -27,8 +29,6 @@ import (
 )
 
 func main() {
-	const taskQueue = "SHIPMENT_TASK_QUEUE"
-	const createWorkflowName = "CreateShipmentWorkflow"
 	logger := slog.Default()
 	// =========================================================================
 	// 1. LOAD CONFIG
@@ -91,24 +91,69 @@ func main() {
 		Client:    &http.Client{Timeout: 10 * time.Second},
 	}
 
-	// Create the Worker listening to the specific Task Queue
-	w := worker.New(c, taskQueue, worker.Options{})
+	// Pickups reuse the same DB and Kafka producer; the carrier sits behind an interface
+	pickupHost := &activities.PickupActivities{
+		Carrier: &activities.ShippoPickupCarrier{
+			ShippoKey: os.Getenv("SHIPPO_API_KEY"),
+			Client:    &http.Client{Timeout: 10 * time.Second},
+		},
+		Store:    shipmentStore,
+		Producer: producer,
+	}
 
-	// 🚨 CRITICAL FIX: Register Workflow
-	// Do NOT call the function with (). Pass the function name only!
-	w.RegisterWorkflowWithOptions(workflow.CreateShipmentWorkflow, temporalworkflow.RegisterOptions{Name: createWorkflowName})
+	// Address validation: offline rules first, Shippo verification only for addresses that pass them
+	addressHost := &activities.AddressActivities{
+		Validator: address.Chain{
+			address.NewRulesValidator(),
+			address.NewShippoValidator(os.Getenv("SHIPPO_API_KEY"), &http.Client{Timeout: 10 * time.Second}),
+		},
+	}
 
-	// Register Activities
-	w.RegisterActivity(activityHost.ACTIVITY_CallShippoAPI)
-	w.RegisterActivity(activityHost.ACTIVITY_SaveShipmentToDB)
-	w.RegisterActivity(activityHost.ACTIVITY_PublishKafkaEvent)
+	// Cross-border shipments: customs declarations filed through Shippo
+	customsHost := &activities.CustomsActivities{
+		Broker: &activities.ShippoCustomsBroker{
+			ShippoKey: os.Getenv("SHIPPO_API_KEY"),
+			Client:    &http.Client{Timeout: 10 * time.Second},
+		},
+	}
+
+	// One worker per task queue. Every ref declares its queue in internal/registry,
+	// and registry.Host panics at boot if a ref is registered on the wrong one.
+	shipmentWorker := worker.New(c, contracts.ShipmentTaskQueue, worker.Options{})
+	notificationWorker := worker.New(c, contracts.NotificationsTaskQueue, worker.Options{})
+	shipments := registry.Host{Queue: contracts.ShipmentTaskQueue, Worker: shipmentWorker}
+	notifications := registry.Host{Queue: contracts.NotificationsTaskQueue, Worker: notificationWorker}
+
+	// Shipments queue: workflows, carrier calls and DB writes
+	registry.RegisterWorkflow(shipments, registry.CreateShipment, workflow.CreateShipmentWorkflow)
+	registry.RegisterWorkflow(shipments, registry.SchedulePickup, workflow.SchedulePickupWorkflow)
+	registry.RegisterActivity(shipments, registry.ValidateAddresses, addressHost.ACTIVITY_ValidateAddresses)
+	registry.RegisterActivity(shipments, registry.CreateCustomsDeclaration, customsHost.ACTIVITY_CreateCustomsDeclaration)
+	registry.RegisterActivity(shipments, registry.CallShippoAPI, activityHost.ACTIVITY_CallShippoAPI)
+	registry.RegisterActivity(shipments, registry.SaveShipmentToDB, activityHost.ACTIVITY_SaveShipmentToDB)
+	registry.RegisterActivity(shipments, registry.BookCarrierPickup, pickupHost.ACTIVITY_BookCarrierPickup)
+	registry.RegisterAction(shipments, registry.CancelCarrierPickup, pickupHost.ACTIVITY_CancelCarrierPickup)
+	registry.RegisterAction(shipments, registry.SavePickup, pickupHost.ACTIVITY_SavePickup)
+
+	// CreateShipmentWorkflows started before the notifications queue existed
+	// (GetVersion == DefaultVersion) still publish from the shipments queue.
+	// Remove once those executions are closed.
+	registry.RegisterAction(shipments, registry.PublishKafkaEvent.OnQueue(contracts.ShipmentTaskQueue), activityHost.ACTIVITY_PublishKafkaEvent)
+
+	// Notifications queue: Kafka only
+	registry.RegisterAction(notifications, registry.PublishKafkaEvent, activityHost.ACTIVITY_PublishKafkaEvent)
+	registry.RegisterAction(notifications, registry.SendPickupReminder, pickupHost.ACTIVITY_SendPickupReminder)
 
 	// =========================================================================
-	// 5. START WORKER
+	// 5. START WORKERS
 	// =========================================================================
-	logger.Info("worker started, pollers running")
+	if err := notificationWorker.Start(); err != nil {
+		log.Fatalln("Unable to start notifications worker", err)
+	}
+	defer notificationWorker.Stop()
+	logger.Info("worker started, pollers running", "queues", []string{shipments.Queue, notifications.Queue})
 
-	err = w.Run(worker.InterruptCh())
+	err = shipmentWorker.Run(worker.InterruptCh())
 	if err != nil {
 		log.Fatalln("Unable to start worker", err)
 	}
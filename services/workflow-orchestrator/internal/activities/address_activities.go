@@ -0,0 +1,65 @@
+// workflow-orchestrator/internal/activities/address_activities.go
+package activities
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/address"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.opentelemetry.io/otel"
+	"go.temporal.io/sdk/temporal"
+)
+
+type AddressActivities struct {
+	Validator address.Validator // Interface! rules + Shippo in prod, fakes in tests
+}
+
+// Activity 0: Validate both addresses before anything is booked.
+// Valid addresses come back with the validator's corrections applied (normalised
+// casing, ZIP+4...). An invalid address fails the workflow straight away with a
+// non-retryable InvalidAddress error whose details carry the issues and suggestions;
+// retrying would not change the answer.
+func (a *AddressActivities) ACTIVITY_ValidateAddresses(ctx context.Context, shipment contracts.Shipment) (contracts.Shipment, error) {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_ValidateAddresses")
+	defer span.End()
+
+	var check contracts.ShipmentAddressValidation
+	var rejected []string
+
+	validate := func(side string, addr *contracts.Address) (*contracts.AddressValidation, error) {
+		if addr.IsZero() {
+			// Legacy city-only shipment: nothing to validate
+			return nil, nil
+		}
+		result, err := a.Validator.Validate(ctx, *addr)
+		if err != nil {
+			// Provider down or misconfigured: let Temporal retry
+			return nil, fmt.Errorf("failed to validate %s address: %w", side, err)
+		}
+		if !result.Valid {
+			rejected = append(rejected, side)
+		} else if result.Suggested != nil {
+			*addr = *result.Suggested
+		}
+		return &result, nil
+	}
+
+	var err error
+	if check.From, err = validate("from", &shipment.FromAddress); err != nil {
+		return contracts.Shipment{}, err
+	}
+	if check.To, err = validate("to", &shipment.ToAddress); err != nil {
+		return contracts.Shipment{}, err
+	}
+	if len(rejected) > 0 {
+		return contracts.Shipment{}, temporal.NewNonRetryableApplicationError(
+			"invalid "+strings.Join(rejected, " and ")+" address",
+			contracts.InvalidAddressErrorType,
+			nil,
+			check,
+		)
+	}
+	return shipment, nil
+}
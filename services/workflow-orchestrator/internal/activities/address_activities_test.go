@@ -0,0 +1,59 @@
+package activities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/address"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// countingValidator wraps a validator and counts calls.
+type countingValidator struct {
+	next  address.Validator
+	calls int
+}
+
+func (c *countingValidator) Validate(ctx context.Context, a contracts.Address) (contracts.AddressValidation, error) {
+	c.calls++
+	return c.next.Validate(ctx, a)
+}
+
+func TestValidateAddresses_SkipsCityOnlyShipments(t *testing.T) {
+	v := &countingValidator{next: address.NewRulesValidator()}
+	a := &AddressActivities{Validator: v}
+
+	_, err := a.ACTIVITY_ValidateAddresses(context.Background(), contracts.Shipment{Origin: "Dhaka", Destination: "Berlin"})
+
+	if err != nil || v.calls != 0 {
+		t.Fatalf("expected no validation for city-only shipment, got err=%v calls=%d", err, v.calls)
+	}
+}
+
+func TestValidateAddresses_AppliesCorrections(t *testing.T) {
+	a := &AddressActivities{Validator: address.NewRulesValidator()}
+	shipment := contracts.Shipment{
+		FromAddress: contracts.Address{Street1: "215 Clayton St", City: "San Francisco", State: "ca", Zip: "94117", Country: "usa"},
+	}
+
+	got, err := a.ACTIVITY_ValidateAddresses(context.Background(), shipment)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FromAddress.Country != "US" || got.FromAddress.State != "CA" {
+		t.Fatalf("expected normalised address, got %+v", got.FromAddress)
+	}
+}
+
+func TestValidateAddresses_RejectsInvalidAddress(t *testing.T) {
+	a := &AddressActivities{Validator: address.NewRulesValidator()}
+	shipment := contracts.Shipment{
+		FromAddress: contracts.Address{Street1: "215 Clayton St", City: "San Francisco", State: "CA", Zip: "94117", Country: "US"},
+		ToAddress:   contracts.Address{Street1: "Unter den Linden 1", City: "Berlin", Zip: "1011", Country: "DE"},
+	}
+
+	if _, err := a.ACTIVITY_ValidateAddresses(context.Background(), shipment); err == nil {
+		t.Fatal("expected an error for a German address with a 4-digit postcode")
+	}
+}
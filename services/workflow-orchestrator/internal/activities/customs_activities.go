@@ -0,0 +1,67 @@
+// workflow-orchestrator/internal/activities/customs_activities.go
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.opentelemetry.io/otel"
+	"go.temporal.io/sdk/temporal"
+)
+
+// CustomsBroker files customs declarations with the carrier.
+// It is an interface so the workflow can be tested without Shippo.
+type CustomsBroker interface {
+	// CreateDeclaration registers the shipment's customs data and returns the declaration ID
+	// to attach to the carrier shipment.
+	CreateDeclaration(ctx context.Context, shipment contracts.Shipment) (string, error)
+}
+
+// ErrCustomsDeclarationRejected means the carrier refused the declaration data itself.
+// Retrying with the same data cannot succeed.
+var ErrCustomsDeclarationRejected = errors.New("customs declaration rejected by carrier")
+
+type CustomsActivities struct {
+	Broker CustomsBroker
+}
+
+// Activity: Create the customs declaration for cross-border shipments.
+// Domestic shipments pass through untouched. Incomplete customs data is a
+// non-retryable MissingCustomsData error, so the workflow fails before any
+// carrier shipment exists.
+func (a *CustomsActivities) ACTIVITY_CreateCustomsDeclaration(ctx context.Context, shipment contracts.Shipment) (contracts.Shipment, error) {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_CreateCustomsDeclaration")
+	defer span.End()
+
+	if !shipment.IsInternational() && shipment.Customs == nil {
+		return shipment, nil
+	}
+	if err := shipment.ValidateCustoms(); err != nil {
+		return contracts.Shipment{}, temporal.NewNonRetryableApplicationError(err.Error(), contracts.MissingCustomsErrorType, err)
+	}
+	if shipment.Customs == nil {
+		return shipment, nil
+	}
+	if shipment.Customs.DeclarationID != "" {
+		// Already declared (activity retried after the declaration was created)
+		return shipment, nil
+	}
+
+	declarationID, err := a.Broker.CreateDeclaration(ctx, shipment)
+	if errors.Is(err, ErrCustomsDeclarationRejected) {
+		return contracts.Shipment{}, temporal.NewNonRetryableApplicationError(err.Error(), contracts.MissingCustomsErrorType, err)
+	}
+	if err != nil {
+		return contracts.Shipment{}, fmt.Errorf("failed to create customs declaration: %w", err)
+	}
+	if declarationID == "" {
+		return contracts.Shipment{}, errors.New("carrier returned no customs declaration ID")
+	}
+	// Copy before modifying: the input pointer is shared with the caller
+	customs := *shipment.Customs
+	customs.DeclarationID = declarationID
+	shipment.Customs = &customs
+	return shipment, nil
+}
@@ -0,0 +1,80 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+type fakeBroker struct {
+	id    string
+	err   error
+	calls int
+}
+
+func (f *fakeBroker) CreateDeclaration(ctx context.Context, shipment contracts.Shipment) (string, error) {
+	f.calls++
+	return f.id, f.err
+}
+
+func crossBorderShipment() contracts.Shipment {
+	return contracts.Shipment{
+		FromAddress: contracts.Address{Name: "Warehouse 3", Street1: "House 12, Road 5", City: "Dhaka", Zip: "1207", Country: "BD"},
+		ToAddress:   contracts.Address{Street1: "Unter den Linden 1", City: "Berlin", Zip: "10117", Country: "DE"},
+		Customs: &contracts.Customs{
+			ContentsType: contracts.ContentsMerchandise,
+			Items: []contracts.CustomsItem{{
+				Description: "Jute tote bag", HSCode: "420222", Quantity: 10,
+				ValueAmount: 80, ValueCurrency: "USD", OriginCountry: "BD", NetWeight: 1.5, MassUnit: "kg",
+			}},
+		},
+	}
+}
+
+func TestCreateCustomsDeclaration_SetsDeclarationID(t *testing.T) {
+	broker := &fakeBroker{id: "decl-1"}
+	a := &CustomsActivities{Broker: broker}
+	input := crossBorderShipment()
+
+	got, err := a.ACTIVITY_CreateCustomsDeclaration(context.Background(), input)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Customs.DeclarationID != "decl-1" || input.Customs.DeclarationID != "" {
+		t.Fatalf("expected declaration ID on the result only, got %q / %q", got.Customs.DeclarationID, input.Customs.DeclarationID)
+	}
+}
+
+func TestCreateCustomsDeclaration_DomesticSkipsBroker(t *testing.T) {
+	broker := &fakeBroker{id: "decl-1"}
+	s := crossBorderShipment()
+	s.ToAddress.Country = "BD"
+	s.Customs = nil
+
+	if _, err := (&CustomsActivities{Broker: broker}).ACTIVITY_CreateCustomsDeclaration(context.Background(), s); err != nil || broker.calls != 0 {
+		t.Fatalf("expected domestic shipment to pass through, got err=%v calls=%d", err, broker.calls)
+	}
+}
+
+func TestCreateCustomsDeclaration_MissingDataFailsBeforeBroker(t *testing.T) {
+	broker := &fakeBroker{id: "decl-1"}
+	s := crossBorderShipment()
+	s.Customs.Items[0].HSCode = ""
+
+	_, err := (&CustomsActivities{Broker: broker}).ACTIVITY_CreateCustomsDeclaration(context.Background(), s)
+
+	if err == nil || broker.calls != 0 {
+		t.Fatalf("expected failure without calling the broker, got err=%v calls=%d", err, broker.calls)
+	}
+}
+
+func TestCreateCustomsDeclaration_BrokerErrorIsReturned(t *testing.T) {
+	broker := &fakeBroker{err: errors.New("timeout")}
+
+	if _, err := (&CustomsActivities{Broker: broker}).ACTIVITY_CreateCustomsDeclaration(context.Background(), crossBorderShipment()); err == nil {
+		t.Fatal("expected broker error to be returned for retry")
+	}
+}
@@ -0,0 +1,105 @@
+// workflow-orchestrator/internal/activities/pickup_activities.go
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.opentelemetry.io/otel"
+	"go.temporal.io/sdk/temporal"
+)
+
+// pickupCarrierErrorType marks carrier errors that retrying cannot fix.
+const pickupCarrierErrorType = "PickupCarrierRejected"
+
+// PickupCarrier books and cancels pickups with a carrier.
+// It is an interface so workflows and activities can be tested with a fake carrier.
+type PickupCarrier interface {
+	// BookPickup asks the carrier to collect the pickup.
+	BookPickup(ctx context.Context, pickup contracts.Pickup) (PickupBooking, error)
+	// CancelPickup releases a previously booked pickup (pickup.CarrierPickupID).
+	CancelPickup(ctx context.Context, pickup contracts.Pickup) error
+}
+
+// PickupBooking is what the carrier returns for a booked pickup.
+type PickupBooking struct {
+	ConfirmationNumber string // shown to the warehouse
+	CarrierPickupID    string // the carrier's ID of the booking, to cancel it later
+}
+
+type PickupActivities struct {
+	Carrier PickupCarrier
+	Store   interface {
+		UpdatePickup(context.Context, contracts.Pickup) error
+	} // Interface!
+	Producer interface {
+		Publish(context.Context, string, interface{}) error
+	} // Interface!
+}
+
+// Activity 1: Book the pickup with the carrier
+func (a *PickupActivities) ACTIVITY_BookCarrierPickup(ctx context.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_BookCarrierPickup")
+	defer span.End()
+	if pickup.ID == "" || len(pickup.ShipmentIDs) == 0 {
+		return contracts.Pickup{}, errors.New("missing required fields")
+	}
+	booking, err := a.Carrier.BookPickup(ctx, pickup)
+	if errors.Is(err, ErrNoPurchasedLabel) {
+		return contracts.Pickup{}, temporal.NewNonRetryableApplicationError(err.Error(), pickupCarrierErrorType, err)
+	}
+	if err != nil {
+		return contracts.Pickup{}, fmt.Errorf("failed to book pickup: %w", err)
+	}
+	if booking.ConfirmationNumber == "" {
+		return contracts.Pickup{}, errors.New("carrier returned no confirmation number")
+	}
+	pickup.ConfirmationNumber = booking.ConfirmationNumber
+	pickup.CarrierPickupID = booking.CarrierPickupID
+	pickup.Status = contracts.PickupScheduled
+	return pickup, nil
+}
+
+// Activity 2: Release the booking with the carrier (cancel or before a reschedule)
+func (a *PickupActivities) ACTIVITY_CancelCarrierPickup(ctx context.Context, pickup contracts.Pickup) error {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_CancelCarrierPickup")
+	defer span.End()
+	if pickup.ConfirmationNumber == "" {
+		// Never booked, nothing to release
+		return nil
+	}
+	err := a.Carrier.CancelPickup(ctx, pickup)
+	if errors.Is(err, ErrNoCarrierPickupID) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), pickupCarrierErrorType, err)
+	}
+	return err
+}
+
+// Activity 3: Persist the latest pickup state
+func (a *PickupActivities) ACTIVITY_SavePickup(ctx context.Context, pickup contracts.Pickup) error {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_SavePickup")
+	defer span.End()
+	return a.Store.UpdatePickup(ctx, pickup)
+}
+
+// Activity 4: Remind the warehouse via the communications service.
+// We publish a "pickup.reminder" event; the communications bridge turns it into email/SMS jobs.
+func (a *PickupActivities) ACTIVITY_SendPickupReminder(ctx context.Context, pickup contracts.Pickup) error {
+	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_SendPickupReminder")
+	defer span.End()
+	if a.Producer == nil {
+		return errors.New("no event producer configured for pickup reminders")
+	}
+	// The key doubles as the event ID and so the notification idempotency
+	// key: a rescheduled window gets its own reminder but activity retries do not.
+	key := fmt.Sprintf("pickup-reminder-%s-%d", pickup.ID, pickup.Window.Start.Unix())
+	event := map[string]interface{}{
+		"event_id":  key,
+		"event":     "pickup.reminder",
+		"tenant_id": pickup.TenantID,
+		"payload":   pickup,
+	}
+	return a.Producer.Publish(ctx, key, event)
+}
@@ -0,0 +1,95 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// fakeCarrier replaces the Shippo adapter in tests.
+type fakeCarrier struct {
+	confirmation string
+	bookErr      error
+	cancelled    []string
+}
+
+func (f *fakeCarrier) BookPickup(ctx context.Context, pickup contracts.Pickup) (PickupBooking, error) {
+	return PickupBooking{ConfirmationNumber: f.confirmation, CarrierPickupID: "shippo-" + f.confirmation}, f.bookErr
+}
+
+func (f *fakeCarrier) CancelPickup(ctx context.Context, pickup contracts.Pickup) error {
+	f.cancelled = append(f.cancelled, pickup.ConfirmationNumber)
+	return nil
+}
+
+type fakePublisher struct {
+	keys []string
+}
+
+func (f *fakePublisher) Publish(ctx context.Context, key string, value interface{}) error {
+	f.keys = append(f.keys, key)
+	return nil
+}
+
+func testPickup() contracts.Pickup {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	return contracts.Pickup{
+		ID:          "p-1",
+		Carrier:     "UPS",
+		Window:      contracts.PickupWindow{Start: start, End: start.Add(3 * time.Hour)},
+		ShipmentIDs: []string{"s-1"},
+		Status:      contracts.PickupRequested,
+	}
+}
+
+func TestBookCarrierPickup_SetsConfirmation(t *testing.T) {
+	a := &PickupActivities{Carrier: &fakeCarrier{confirmation: "CONF-42"}}
+
+	got, err := a.ACTIVITY_BookCarrierPickup(context.Background(), testPickup())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ConfirmationNumber != "CONF-42" || got.CarrierPickupID != "shippo-CONF-42" || got.Status != contracts.PickupScheduled {
+		t.Fatalf("expected scheduled pickup with CONF-42, got %+v", got)
+	}
+}
+
+func TestBookCarrierPickup_CarrierError(t *testing.T) {
+	a := &PickupActivities{Carrier: &fakeCarrier{bookErr: errors.New("carrier down")}}
+
+	if _, err := a.ACTIVITY_BookCarrierPickup(context.Background(), testPickup()); err == nil {
+		t.Fatal("expected carrier error to be returned")
+	}
+}
+
+func TestCancelCarrierPickup_SkipsUnbooked(t *testing.T) {
+	carrier := &fakeCarrier{}
+	a := &PickupActivities{Carrier: carrier}
+
+	if err := a.ACTIVITY_CancelCarrierPickup(context.Background(), testPickup()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(carrier.cancelled) != 0 {
+		t.Fatalf("expected no carrier call for an unbooked pickup")
+	}
+}
+
+func TestSendPickupReminder_KeyChangesWithWindow(t *testing.T) {
+	pub := &fakePublisher{}
+	a := &PickupActivities{Producer: pub}
+	p := testPickup()
+
+	if err := a.ACTIVITY_SendPickupReminder(context.Background(), p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Window.Start = p.Window.Start.Add(24 * time.Hour)
+	if err := a.ACTIVITY_SendPickupReminder(context.Background(), p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.keys) != 2 || pub.keys[0] == pub.keys[1] {
+		t.Fatalf("expected distinct reminder keys per window, got %v", pub.keys)
+	}
+}
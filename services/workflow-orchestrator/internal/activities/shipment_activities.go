@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
@@ -35,6 +36,13 @@ type ShipmentActivities struct {
 	Client    *http.Client
 }
 
+// shippoCarrierAccounts maps our carrier names to Shippo carrier account tokens.
+var shippoCarrierAccounts = map[string]string{
+	"FedEx": "fedex",
+	"UPS":   "ups",
+	"DHL":   "dhl_express",
+}
+
 var shippoCircuit = struct {
 	mu               sync.Mutex
 	consecutiveFails int
@@ -61,14 +69,8 @@ func (a *ShipmentActivities) ACTIVITY_CallShippoAPI(ctx context.Context, shipmen
 
 	}
 	shippoReq := map[string]interface{}{
-		"address_from": map[string]string{
-			"city":    shipment.Origin, // e.g., "Dhaka"
-			"country": "US",            // Adjust based on origin
-		},
-		"address_to": map[string]string{
-			"city":    shipment.Destination, // e.g., "Berlin"
-			"country": "BD",                 // Adjust for destination
-		},
+		"address_from": shippoAddress(shipment.FromAddress, shipment.Origin, "US"),    // e.g., "Dhaka"
+		"address_to":   shippoAddress(shipment.ToAddress, shipment.Destination, "BD"), // e.g., "Berlin"
 		// Dynamic dimensions from mutation
 		// Why: Uses client input for accurate shipping, like Amazon
 		"parcels": []map[string]interface{}{
@@ -84,13 +86,12 @@ func (a *ShipmentActivities) ACTIVITY_CallShippoAPI(ctx context.Context, shipmen
 		// Why: Optimizes cost if client doesn’t specify
 		"carrier_account": "",
 	}
+	if shipment.Customs != nil && shipment.Customs.DeclarationID != "" {
+		// Created by ACTIVITY_CreateCustomsDeclaration for cross-border shipments
+		shippoReq["customs_declaration"] = shipment.Customs.DeclarationID
+	}
 	if shipment.Carrier.Name != "" {
-		carrierMap := map[string]string{
-			"FedEx": "fedex",
-			"UPS":   "ups",
-			"DHL":   "dhl_express",
-		}
-		if carrierID, ok := carrierMap[shipment.Carrier.Name]; ok {
+		if carrierID, ok := shippoCarrierAccounts[shipment.Carrier.Name]; ok {
 			shippoReq["carrier_account"] = carrierID
 		}
 
@@ -176,6 +177,26 @@ func (a *ShipmentActivities) ACTIVITY_CallShippoAPI(ctx context.Context, shipmen
 	return shipment, nil
 }
 
+// shippoAddress builds a Shippo address object. Shipments with a full (validated)
+// address send all of it; legacy city-only shipments fall back to city + default country.
+func shippoAddress(a contracts.Address, city, defaultCountry string) map[string]string {
+	if a.IsZero() {
+		return map[string]string{"city": city, "country": defaultCountry}
+	}
+	return map[string]string{
+		"name":    a.Name,
+		"company": a.Company,
+		"street1": a.Street1,
+		"street2": a.Street2,
+		"city":    a.City,
+		"state":   a.State,
+		"zip":     a.Zip,
+		"country": a.Country,
+		"phone":   a.Phone,
+		"email":   a.Email,
+	}
+}
+
 // mapShippoStatusToProto maps Shippo status strings to the proto ShipmentStatus enum
 func mapShippoStatusToProto(s string) proto.ShipmentStatus {
 	switch s {
@@ -198,11 +219,7 @@ func mapShippoStatusToProto(s string) proto.ShipmentStatus {
 func (a *ShipmentActivities) ACTIVITY_SaveShipmentToDB(ctx context.Context, shipment contracts.Shipment) (contracts.Shipment, error) {
 	ctx, span := otel.Tracer("workflow-orchestrator").Start(ctx, "ACTIVITY_SaveShipmentToDB")
 	defer span.End()
-	event := map[string]interface{}{
-		"event":   "shipment.created",
-		"payload": shipment,
-	}
-	eventPayload, err := json.Marshal(event)
+	eventPayload, err := json.Marshal(contracts.NewShipmentEvent("shipment.created", shipment))
 	if err != nil {
 		return contracts.Shipment{}, errors.New("failed to marshal outbox event: " + err.Error())
 	}
@@ -220,8 +237,31 @@ func (a *ShipmentActivities) ACTIVITY_PublishKafkaEvent(ctx context.Context, shi
 	if len(payload) == 0 {
 		return nil
 	}
+	payload, err = withEventID(payload, eventID)
+	if err != nil {
+		return err
+	}
 	if err := a.Producer.Publish(ctx, shipment.ID, json.RawMessage(payload)); err != nil {
 		return err
 	}
 	return a.Store.MarkOutboxEventPublished(ctx, eventID)
 }
+
+// withEventID stamps the outbox row's ID on the event as event_id, so
+// consumers can tell a shipment's events apart (they share the Kafka key) and
+// recognise a republished one.
+func withEventID(payload []byte, eventID string) ([]byte, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("outbox event %s: %w", eventID, err)
+	}
+	if _, ok := envelope["event_id"]; ok || eventID == "" {
+		return payload, nil
+	}
+	id, err := json.Marshal(eventID)
+	if err != nil {
+		return nil, err
+	}
+	envelope["event_id"] = id
+	return json.Marshal(envelope)
+}
@@ -0,0 +1,58 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+type fakeOutboxStore struct {
+	payload []byte
+}
+
+func (f *fakeOutboxStore) CreateShipment(_ context.Context, s contracts.Shipment) (contracts.Shipment, error) {
+	return s, nil
+}
+
+func (f *fakeOutboxStore) CreateShipmentWithOutbox(_ context.Context, s contracts.Shipment, _ string, payload []byte) (contracts.Shipment, error) {
+	f.payload = payload
+	return s, nil
+}
+
+func (f *fakeOutboxStore) PopPendingOutboxEvent(context.Context, string) (string, []byte, error) {
+	return "evt-1", f.payload, nil
+}
+
+func (f *fakeOutboxStore) MarkOutboxEventPublished(context.Context, string) error { return nil }
+
+func TestSaveShipmentToDB_EventCarriesTenant(t *testing.T) {
+	store := &fakeOutboxStore{}
+	a := &ShipmentActivities{Store: store}
+
+	if _, err := a.ACTIVITY_SaveShipmentToDB(context.Background(), contracts.Shipment{ID: "shp-1", TenantID: "t-1", Origin: "Dhaka"}); err != nil {
+		t.Fatal(err)
+	}
+	var event map[string]json.RawMessage
+	if err := json.Unmarshal(store.payload, &event); err != nil {
+		t.Fatal(err)
+	}
+	if string(event["event"]) != `"shipment.created"` || string(event["tenant_id"]) != `"t-1"` {
+		t.Errorf("event = %s", store.payload)
+	}
+}
+
+func TestPublishedEventCarriesOutboxID(t *testing.T) {
+	got, err := withEventID([]byte(`{"event":"shipment.created","tenant_id":"t-1","payload":{"ID":"s-1"}}`), "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event contracts.ShipmentEvent
+	if err := json.Unmarshal(got, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.EventID != "42" || event.Event != "shipment.created" || event.TenantID != "t-1" || event.Payload.ID != "s-1" {
+		t.Errorf("event = %+v", event)
+	}
+}
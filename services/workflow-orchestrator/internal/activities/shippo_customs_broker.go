@@ -0,0 +1,89 @@
+// workflow-orchestrator/internal/activities/shippo_customs_broker.go
+package activities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// ShippoCustomsBroker creates declarations through Shippo's /customs/declarations endpoint.
+type ShippoCustomsBroker struct {
+	ShippoKey string
+	Client    *http.Client
+}
+
+// CreateDeclaration implements CustomsBroker.
+func (b *ShippoCustomsBroker) CreateDeclaration(ctx context.Context, shipment contracts.Shipment) (string, error) {
+	c := shipment.Customs
+	items := make([]map[string]interface{}, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = map[string]interface{}{
+			"description":    item.Description,
+			"tariff_number":  item.HSCode,
+			"quantity":       item.Quantity,
+			"value_amount":   strconv.FormatFloat(item.ValueAmount, 'f', 2, 64),
+			"value_currency": item.ValueCurrency,
+			"origin_country": item.OriginCountry,
+			"net_weight":     strconv.FormatFloat(item.NetWeight, 'f', 3, 64),
+			"mass_unit":      item.MassUnit,
+		}
+	}
+	incoterm := c.Incoterm
+	if incoterm == "" {
+		incoterm = contracts.IncotermDDU
+	}
+	shippoReq := map[string]interface{}{
+		"contents_type":        c.ContentsType,
+		"contents_explanation": c.ContentsExplanation,
+		"incoterm":             incoterm,
+		"non_delivery_option":  "RETURN",
+		"certify":              true,
+		"certify_signer":       shipment.FromAddress.Name, // the shipper signs the declaration
+		"items":                items,
+	}
+	if c.EELPFC != "" {
+		shippoReq["eel_pfc"] = c.EELPFC
+	}
+	if c.AESITN != "" {
+		shippoReq["aes_itn"] = c.AESITN
+	}
+
+	reqBody, err := json.Marshal(shippoReq)
+	if err != nil {
+		return "", errors.New("failed to marshal Shippo customs request: " + err.Error())
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.goshippo.com/customs/declarations/", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", errors.New("failed to create Shippo customs request: " + err.Error())
+	}
+	req.Header.Set("Authorization", "ShippoToken "+b.ShippoKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return "", errors.New("failed to call Shippo customs API: " + err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", errors.New("Shippo customs API error: status " + resp.Status)
+	}
+
+	var shippoResp struct {
+		ObjectID    string `json:"object_id"`
+		ObjectState string `json:"object_state"` // "VALID" or "INVALID"
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&shippoResp); err != nil {
+		return "", errors.New("failed to parse Shippo customs response: " + err.Error())
+	}
+	if shippoResp.ObjectState == "INVALID" {
+		return "", fmt.Errorf("%w: Shippo declaration %s", ErrCustomsDeclarationRejected, shippoResp.ObjectID)
+	}
+	return shippoResp.ObjectID, nil
+}
@@ -0,0 +1,173 @@
+// workflow-orchestrator/internal/activities/shippo_pickup_carrier.go
+package activities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+const shippoAPI = "https://api.goshippo.com"
+
+// Carrier errors that retrying cannot fix.
+var (
+	ErrNoPurchasedLabel  = errors.New("no purchased label to collect")
+	ErrNoCarrierPickupID = errors.New("no carrier pickup ID to cancel")
+)
+
+// ShippoPickupCarrier books pickups through Shippo's /pickups endpoint.
+type ShippoPickupCarrier struct {
+	ShippoKey string
+	Client    *http.Client
+}
+
+// BookPickup implements PickupCarrier.
+func (c *ShippoPickupCarrier) BookPickup(ctx context.Context, pickup contracts.Pickup) (PickupBooking, error) {
+	carrierAccount, ok := shippoCarrierAccounts[pickup.Carrier]
+	if !ok {
+		return PickupBooking{}, errors.New("unsupported pickup carrier: " + pickup.Carrier)
+	}
+	// Shippo collects labels, not shipments: it wants the label transactions
+	transactions, err := c.labelTransactions(ctx, pickup.ShipmentIDs)
+	if err != nil {
+		return PickupBooking{}, err
+	}
+	a := pickup.Address
+	shippoReq := map[string]interface{}{
+		"carrier_account": carrierAccount,
+		"location": map[string]interface{}{
+			"building_location_type": "Knock on Door",
+			"instructions":           pickup.Instructions,
+			"address": map[string]string{
+				"name":    a.Name,
+				"company": a.Company,
+				"street1": a.Street1,
+				"street2": a.Street2,
+				"city":    a.City,
+				"state":   a.State,
+				"zip":     a.Zip,
+				"country": a.Country,
+				"phone":   a.Phone,
+				"email":   a.Email,
+			},
+		},
+		"transactions":         transactions,
+		"requested_start_time": pickup.Window.Start.UTC().Format(time.RFC3339),
+		"requested_end_time":   pickup.Window.End.UTC().Format(time.RFC3339),
+	}
+
+	var shippoResp struct {
+		ObjectID         string `json:"object_id"`
+		ConfirmationCode string `json:"confirmation_code"`
+		Status           string `json:"status"` // e.g. "CONFIRMED", "PENDING", "ERROR"
+	}
+	if err := c.call(ctx, http.MethodPost, "/pickups/", shippoReq, &shippoResp); err != nil {
+		return PickupBooking{}, errors.New("Shippo pickup API: " + err.Error())
+	}
+	if shippoResp.Status == "ERROR" {
+		return PickupBooking{}, errors.New("Shippo rejected pickup " + shippoResp.ObjectID)
+	}
+	return PickupBooking{ConfirmationNumber: shippoResp.ConfirmationCode, CarrierPickupID: shippoResp.ObjectID}, nil
+}
+
+// CancelPickup implements PickupCarrier.
+func (c *ShippoPickupCarrier) CancelPickup(ctx context.Context, pickup contracts.Pickup) error {
+	if pickup.CarrierPickupID == "" {
+		// Booked before the Shippo ID was stored; there is nothing to address the cancellation to
+		return fmt.Errorf("pickup %s: %w", pickup.ID, ErrNoCarrierPickupID)
+	}
+	var shippoResp struct {
+		Status string `json:"status"` // "CANCELLED" once the carrier released the slot
+	}
+	if err := c.call(ctx, http.MethodPost, "/pickups/"+url.PathEscape(pickup.CarrierPickupID)+"/cancel", nil, &shippoResp); err != nil {
+		return errors.New("Shippo pickup cancel API: " + err.Error())
+	}
+	if shippoResp.Status == "ERROR" {
+		return errors.New("Shippo refused to cancel pickup " + pickup.CarrierPickupID)
+	}
+	return nil
+}
+
+// labelTransactions maps our shipment IDs (Shippo shipment object IDs) to the
+// transactions of the labels bought for them. A shipment without a purchased
+// label cannot be collected, so it fails the booking.
+func (c *ShippoPickupCarrier) labelTransactions(ctx context.Context, shipmentIDs []string) ([]string, error) {
+	transactions := make([]string, 0, len(shipmentIDs))
+	for _, shipmentID := range shipmentIDs {
+		var shipment struct {
+			Rates []struct {
+				ObjectID string `json:"object_id"`
+			} `json:"rates"`
+		}
+		if err := c.call(ctx, http.MethodGet, "/shipments/"+url.PathEscape(shipmentID), nil, &shipment); err != nil {
+			return nil, errors.New("Shippo shipment " + shipmentID + ": " + err.Error())
+		}
+		transactionID := ""
+		for _, rate := range shipment.Rates {
+			var labels struct {
+				Results []struct {
+					ObjectID     string `json:"object_id"`
+					ObjectStatus string `json:"object_status"`
+				} `json:"results"`
+			}
+			query := url.Values{"rate": {rate.ObjectID}, "object_status": {"SUCCESS"}}
+			if err := c.call(ctx, http.MethodGet, "/transactions/?"+query.Encode(), nil, &labels); err != nil {
+				return nil, errors.New("Shippo transactions of shipment " + shipmentID + ": " + err.Error())
+			}
+			for _, label := range labels.Results {
+				if label.ObjectStatus == "SUCCESS" {
+					transactionID = label.ObjectID
+					break
+				}
+			}
+			if transactionID != "" {
+				break
+			}
+		}
+		if transactionID == "" {
+			return nil, fmt.Errorf("shipment %s: %w", shipmentID, ErrNoPurchasedLabel)
+		}
+		transactions = append(transactions, transactionID)
+	}
+	return transactions, nil
+}
+
+// call sends a request to the Shippo API and decodes the JSON response into out.
+func (c *ShippoPickupCarrier) call(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return errors.New("failed to marshal request: " + err.Error())
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, shippoAPI+path, reqBody)
+	if err != nil {
+		return errors.New("failed to create request: " + err.Error())
+	}
+	req.Header.Set("Authorization", "ShippoToken "+c.ShippoKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return errors.New("request failed: " + err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return errors.New("status " + resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.New("failed to parse response: " + err.Error())
+	}
+	return nil
+}
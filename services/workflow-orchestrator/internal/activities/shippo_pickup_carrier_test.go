@@ -0,0 +1,118 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// shippoStub answers Shippo API calls from canned JSON, keyed by method and path.
+type shippoStub struct {
+	responses map[string]string
+	requests  map[string]string // request bodies, same keys
+}
+
+func (s *shippoStub) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.RequestURI()
+	s.requests[key] = ""
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		s.requests[key] = string(body)
+	}
+	body, ok := s.responses[key]
+	status := http.StatusOK
+	if !ok {
+		body, status = `{"detail":"Not found."}`, http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+	}, nil
+}
+
+func newShippoStub(responses map[string]string) (*ShippoPickupCarrier, *shippoStub) {
+	stub := &shippoStub{responses: responses, requests: map[string]string{}}
+	return &ShippoPickupCarrier{ShippoKey: "test", Client: &http.Client{Transport: stub}}, stub
+}
+
+func shippoPickup() contracts.Pickup {
+	p := testPickup()
+	p.ShipmentIDs = []string{"shp-1", "shp-2"}
+	return p
+}
+
+func TestShippoBookPickup_SendsLabelTransactions(t *testing.T) {
+	carrier, stub := newShippoStub(map[string]string{
+		"GET /shipments/shp-1": `{"rates":[{"object_id":"rate-1a"},{"object_id":"rate-1b"}]}`,
+		"GET /shipments/shp-2": `{"rates":[{"object_id":"rate-2a"}]}`,
+		"GET /transactions/?object_status=SUCCESS&rate=rate-1a": `{"results":[]}`,
+		"GET /transactions/?object_status=SUCCESS&rate=rate-1b": `{"results":[{"object_id":"txn-1","object_status":"SUCCESS"}]}`,
+		"GET /transactions/?object_status=SUCCESS&rate=rate-2a": `{"results":[{"object_id":"txn-2","object_status":"SUCCESS"}]}`,
+		"POST /pickups/": `{"object_id":"pk-9","confirmation_code":"CONF-9","status":"CONFIRMED"}`,
+	})
+
+	booking, err := carrier.BookPickup(context.Background(), shippoPickup())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if booking.ConfirmationNumber != "CONF-9" || booking.CarrierPickupID != "pk-9" {
+		t.Fatalf("unexpected booking %+v", booking)
+	}
+	var sent struct {
+		Transactions []string `json:"transactions"`
+		Start        string   `json:"requested_start_time"`
+	}
+	if err := json.Unmarshal([]byte(stub.requests["POST /pickups/"]), &sent); err != nil {
+		t.Fatalf("pickup request: %v", err)
+	}
+	if strings.Join(sent.Transactions, ",") != "txn-1,txn-2" {
+		t.Fatalf("expected label transactions txn-1,txn-2, got %v", sent.Transactions)
+	}
+	if sent.Start != testPickup().Window.Start.Format(time.RFC3339) {
+		t.Fatalf("unexpected start %q", sent.Start)
+	}
+}
+
+func TestShippoBookPickup_ShipmentWithoutLabel(t *testing.T) {
+	carrier, stub := newShippoStub(map[string]string{
+		"GET /shipments/shp-1": `{"rates":[{"object_id":"rate-1a"}]}`,
+		"GET /transactions/?object_status=SUCCESS&rate=rate-1a": `{"results":[]}`,
+	})
+
+	_, err := carrier.BookPickup(context.Background(), shippoPickup())
+	if !errors.Is(err, ErrNoPurchasedLabel) {
+		t.Fatalf("expected ErrNoPurchasedLabel, got %v", err)
+	}
+	if _, ok := stub.requests["POST /pickups/"]; ok {
+		t.Fatal("pickup must not be booked without labels")
+	}
+}
+
+func TestShippoCancelPickup_CallsCarrier(t *testing.T) {
+	carrier, stub := newShippoStub(map[string]string{
+		"POST /pickups/pk-9/cancel": `{"object_id":"pk-9","status":"CANCELLED"}`,
+	})
+	p := shippoPickup()
+	p.ConfirmationNumber, p.CarrierPickupID = "CONF-9", "pk-9"
+
+	if err := carrier.CancelPickup(context.Background(), p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := stub.requests["POST /pickups/pk-9/cancel"]; !ok {
+		t.Fatal("expected the Shippo cancel endpoint to be called")
+	}
+
+	p.CarrierPickupID = ""
+	if err := carrier.CancelPickup(context.Background(), p); !errors.Is(err, ErrNoCarrierPickupID) {
+		t.Fatalf("expected ErrNoCarrierPickupID, got %v", err)
+	}
+}
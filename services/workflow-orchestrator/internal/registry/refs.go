@@ -0,0 +1,32 @@
+// workflow-orchestrator/internal/registry/refs.go
+
+package registry
+
+import "github.com/Tanmoy095/LogiSynapse/shared/contracts"
+
+// --- Shipments queue: carrier calls and DB writes ---
+
+var (
+	CreateShipment = WorkflowRef[contracts.Shipment, contracts.Shipment]{Name: contracts.CreateShipmentWorkflowName, TaskQueue: contracts.ShipmentTaskQueue}
+	SchedulePickup = WorkflowRef[contracts.Pickup, contracts.Pickup]{Name: contracts.SchedulePickupWorkflowName, TaskQueue: contracts.ShipmentTaskQueue}
+
+	ValidateAddresses        = ActivityRef[contracts.Shipment, contracts.Shipment]{Name: "ACTIVITY_ValidateAddresses", TaskQueue: contracts.ShipmentTaskQueue}
+	CreateCustomsDeclaration = ActivityRef[contracts.Shipment, contracts.Shipment]{Name: "ACTIVITY_CreateCustomsDeclaration", TaskQueue: contracts.ShipmentTaskQueue}
+	CallShippoAPI            = ActivityRef[contracts.Shipment, contracts.Shipment]{Name: "ACTIVITY_CallShippoAPI", TaskQueue: contracts.ShipmentTaskQueue}
+	SaveShipmentToDB         = ActivityRef[contracts.Shipment, contracts.Shipment]{Name: "ACTIVITY_SaveShipmentToDB", TaskQueue: contracts.ShipmentTaskQueue}
+	BookCarrierPickup        = ActivityRef[contracts.Pickup, contracts.Pickup]{Name: "ACTIVITY_BookCarrierPickup", TaskQueue: contracts.ShipmentTaskQueue}
+	CancelCarrierPickup      = ActionRef[contracts.Pickup]{Name: "ACTIVITY_CancelCarrierPickup", TaskQueue: contracts.ShipmentTaskQueue}
+	SavePickup               = ActionRef[contracts.Pickup]{Name: "ACTIVITY_SavePickup", TaskQueue: contracts.ShipmentTaskQueue}
+)
+
+// --- Notifications queue: anything that only talks to Kafka ---
+// Kept apart so a Kafka outage backs up this queue without starving carrier calls.
+
+var (
+	PublishKafkaEvent  = ActionRef[contracts.Shipment]{Name: "ACTIVITY_PublishKafkaEvent", TaskQueue: contracts.NotificationsTaskQueue}
+	SendPickupReminder = ActionRef[contracts.Pickup]{Name: "ACTIVITY_SendPickupReminder", TaskQueue: contracts.NotificationsTaskQueue}
+)
+
+// --- Billing queue ---
+// MonthlyBillingWorkflow and TenantBillingWorkflow are hosted by billing-service
+// on contracts.BillingTaskQueue; their inputs live in billing-service/internal/billingrun.
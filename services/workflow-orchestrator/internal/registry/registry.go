@@ -0,0 +1,101 @@
+// workflow-orchestrator/internal/registry/registry.go
+
+// Package registry declares every workflow and activity the orchestrator knows about,
+// together with the task queue it runs on.
+//
+// Before this, activity names were string literals ("ACTIVITY_CallShippoAPI") repeated in
+// the workflow, the worker registration and the tests, and a typo only showed up as a
+// workflow stuck on "activity not registered". Refs are typed, so passing the wrong input
+// type or registering a function with the wrong signature is a compile error.
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ActivityRef names an activity that takes I and returns (O, error).
+type ActivityRef[I, O any] struct {
+	Name      string
+	TaskQueue string
+}
+
+// Execute schedules the activity on its task queue and waits for the result.
+func (r ActivityRef[I, O]) Execute(ctx workflow.Context, in I) (O, error) {
+	var out O
+	err := workflow.ExecuteActivity(withQueue(ctx, r.TaskQueue), r.Name, in).Get(ctx, &out)
+	return out, err
+}
+
+// OnQueue returns the same activity bound to another task queue.
+// Used to keep serving histories recorded before an activity moved queues.
+func (r ActivityRef[I, O]) OnQueue(queue string) ActivityRef[I, O] {
+	r.TaskQueue = queue
+	return r
+}
+
+// ActionRef names an activity that takes I and only returns an error.
+type ActionRef[I any] struct {
+	Name      string
+	TaskQueue string
+}
+
+// Execute schedules the activity on its task queue and waits for it to finish.
+func (r ActionRef[I]) Execute(ctx workflow.Context, in I) error {
+	return workflow.ExecuteActivity(withQueue(ctx, r.TaskQueue), r.Name, in).Get(ctx, nil)
+}
+
+// OnQueue returns the same activity bound to another task queue.
+func (r ActionRef[I]) OnQueue(queue string) ActionRef[I] {
+	r.TaskQueue = queue
+	return r
+}
+
+// WorkflowRef names a workflow that takes I and returns (O, error).
+type WorkflowRef[I, O any] struct {
+	Name      string
+	TaskQueue string
+}
+
+// withQueue routes the call to a specific task queue. Empty means "same queue as the workflow".
+func withQueue(ctx workflow.Context, queue string) workflow.Context {
+	if queue == "" {
+		return ctx
+	}
+	return workflow.WithTaskQueue(ctx, queue)
+}
+
+// Host is a worker bound to one task queue. Registering a ref that belongs
+// to another queue panics at boot, instead of leaving tasks unpolled in production.
+type Host struct {
+	Queue  string
+	Worker worker.Registry
+}
+
+func (h Host) check(name string, queue string) {
+	if queue != h.Queue {
+		panic(fmt.Sprintf("registry: %s belongs to task queue %q, not %q", name, queue, h.Queue))
+	}
+}
+
+// RegisterWorkflow registers fn under the ref's name.
+func RegisterWorkflow[I, O any](h Host, ref WorkflowRef[I, O], fn func(workflow.Context, I) (O, error)) {
+	h.check(ref.Name, ref.TaskQueue)
+	h.Worker.RegisterWorkflowWithOptions(fn, workflow.RegisterOptions{Name: ref.Name})
+}
+
+// RegisterActivity registers fn under the ref's name.
+func RegisterActivity[I, O any](h Host, ref ActivityRef[I, O], fn func(context.Context, I) (O, error)) {
+	h.check(ref.Name, ref.TaskQueue)
+	h.Worker.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: ref.Name})
+}
+
+// RegisterAction registers fn under the ref's name.
+func RegisterAction[I any](h Host, ref ActionRef[I], fn func(context.Context, I) error) {
+	h.check(ref.Name, ref.TaskQueue)
+	h.Worker.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: ref.Name})
+}
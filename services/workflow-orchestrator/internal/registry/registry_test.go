@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/workflow"
+)
+
+// fakeWorker records what was registered under which name.
+type fakeWorker struct {
+	names []string
+}
+
+func (f *fakeWorker) RegisterWorkflow(w interface{}) {}
+func (f *fakeWorker) RegisterWorkflowWithOptions(w interface{}, options workflow.RegisterOptions) {
+	f.names = append(f.names, options.Name)
+}
+func (f *fakeWorker) RegisterActivity(a interface{}) {}
+func (f *fakeWorker) RegisterActivityWithOptions(a interface{}, options activity.RegisterOptions) {
+	f.names = append(f.names, options.Name)
+}
+
+func publish(ctx context.Context, s contracts.Shipment) error { return nil }
+
+func TestRegisterAction_UsesRefName(t *testing.T) {
+	w := &fakeWorker{}
+	RegisterAction(Host{Queue: contracts.NotificationsTaskQueue, Worker: w}, PublishKafkaEvent, publish)
+
+	if len(w.names) != 1 || w.names[0] != "ACTIVITY_PublishKafkaEvent" {
+		t.Fatalf("expected activity registered under its ref name, got %v", w.names)
+	}
+}
+
+func TestRegisterAction_WrongQueuePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected registering on the wrong task queue to panic")
+		}
+	}()
+	RegisterAction(Host{Queue: contracts.ShipmentTaskQueue, Worker: &fakeWorker{}}, PublishKafkaEvent, publish)
+}
+
+func TestOnQueue_LeavesOriginalRefAlone(t *testing.T) {
+	legacy := PublishKafkaEvent.OnQueue(contracts.ShipmentTaskQueue)
+
+	if legacy.TaskQueue != contracts.ShipmentTaskQueue || PublishKafkaEvent.TaskQueue != contracts.NotificationsTaskQueue {
+		t.Fatalf("OnQueue must return a copy, got %q / %q", legacy.TaskQueue, PublishKafkaEvent.TaskQueue)
+	}
+}
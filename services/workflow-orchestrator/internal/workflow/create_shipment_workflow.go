@@ -5,6 +5,7 @@ package workflow
 import (
 	"time"
 
+	"github.com/Tanmoy095/LogiSynapse/services/workflow-orchestrator/internal/registry"
 	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
@@ -30,33 +31,57 @@ func CreateShipmentWorkflow(ctx workflow.Context, shipment contracts.Shipment) (
 
 	ctx = workflow.WithActivityOptions(ctx, options)
 
+	// Patch point: histories started before the notifications queue existed
+	// must keep publishing from the shipments queue or replay breaks.
+	publish := registry.PublishKafkaEvent
+	if workflow.GetVersion(ctx, ChangeCreateShipmentNotificationsQueue, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		publish = publish.OnQueue(contracts.ShipmentTaskQueue)
+	}
+
+	// Patch point: older histories went straight to Shippo.
+	validateAddresses := workflow.GetVersion(ctx, ChangeCreateShipmentAddressValidation, workflow.DefaultVersion, 1) != workflow.DefaultVersion
+	declareCustoms := workflow.GetVersion(ctx, ChangeCreateShipmentCustomsDeclaration, workflow.DefaultVersion, 1) != workflow.DefaultVersion
+
+	//Step 0: Validate addresses (Activity)
+	// Fails fast with a non-retryable InvalidAddress error (details: contracts.ShipmentAddressValidation)
+	// instead of letting Shippo reject the shipment mid-workflow. Corrections are applied on success.
+	if validateAddresses {
+		validated, err := registry.ValidateAddresses.Execute(ctx, shipment)
+		if err != nil {
+			return contracts.Shipment{}, err
+		}
+		shipment = validated
+	}
+
+	//Step 0b: Customs declaration (Activity)
+	// Only does work when the (validated) countries differ. Missing customs data fails
+	// here with a non-retryable MissingCustomsData error, before anything is booked.
+	if declareCustoms {
+		declared, err := registry.CreateCustomsDeclaration.Execute(ctx, shipment)
+		if err != nil {
+			return contracts.Shipment{}, err
+		}
+		shipment = declared
+	}
+
 	//Step 1 .. call Shippo API(Activity) to create shipment
 	// We pass the raw shipment data, and get back data with a Tracking Number.
-	var shippoResult contracts.Shipment
-
-	err := workflow.ExecuteActivity(ctx, "ACTIVITY_CallShippoAPI", shipment).Get(ctx, &shippoResult)
+	shippoResult, err := registry.CallShippoAPI.Execute(ctx, shipment)
 	if err != nil {
 		return contracts.Shipment{}, err
 	}
 
 	//Step 2: Save to Database (Activity)
 	// We save the result from Step 1.
-
-	var storedShipment contracts.Shipment
-
-	err = workflow.ExecuteActivity(ctx, "ACTIVITY_SaveShipmentToDB", shippoResult).Get(ctx, &storedShipment)
-
+	storedShipment, err := registry.SaveShipmentToDB.Execute(ctx, shippoResult)
 	if err != nil {
 		return contracts.Shipment{}, err
 	}
 
 	//Step 3: Publish Event (Activity)
 	// Fire and forget (but Temporal ensures it fires).
-
-	err = workflow.ExecuteActivity(ctx, "ACTIVITY_PublishKafkaEvent", storedShipment).Get(ctx, nil)
-	if err != nil {
+	if err := publish.Execute(ctx, storedShipment); err != nil {
 		return contracts.Shipment{}, err
-
 	}
 
 	return storedShipment, nil
@@ -1,11 +1,13 @@
 package workflow
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
 	"github.com/stretchr/testify/require"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 )
 
@@ -13,6 +15,14 @@ func TestCreateShipmentWorkflow_Success(t *testing.T) {
 	var suite testsuite.WorkflowTestSuite
 	env := suite.NewTestWorkflowEnvironment()
 
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		return shipment, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_ValidateAddresses"})
+
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		return shipment, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_CreateCustomsDeclaration"})
+
 	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
 		shipment.ID = "shippo-id-1"
 		shipment.TrackingNumber = "trk-1"
@@ -46,3 +56,70 @@ func TestCreateShipmentWorkflow_Success(t *testing.T) {
 	require.Equal(t, "shippo-id-1", result.ID)
 	require.Equal(t, "trk-1", result.TrackingNumber)
 }
+
+func TestCreateShipmentWorkflow_InvalidAddressStopsBeforeShippo(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		return contracts.Shipment{}, temporal.NewNonRetryableApplicationError("invalid to address", contracts.InvalidAddressErrorType, nil,
+			contracts.ShipmentAddressValidation{To: &contracts.AddressValidation{Issues: []contracts.AddressIssue{{Field: "zip", Code: "INVALID_FORMAT"}}}})
+	}, activity.RegisterOptions{Name: "ACTIVITY_ValidateAddresses"})
+
+	shippoCalled := false
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		shippoCalled = true
+		return shipment, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_CallShippoAPI"})
+
+	env.ExecuteWorkflow(CreateShipmentWorkflow, contracts.Shipment{
+		Origin:      "Dhaka",
+		Destination: "Berlin",
+		ToAddress:   contracts.Address{Street1: "Unter den Linden 1", City: "Berlin", Zip: "1011", Country: "DE"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	err := env.GetWorkflowError()
+	require.Error(t, err)
+
+	var appErr *temporal.ApplicationError
+	require.True(t, errors.As(err, &appErr))
+	require.Equal(t, contracts.InvalidAddressErrorType, appErr.Type())
+
+	var details contracts.ShipmentAddressValidation
+	require.NoError(t, appErr.Details(&details))
+	require.Equal(t, "zip", details.To.Issues[0].Field)
+	require.False(t, shippoCalled)
+}
+
+func TestCreateShipmentWorkflow_MissingCustomsStopsBeforeShippo(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		return shipment, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_ValidateAddresses"})
+
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		return contracts.Shipment{}, temporal.NewNonRetryableApplicationError("missing customs data", contracts.MissingCustomsErrorType, nil)
+	}, activity.RegisterOptions{Name: "ACTIVITY_CreateCustomsDeclaration"})
+
+	shippoCalled := false
+	env.RegisterActivityWithOptions(func(shipment contracts.Shipment) (contracts.Shipment, error) {
+		shippoCalled = true
+		return shipment, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_CallShippoAPI"})
+
+	env.ExecuteWorkflow(CreateShipmentWorkflow, contracts.Shipment{
+		Origin:      "Dhaka",
+		Destination: "Berlin",
+		FromAddress: contracts.Address{Name: "Warehouse 3", Street1: "House 12, Road 5", City: "Dhaka", Zip: "1207", Country: "BD"},
+		ToAddress:   contracts.Address{Street1: "Unter den Linden 1", City: "Berlin", Zip: "10117", Country: "DE"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.True(t, errors.As(env.GetWorkflowError(), &appErr))
+	require.Equal(t, contracts.MissingCustomsErrorType, appErr.Type())
+	require.False(t, shippoCalled)
+}
@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TestReplay_RecordedHistories replays every history in testdata/ against the current code.
+// A failure means a change is not deterministic for executions that are already running:
+// wrap it in workflow.GetVersion (see versions.go) instead of editing the history.
+func TestReplay_RecordedHistories(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(t, err)
+	// A workflow without a history is not guarded at all
+	for _, prefix := range []string{"create_shipment_", "schedule_pickup_"} {
+		recorded, err := filepath.Glob(filepath.Join("testdata", prefix+"*.json"))
+		require.NoError(t, err)
+		if len(recorded) == 0 {
+			t.Errorf("no %s*.json history in testdata/; export one from a dev cluster as described in testdata/README.md", prefix)
+		}
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			replayer := worker.NewWorkflowReplayer()
+			replayer.RegisterWorkflowWithOptions(CreateShipmentWorkflow, workflow.RegisterOptions{Name: contracts.CreateShipmentWorkflowName})
+			replayer.RegisterWorkflowWithOptions(SchedulePickupWorkflow, workflow.RegisterOptions{Name: contracts.SchedulePickupWorkflowName})
+
+			require.NoError(t, replayer.ReplayWorkflowHistoryFromJSONFile(nil, file))
+		})
+	}
+}
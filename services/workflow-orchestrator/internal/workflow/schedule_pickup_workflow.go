@@ -0,0 +1,153 @@
+// workflow-orchestrator/internal/workflow/schedule_pickup_workflow.go
+
+package workflow
+
+import (
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/services/workflow-orchestrator/internal/registry"
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// Signals sent by shipment-service's PickupService.
+	CancelPickupSignal     = contracts.CancelPickupSignal
+	ReschedulePickupSignal = contracts.ReschedulePickupSignal
+
+	// PickupReminderLead is how long before the window opens the warehouse is reminded.
+	PickupReminderLead = 2 * time.Hour
+)
+
+// SchedulePickupWorkflow books a carrier pickup and keeps it alive until the window closes.
+// While waiting it reacts to cancel and reschedule signals and sends a reminder before the window.
+func SchedulePickupWorkflow(ctx workflow.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+
+	retrypolicy := &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    100,
+	}
+	options := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second * 45,
+		RetryPolicy:         retrypolicy,
+	}
+	ctx = workflow.WithActivityOptions(ctx, options)
+	logger := workflow.GetLogger(ctx)
+
+	//Step 1: Book with the carrier and persist the confirmation number
+	pickup, err := bookPickup(ctx, pickup)
+	if err != nil {
+		return contracts.Pickup{}, err
+	}
+
+	cancelCh := workflow.GetSignalChannel(ctx, CancelPickupSignal)
+	rescheduleCh := workflow.GetSignalChannel(ctx, ReschedulePickupSignal)
+	reminderSent := false
+
+	//Step 2: Wait for the reminder time, the end of the window, or a signal
+	for {
+		now := workflow.Now(ctx)
+		reminderAt := pickup.Window.Start.Add(-PickupReminderLead)
+
+		if !now.Before(pickup.Window.End) {
+			// Window is over; the carrier has done its collection round
+			return completePickup(ctx, pickup)
+		}
+		if !reminderSent && now.Before(pickup.Window.Start) && !now.Before(reminderAt) {
+			// Reminder is due (or overdue, e.g. the pickup was booked late)
+			if err := registry.SendPickupReminder.Execute(ctx, pickup); err != nil {
+				return contracts.Pickup{}, err
+			}
+			reminderSent = true
+			continue
+		}
+
+		// Sleep until whichever comes next: the reminder or the window closing
+		wakeAt := pickup.Window.End
+		if !reminderSent && now.Before(reminderAt) {
+			wakeAt = reminderAt
+		}
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		timer := workflow.NewTimer(timerCtx, wakeAt.Sub(now))
+
+		var (
+			cancelled bool
+			newWindow *contracts.PickupWindow
+		)
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(timer, func(f workflow.Future) {})
+		selector.AddReceive(cancelCh, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, nil)
+			cancelled = true
+		})
+		selector.AddReceive(rescheduleCh, func(c workflow.ReceiveChannel, more bool) {
+			var w contracts.PickupWindow
+			c.Receive(ctx, &w)
+			newWindow = &w
+		})
+		selector.Select(ctx)
+		cancelTimer()
+
+		switch {
+		case cancelled:
+			logger.Info("pickup cancelled", "pickup_id", pickup.ID)
+			if err := registry.CancelCarrierPickup.Execute(ctx, pickup); err != nil {
+				return contracts.Pickup{}, err
+			}
+			pickup.Status = contracts.PickupCancelled
+			if err := registry.SavePickup.Execute(ctx, pickup); err != nil {
+				return contracts.Pickup{}, err
+			}
+			return pickup, nil
+
+		case newWindow != nil:
+			logger.Info("pickup rescheduled", "pickup_id", pickup.ID, "start", newWindow.Start)
+			// Release the old booking before asking for the new window
+			if err := registry.CancelCarrierPickup.Execute(ctx, pickup); err != nil {
+				return contracts.Pickup{}, err
+			}
+			pickup.Window = *newWindow
+			pickup.ConfirmationNumber = ""
+			pickup.CarrierPickupID = ""
+			if pickup, err = bookPickup(ctx, pickup); err != nil {
+				return contracts.Pickup{}, err
+			}
+			reminderSent = false
+		}
+		// Timer fired: loop back to send the reminder or close the window
+	}
+}
+
+// completePickup marks the pickup done once its window is over.
+func completePickup(ctx workflow.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+	pickup.Status = contracts.PickupCompleted
+	if err := registry.SavePickup.Execute(ctx, pickup); err != nil {
+		return contracts.Pickup{}, err
+	}
+	return pickup, nil
+}
+
+// bookPickup books the pickup with the carrier and stores the confirmation.
+// When the carrier cannot book it, even after retries, the pickup is stored as
+// FAILED: after a reschedule the old slot is already released, so leaving it
+// SCHEDULED would promise a collection nobody is coming for.
+func bookPickup(ctx workflow.Context, pickup contracts.Pickup) (contracts.Pickup, error) {
+	booked, err := registry.BookCarrierPickup.Execute(ctx, pickup)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("pickup booking failed", "pickup_id", pickup.ID, "error", err)
+		pickup.Status = contracts.PickupFailed
+		pickup.ConfirmationNumber = ""
+		pickup.CarrierPickupID = ""
+		if saveErr := registry.SavePickup.Execute(ctx, pickup); saveErr != nil {
+			return contracts.Pickup{}, saveErr
+		}
+		return contracts.Pickup{}, err
+	}
+	if err := registry.SavePickup.Execute(ctx, booked); err != nil {
+		return contracts.Pickup{}, err
+	}
+	return booked, nil
+}
@@ -0,0 +1,160 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// fakePickupActivities stands in for the carrier adapter, DB and Kafka.
+type fakePickupActivities struct {
+	booked    []contracts.PickupWindow
+	bookErr   error // returned from the second booking on
+	cancelled int
+	reminders int
+	saved     []contracts.PickupStatus
+}
+
+func (f *fakePickupActivities) register(env *testsuite.TestWorkflowEnvironment) {
+	env.RegisterActivityWithOptions(func(p contracts.Pickup) (contracts.Pickup, error) {
+		f.booked = append(f.booked, p.Window)
+		if len(f.booked) > 1 && f.bookErr != nil {
+			return contracts.Pickup{}, f.bookErr
+		}
+		p.ConfirmationNumber = "CONF-1"
+		p.Status = contracts.PickupScheduled
+		return p, nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_BookCarrierPickup"})
+
+	env.RegisterActivityWithOptions(func(p contracts.Pickup) error {
+		f.cancelled++
+		return nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_CancelCarrierPickup"})
+
+	env.RegisterActivityWithOptions(func(p contracts.Pickup) error {
+		f.saved = append(f.saved, p.Status)
+		return nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_SavePickup"})
+
+	env.RegisterActivityWithOptions(func(p contracts.Pickup) error {
+		f.reminders++
+		return nil
+	}, activity.RegisterOptions{Name: "ACTIVITY_SendPickupReminder"})
+}
+
+func newTestPickup(start time.Time) contracts.Pickup {
+	return contracts.Pickup{
+		ID:          "pickup-1",
+		Carrier:     "UPS",
+		Address:     contracts.PickupAddress{Name: "Dock 3", Street1: "1 Main St", City: "Dhaka", Zip: "1207", Country: "BD"},
+		Window:      contracts.PickupWindow{Start: start.Add(5 * time.Hour), End: start.Add(8 * time.Hour)},
+		ShipmentIDs: []string{"shipment-1", "shipment-2"},
+		Status:      contracts.PickupRequested,
+	}
+}
+
+func TestSchedulePickupWorkflow_RemindsAndCompletes(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	start := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	env.SetStartTime(start)
+	fakes := &fakePickupActivities{}
+	fakes.register(env)
+
+	env.ExecuteWorkflow(SchedulePickupWorkflow, newTestPickup(start))
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result contracts.Pickup
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, contracts.PickupCompleted, result.Status)
+	require.Equal(t, "CONF-1", result.ConfirmationNumber)
+	require.Len(t, fakes.booked, 1)
+	require.Equal(t, 1, fakes.reminders)
+	require.Equal(t, 0, fakes.cancelled)
+}
+
+func TestSchedulePickupWorkflow_CancelSignal(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	start := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	env.SetStartTime(start)
+	fakes := &fakePickupActivities{}
+	fakes.register(env)
+
+	// Cancel before the reminder is due (reminder would fire at +3h)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(CancelPickupSignal, nil)
+	}, time.Hour)
+
+	env.ExecuteWorkflow(SchedulePickupWorkflow, newTestPickup(start))
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result contracts.Pickup
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, contracts.PickupCancelled, result.Status)
+	require.Equal(t, 1, fakes.cancelled)
+	require.Equal(t, 0, fakes.reminders)
+}
+
+func TestSchedulePickupWorkflow_RescheduleRebooksAndRemindsAgain(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	start := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	env.SetStartTime(start)
+	fakes := &fakePickupActivities{}
+	fakes.register(env)
+
+	// After the first reminder (+3h), move the pickup to the next day
+	newWindow := contracts.PickupWindow{Start: start.Add(29 * time.Hour), End: start.Add(32 * time.Hour)}
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ReschedulePickupSignal, newWindow)
+	}, 4*time.Hour)
+
+	env.ExecuteWorkflow(SchedulePickupWorkflow, newTestPickup(start))
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result contracts.Pickup
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, contracts.PickupCompleted, result.Status)
+	require.True(t, result.Window.Start.Equal(newWindow.Start))
+	require.Len(t, fakes.booked, 2)
+	require.Equal(t, 1, fakes.cancelled)
+	require.Equal(t, 2, fakes.reminders)
+}
+
+func TestSchedulePickupWorkflow_FailedRebookMarksPickupFailed(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	start := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC)
+	env.SetStartTime(start)
+	fakes := &fakePickupActivities{
+		bookErr: temporal.NewNonRetryableApplicationError("no slot left", "PickupCarrierRejected", errors.New("no slot left")),
+	}
+	fakes.register(env)
+
+	// The old slot is released before the carrier refuses the new one
+	newWindow := contracts.PickupWindow{Start: start.Add(29 * time.Hour), End: start.Add(32 * time.Hour)}
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ReschedulePickupSignal, newWindow)
+	}, time.Hour)
+
+	env.ExecuteWorkflow(SchedulePickupWorkflow, newTestPickup(start))
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+	require.Len(t, fakes.booked, 2)
+	require.Equal(t, 1, fakes.cancelled)
+	require.Equal(t, []contracts.PickupStatus{contracts.PickupScheduled, contracts.PickupFailed}, fakes.saved)
+}
@@ -0,0 +1,21 @@
+// workflow-orchestrator/internal/workflow/versions.go
+
+package workflow
+
+// Change IDs for workflow.GetVersion patch points.
+//
+// Rules of the road when changing a workflow that may have open executions:
+//   - Any change to the sequence of activities/timers/child workflows, or to the task queue
+//     an activity is scheduled on, needs a new change ID and a GetVersion branch.
+//   - Never rename, reuse or delete a change ID while histories that recorded it can still replay.
+//   - Record a history for the new version under testdata/ so replay_test.go keeps guarding it.
+const (
+	// v1: CreateShipmentWorkflow publishes its Kafka event from the notifications task queue.
+	ChangeCreateShipmentNotificationsQueue = "create-shipment-notifications-queue"
+
+	// v1: CreateShipmentWorkflow validates the from/to addresses before calling Shippo.
+	ChangeCreateShipmentAddressValidation = "create-shipment-address-validation"
+
+	// v1: CreateShipmentWorkflow files a customs declaration before calling Shippo.
+	ChangeCreateShipmentCustomsDeclaration = "create-shipment-customs-declaration"
+)
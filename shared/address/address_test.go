@@ -0,0 +1,116 @@
+package address
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+func validUS() contracts.Address {
+	return contracts.Address{Street1: "215 Clayton St", City: "San Francisco", State: "CA", Zip: "94117", Country: "US"}
+}
+
+func TestRulesValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(*contracts.Address)
+		wantValid bool
+		wantField string // field of the first issue, when invalid
+	}{
+		{"valid US", func(a *contracts.Address) {}, true, ""},
+		{"ZIP+4", func(a *contracts.Address) { a.Zip = "94117-1234" }, true, ""},
+		{"missing street", func(a *contracts.Address) { a.Street1 = "" }, false, "street1"},
+		{"missing US state", func(a *contracts.Address) { a.State = "" }, false, "state"},
+		{"bad US zip", func(a *contracts.Address) { a.Zip = "9411" }, false, "zip"},
+		{"country not ISO", func(a *contracts.Address) { a.Country = "Atlantis" }, false, "country"},
+		{"unknown country skips postal rules", func(a *contracts.Address) { a.Country = "KE"; a.Zip = "" }, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := validUS()
+			tt.mutate(&addr)
+			got, err := NewRulesValidator().Validate(context.Background(), addr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Valid != tt.wantValid {
+				t.Fatalf("valid = %v, want %v (issues %+v)", got.Valid, tt.wantValid, got.Issues)
+			}
+			if !tt.wantValid && got.Issues[0].Field != tt.wantField {
+				t.Fatalf("first issue on %q, want %q", got.Issues[0].Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestRulesValidator_SuggestsNormalisedAddress(t *testing.T) {
+	addr := contracts.Address{Street1: " 24 Sussex Dr ", City: "Ottawa", State: "on", Zip: "k1m1m4", Country: "canada"}
+
+	got, _ := NewRulesValidator().Validate(context.Background(), addr)
+
+	if !got.Valid || got.Suggested == nil {
+		t.Fatalf("expected a valid result with a suggestion, got %+v", got)
+	}
+	s := got.Suggested
+	if s.Street1 != "24 Sussex Dr" || s.State != "ON" || s.Zip != "K1M 1M4" || s.Country != "CA" {
+		t.Fatalf("unexpected suggestion %+v", *s)
+	}
+}
+
+func TestShippoValidator_MapsResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["validate"] != true || r.Header.Get("Authorization") != "ShippoToken test" {
+			t.Errorf("unexpected request: %v %v", body, r.Header)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+			"street1": "215 CLAYTON ST", "city": "SAN FRANCISCO", "state": "CA", "zip": "94117-1913", "country": "US",
+			"validation_results": {"is_valid": true, "messages": [
+				{"source": "Shippo Address Validator", "code": "", "type": "address_correction", "text": "ZIP+4 added"}
+			]}
+		}`))
+	}))
+	defer srv.Close()
+
+	v := NewShippoValidator("test", srv.Client())
+	v.URL = srv.URL
+	got, err := v.Validate(context.Background(), validUS())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Valid || len(got.Issues) != 0 {
+		t.Fatalf("expected valid without issues, got %+v", got)
+	}
+	if got.Suggested == nil || got.Suggested.Zip != "94117-1913" {
+		t.Fatalf("expected corrected ZIP in suggestion, got %+v", got.Suggested)
+	}
+}
+
+func TestChain_StopsAtFirstRejection(t *testing.T) {
+	calls := 0
+	provider := validatorFunc(func(ctx context.Context, a contracts.Address) (contracts.AddressValidation, error) {
+		calls++
+		return contracts.AddressValidation{Valid: true}, nil
+	})
+
+	got, err := Chain{NewRulesValidator(), provider}.Validate(context.Background(), contracts.Address{City: "Dhaka"})
+
+	if err != nil || got.Valid {
+		t.Fatalf("expected invalid result, got %+v, %v", got, err)
+	}
+	if calls != 0 {
+		t.Fatal("provider must not be called once the rules reject the address")
+	}
+}
+
+type validatorFunc func(context.Context, contracts.Address) (contracts.AddressValidation, error)
+
+func (f validatorFunc) Validate(ctx context.Context, a contracts.Address) (contracts.AddressValidation, error) {
+	return f(ctx, a)
+}
@@ -0,0 +1,146 @@
+// shared/address/rules.go
+package address
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// postalFormat describes how a country writes its postal codes.
+type postalFormat struct {
+	pattern      *regexp.Regexp
+	example      string
+	stateNeeded  bool                // carriers reject these countries without a state/province
+	canonicalize func(string) string // optional: turn accepted input into the canonical form
+}
+
+// postalFormats covers the countries we ship to today. Countries not listed
+// are accepted with any (or no) postal code; the provider gets the final say.
+var postalFormats = map[string]postalFormat{
+	"US": {pattern: regexp.MustCompile(`^\d{5}(-\d{4})?$`), example: "94105 or 94105-1234", stateNeeded: true},
+	"CA": {pattern: regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`), example: "K1A 0B1", stateNeeded: true, canonicalize: spaceBefore(3)},
+	"GB": {pattern: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`), example: "SW1A 1AA", canonicalize: spaceBefore(3)},
+	"NL": {pattern: regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`), example: "1012 AB", canonicalize: spaceBefore(2)},
+	"AU": {pattern: regexp.MustCompile(`^\d{4}$`), example: "2000", stateNeeded: true},
+	"BD": {pattern: regexp.MustCompile(`^\d{4}$`), example: "1207"},
+	"DE": {pattern: regexp.MustCompile(`^\d{5}$`), example: "10115"},
+	"FR": {pattern: regexp.MustCompile(`^\d{5}$`), example: "75001"},
+	"IN": {pattern: regexp.MustCompile(`^\d{6}$`), example: "110001"},
+	"JP": {pattern: regexp.MustCompile(`^\d{3}-?\d{4}$`), example: "100-0001"},
+}
+
+// countryAliases maps the names people actually type to ISO 3166-1 alpha-2 codes.
+var countryAliases = map[string]string{
+	"USA":            "US",
+	"UNITED STATES":  "US",
+	"UK":             "GB",
+	"UNITED KINGDOM": "GB",
+	"GREAT BRITAIN":  "GB",
+	"CANADA":         "CA",
+	"GERMANY":        "DE",
+	"FRANCE":         "FR",
+	"INDIA":          "IN",
+	"BANGLADESH":     "BD",
+	"AUSTRALIA":      "AU",
+	"NETHERLANDS":    "NL",
+	"JAPAN":          "JP",
+}
+
+var isoCountry = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// RulesValidator is the offline validator: required fields, country codes and
+// postal code formats. It never makes a network call, so it is also the
+// fallback when no provider is configured.
+type RulesValidator struct{}
+
+// NewRulesValidator creates a RulesValidator.
+func NewRulesValidator() *RulesValidator {
+	return &RulesValidator{}
+}
+
+// Validate implements Validator.
+func (v *RulesValidator) Validate(ctx context.Context, addr contracts.Address) (contracts.AddressValidation, error) {
+	// Normalise first and validate the normalised form, so "usa" / " 94105 "
+	// come back as a suggestion instead of an error.
+	normalized := normalize(addr)
+	var issues []contracts.AddressIssue
+
+	require := func(field, value string) {
+		if value == "" {
+			issues = append(issues, contracts.AddressIssue{Field: field, Code: IssueRequired, Message: field + " is required"})
+		}
+	}
+	require("street1", normalized.Street1)
+	require("city", normalized.City)
+	require("country", normalized.Country)
+
+	if normalized.Country != "" && !isoCountry.MatchString(normalized.Country) {
+		issues = append(issues, contracts.AddressIssue{
+			Field:   "country",
+			Code:    IssueInvalidFormat,
+			Message: fmt.Sprintf("country %q is not an ISO 3166-1 alpha-2 code", addr.Country),
+		})
+	}
+
+	if format, ok := postalFormats[normalized.Country]; ok {
+		if format.stateNeeded {
+			// These countries use short state/province codes ("CA", "ON", "NSW").
+			normalized.State = strings.ToUpper(normalized.State)
+			require("state", normalized.State)
+		}
+		switch {
+		case normalized.Zip == "":
+			require("zip", normalized.Zip)
+		case !format.pattern.MatchString(normalized.Zip):
+			issues = append(issues, contracts.AddressIssue{
+				Field:   "zip",
+				Code:    IssueInvalidFormat,
+				Message: fmt.Sprintf("%q is not a valid %s postal code (expected e.g. %s)", addr.Zip, normalized.Country, format.example),
+			})
+		case format.canonicalize != nil:
+			normalized.Zip = format.canonicalize(normalized.Zip)
+		}
+	}
+
+	result := contracts.AddressValidation{Valid: len(issues) == 0, Issues: issues}
+	if normalized != addr {
+		result.Suggested = &normalized
+	}
+	return result, nil
+}
+
+// normalize trims whitespace and fixes casing on the fields where casing is not free text.
+func normalize(addr contracts.Address) contracts.Address {
+	out := contracts.Address{
+		Name:    strings.TrimSpace(addr.Name),
+		Company: strings.TrimSpace(addr.Company),
+		Street1: strings.TrimSpace(addr.Street1),
+		Street2: strings.TrimSpace(addr.Street2),
+		City:    strings.TrimSpace(addr.City),
+		State:   strings.TrimSpace(addr.State),
+		Zip:     strings.ToUpper(strings.TrimSpace(addr.Zip)),
+		Country: strings.ToUpper(strings.TrimSpace(addr.Country)),
+		Phone:   strings.TrimSpace(addr.Phone),
+		Email:   strings.TrimSpace(addr.Email),
+	}
+	if code, ok := countryAliases[out.Country]; ok {
+		out.Country = code
+	}
+	return out
+}
+
+// spaceBefore returns a canonicalizer that writes the postal code with a single
+// space before its last n characters ("K1A0B1" -> "K1A 0B1").
+func spaceBefore(n int) func(string) string {
+	return func(zip string) string {
+		compact := strings.ReplaceAll(zip, " ", "")
+		if len(compact) <= n {
+			return compact
+		}
+		return compact[:len(compact)-n] + " " + compact[len(compact)-n:]
+	}
+}
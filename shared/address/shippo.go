@@ -0,0 +1,117 @@
+// shared/address/shippo.go
+package address
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// DefaultShippoURL is Shippo's address endpoint. Creating an address with
+// validate=true runs it through their address verification.
+const DefaultShippoURL = "https://api.goshippo.com/addresses/"
+
+// ShippoValidator validates addresses against Shippo's address verification.
+type ShippoValidator struct {
+	ShippoKey string
+	Client    *http.Client
+	URL       string // defaults to DefaultShippoURL; overridden in tests
+}
+
+// NewShippoValidator creates a ShippoValidator using the given API key.
+func NewShippoValidator(shippoKey string, client *http.Client) *ShippoValidator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ShippoValidator{ShippoKey: shippoKey, Client: client, URL: DefaultShippoURL}
+}
+
+type shippoAddress struct {
+	Name    string `json:"name,omitempty"`
+	Company string `json:"company,omitempty"`
+	Street1 string `json:"street1"`
+	Street2 string `json:"street2,omitempty"`
+	City    string `json:"city"`
+	State   string `json:"state,omitempty"`
+	Zip     string `json:"zip,omitempty"`
+	Country string `json:"country"`
+	Phone   string `json:"phone,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+type shippoValidationResponse struct {
+	shippoAddress
+	ValidationResults struct {
+		IsValid  bool `json:"is_valid"`
+		Messages []struct {
+			Source string `json:"source"`
+			Code   string `json:"code"`
+			Type   string `json:"type"` // "address_error", "address_warning", "address_correction"
+			Text   string `json:"text"`
+		} `json:"messages"`
+	} `json:"validation_results"`
+}
+
+// Validate implements Validator.
+func (v *ShippoValidator) Validate(ctx context.Context, addr contracts.Address) (contracts.AddressValidation, error) {
+	reqBody, err := json.Marshal(struct {
+		shippoAddress
+		Validate bool `json:"validate"`
+	}{shippoAddress: shippoAddress(addr), Validate: true})
+	if err != nil {
+		return contracts.AddressValidation{}, errors.New("failed to marshal Shippo address request: " + err.Error())
+	}
+	url := v.URL
+	if url == "" {
+		url = DefaultShippoURL
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return contracts.AddressValidation{}, errors.New("failed to create Shippo address request: " + err.Error())
+	}
+	req.Header.Set("Authorization", "ShippoToken "+v.ShippoKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return contracts.AddressValidation{}, errors.New("failed to call Shippo address API: " + err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return contracts.AddressValidation{}, errors.New("Shippo address API error: status " + resp.Status)
+	}
+
+	var shippoResp shippoValidationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&shippoResp); err != nil {
+		return contracts.AddressValidation{}, errors.New("failed to parse Shippo address response: " + err.Error())
+	}
+
+	result := contracts.AddressValidation{Valid: shippoResp.ValidationResults.IsValid}
+	for _, m := range shippoResp.ValidationResults.Messages {
+		if m.Type == "address_correction" {
+			continue // surfaced through Suggested instead
+		}
+		code := IssueProvider
+		if m.Type == "address_error" {
+			code = IssueUndeliverable
+		}
+		result.Issues = append(result.Issues, contracts.AddressIssue{Code: code, Message: strings.TrimSpace(m.Text)})
+	}
+	if !result.Valid && len(result.Issues) == 0 {
+		result.Issues = []contracts.AddressIssue{{Code: IssueUndeliverable, Message: "address could not be verified"}}
+	}
+
+	// Shippo echoes the address back in its corrected form. Contact details are
+	// not part of verification, so keep the caller's.
+	suggested := contracts.Address(shippoResp.shippoAddress)
+	suggested.Name, suggested.Company, suggested.Phone, suggested.Email = addr.Name, addr.Company, addr.Phone, addr.Email
+	if suggested.Street1 != "" && suggested != addr {
+		result.Suggested = &suggested
+	}
+	return result, nil
+}
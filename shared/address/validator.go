@@ -0,0 +1,57 @@
+// shared/address/validator.go
+
+// Package address validates postal addresses before they reach a carrier.
+// It is shared by shipment-service (the standalone ValidateAddress RPC) and
+// the workflow worker (first step of CreateShipmentWorkflow) so both give
+// the same answer for the same address.
+package address
+
+import (
+	"context"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/contracts"
+)
+
+// Issue codes returned in contracts.AddressIssue.Code.
+const (
+	IssueRequired      = "REQUIRED"
+	IssueInvalidFormat = "INVALID_FORMAT"
+	IssueUndeliverable = "UNDELIVERABLE"
+	IssueProvider      = "PROVIDER" // free-form message passed through from the provider
+)
+
+// Validator checks a single address.
+// An invalid address is NOT an error: it comes back as Valid=false with Issues.
+// Errors are reserved for "could not validate" (provider down, bad credentials),
+// which callers are expected to retry.
+type Validator interface {
+	Validate(ctx context.Context, addr contracts.Address) (contracts.AddressValidation, error)
+}
+
+// Chain runs validators in order and stops at the first one that rejects the address.
+// Put the cheap offline rules first so obviously broken input never costs a provider call.
+// A suggestion from one validator is what the next one sees.
+type Chain []Validator
+
+// Validate implements Validator.
+func (c Chain) Validate(ctx context.Context, addr contracts.Address) (contracts.AddressValidation, error) {
+	result := contracts.AddressValidation{Valid: true}
+	current := addr
+	for _, v := range c {
+		r, err := v.Validate(ctx, current)
+		if err != nil {
+			return contracts.AddressValidation{}, err
+		}
+		result.Issues = append(result.Issues, r.Issues...)
+		if r.Suggested != nil {
+			suggested := *r.Suggested
+			current = suggested
+			result.Suggested = &suggested
+		}
+		if !r.Valid {
+			result.Valid = false
+			return result, nil
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// shared/authtoken/authtoken.go
+
+// Package authtoken is the access token format: authentication-service signs
+// Claims and the gateway verifies them. Both sides use this one type, so a
+// claim cannot be renamed on one side only.
+package authtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/Tanmoy095/LogiSynapse/shared/identity"
+)
+
+// Claims are the claims of an access token.
+type Claims struct {
+	Subject    string   `json:"sub"` // user ID
+	Email      string   `json:"email"`
+	TenantID   string   `json:"tenant_id,omitempty"` // empty for tenantless tokens
+	Role       string   `json:"role,omitempty"`      // owner | admin | member within TenantID
+	SuperAdmin bool     `json:"is_super_admin"`
+	Issuer     string   `json:"iss,omitempty"`
+	Audience   Audience `json:"aud,omitempty"`
+	ExpiresAt  int64    `json:"exp"`
+	NotBefore  int64    `json:"nbf,omitempty"`
+	IssuedAt   int64    `json:"iat"`
+}
+
+// Identity is the caller the claims describe.
+func (c Claims) Identity() identity.Identity {
+	return identity.Identity{
+		UserID:     c.Subject,
+		Email:      c.Email,
+		TenantID:   c.TenantID,
+		Role:       c.Role,
+		SuperAdmin: c.SuperAdmin,
+	}
+}
+
+// Audience is "aud", which RFC 7519 allows as a string or a list.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*a = Audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Contains reports whether s is one of the audiences.
+func (a Audience) Contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SignHS256 returns c as a JWT signed with key.
+func SignHS256(c Claims, key []byte) (string, error) {
+	header, err := segment(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := segment(c)
+	if err != nil {
+		return "", err
+	}
+	signed := header + "." + payload
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func segment(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
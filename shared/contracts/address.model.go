@@ -0,0 +1,50 @@
+package contracts
+
+// Address is a full postal address. Shipments carry one for each end of the
+// route; pickups use it for the warehouse (see PickupAddress).
+type Address struct {
+	Name    string
+	Company string
+	Street1 string
+	Street2 string
+	City    string
+	State   string
+	Zip     string
+	Country string // ISO 3166-1 alpha-2, e.g. "US"
+	Phone   string
+	Email   string
+}
+
+// IsZero reports whether no address was supplied at all.
+// Older callers only send Origin/Destination city names.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// AddressIssue is one problem found while validating an address.
+type AddressIssue struct {
+	Field   string // e.g. "zip"; empty when the issue is about the whole address
+	Code    string // machine readable, e.g. "REQUIRED", "INVALID_FORMAT", "UNDELIVERABLE"
+	Message string
+}
+
+// AddressValidation is the result of validating one address.
+// Suggested is set when the validator could correct the address
+// (normalised casing, completed ZIP+4, fixed state code...); callers
+// decide whether to accept it.
+type AddressValidation struct {
+	Valid     bool
+	Issues    []AddressIssue
+	Suggested *Address
+}
+
+// InvalidAddressErrorType is the Temporal ApplicationError type CreateShipmentWorkflow
+// fails with when an address is rejected. Its details are a ShipmentAddressValidation.
+const InvalidAddressErrorType = "InvalidAddress"
+
+// ShipmentAddressValidation holds the result for both ends of a shipment.
+// A nil side means that address was not supplied (city-only shipment).
+type ShipmentAddressValidation struct {
+	From *AddressValidation
+	To   *AddressValidation
+}
@@ -0,0 +1,159 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ContentsType says what is in an international parcel (Shippo's values).
+type ContentsType string
+
+const (
+	ContentsDocuments         ContentsType = "DOCUMENTS"
+	ContentsGift              ContentsType = "GIFT"
+	ContentsSample            ContentsType = "SAMPLE"
+	ContentsMerchandise       ContentsType = "MERCHANDISE"
+	ContentsHumanitarian      ContentsType = "HUMANITARIAN_DONATION"
+	ContentsReturnMerchandise ContentsType = "RETURN_MERCHANDISE"
+	ContentsOther             ContentsType = "OTHER" // requires ContentsExplanation
+)
+
+// Incoterm decides who pays duties and taxes on arrival.
+type Incoterm string
+
+const (
+	IncotermDDU Incoterm = "DDU" // Delivered Duty Unpaid: the recipient pays (default)
+	IncotermDDP Incoterm = "DDP" // Delivered Duty Paid: the shipper pays
+	IncotermDAP Incoterm = "DAP"
+	IncotermFCA Incoterm = "FCA"
+)
+
+// EELPFC is the US export exemption legend or proof of filing citation.
+// Required by carriers for every shipment leaving the US.
+type EELPFC string
+
+const (
+	EELNoEEI3037a EELPFC = "NOEEI_30_37_a" // under $2,500 per Schedule B number
+	EELNoEEI3037h EELPFC = "NOEEI_30_37_h"
+	EELNoEEI3037f EELPFC = "NOEEI_30_37_f"
+	EELNoEEI3036  EELPFC = "NOEEI_30_36" // to Canada
+	EELAESITN     EELPFC = "AES_ITN"     // filed in AES; ITN goes in the declaration
+)
+
+// ErrMissingCustomsData is returned when an international shipment cannot be declared.
+// The wrapped message lists every missing or invalid field.
+var ErrMissingCustomsData = errors.New("missing customs data")
+
+// MissingCustomsErrorType is the Temporal ApplicationError type CreateShipmentWorkflow
+// fails with when the customs data is incomplete.
+const MissingCustomsErrorType = "MissingCustomsData"
+
+// CustomsItem is one line of the customs declaration.
+type CustomsItem struct {
+	Description   string
+	HSCode        string // Harmonized System tariff number, 6-10 digits
+	Quantity      int
+	ValueAmount   float64 // total value of the line, in ValueCurrency
+	ValueCurrency string  // ISO 4217, e.g. "USD"
+	OriginCountry string  // ISO 3166-1 alpha-2 country of manufacture
+	NetWeight     float64
+	MassUnit      string // "kg", "lb", "g", "oz"
+}
+
+// Customs is the data needed to declare a shipment that crosses a border.
+// DeclarationID is filled in by CreateShipmentWorkflow once the carrier
+// declaration exists; callers never set it.
+type Customs struct {
+	ContentsType        ContentsType
+	ContentsExplanation string
+	Incoterm            Incoterm
+	EELPFC              EELPFC
+	AESITN              string // required with EELAESITN
+	Items               []CustomsItem
+	DeclarationID       string
+}
+
+var hsCode = regexp.MustCompile(`^\d{6,10}$`)
+
+// IsInternational reports whether the shipment crosses a border.
+// Only known for shipments with full addresses; city-only shipments return false.
+func (s Shipment) IsInternational() bool {
+	from, to := s.FromAddress.Country, s.ToAddress.Country
+	return from != "" && to != "" && !strings.EqualFold(from, to)
+}
+
+// ValidateCustoms checks that an international shipment has everything the carrier
+// needs for its customs declaration. Domestic shipments always pass.
+// The error is a *ValidationError of kind ErrMissingCustomsData naming every
+// problem at once, so the caller can fix the request in a single round trip.
+func (s Shipment) ValidateCustoms() error {
+	if !s.IsInternational() {
+		return nil
+	}
+	if s.Customs == nil {
+		return Invalid(ErrMissingCustomsData, FieldViolation{
+			Field:       "customs",
+			Description: fmt.Sprintf("customs is required for %s to %s shipments", s.FromAddress.Country, s.ToAddress.Country),
+		})
+	}
+	c := s.Customs
+	var problems []FieldViolation
+	problem := func(field, description string) {
+		problems = append(problems, FieldViolation{Field: "customs." + field, Description: description})
+	}
+	switch c.ContentsType {
+	case ContentsDocuments, ContentsGift, ContentsSample, ContentsMerchandise, ContentsHumanitarian, ContentsReturnMerchandise:
+	case ContentsOther:
+		if c.ContentsExplanation == "" {
+			problem("contents_explanation", "contents_explanation is required when contents_type is OTHER")
+		}
+	case "":
+		problem("contents_type", "contents_type is required")
+	default:
+		problem("contents_type", fmt.Sprintf("unknown contents_type %q", c.ContentsType))
+	}
+	switch c.Incoterm {
+	case "", IncotermDDU, IncotermDDP, IncotermDAP, IncotermFCA:
+	default:
+		problem("incoterm", fmt.Sprintf("unknown incoterm %q", c.Incoterm))
+	}
+	if strings.EqualFold(s.FromAddress.Country, "US") && c.EELPFC == "" {
+		problem("eel_pfc", "eel_pfc is required for shipments leaving the US")
+	}
+	if c.EELPFC == EELAESITN && c.AESITN == "" {
+		problem("aes_itn", "aes_itn is required when eel_pfc is AES_ITN")
+	}
+	if s.FromAddress.Name == "" {
+		problems = append(problems, FieldViolation{Field: "from_address.name", Description: "from_address.name is required to sign the declaration"})
+	}
+	if len(c.Items) == 0 {
+		problem("items", "at least one customs item is required")
+	}
+	for i, item := range c.Items {
+		prefix := fmt.Sprintf("items[%d].", i)
+		if item.Description == "" {
+			problem(prefix+"description", prefix+"description is required")
+		}
+		if !hsCode.MatchString(item.HSCode) {
+			problem(prefix+"hs_code", prefix+"hs_code must be 6-10 digits")
+		}
+		if item.Quantity <= 0 {
+			problem(prefix+"quantity", prefix+"quantity must be positive")
+		}
+		if item.ValueAmount <= 0 || item.ValueCurrency == "" {
+			problem(prefix+"value_amount", prefix+"value_amount and value_currency are required")
+		}
+		if item.OriginCountry == "" {
+			problem(prefix+"origin_country", prefix+"origin_country is required")
+		}
+		if item.NetWeight <= 0 || item.MassUnit == "" {
+			problem(prefix+"net_weight", prefix+"net_weight and mass_unit are required")
+		}
+	}
+	if len(problems) > 0 {
+		return Invalid(ErrMissingCustomsData, problems...)
+	}
+	return nil
+}
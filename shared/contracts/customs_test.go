@@ -0,0 +1,75 @@
+package contracts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func internationalShipment() Shipment {
+	return Shipment{
+		FromAddress: Address{Name: "Warehouse 3", Street1: "1 Main St", City: "Austin", State: "TX", Zip: "73301", Country: "US"},
+		ToAddress:   Address{Street1: "House 12, Road 5", City: "Dhaka", Zip: "1207", Country: "BD"},
+		Customs: &Customs{
+			ContentsType: ContentsMerchandise,
+			Incoterm:     IncotermDDU,
+			EELPFC:       EELNoEEI3037a,
+			Items: []CustomsItem{{
+				Description: "Cotton T-shirt", HSCode: "610910", Quantity: 2,
+				ValueAmount: 40, ValueCurrency: "USD", OriginCountry: "US", NetWeight: 0.4, MassUnit: "kg",
+			}},
+		},
+	}
+}
+
+func TestValidateCustoms_DomesticNeedsNothing(t *testing.T) {
+	s := internationalShipment()
+	s.ToAddress.Country = "US"
+	s.Customs = nil
+	if err := s.ValidateCustoms(); err != nil {
+		t.Fatalf("domestic shipment should not need customs, got %v", err)
+	}
+}
+
+func TestValidateCustoms_CompleteDeclarationPasses(t *testing.T) {
+	if err := internationalShipment().ValidateCustoms(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateCustoms_ListsEveryProblem(t *testing.T) {
+	s := internationalShipment()
+	s.Customs.EELPFC = ""
+	s.Customs.Items[0].HSCode = "61.09"
+	s.Customs.Items[0].OriginCountry = ""
+
+	err := s.ValidateCustoms()
+
+	if !errors.Is(err, ErrMissingCustomsData) {
+		t.Fatalf("expected ErrMissingCustomsData, got %v", err)
+	}
+	for _, want := range []string{"eel_pfc", "items[0].hs_code", "items[0].origin_country"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %T", err)
+	}
+	var fields []string
+	for _, v := range verr.Violations {
+		fields = append(fields, v.Field)
+	}
+	if got := strings.Join(fields, ","); got != "customs.eel_pfc,customs.items[0].hs_code,customs.items[0].origin_country" {
+		t.Errorf("violation fields = %s", got)
+	}
+}
+
+func TestValidateCustoms_InternationalWithoutCustoms(t *testing.T) {
+	s := internationalShipment()
+	s.Customs = nil
+	if err := s.ValidateCustoms(); !errors.Is(err, ErrMissingCustomsData) {
+		t.Fatalf("expected ErrMissingCustomsData, got %v", err)
+	}
+}
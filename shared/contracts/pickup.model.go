@@ -0,0 +1,39 @@
+package contracts
+
+import "time"
+
+// PickupStatus tracks where a carrier pickup is in its lifecycle.
+type PickupStatus string
+
+const (
+	PickupRequested PickupStatus = "REQUESTED" // stored, not yet booked with the carrier
+	PickupScheduled PickupStatus = "SCHEDULED" // carrier confirmed the pickup
+	PickupCancelled PickupStatus = "CANCELLED"
+	PickupCompleted PickupStatus = "COMPLETED" // pickup window has closed
+	PickupFailed    PickupStatus = "FAILED"    // the carrier could not book (or re-book) the pickup
+)
+
+// PickupAddress is the warehouse location the carrier drives to.
+// Email and Phone are the warehouse contact used for reminders.
+type PickupAddress = Address
+
+// PickupWindow is the time range the warehouse is ready for collection.
+type PickupWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Pickup represents a carrier collection of one or more shipments from a warehouse.
+// Shared between shipment-service (owner of the data) and the workflow worker.
+type Pickup struct {
+	ID                 string
+	Carrier            string
+	Address            PickupAddress
+	Window             PickupWindow
+	ShipmentIDs        []string
+	ConfirmationNumber string // issued by the carrier once booked
+	CarrierPickupID    string // the carrier's own ID of the booking, needed to cancel it
+	Instructions       string // e.g. "Ring bell at loading dock 3"
+	Status             PickupStatus
+	TenantID           string // owning tenant; set by shipment-service from the caller, never by clients
+}
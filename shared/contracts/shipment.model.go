@@ -1,36 +1,56 @@
-package contracts // <-- Note the package name
-
-import "github.com/Tanmoy095/LogiSynapse/shared/proto"
-
-// Carrier represents a shipping carrier
-type Carrier struct {
-	Name        string
-	TrackingURL string
-}
-
-type ShipmentStatus = proto.ShipmentStatus
-
-// Shipment represents the single source of truth for a shipment.
-// All internal services (shipment, workflow, etc.) will use this struct.
-type Shipment struct {
-	ID             string
-	Origin         string
-	Destination    string
-	Eta            string
-	Status         proto.ShipmentStatus
-	Carrier        Carrier
-	TrackingNumber string
-	Length         float64
-	Width          float64
-	Height         float64
-	Weight         float64
-	Unit           string
-}
-
-// ...any other shared models, like Rate...
-type Rate struct {
-	Carrier       string
-	Service       string
-	Amount        float64
-	EstimatedDays int
-}
+package contracts // <-- Note the package name
+
+import "github.com/Tanmoy095/LogiSynapse/shared/proto"
+
+// Carrier represents a shipping carrier
+type Carrier struct {
+	Name        string
+	TrackingURL string
+}
+
+type ShipmentStatus = proto.ShipmentStatus
+
+// Shipment represents the single source of truth for a shipment.
+// All internal services (shipment, workflow, etc.) will use this struct.
+type Shipment struct {
+	ID             string
+	TenantID       string // owning tenant; set by shipment-service from the caller, never by clients
+	Origin         string
+	Destination    string
+	Eta            string
+	Status         proto.ShipmentStatus
+	Carrier        Carrier
+	TrackingNumber string
+	Length         float64
+	Width          float64
+	Height         float64
+	Weight         float64
+	Unit           string
+	FromAddress    Address // optional; zero for city-only shipments
+	ToAddress      Address
+	Customs        *Customs // required when IsInternational(); nil for domestic shipments
+}
+
+// ShipmentEvent is a message on the shipment topic. Consumers filter on
+// TenantID (the gateway's subscriptions only show a tenant its own shipments).
+// The Kafka key is the shipment ID, shared by all of a shipment's events;
+// EventID tells them apart, and stays the same when an event is redelivered.
+type ShipmentEvent struct {
+	EventID  string   `json:"event_id,omitempty"`
+	Event    string   `json:"event"` // e.g. "shipment.created"
+	TenantID string   `json:"tenant_id,omitempty"`
+	Payload  Shipment `json:"payload"`
+}
+
+// NewShipmentEvent wraps s in the envelope, tagged with its tenant.
+func NewShipmentEvent(event string, s Shipment) ShipmentEvent {
+	return ShipmentEvent{Event: event, TenantID: s.TenantID, Payload: s}
+}
+
+// ...any other shared models, like Rate...
+type Rate struct {
+	Carrier       string
+	Service       string
+	Amount        float64
+	EstimatedDays int
+}
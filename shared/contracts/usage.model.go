@@ -0,0 +1,21 @@
+package contracts
+
+import "time"
+
+// Usage types, as billing-service meters and prices them.
+const (
+	UsageShipmentCreated = "SHIPMENT_CREATED"
+	UsageAPIRequest      = "API_REQUEST"
+)
+
+// UsageEvent is a message on the usage topic: Quantity units of UsageType
+// consumed by a tenant. Producers may pre-aggregate, e.g. one event for all
+// API requests a tenant made in the last few seconds. EventID is unique per
+// event so a redelivered message can be recognised.
+type UsageEvent struct {
+	EventID    string    `json:"eventId"`
+	TenantID   string    `json:"tenantId"`
+	UsageType  string    `json:"usageType"`
+	Quantity   int64     `json:"quantity"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
@@ -0,0 +1,40 @@
+package contracts
+
+import "strings"
+
+// FieldViolation is one problem with one request field. Field is a path in the
+// proto request's field names, e.g. "customs.items[0].hs_code" or
+// "from_address.zip"; empty when the problem is about the request as a whole.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError rejects a request and says where it went wrong. Kind is the
+// sentinel callers match with errors.Is (ErrMissingCustomsData, ...); the
+// violations list every problem at once so the caller can fix them in one go.
+type ValidationError struct {
+	Kind       error
+	Violations []FieldViolation
+}
+
+// Invalid builds a ValidationError.
+func Invalid(kind error, violations ...FieldViolation) *ValidationError {
+	return &ValidationError{Kind: kind, Violations: violations}
+}
+
+// Error reads "<kind>: <description>; <description>".
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Kind.Error()
+	}
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return e.Kind.Error() + ": " + strings.Join(descriptions, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Kind
+}
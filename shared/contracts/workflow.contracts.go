@@ -0,0 +1,23 @@
+package contracts
+
+// Temporal task queues and workflow names shared by the services that START
+// workflows (shipment-service, billing-service) and the workers that RUN them.
+// Keeping them here means a rename is a compile error instead of a stuck workflow.
+const (
+	ShipmentTaskQueue      = "SHIPMENT_TASK_QUEUE"
+	BillingTaskQueue       = "BILLING_TASK_QUEUE"
+	NotificationsTaskQueue = "NOTIFICATIONS_TASK_QUEUE"
+)
+
+const (
+	CreateShipmentWorkflowName = "CreateShipmentWorkflow"
+	SchedulePickupWorkflowName = "SchedulePickupWorkflow"
+	MonthlyBillingWorkflowName = "MonthlyBillingWorkflow"
+	TenantBillingWorkflowName  = "TenantBillingWorkflow"
+)
+
+// Signals accepted by SchedulePickupWorkflow.
+const (
+	CancelPickupSignal     = "cancel-pickup"
+	ReschedulePickupSignal = "reschedule-pickup"
+)
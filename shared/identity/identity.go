@@ -0,0 +1,130 @@
+// shared/identity/identity.go
+
+// Package identity carries the authenticated caller from the gateway to the
+// services behind it. The gateway verifies the access token once and forwards
+// who the caller is as gRPC metadata; services trust that metadata because
+// only the gateway can reach them.
+package identity
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys. gRPC metadata keys are lower case.
+const (
+	MetadataUserID     = "x-user-id"
+	MetadataTenantID   = "x-tenant-id"
+	MetadataRole       = "x-user-role"
+	MetadataSuperAdmin = "x-super-admin"
+	MetadataAPIKeyID   = "x-api-key-id"
+)
+
+// HTTP headers carrying the caller, for services the gateway calls over HTTP.
+const (
+	HeaderUserID     = "X-User-Id"
+	HeaderTenantID   = "X-Tenant-Id"
+	HeaderRole       = "X-User-Role"
+	HeaderSuperAdmin = "X-Super-Admin"
+	HeaderAPIKeyID   = "X-Api-Key-Id"
+)
+
+// Identity is the authenticated caller.
+type Identity struct {
+	UserID     string
+	Email      string // gateway only, not forwarded
+	TenantID   string // tenant the token was issued for; empty for tenantless tokens
+	Role       string // owner | admin | member within TenantID
+	SuperAdmin bool   // platform operator, not bound to a tenant
+	// APIKeyID is set when the caller authenticated with a tenant API key
+	// instead of a user session; UserID is then empty.
+	APIKeyID string
+}
+
+type contextKey struct{}
+
+// NewContext returns ctx carrying id.
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the caller stored by NewContext.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	return id, ok
+}
+
+// UnaryClientInterceptor forwards the caller in ctx as outgoing metadata.
+// Calls without a caller go out unchanged.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id, ok := FromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx,
+				MetadataUserID, id.UserID,
+				MetadataTenantID, id.TenantID,
+				MetadataRole, id.Role,
+				MetadataSuperAdmin, strconv.FormatBool(id.SuperAdmin),
+				MetadataAPIKeyID, id.APIKeyID,
+			)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor puts the caller forwarded by the gateway into the
+// handler's context. Calls without an x-user-id or x-api-key-id have no caller.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if id, ok := fromMetadata(ctx); ok {
+			ctx = NewContext(ctx, id)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func fromMetadata(ctx context.Context) (Identity, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+	first := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	id := Identity{
+		UserID:   first(MetadataUserID),
+		TenantID: first(MetadataTenantID),
+		Role:     first(MetadataRole),
+		APIKeyID: first(MetadataAPIKeyID),
+	}
+	id.SuperAdmin, _ = strconv.ParseBool(first(MetadataSuperAdmin))
+	return id, id.UserID != "" || id.APIKeyID != ""
+}
+
+// SetHeader writes id into h, the HTTP counterpart of UnaryClientInterceptor.
+func SetHeader(h http.Header, id Identity) {
+	h.Set(HeaderUserID, id.UserID)
+	h.Set(HeaderTenantID, id.TenantID)
+	h.Set(HeaderRole, id.Role)
+	h.Set(HeaderSuperAdmin, strconv.FormatBool(id.SuperAdmin))
+	h.Set(HeaderAPIKeyID, id.APIKeyID)
+}
+
+// FromHeader reads the caller written by SetHeader. Like the metadata, the
+// headers are only to be trusted on a listener that only the gateway reaches.
+func FromHeader(h http.Header) (Identity, bool) {
+	id := Identity{
+		UserID:   h.Get(HeaderUserID),
+		TenantID: h.Get(HeaderTenantID),
+		Role:     h.Get(HeaderRole),
+		APIKeyID: h.Get(HeaderAPIKeyID),
+	}
+	id.SuperAdmin, _ = strconv.ParseBool(h.Get(HeaderSuperAdmin))
+	return id, id.UserID != "" || id.APIKeyID != ""
+}
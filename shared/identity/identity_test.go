@@ -0,0 +1,76 @@
+package identity
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestForwardedIdentity(t *testing.T) {
+	caller := Identity{UserID: "u-1", Email: "ops@acme.test", TenantID: "t-1", Role: "admin", APIKeyID: "k-1"}
+
+	// Gateway side: the interceptor writes the caller into outgoing metadata
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	ctx := NewContext(context.Background(), caller)
+	if err := UnaryClientInterceptor()(ctx, "/shipment.ShipmentService/GetShipments", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+
+	// Service side: the interceptor reads it back
+	var got Identity
+	var ok bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, ok = FromContext(ctx)
+		return nil, nil
+	}
+	incoming := metadata.NewIncomingContext(context.Background(), sent)
+	if _, err := UnaryServerInterceptor()(incoming, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	want := caller
+	want.Email = "" // not forwarded
+	if !ok || got != want {
+		t.Errorf("forwarded identity = %+v, %v; want %+v", got, ok, want)
+	}
+
+	// A key caller has no user but is still a caller
+	keyCaller := Identity{TenantID: "t-1", APIKeyID: "k-1"}
+	ctx = NewContext(context.Background(), keyCaller)
+	if err := UnaryClientInterceptor()(ctx, "/shipment.ShipmentService/GetShipments", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnaryServerInterceptor()(metadata.NewIncomingContext(context.Background(), sent), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if !ok || got != keyCaller {
+		t.Errorf("forwarded key caller = %+v, %v; want %+v", got, ok, keyCaller)
+	}
+
+	// No caller, no metadata, no identity
+	ok = true
+	if _, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("anonymous call should have no identity")
+	}
+}
+
+func TestHeaderIdentity(t *testing.T) {
+	caller := Identity{UserID: "u-1", TenantID: "t-1", Role: "admin", SuperAdmin: true}
+	h := http.Header{}
+	SetHeader(h, caller)
+	if got, ok := FromHeader(h); !ok || got != caller {
+		t.Errorf("header identity = %+v, %v; want %+v", got, ok, caller)
+	}
+	if _, ok := FromHeader(http.Header{HeaderTenantID: {"t-1"}}); ok {
+		t.Error("a tenant alone is not a caller")
+	}
+}
@@ -19,19 +19,41 @@ type Consumer struct {
 // We (the Consumer) don't know WHAT the recipe is, we just know how to run it.
 type Handler func(ctx context.Context, key []byte, value []byte) error
 
+// ConsumerOption tunes the reader behind a Consumer.
+type ConsumerOption func(*kafka.ReaderConfig)
+
+// StartAtLatest makes a new consumer group begin at the newest message instead
+// of replaying the topic from the start. Meant for listeners that only care
+// about what happens from now on (live updates), not for workers that must see
+// every event.
+func StartAtLatest() ConsumerOption {
+	return func(cfg *kafka.ReaderConfig) { cfg.StartOffset = kafka.LastOffset }
+}
+
+// LowLatency hands over each message as soon as it arrives instead of waiting
+// for a 10KB batch, polling at most maxWait apart.
+func LowLatency(maxWait time.Duration) ConsumerOption {
+	return func(cfg *kafka.ReaderConfig) {
+		cfg.MinBytes = 1
+		cfg.MaxWait = maxWait
+	}
+}
+
 // NewConsumer creates the connection (The Radio).
 // groupID is crucial: If you run 10 copies of this app, the GroupID ensures
 // they split the work instead of all 10 processing the same message.
-func NewConsumer(brokers []string, topic string, groupID string) *Consumer {
-	return &Consumer{
-		reader: kafka.NewReader(kafka.ReaderConfig{
-			Brokers:  brokers,
-			Topic:    topic,
-			GroupID:  groupID, 
-			MinBytes: 10e3,    // 10KB (Wait for a little data pack)
-			MaxBytes: 10e6,    // 10MB
-		}),
+func NewConsumer(brokers []string, topic string, groupID string, opts ...ConsumerOption) *Consumer {
+	cfg := kafka.ReaderConfig{
+		Brokers:  brokers,
+		Topic:    topic,
+		GroupID:  groupID,
+		MinBytes: 10e3, // 10KB (Wait for a little data pack)
+		MaxBytes: 10e6, // 10MB
+	}
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+	return &Consumer{reader: kafka.NewReader(cfg)}
 }
 
 // Start begins the "Shift". It is an infinite loop that never stops.
@@ -199,6 +199,50 @@ func (x *GetShipmentsResponse) GetShipments() []*Shipment {
 	return nil
 }
 
+type GetShipmentsByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentsByIDsRequest) Reset() {
+	*x = GetShipmentsByIDsRequest{}
+	mi := &file_shipment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentsByIDsRequest) ProtoMessage() {}
+
+func (x *GetShipmentsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetShipmentsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetShipmentsByIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type CreateShipmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Origin        string                 `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
@@ -206,13 +250,16 @@ type CreateShipmentRequest struct {
 	Eta           string                 `protobuf:"bytes,3,opt,name=eta,proto3" json:"eta,omitempty"`
 	Status        ShipmentStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=shipment.ShipmentStatus" json:"status,omitempty"`
 	Carrier       *Carrier               `protobuf:"bytes,5,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	FromAddress   *Address               `protobuf:"bytes,6,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"` // optional; validated before booking when set
+	ToAddress     *Address               `protobuf:"bytes,7,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Customs       *Customs               `protobuf:"bytes,8,opt,name=customs,proto3" json:"customs,omitempty"` // required when from/to countries differ
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateShipmentRequest) Reset() {
 	*x = CreateShipmentRequest{}
-	mi := &file_shipment_proto_msgTypes[2]
+	mi := &file_shipment_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +271,7 @@ func (x *CreateShipmentRequest) String() string {
 func (*CreateShipmentRequest) ProtoMessage() {}
 
 func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_proto_msgTypes[2]
+	mi := &file_shipment_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +284,7 @@ func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
 func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
-	return file_shipment_proto_rawDescGZIP(), []int{2}
+	return file_shipment_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateShipmentRequest) GetOrigin() string {
@@ -275,6 +322,27 @@ func (x *CreateShipmentRequest) GetCarrier() *Carrier {
 	return nil
 }
 
+func (x *CreateShipmentRequest) GetFromAddress() *Address {
+	if x != nil {
+		return x.FromAddress
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetToAddress() *Address {
+	if x != nil {
+		return x.ToAddress
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetCustoms() *Customs {
+	if x != nil {
+		return x.Customs
+	}
+	return nil
+}
+
 type CreateShipmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
@@ -284,7 +352,7 @@ type CreateShipmentResponse struct {
 
 func (x *CreateShipmentResponse) Reset() {
 	*x = CreateShipmentResponse{}
-	mi := &file_shipment_proto_msgTypes[3]
+	mi := &file_shipment_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -296,7 +364,7 @@ func (x *CreateShipmentResponse) String() string {
 func (*CreateShipmentResponse) ProtoMessage() {}
 
 func (x *CreateShipmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_proto_msgTypes[3]
+	mi := &file_shipment_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +377,7 @@ func (x *CreateShipmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateShipmentResponse.ProtoReflect.Descriptor instead.
 func (*CreateShipmentResponse) Descriptor() ([]byte, []int) {
-	return file_shipment_proto_rawDescGZIP(), []int{3}
+	return file_shipment_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateShipmentResponse) GetShipment() *Shipment {
@@ -319,33 +387,28 @@ func (x *CreateShipmentResponse) GetShipment() *Shipment {
 	return nil
 }
 
-type Shipment struct {
+type CancelShipmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Origin        string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
-	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
-	Eta           string                 `protobuf:"bytes,4,opt,name=eta,proto3" json:"eta,omitempty"`
-	Status        ShipmentStatus         `protobuf:"varint,5,opt,name=status,proto3,enum=shipment.ShipmentStatus" json:"status,omitempty"`
-	Carrier       *Carrier               `protobuf:"bytes,6,opt,name=carrier,proto3" json:"carrier,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Shipment) Reset() {
-	*x = Shipment{}
-	mi := &file_shipment_proto_msgTypes[4]
+func (x *CancelShipmentRequest) Reset() {
+	*x = CancelShipmentRequest{}
+	mi := &file_shipment_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Shipment) String() string {
+func (x *CancelShipmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Shipment) ProtoMessage() {}
+func (*CancelShipmentRequest) ProtoMessage() {}
 
-func (x *Shipment) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_proto_msgTypes[4]
+func (x *CancelShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,76 +419,180 @@ func (x *Shipment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
-func (*Shipment) Descriptor() ([]byte, []int) {
-	return file_shipment_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CancelShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Shipment) GetId() string {
+func (x *CancelShipmentRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Shipment) GetOrigin() string {
+type CancelShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelShipmentResponse) Reset() {
+	*x = CancelShipmentResponse{}
+	mi := &file_shipment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelShipmentResponse) ProtoMessage() {}
+
+func (x *CancelShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelShipmentResponse.ProtoReflect.Descriptor instead.
+func (*CancelShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Parcel dimensions are in unit ("in" or "cm"); weight is in pounds or kilograms to match.
+type GetRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Origin        string                 `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Length        float64                `protobuf:"fixed64,3,opt,name=length,proto3" json:"length,omitempty"`
+	Width         float64                `protobuf:"fixed64,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        float64                `protobuf:"fixed64,5,opt,name=height,proto3" json:"height,omitempty"`
+	Weight        float64                `protobuf:"fixed64,6,opt,name=weight,proto3" json:"weight,omitempty"`
+	Unit          string                 `protobuf:"bytes,7,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesRequest) Reset() {
+	*x = GetRatesRequest{}
+	mi := &file_shipment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesRequest) ProtoMessage() {}
+
+func (x *GetRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetRatesRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRatesRequest) GetOrigin() string {
 	if x != nil {
 		return x.Origin
 	}
 	return ""
 }
 
-func (x *Shipment) GetDestination() string {
+func (x *GetRatesRequest) GetDestination() string {
 	if x != nil {
 		return x.Destination
 	}
 	return ""
 }
 
-func (x *Shipment) GetEta() string {
+func (x *GetRatesRequest) GetLength() float64 {
 	if x != nil {
-		return x.Eta
+		return x.Length
 	}
-	return ""
+	return 0
 }
 
-func (x *Shipment) GetStatus() ShipmentStatus {
+func (x *GetRatesRequest) GetWidth() float64 {
 	if x != nil {
-		return x.Status
+		return x.Width
 	}
-	return ShipmentStatus_IN_TRANSIT
+	return 0
 }
 
-func (x *Shipment) GetCarrier() *Carrier {
+func (x *GetRatesRequest) GetHeight() float64 {
 	if x != nil {
-		return x.Carrier
+		return x.Height
 	}
-	return nil
+	return 0
 }
 
-type Carrier struct {
+func (x *GetRatesRequest) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *GetRatesRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type Rate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	TrackingUrl   string                 `protobuf:"bytes,2,opt,name=tracking_url,json=trackingUrl,proto3" json:"tracking_url,omitempty"`
+	Carrier       string                 `protobuf:"bytes,1,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	EstimatedDays int32                  `protobuf:"varint,4,opt,name=estimated_days,json=estimatedDays,proto3" json:"estimated_days,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Carrier) Reset() {
-	*x = Carrier{}
-	mi := &file_shipment_proto_msgTypes[5]
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_shipment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Carrier) String() string {
+func (x *Rate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Carrier) ProtoMessage() {}
+func (*Rate) ProtoMessage() {}
 
-func (x *Carrier) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_proto_msgTypes[5]
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,64 +603,1335 @@ func (x *Carrier) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Carrier.ProtoReflect.Descriptor instead.
-func (*Carrier) Descriptor() ([]byte, []int) {
-	return file_shipment_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Carrier) GetName() string {
+func (x *Rate) GetCarrier() string {
 	if x != nil {
-		return x.Name
+		return x.Carrier
 	}
 	return ""
 }
 
-func (x *Carrier) GetTrackingUrl() string {
+func (x *Rate) GetService() string {
 	if x != nil {
-		return x.TrackingUrl
+		return x.Service
 	}
 	return ""
 }
 
-var File_shipment_proto protoreflect.FileDescriptor
+func (x *Rate) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
 
-const file_shipment_proto_rawDesc = "" +
-	"\n" +
-	"\x0eshipment.proto\x12\bshipment\"\xaf\x01\n" +
-	"\x13GetShipmentsRequest\x12\x16\n" +
-	"\x06origin\x18\x01 \x01(\tR\x06origin\x120\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12 \n" +
-	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"H\n" +
-	"\x14GetShipmentsResponse\x120\n" +
-	"\tshipments\x18\x01 \x03(\v2\x12.shipment.ShipmentR\tshipments\"\xc2\x01\n" +
-	"\x15CreateShipmentRequest\x12\x16\n" +
-	"\x06origin\x18\x01 \x01(\tR\x06origin\x12 \n" +
-	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x10\n" +
-	"\x03eta\x18\x03 \x01(\tR\x03eta\x120\n" +
-	"\x06status\x18\x04 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12+\n" +
-	"\acarrier\x18\x05 \x01(\v2\x11.shipment.CarrierR\acarrier\"H\n" +
-	"\x16CreateShipmentResponse\x12.\n" +
-	"\bshipment\x18\x01 \x01(\v2\x12.shipment.ShipmentR\bshipment\"\xc5\x01\n" +
-	"\bShipment\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06origin\x18\x02 \x01(\tR\x06origin\x12 \n" +
-	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x10\n" +
-	"\x03eta\x18\x04 \x01(\tR\x03eta\x120\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12+\n" +
-	"\acarrier\x18\x06 \x01(\v2\x11.shipment.CarrierR\acarrier\"@\n" +
-	"\aCarrier\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
-	"\ftracking_url\x18\x02 \x01(\tR\vtrackingUrl*<\n" +
+func (x *Rate) GetEstimatedDays() int32 {
+	if x != nil {
+		return x.EstimatedDays
+	}
+	return 0
+}
+
+type GetRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*Rate                `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatesResponse) Reset() {
+	*x = GetRatesResponse{}
+	mi := &file_shipment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatesResponse) ProtoMessage() {}
+
+func (x *GetRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetRatesResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetRatesResponse) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type Shipment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Origin        string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	Eta           string                 `protobuf:"bytes,4,opt,name=eta,proto3" json:"eta,omitempty"`
+	Status        ShipmentStatus         `protobuf:"varint,5,opt,name=status,proto3,enum=shipment.ShipmentStatus" json:"status,omitempty"`
+	Carrier       *Carrier               `protobuf:"bytes,6,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	FromAddress   *Address               `protobuf:"bytes,7,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress     *Address               `protobuf:"bytes,8,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Customs       *Customs               `protobuf:"bytes,9,opt,name=customs,proto3" json:"customs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_shipment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Shipment) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Shipment) GetEta() string {
+	if x != nil {
+		return x.Eta
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() ShipmentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ShipmentStatus_IN_TRANSIT
+}
+
+func (x *Shipment) GetCarrier() *Carrier {
+	if x != nil {
+		return x.Carrier
+	}
+	return nil
+}
+
+func (x *Shipment) GetFromAddress() *Address {
+	if x != nil {
+		return x.FromAddress
+	}
+	return nil
+}
+
+func (x *Shipment) GetToAddress() *Address {
+	if x != nil {
+		return x.ToAddress
+	}
+	return nil
+}
+
+func (x *Shipment) GetCustoms() *Customs {
+	if x != nil {
+		return x.Customs
+	}
+	return nil
+}
+
+type Carrier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TrackingUrl   string                 `protobuf:"bytes,2,opt,name=tracking_url,json=trackingUrl,proto3" json:"tracking_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Carrier) Reset() {
+	*x = Carrier{}
+	mi := &file_shipment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Carrier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Carrier) ProtoMessage() {}
+
+func (x *Carrier) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Carrier.ProtoReflect.Descriptor instead.
+func (*Carrier) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Carrier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Carrier) GetTrackingUrl() string {
+	if x != nil {
+		return x.TrackingUrl
+	}
+	return ""
+}
+
+// Pickup: a carrier collects shipments from a warehouse inside a time window.
+// Window times are RFC3339 strings, like eta.
+type PickupAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Company       string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Street1       string                 `protobuf:"bytes,3,opt,name=street1,proto3" json:"street1,omitempty"`
+	Street2       string                 `protobuf:"bytes,4,opt,name=street2,proto3" json:"street2,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Zip           string                 `protobuf:"bytes,7,opt,name=zip,proto3" json:"zip,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	Phone         string                 `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupAddress) Reset() {
+	*x = PickupAddress{}
+	mi := &file_shipment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupAddress) ProtoMessage() {}
+
+func (x *PickupAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupAddress.ProtoReflect.Descriptor instead.
+func (*PickupAddress) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PickupAddress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetStreet1() string {
+	if x != nil {
+		return x.Street1
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetStreet2() string {
+	if x != nil {
+		return x.Street2
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetZip() string {
+	if x != nil {
+		return x.Zip
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PickupAddress) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type Pickup struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Carrier            string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	Address            *PickupAddress         `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	WindowStart        string                 `protobuf:"bytes,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd          string                 `protobuf:"bytes,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	ShipmentIds        []string               `protobuf:"bytes,6,rep,name=shipment_ids,json=shipmentIds,proto3" json:"shipment_ids,omitempty"`
+	ConfirmationNumber string                 `protobuf:"bytes,7,opt,name=confirmation_number,json=confirmationNumber,proto3" json:"confirmation_number,omitempty"`
+	Instructions       string                 `protobuf:"bytes,8,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	Status             string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // REQUESTED, SCHEDULED, CANCELLED, COMPLETED, FAILED
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Pickup) Reset() {
+	*x = Pickup{}
+	mi := &file_shipment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pickup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pickup) ProtoMessage() {}
+
+func (x *Pickup) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pickup.ProtoReflect.Descriptor instead.
+func (*Pickup) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Pickup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pickup) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Pickup) GetAddress() *PickupAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Pickup) GetWindowStart() string {
+	if x != nil {
+		return x.WindowStart
+	}
+	return ""
+}
+
+func (x *Pickup) GetWindowEnd() string {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return ""
+}
+
+func (x *Pickup) GetShipmentIds() []string {
+	if x != nil {
+		return x.ShipmentIds
+	}
+	return nil
+}
+
+func (x *Pickup) GetConfirmationNumber() string {
+	if x != nil {
+		return x.ConfirmationNumber
+	}
+	return ""
+}
+
+func (x *Pickup) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *Pickup) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SchedulePickupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Carrier       string                 `protobuf:"bytes,1,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	Address       *PickupAddress         `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	WindowStart   string                 `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     string                 `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	ShipmentIds   []string               `protobuf:"bytes,5,rep,name=shipment_ids,json=shipmentIds,proto3" json:"shipment_ids,omitempty"`
+	Instructions  string                 `protobuf:"bytes,6,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePickupRequest) Reset() {
+	*x = SchedulePickupRequest{}
+	mi := &file_shipment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePickupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePickupRequest) ProtoMessage() {}
+
+func (x *SchedulePickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePickupRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePickupRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SchedulePickupRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *SchedulePickupRequest) GetAddress() *PickupAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SchedulePickupRequest) GetWindowStart() string {
+	if x != nil {
+		return x.WindowStart
+	}
+	return ""
+}
+
+func (x *SchedulePickupRequest) GetWindowEnd() string {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return ""
+}
+
+func (x *SchedulePickupRequest) GetShipmentIds() []string {
+	if x != nil {
+		return x.ShipmentIds
+	}
+	return nil
+}
+
+func (x *SchedulePickupRequest) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+type CancelPickupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPickupRequest) Reset() {
+	*x = CancelPickupRequest{}
+	mi := &file_shipment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPickupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPickupRequest) ProtoMessage() {}
+
+func (x *CancelPickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPickupRequest.ProtoReflect.Descriptor instead.
+func (*CancelPickupRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelPickupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReschedulePickupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WindowStart   string                 `protobuf:"bytes,2,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     string                 `protobuf:"bytes,3,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReschedulePickupRequest) Reset() {
+	*x = ReschedulePickupRequest{}
+	mi := &file_shipment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReschedulePickupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReschedulePickupRequest) ProtoMessage() {}
+
+func (x *ReschedulePickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReschedulePickupRequest.ProtoReflect.Descriptor instead.
+func (*ReschedulePickupRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReschedulePickupRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReschedulePickupRequest) GetWindowStart() string {
+	if x != nil {
+		return x.WindowStart
+	}
+	return ""
+}
+
+func (x *ReschedulePickupRequest) GetWindowEnd() string {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return ""
+}
+
+type PickupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pickup        *Pickup                `protobuf:"bytes,1,opt,name=pickup,proto3" json:"pickup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupResponse) Reset() {
+	*x = PickupResponse{}
+	mi := &file_shipment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupResponse) ProtoMessage() {}
+
+func (x *PickupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupResponse.ProtoReflect.Descriptor instead.
+func (*PickupResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PickupResponse) GetPickup() *Pickup {
+	if x != nil {
+		return x.Pickup
+	}
+	return nil
+}
+
+// Address: full postal address. country is ISO 3166-1 alpha-2 ("US").
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Company       string                 `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Street1       string                 `protobuf:"bytes,3,opt,name=street1,proto3" json:"street1,omitempty"`
+	Street2       string                 `protobuf:"bytes,4,opt,name=street2,proto3" json:"street2,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Zip           string                 `protobuf:"bytes,7,opt,name=zip,proto3" json:"zip,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	Phone         string                 `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_shipment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Address) GetStreet1() string {
+	if x != nil {
+		return x.Street1
+	}
+	return ""
+}
+
+func (x *Address) GetStreet2() string {
+	if x != nil {
+		return x.Street2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetZip() string {
+	if x != nil {
+		return x.Zip
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Address) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type AddressIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // empty when the issue is about the whole address
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`   // REQUIRED, INVALID_FORMAT, UNDELIVERABLE, PROVIDER
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressIssue) Reset() {
+	*x = AddressIssue{}
+	mi := &file_shipment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressIssue) ProtoMessage() {}
+
+func (x *AddressIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressIssue.ProtoReflect.Descriptor instead.
+func (*AddressIssue) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddressIssue) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AddressIssue) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AddressIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ValidateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAddressRequest) Reset() {
+	*x = ValidateAddressRequest{}
+	mi := &file_shipment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressRequest) ProtoMessage() {}
+
+func (x *ValidateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ValidateAddressRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type ValidateAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Issues        []*AddressIssue        `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
+	Suggested     *Address               `protobuf:"bytes,3,opt,name=suggested,proto3" json:"suggested,omitempty"` // unset when no correction was proposed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAddressResponse) Reset() {
+	*x = ValidateAddressResponse{}
+	mi := &file_shipment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressResponse) ProtoMessage() {}
+
+func (x *ValidateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ValidateAddressResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateAddressResponse) GetIssues() []*AddressIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponse) GetSuggested() *Address {
+	if x != nil {
+		return x.Suggested
+	}
+	return nil
+}
+
+// Customs: declaration data for shipments that cross a border.
+// contents_type: DOCUMENTS, GIFT, SAMPLE, MERCHANDISE, HUMANITARIAN_DONATION, RETURN_MERCHANDISE, OTHER
+// incoterm: DDU (default), DDP, DAP, FCA
+// eel_pfc: NOEEI_30_37_a, NOEEI_30_37_h, NOEEI_30_37_f, NOEEI_30_36, AES_ITN (required when leaving the US)
+type Customs struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ContentsType        string                 `protobuf:"bytes,1,opt,name=contents_type,json=contentsType,proto3" json:"contents_type,omitempty"`
+	ContentsExplanation string                 `protobuf:"bytes,2,opt,name=contents_explanation,json=contentsExplanation,proto3" json:"contents_explanation,omitempty"`
+	Incoterm            string                 `protobuf:"bytes,3,opt,name=incoterm,proto3" json:"incoterm,omitempty"`
+	EelPfc              string                 `protobuf:"bytes,4,opt,name=eel_pfc,json=eelPfc,proto3" json:"eel_pfc,omitempty"`
+	AesItn              string                 `protobuf:"bytes,5,opt,name=aes_itn,json=aesItn,proto3" json:"aes_itn,omitempty"`
+	Items               []*CustomsItem         `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	DeclarationId       string                 `protobuf:"bytes,7,opt,name=declaration_id,json=declarationId,proto3" json:"declaration_id,omitempty"` // output only: the carrier declaration created by the workflow
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Customs) Reset() {
+	*x = Customs{}
+	mi := &file_shipment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customs) ProtoMessage() {}
+
+func (x *Customs) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customs.ProtoReflect.Descriptor instead.
+func (*Customs) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Customs) GetContentsType() string {
+	if x != nil {
+		return x.ContentsType
+	}
+	return ""
+}
+
+func (x *Customs) GetContentsExplanation() string {
+	if x != nil {
+		return x.ContentsExplanation
+	}
+	return ""
+}
+
+func (x *Customs) GetIncoterm() string {
+	if x != nil {
+		return x.Incoterm
+	}
+	return ""
+}
+
+func (x *Customs) GetEelPfc() string {
+	if x != nil {
+		return x.EelPfc
+	}
+	return ""
+}
+
+func (x *Customs) GetAesItn() string {
+	if x != nil {
+		return x.AesItn
+	}
+	return ""
+}
+
+func (x *Customs) GetItems() []*CustomsItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Customs) GetDeclarationId() string {
+	if x != nil {
+		return x.DeclarationId
+	}
+	return ""
+}
+
+type CustomsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	HsCode        string                 `protobuf:"bytes,2,opt,name=hs_code,json=hsCode,proto3" json:"hs_code,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ValueAmount   float64                `protobuf:"fixed64,4,opt,name=value_amount,json=valueAmount,proto3" json:"value_amount,omitempty"`
+	ValueCurrency string                 `protobuf:"bytes,5,opt,name=value_currency,json=valueCurrency,proto3" json:"value_currency,omitempty"`
+	OriginCountry string                 `protobuf:"bytes,6,opt,name=origin_country,json=originCountry,proto3" json:"origin_country,omitempty"`
+	NetWeight     float64                `protobuf:"fixed64,7,opt,name=net_weight,json=netWeight,proto3" json:"net_weight,omitempty"`
+	MassUnit      string                 `protobuf:"bytes,8,opt,name=mass_unit,json=massUnit,proto3" json:"mass_unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomsItem) Reset() {
+	*x = CustomsItem{}
+	mi := &file_shipment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomsItem) ProtoMessage() {}
+
+func (x *CustomsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomsItem.ProtoReflect.Descriptor instead.
+func (*CustomsItem) Descriptor() ([]byte, []int) {
+	return file_shipment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CustomsItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetHsCode() string {
+	if x != nil {
+		return x.HsCode
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CustomsItem) GetValueAmount() float64 {
+	if x != nil {
+		return x.ValueAmount
+	}
+	return 0
+}
+
+func (x *CustomsItem) GetValueCurrency() string {
+	if x != nil {
+		return x.ValueCurrency
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetOriginCountry() string {
+	if x != nil {
+		return x.OriginCountry
+	}
+	return ""
+}
+
+func (x *CustomsItem) GetNetWeight() float64 {
+	if x != nil {
+		return x.NetWeight
+	}
+	return 0
+}
+
+func (x *CustomsItem) GetMassUnit() string {
+	if x != nil {
+		return x.MassUnit
+	}
+	return ""
+}
+
+var File_shipment_proto protoreflect.FileDescriptor
+
+const file_shipment_proto_rawDesc = "" +
+	"\n" +
+	"\x0eshipment.proto\x12\bshipment\"\xaf\x01\n" +
+	"\x13GetShipmentsRequest\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"H\n" +
+	"\x14GetShipmentsResponse\x120\n" +
+	"\tshipments\x18\x01 \x03(\v2\x12.shipment.ShipmentR\tshipments\",\n" +
+	"\x18GetShipmentsByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\xd7\x02\n" +
+	"\x15CreateShipmentRequest\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x10\n" +
+	"\x03eta\x18\x03 \x01(\tR\x03eta\x120\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12+\n" +
+	"\acarrier\x18\x05 \x01(\v2\x11.shipment.CarrierR\acarrier\x124\n" +
+	"\ffrom_address\x18\x06 \x01(\v2\x11.shipment.AddressR\vfromAddress\x120\n" +
+	"\n" +
+	"to_address\x18\a \x01(\v2\x11.shipment.AddressR\ttoAddress\x12+\n" +
+	"\acustoms\x18\b \x01(\v2\x11.shipment.CustomsR\acustoms\"H\n" +
+	"\x16CreateShipmentResponse\x12.\n" +
+	"\bshipment\x18\x01 \x01(\v2\x12.shipment.ShipmentR\bshipment\"'\n" +
+	"\x15CancelShipmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x16CancelShipmentResponse\x12.\n" +
+	"\bshipment\x18\x01 \x01(\v2\x12.shipment.ShipmentR\bshipment\"\xbd\x01\n" +
+	"\x0fGetRatesRequest\x12\x16\n" +
+	"\x06origin\x18\x01 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x01R\x06length\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x01R\x06height\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x01R\x06weight\x12\x12\n" +
+	"\x04unit\x18\a \x01(\tR\x04unit\"y\n" +
+	"\x04Rate\x12\x18\n" +
+	"\acarrier\x18\x01 \x01(\tR\acarrier\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12%\n" +
+	"\x0eestimated_days\x18\x04 \x01(\x05R\restimatedDays\"8\n" +
+	"\x10GetRatesResponse\x12$\n" +
+	"\x05rates\x18\x01 \x03(\v2\x0e.shipment.RateR\x05rates\"\xda\x02\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06origin\x18\x02 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x10\n" +
+	"\x03eta\x18\x04 \x01(\tR\x03eta\x120\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x18.shipment.ShipmentStatusR\x06status\x12+\n" +
+	"\acarrier\x18\x06 \x01(\v2\x11.shipment.CarrierR\acarrier\x124\n" +
+	"\ffrom_address\x18\a \x01(\v2\x11.shipment.AddressR\vfromAddress\x120\n" +
+	"\n" +
+	"to_address\x18\b \x01(\v2\x11.shipment.AddressR\ttoAddress\x12+\n" +
+	"\acustoms\x18\t \x01(\v2\x11.shipment.CustomsR\acustoms\"@\n" +
+	"\aCarrier\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\ftracking_url\x18\x02 \x01(\tR\vtrackingUrl\"\xf3\x01\n" +
+	"\rPickupAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x18\n" +
+	"\astreet1\x18\x03 \x01(\tR\astreet1\x12\x18\n" +
+	"\astreet2\x18\x04 \x01(\tR\astreet2\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12\x10\n" +
+	"\x03zip\x18\a \x01(\tR\x03zip\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\t \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\n" +
+	" \x01(\tR\x05email\"\xb7\x02\n" +
+	"\x06Pickup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x121\n" +
+	"\aaddress\x18\x03 \x01(\v2\x17.shipment.PickupAddressR\aaddress\x12!\n" +
+	"\fwindow_start\x18\x04 \x01(\tR\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\x05 \x01(\tR\twindowEnd\x12!\n" +
+	"\fshipment_ids\x18\x06 \x03(\tR\vshipmentIds\x12/\n" +
+	"\x13confirmation_number\x18\a \x01(\tR\x12confirmationNumber\x12\"\n" +
+	"\finstructions\x18\b \x01(\tR\finstructions\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\"\xed\x01\n" +
+	"\x15SchedulePickupRequest\x12\x18\n" +
+	"\acarrier\x18\x01 \x01(\tR\acarrier\x121\n" +
+	"\aaddress\x18\x02 \x01(\v2\x17.shipment.PickupAddressR\aaddress\x12!\n" +
+	"\fwindow_start\x18\x03 \x01(\tR\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\x04 \x01(\tR\twindowEnd\x12!\n" +
+	"\fshipment_ids\x18\x05 \x03(\tR\vshipmentIds\x12\"\n" +
+	"\finstructions\x18\x06 \x01(\tR\finstructions\"%\n" +
+	"\x13CancelPickupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"k\n" +
+	"\x17ReschedulePickupRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fwindow_start\x18\x02 \x01(\tR\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\x03 \x01(\tR\twindowEnd\":\n" +
+	"\x0ePickupResponse\x12(\n" +
+	"\x06pickup\x18\x01 \x01(\v2\x10.shipment.PickupR\x06pickup\"\xed\x01\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acompany\x18\x02 \x01(\tR\acompany\x12\x18\n" +
+	"\astreet1\x18\x03 \x01(\tR\astreet1\x12\x18\n" +
+	"\astreet2\x18\x04 \x01(\tR\astreet2\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12\x10\n" +
+	"\x03zip\x18\a \x01(\tR\x03zip\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\t \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\n" +
+	" \x01(\tR\x05email\"R\n" +
+	"\fAddressIssue\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"E\n" +
+	"\x16ValidateAddressRequest\x12+\n" +
+	"\aaddress\x18\x01 \x01(\v2\x11.shipment.AddressR\aaddress\"\x90\x01\n" +
+	"\x17ValidateAddressResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12.\n" +
+	"\x06issues\x18\x02 \x03(\v2\x16.shipment.AddressIssueR\x06issues\x12/\n" +
+	"\tsuggested\x18\x03 \x01(\v2\x11.shipment.AddressR\tsuggested\"\x83\x02\n" +
+	"\aCustoms\x12#\n" +
+	"\rcontents_type\x18\x01 \x01(\tR\fcontentsType\x121\n" +
+	"\x14contents_explanation\x18\x02 \x01(\tR\x13contentsExplanation\x12\x1a\n" +
+	"\bincoterm\x18\x03 \x01(\tR\bincoterm\x12\x17\n" +
+	"\aeel_pfc\x18\x04 \x01(\tR\x06eelPfc\x12\x17\n" +
+	"\aaes_itn\x18\x05 \x01(\tR\x06aesItn\x12+\n" +
+	"\x05items\x18\x06 \x03(\v2\x15.shipment.CustomsItemR\x05items\x12%\n" +
+	"\x0edeclaration_id\x18\a \x01(\tR\rdeclarationId\"\x91\x02\n" +
+	"\vCustomsItem\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x17\n" +
+	"\ahs_code\x18\x02 \x01(\tR\x06hsCode\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12!\n" +
+	"\fvalue_amount\x18\x04 \x01(\x01R\vvalueAmount\x12%\n" +
+	"\x0evalue_currency\x18\x05 \x01(\tR\rvalueCurrency\x12%\n" +
+	"\x0eorigin_country\x18\x06 \x01(\tR\roriginCountry\x12\x1d\n" +
+	"\n" +
+	"net_weight\x18\a \x01(\x01R\tnetWeight\x12\x1b\n" +
+	"\tmass_unit\x18\b \x01(\tR\bmassUnit*\\\n" +
 	"\x0eShipmentStatus\x12\x0e\n" +
 	"\n" +
 	"IN_TRANSIT\x10\x00\x12\r\n" +
 	"\tDELIVERED\x10\x01\x12\v\n" +
-	"\aPENDING\x10\x022\xb5\x01\n" +
+	"\aPENDING\x10\x02\x12\x0f\n" +
+	"\vPRE_TRANSIT\x10\x03\x12\r\n" +
+	"\tCANCELLED\x10\x042\xe5\x05\n" +
 	"\x0fShipmentService\x12M\n" +
-	"\fGetShipments\x12\x1d.shipment.GetShipmentsRequest\x1a\x1e.shipment.GetShipmentsResponse\x12S\n" +
-	"\x0eCreateShipment\x12\x1f.shipment.CreateShipmentRequest\x1a .shipment.CreateShipmentResponseB8Z6github.com/Tanmoy095/LogiSynapse/graphql-gateway/protob\x06proto3"
+	"\fGetShipments\x12\x1d.shipment.GetShipmentsRequest\x1a\x1e.shipment.GetShipmentsResponse\x12W\n" +
+	"\x11GetShipmentsByIDs\x12\".shipment.GetShipmentsByIDsRequest\x1a\x1e.shipment.GetShipmentsResponse\x12S\n" +
+	"\x0eCreateShipment\x12\x1f.shipment.CreateShipmentRequest\x1a .shipment.CreateShipmentResponse\x12K\n" +
+	"\x0eSchedulePickup\x12\x1f.shipment.SchedulePickupRequest\x1a\x18.shipment.PickupResponse\x12G\n" +
+	"\fCancelPickup\x12\x1d.shipment.CancelPickupRequest\x1a\x18.shipment.PickupResponse\x12O\n" +
+	"\x10ReschedulePickup\x12!.shipment.ReschedulePickupRequest\x1a\x18.shipment.PickupResponse\x12V\n" +
+	"\x0fValidateAddress\x12 .shipment.ValidateAddressRequest\x1a!.shipment.ValidateAddressResponse\x12A\n" +
+	"\bGetRates\x12\x19.shipment.GetRatesRequest\x1a\x1a.shipment.GetRatesResponse\x12S\n" +
+	"\x0eCancelShipment\x12\x1f.shipment.CancelShipmentRequest\x1a .shipment.CancelShipmentResponseB5Z3github.com/Tanmoy095/LogiSynapse/shared/proto;protob\x06proto3"
 
 var (
 	file_shipment_proto_rawDescOnce sync.Once
@@ -508,33 +1946,80 @@ func file_shipment_proto_rawDescGZIP() []byte {
 }
 
 var file_shipment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_shipment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_shipment_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_shipment_proto_goTypes = []any{
-	(ShipmentStatus)(0),            // 0: shipment.ShipmentStatus
-	(*GetShipmentsRequest)(nil),    // 1: shipment.GetShipmentsRequest
-	(*GetShipmentsResponse)(nil),   // 2: shipment.GetShipmentsResponse
-	(*CreateShipmentRequest)(nil),  // 3: shipment.CreateShipmentRequest
-	(*CreateShipmentResponse)(nil), // 4: shipment.CreateShipmentResponse
-	(*Shipment)(nil),               // 5: shipment.Shipment
-	(*Carrier)(nil),                // 6: shipment.Carrier
+	(ShipmentStatus)(0),              // 0: shipment.ShipmentStatus
+	(*GetShipmentsRequest)(nil),      // 1: shipment.GetShipmentsRequest
+	(*GetShipmentsResponse)(nil),     // 2: shipment.GetShipmentsResponse
+	(*GetShipmentsByIDsRequest)(nil), // 3: shipment.GetShipmentsByIDsRequest
+	(*CreateShipmentRequest)(nil),    // 4: shipment.CreateShipmentRequest
+	(*CreateShipmentResponse)(nil),   // 5: shipment.CreateShipmentResponse
+	(*CancelShipmentRequest)(nil),    // 6: shipment.CancelShipmentRequest
+	(*CancelShipmentResponse)(nil),   // 7: shipment.CancelShipmentResponse
+	(*GetRatesRequest)(nil),          // 8: shipment.GetRatesRequest
+	(*Rate)(nil),                     // 9: shipment.Rate
+	(*GetRatesResponse)(nil),         // 10: shipment.GetRatesResponse
+	(*Shipment)(nil),                 // 11: shipment.Shipment
+	(*Carrier)(nil),                  // 12: shipment.Carrier
+	(*PickupAddress)(nil),            // 13: shipment.PickupAddress
+	(*Pickup)(nil),                   // 14: shipment.Pickup
+	(*SchedulePickupRequest)(nil),    // 15: shipment.SchedulePickupRequest
+	(*CancelPickupRequest)(nil),      // 16: shipment.CancelPickupRequest
+	(*ReschedulePickupRequest)(nil),  // 17: shipment.ReschedulePickupRequest
+	(*PickupResponse)(nil),           // 18: shipment.PickupResponse
+	(*Address)(nil),                  // 19: shipment.Address
+	(*AddressIssue)(nil),             // 20: shipment.AddressIssue
+	(*ValidateAddressRequest)(nil),   // 21: shipment.ValidateAddressRequest
+	(*ValidateAddressResponse)(nil),  // 22: shipment.ValidateAddressResponse
+	(*Customs)(nil),                  // 23: shipment.Customs
+	(*CustomsItem)(nil),              // 24: shipment.CustomsItem
 }
 var file_shipment_proto_depIdxs = []int32{
-	0, // 0: shipment.GetShipmentsRequest.status:type_name -> shipment.ShipmentStatus
-	5, // 1: shipment.GetShipmentsResponse.shipments:type_name -> shipment.Shipment
-	0, // 2: shipment.CreateShipmentRequest.status:type_name -> shipment.ShipmentStatus
-	6, // 3: shipment.CreateShipmentRequest.carrier:type_name -> shipment.Carrier
-	5, // 4: shipment.CreateShipmentResponse.shipment:type_name -> shipment.Shipment
-	0, // 5: shipment.Shipment.status:type_name -> shipment.ShipmentStatus
-	6, // 6: shipment.Shipment.carrier:type_name -> shipment.Carrier
-	1, // 7: shipment.ShipmentService.GetShipments:input_type -> shipment.GetShipmentsRequest
-	3, // 8: shipment.ShipmentService.CreateShipment:input_type -> shipment.CreateShipmentRequest
-	2, // 9: shipment.ShipmentService.GetShipments:output_type -> shipment.GetShipmentsResponse
-	4, // 10: shipment.ShipmentService.CreateShipment:output_type -> shipment.CreateShipmentResponse
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	0,  // 0: shipment.GetShipmentsRequest.status:type_name -> shipment.ShipmentStatus
+	11, // 1: shipment.GetShipmentsResponse.shipments:type_name -> shipment.Shipment
+	0,  // 2: shipment.CreateShipmentRequest.status:type_name -> shipment.ShipmentStatus
+	12, // 3: shipment.CreateShipmentRequest.carrier:type_name -> shipment.Carrier
+	19, // 4: shipment.CreateShipmentRequest.from_address:type_name -> shipment.Address
+	19, // 5: shipment.CreateShipmentRequest.to_address:type_name -> shipment.Address
+	23, // 6: shipment.CreateShipmentRequest.customs:type_name -> shipment.Customs
+	11, // 7: shipment.CreateShipmentResponse.shipment:type_name -> shipment.Shipment
+	11, // 8: shipment.CancelShipmentResponse.shipment:type_name -> shipment.Shipment
+	9,  // 9: shipment.GetRatesResponse.rates:type_name -> shipment.Rate
+	0,  // 10: shipment.Shipment.status:type_name -> shipment.ShipmentStatus
+	12, // 11: shipment.Shipment.carrier:type_name -> shipment.Carrier
+	19, // 12: shipment.Shipment.from_address:type_name -> shipment.Address
+	19, // 13: shipment.Shipment.to_address:type_name -> shipment.Address
+	23, // 14: shipment.Shipment.customs:type_name -> shipment.Customs
+	13, // 15: shipment.Pickup.address:type_name -> shipment.PickupAddress
+	13, // 16: shipment.SchedulePickupRequest.address:type_name -> shipment.PickupAddress
+	14, // 17: shipment.PickupResponse.pickup:type_name -> shipment.Pickup
+	19, // 18: shipment.ValidateAddressRequest.address:type_name -> shipment.Address
+	20, // 19: shipment.ValidateAddressResponse.issues:type_name -> shipment.AddressIssue
+	19, // 20: shipment.ValidateAddressResponse.suggested:type_name -> shipment.Address
+	24, // 21: shipment.Customs.items:type_name -> shipment.CustomsItem
+	1,  // 22: shipment.ShipmentService.GetShipments:input_type -> shipment.GetShipmentsRequest
+	3,  // 23: shipment.ShipmentService.GetShipmentsByIDs:input_type -> shipment.GetShipmentsByIDsRequest
+	4,  // 24: shipment.ShipmentService.CreateShipment:input_type -> shipment.CreateShipmentRequest
+	15, // 25: shipment.ShipmentService.SchedulePickup:input_type -> shipment.SchedulePickupRequest
+	16, // 26: shipment.ShipmentService.CancelPickup:input_type -> shipment.CancelPickupRequest
+	17, // 27: shipment.ShipmentService.ReschedulePickup:input_type -> shipment.ReschedulePickupRequest
+	21, // 28: shipment.ShipmentService.ValidateAddress:input_type -> shipment.ValidateAddressRequest
+	8,  // 29: shipment.ShipmentService.GetRates:input_type -> shipment.GetRatesRequest
+	6,  // 30: shipment.ShipmentService.CancelShipment:input_type -> shipment.CancelShipmentRequest
+	2,  // 31: shipment.ShipmentService.GetShipments:output_type -> shipment.GetShipmentsResponse
+	2,  // 32: shipment.ShipmentService.GetShipmentsByIDs:output_type -> shipment.GetShipmentsResponse
+	5,  // 33: shipment.ShipmentService.CreateShipment:output_type -> shipment.CreateShipmentResponse
+	18, // 34: shipment.ShipmentService.SchedulePickup:output_type -> shipment.PickupResponse
+	18, // 35: shipment.ShipmentService.CancelPickup:output_type -> shipment.PickupResponse
+	18, // 36: shipment.ShipmentService.ReschedulePickup:output_type -> shipment.PickupResponse
+	22, // 37: shipment.ShipmentService.ValidateAddress:output_type -> shipment.ValidateAddressResponse
+	10, // 38: shipment.ShipmentService.GetRates:output_type -> shipment.GetRatesResponse
+	7,  // 39: shipment.ShipmentService.CancelShipment:output_type -> shipment.CancelShipmentResponse
+	31, // [31:40] is the sub-list for method output_type
+	22, // [22:31] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_shipment_proto_init() }
@@ -548,7 +2033,7 @@ func file_shipment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shipment_proto_rawDesc), len(file_shipment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -10,6 +10,7 @@ package proto
 
 import (
 	context "context"
+
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -21,8 +22,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShipmentService_GetShipments_FullMethodName   = "/shipment.ShipmentService/GetShipments"
-	ShipmentService_CreateShipment_FullMethodName = "/shipment.ShipmentService/CreateShipment"
+	ShipmentService_GetShipments_FullMethodName      = "/shipment.ShipmentService/GetShipments"
+	ShipmentService_GetShipmentsByIDs_FullMethodName = "/shipment.ShipmentService/GetShipmentsByIDs"
+	ShipmentService_CreateShipment_FullMethodName    = "/shipment.ShipmentService/CreateShipment"
+	ShipmentService_SchedulePickup_FullMethodName    = "/shipment.ShipmentService/SchedulePickup"
+	ShipmentService_CancelPickup_FullMethodName      = "/shipment.ShipmentService/CancelPickup"
+	ShipmentService_ReschedulePickup_FullMethodName  = "/shipment.ShipmentService/ReschedulePickup"
+	ShipmentService_ValidateAddress_FullMethodName   = "/shipment.ShipmentService/ValidateAddress"
+	ShipmentService_GetRates_FullMethodName          = "/shipment.ShipmentService/GetRates"
+	ShipmentService_CancelShipment_FullMethodName    = "/shipment.ShipmentService/CancelShipment"
 )
 
 // ShipmentServiceClient is the client API for ShipmentService service.
@@ -30,7 +38,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShipmentServiceClient interface {
 	GetShipments(ctx context.Context, in *GetShipmentsRequest, opts ...grpc.CallOption) (*GetShipmentsResponse, error)
+	// GetShipmentsByIDs is the batch lookup behind the gateway's shipment loader.
+	// Unknown IDs are left out; the order of the result is not the order of ids.
+	GetShipmentsByIDs(ctx context.Context, in *GetShipmentsByIDsRequest, opts ...grpc.CallOption) (*GetShipmentsResponse, error)
 	CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*CreateShipmentResponse, error)
+	SchedulePickup(ctx context.Context, in *SchedulePickupRequest, opts ...grpc.CallOption) (*PickupResponse, error)
+	CancelPickup(ctx context.Context, in *CancelPickupRequest, opts ...grpc.CallOption) (*PickupResponse, error)
+	ReschedulePickup(ctx context.Context, in *ReschedulePickupRequest, opts ...grpc.CallOption) (*PickupResponse, error)
+	ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error)
+	// GetRates quotes carrier rates for one parcel; nothing is booked.
+	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	// CancelShipment cancels a PRE_TRANSIT shipment. Cancelling a CANCELLED
+	// shipment returns it unchanged.
+	CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*CancelShipmentResponse, error)
 }
 
 type shipmentServiceClient struct {
@@ -51,6 +71,16 @@ func (c *shipmentServiceClient) GetShipments(ctx context.Context, in *GetShipmen
 	return out, nil
 }
 
+func (c *shipmentServiceClient) GetShipmentsByIDs(ctx context.Context, in *GetShipmentsByIDsRequest, opts ...grpc.CallOption) (*GetShipmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShipmentsResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetShipmentsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shipmentServiceClient) CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*CreateShipmentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateShipmentResponse)
@@ -61,12 +91,84 @@ func (c *shipmentServiceClient) CreateShipment(ctx context.Context, in *CreateSh
 	return out, nil
 }
 
+func (c *shipmentServiceClient) SchedulePickup(ctx context.Context, in *SchedulePickupRequest, opts ...grpc.CallOption) (*PickupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickupResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_SchedulePickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) CancelPickup(ctx context.Context, in *CancelPickupRequest, opts ...grpc.CallOption) (*PickupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickupResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_CancelPickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ReschedulePickup(ctx context.Context, in *ReschedulePickupRequest, opts ...grpc.CallOption) (*PickupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickupResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ReschedulePickup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ValidateAddress(ctx context.Context, in *ValidateAddressRequest, opts ...grpc.CallOption) (*ValidateAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateAddressResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ValidateAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatesResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) CancelShipment(ctx context.Context, in *CancelShipmentRequest, opts ...grpc.CallOption) (*CancelShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_CancelShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShipmentServiceServer is the server API for ShipmentService service.
 // All implementations must embed UnimplementedShipmentServiceServer
 // for forward compatibility.
 type ShipmentServiceServer interface {
 	GetShipments(context.Context, *GetShipmentsRequest) (*GetShipmentsResponse, error)
+	// GetShipmentsByIDs is the batch lookup behind the gateway's shipment loader.
+	// Unknown IDs are left out; the order of the result is not the order of ids.
+	GetShipmentsByIDs(context.Context, *GetShipmentsByIDsRequest) (*GetShipmentsResponse, error)
 	CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error)
+	SchedulePickup(context.Context, *SchedulePickupRequest) (*PickupResponse, error)
+	CancelPickup(context.Context, *CancelPickupRequest) (*PickupResponse, error)
+	ReschedulePickup(context.Context, *ReschedulePickupRequest) (*PickupResponse, error)
+	ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error)
+	// GetRates quotes carrier rates for one parcel; nothing is booked.
+	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	// CancelShipment cancels a PRE_TRANSIT shipment. Cancelling a CANCELLED
+	// shipment returns it unchanged.
+	CancelShipment(context.Context, *CancelShipmentRequest) (*CancelShipmentResponse, error)
 	mustEmbedUnimplementedShipmentServiceServer()
 }
 
@@ -80,9 +182,30 @@ type UnimplementedShipmentServiceServer struct{}
 func (UnimplementedShipmentServiceServer) GetShipments(context.Context, *GetShipmentsRequest) (*GetShipmentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShipments not implemented")
 }
+func (UnimplementedShipmentServiceServer) GetShipmentsByIDs(context.Context, *GetShipmentsByIDsRequest) (*GetShipmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipmentsByIDs not implemented")
+}
 func (UnimplementedShipmentServiceServer) CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
 }
+func (UnimplementedShipmentServiceServer) SchedulePickup(context.Context, *SchedulePickupRequest) (*PickupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePickup not implemented")
+}
+func (UnimplementedShipmentServiceServer) CancelPickup(context.Context, *CancelPickupRequest) (*PickupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPickup not implemented")
+}
+func (UnimplementedShipmentServiceServer) ReschedulePickup(context.Context, *ReschedulePickupRequest) (*PickupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReschedulePickup not implemented")
+}
+func (UnimplementedShipmentServiceServer) ValidateAddress(context.Context, *ValidateAddressRequest) (*ValidateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAddress not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRates not implemented")
+}
+func (UnimplementedShipmentServiceServer) CancelShipment(context.Context, *CancelShipmentRequest) (*CancelShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelShipment not implemented")
+}
 func (UnimplementedShipmentServiceServer) mustEmbedUnimplementedShipmentServiceServer() {}
 func (UnimplementedShipmentServiceServer) testEmbeddedByValue()                         {}
 
@@ -122,6 +245,24 @@ func _ShipmentService_GetShipments_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShipmentService_GetShipmentsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetShipmentsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetShipmentsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetShipmentsByIDs(ctx, req.(*GetShipmentsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShipmentService_CreateShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateShipmentRequest)
 	if err := dec(in); err != nil {
@@ -140,6 +281,114 @@ func _ShipmentService_CreateShipment_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShipmentService_SchedulePickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).SchedulePickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_SchedulePickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).SchedulePickup(ctx, req.(*SchedulePickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_CancelPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).CancelPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_CancelPickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).CancelPickup(ctx, req.(*CancelPickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ReschedulePickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReschedulePickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ReschedulePickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ReschedulePickup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ReschedulePickup(ctx, req.(*ReschedulePickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ValidateAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ValidateAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ValidateAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ValidateAddress(ctx, req.(*ValidateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetRates(ctx, req.(*GetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_CancelShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).CancelShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_CancelShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).CancelShipment(ctx, req.(*CancelShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShipmentService_ServiceDesc is the grpc.ServiceDesc for ShipmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -151,10 +400,38 @@ var ShipmentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetShipments",
 			Handler:    _ShipmentService_GetShipments_Handler,
 		},
+		{
+			MethodName: "GetShipmentsByIDs",
+			Handler:    _ShipmentService_GetShipmentsByIDs_Handler,
+		},
 		{
 			MethodName: "CreateShipment",
 			Handler:    _ShipmentService_CreateShipment_Handler,
 		},
+		{
+			MethodName: "SchedulePickup",
+			Handler:    _ShipmentService_SchedulePickup_Handler,
+		},
+		{
+			MethodName: "CancelPickup",
+			Handler:    _ShipmentService_CancelPickup_Handler,
+		},
+		{
+			MethodName: "ReschedulePickup",
+			Handler:    _ShipmentService_ReschedulePickup_Handler,
+		},
+		{
+			MethodName: "ValidateAddress",
+			Handler:    _ShipmentService_ValidateAddress_Handler,
+		},
+		{
+			MethodName: "GetRates",
+			Handler:    _ShipmentService_GetRates_Handler,
+		},
+		{
+			MethodName: "CancelShipment",
+			Handler:    _ShipmentService_CancelShipment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "shipment.proto",
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"fmt"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -47,15 +48,45 @@ func (r *RabbitmqClient) Close() error {
 
 }
 
+// QueueOption sets an x-argument on a queue declaration.
+type QueueOption func(args amqp.Table)
+
+// WithMessageTTL expires messages that sit in the queue longer than ttl.
+// Combined with WithDeadLetter this turns a queue into a delay line.
+func WithMessageTTL(ttl time.Duration) QueueOption {
+	return func(args amqp.Table) {
+		args["x-message-ttl"] = ttl.Milliseconds()
+	}
+}
+
+// WithDeadLetter re-routes expired or rejected messages to routingKey on
+// exchange. Use exchange "" (the default exchange) to send them straight to
+// the queue named routingKey.
+func WithDeadLetter(exchange, routingKey string) QueueOption {
+	return func(args amqp.Table) {
+		args["x-dead-letter-exchange"] = exchange
+		args["x-dead-letter-routing-key"] = routingKey
+	}
+}
+
 // Crreate Queue prepares a queue to hold messeege
-func (r *RabbitmqClient) CreateQueue(queueName string) error {
+// NOTE: RabbitMQ refuses to redeclare an existing queue with different
+// arguments, so changing options on a live queue means deleting it first.
+func (r *RabbitmqClient) CreateQueue(queueName string, opts ...QueueOption) error {
+	var args amqp.Table
+	if len(opts) > 0 {
+		args = amqp.Table{}
+		for _, opt := range opts {
+			opt(args)
+		}
+	}
 	_, err := r.chn.QueueDeclare(
 		queueName, //name of queue
 		true,      //durable
 		false,     //delete when unused
 		false,     //exclusive
 		false,     //no-wait
-		nil,       //arguments
+		args,      //arguments (TTL, dead-lettering)
 	)
 	return err
 }
@@ -96,3 +127,24 @@ func (r *RabbitmqClient) Consume(queueName string) (<-chan amqp.Delivery, error)
 	}
 	return msgs, nil
 }
+
+// Get pulls one message without a consumer (basic.get). ok is false when the
+// queue is empty. The message stays unacked until Ack or Nack is called on it,
+// so callers can inspect a queue and put everything back with Nack(requeue).
+func (r *RabbitmqClient) Get(queueName string) (amqp.Delivery, bool, error) {
+	return r.chn.Get(queueName, false)
+}
+
+// QueueDepth returns the number of ready messages in an existing queue.
+func (r *RabbitmqClient) QueueDepth(queueName string) (int, error) {
+	q, err := r.chn.QueueDeclarePassive(queueName, true, false, false, false, nil)
+	if err != nil {
+		return 0, err
+	}
+	return q.Messages, nil
+}
+
+// Purge deletes every ready message in the queue and returns how many were removed.
+func (r *RabbitmqClient) Purge(queueName string) (int, error) {
+	return r.chn.QueuePurge(queueName, false)
+}